@@ -0,0 +1,150 @@
+// Package cmd 提供应用程序的启动和运行入口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/db"
+	"github.com/Done-0/metaphysics/internal/logger"
+	"github.com/Done-0/metaphysics/internal/model/bazi"
+	"github.com/Done-0/metaphysics/internal/redis"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/bazi/dto"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	baziImpl "github.com/Done-0/metaphysics/pkg/serve/service/bazi/impl"
+)
+
+// IMPORT_COMMAND 批量导入出生档案的子命令名
+const IMPORT_COMMAND = "import"
+
+// importPollInterval 轮询导入进度的间隔
+const importPollInterval = 500 * time.Millisecond
+
+// Import 通过命令行批量导入出生档案，复用接口的校验、排盘与入库流程；参数错误时退出码为 2，导入失败时为 1
+// 参数：
+//   - args: 子命令参数，如 -user 1 -file clients.xlsx -mapping '{"name":"客户姓名"}'
+func Import(args []string) {
+	if err := runImport(args); err != nil {
+		log.Printf("%v", err)
+		os.Exit(1)
+	}
+}
+
+// runImport 执行批量导入并等待任务结束
+// 参数：
+//   - args: 子命令参数
+//
+// 返回值：
+//   - error: 初始化失败、导入失败或导入任务处理失败时返回错误
+func runImport(args []string) error {
+	fs := flag.NewFlagSet(IMPORT_COMMAND, flag.ExitOnError)
+	userID := fs.Int64("user", 0, "导入到的用户 ID")
+	filePath := fs.String("file", "", "CSV 或 XLSX 文件路径")
+	mapping := fs.String("mapping", "", "列映射（JSON 对象），如 {\"name\":\"客户姓名\"}")
+	calendar := fs.String("calendar", "", "默认历法 (lunar/solar)")
+	relation := fs.String("relation", "", "默认关系 (spouse/child/client/other)")
+	timezone := fs.String("timezone", "", "出生时间时区，如 Asia/Shanghai")
+	configPath := fs.String("config", configs.DefaultConfigPath, "配置文件路径")
+	_ = fs.Parse(args)
+
+	if *userID <= 0 || *filePath == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	req := &dto.ImportBaziRequest{
+		Mapping:  *mapping,
+		Calendar: *calendar,
+		Relation: *relation,
+		Timezone: *timezone,
+	}
+	switch req.Calendar {
+	case "", "lunar", "solar":
+	default:
+		log.Printf("参数错误: 历法 %q 无效", req.Calendar)
+		os.Exit(2)
+	}
+	switch req.Relation {
+	case "", bazi.RELATION_SPOUSE, bazi.RELATION_CHILD, bazi.RELATION_CLIENT, bazi.RELATION_OTHER:
+	default:
+		log.Printf("参数错误: 关系 %q 无效", req.Relation)
+		os.Exit(2)
+	}
+
+	// 初始化配置
+	if err := configs.Init(*configPath); err != nil {
+		return fmt.Errorf("配置初始化失败: %w", err)
+	}
+
+	config, err := configs.GetConfig()
+	if err != nil {
+		return fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	// 初始化 Logger、数据库与 Redis
+	logger.New()
+	db.New(config)
+	redis.New(config)
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Printf("数据库关闭异常: %v", err)
+		}
+		if err := redis.Close(); err != nil {
+			log.Printf("Redis 关闭异常: %v", err)
+		}
+	}()
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return fmt.Errorf("打开导入文件失败: %w", err)
+	}
+	defer file.Close()
+
+	ctx := utils.NewJobContext(context.Background())
+	ctx.Set("user_id", *userID)
+
+	service := baziImpl.NewBaziService(baziMapperImpl.NewBaziMapper())
+	response, err := service.ImportBazi(ctx, req, filepath.Base(*filePath), file)
+	if err != nil {
+		return fmt.Errorf("导入失败: %w", err)
+	}
+	fmt.Printf("导入任务 %s：共 %d 行，校验通过 %d 行，校验失败 %d 行\n", response.JobID, response.Total, response.Accepted, response.Rejected)
+
+	jobID, err := strconv.ParseInt(response.JobID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("导入任务 ID 无效: %w", err)
+	}
+	jobReq := &dto.GetImportJobRequest{JobID: jobID}
+	for {
+		job, err := service.GetImportJob(ctx, jobReq)
+		if err != nil {
+			return fmt.Errorf("查询导入进度失败: %w", err)
+		}
+		fmt.Printf("\r进度 %d/%d，成功 %d，失败 %d", job.Processed, job.Total, job.Succeeded, job.Failed)
+
+		if job.Status != bazi.IMPORT_STATUS_RUNNING {
+			fmt.Println()
+			if job.Message != "" {
+				fmt.Printf("导入任务结束：%s\n", job.Message)
+			}
+			for _, rowError := range job.Errors {
+				fmt.Printf("第 %d 行：%s\n", rowError.Row, rowError.Message)
+			}
+			if job.Status == bazi.IMPORT_STATUS_FAILED {
+				return fmt.Errorf("导入任务 %s 处理失败", response.JobID)
+			}
+			return nil
+		}
+		time.Sleep(importPollInterval)
+	}
+}
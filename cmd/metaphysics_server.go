@@ -21,6 +21,8 @@ import (
 	"github.com/Done-0/metaphysics/internal/logger"
 	"github.com/Done-0/metaphysics/internal/middleware"
 	"github.com/Done-0/metaphysics/internal/redis"
+	"github.com/Done-0/metaphysics/internal/scheduler"
+	"github.com/Done-0/metaphysics/pkg/job"
 	"github.com/Done-0/metaphysics/pkg/router"
 )
 
@@ -54,6 +56,12 @@ func Start() {
 	// 注册路由
 	router.New(app)
 
+	// 注册定时任务
+	sched := scheduler.New()
+	if err := job.New(sched, config); err != nil {
+		log.Fatalf("定时任务注册失败: %v", err)
+	}
+
 	// 创建 HTTP 服务器
 	srv := &http.Server{
 		Addr:    fmt.Sprintf("%s:%s", config.AppConfig.AppHost, config.AppConfig.AppPort),
@@ -73,6 +81,9 @@ func Start() {
 	log.Printf("⇨ http server started on %s", srv.Addr)
 	global.SysLog.Infof("⇨ http server started on %s", srv.Addr)
 
+	// 启动定时任务
+	sched.Start(ctx)
+
 	// 等待中断信号
 	<-ctx.Done()
 	global.SysLog.Info("正在优雅关闭服务...")
@@ -86,6 +97,9 @@ func Start() {
 		global.SysLog.Errorf("服务关闭异常: %v", err)
 	}
 
+	// 等待定时任务退出
+	sched.Wait()
+
 	// 清理资源
 	if err := db.Close(); err != nil {
 		global.SysLog.Errorf("数据库关闭异常: %v", err)
@@ -68,15 +68,108 @@ type AIConfig struct {
 	DeepseekAPIKey  string `mapstructure:"DEEPSEEK_API_KEY"`
 	DeepseekAPIBase string `mapstructure:"DEEPSEEK_API_BASE"`
 	DeepseekModel   string `mapstructure:"DEEPSEEK_MODEL"`
+	// openai 兼容服务配置
+	OpenAIEnabled     bool              `mapstructure:"OPENAI_ENABLED"`
+	OpenAIAPIBase     string            `mapstructure:"OPENAI_API_BASE"`
+	OpenAIAPIKey      string            `mapstructure:"OPENAI_API_KEY"`
+	OpenAIModel       string            `mapstructure:"OPENAI_MODEL"`
+	OpenAIHeaders     map[string]string `mapstructure:"OPENAI_HEADERS"`
+	OpenAIStreamUsage bool              `mapstructure:"OPENAI_STREAM_USAGE"`
+	// 路由配置
+	RoutingMode             string         `mapstructure:"ROUTING_MODE"`
+	ProviderOrder           []string       `mapstructure:"PROVIDER_ORDER"`
+	ProviderWeights         map[string]int `mapstructure:"PROVIDER_WEIGHTS"`
+	ProviderTimeoutSeconds  int            `mapstructure:"PROVIDER_TIMEOUT_SECONDS"`
+	BlockingTimeoutSeconds  int            `mapstructure:"BLOCKING_TIMEOUT_SECONDS"`
+	CircuitFailureThreshold int            `mapstructure:"CIRCUIT_FAILURE_THRESHOLD"`
+	CircuitCooldownSeconds  int            `mapstructure:"CIRCUIT_COOLDOWN_SECONDS"`
+	RuleFallbackEnabled     bool           `mapstructure:"RULE_FALLBACK_ENABLED"`
+	// 结构化输出配置
+	StructuredJSONMode    bool `mapstructure:"STRUCTURED_JSON_MODE"`
+	StructuredMaxAttempts int  `mapstructure:"STRUCTURED_MAX_ATTEMPTS"`
+}
+
+// NamingConfig 起名相关配置
+type NamingConfig struct {
+	NamingBlacklist     []string `mapstructure:"NAMING_BLACKLIST"`
+	NamingTonePatterns  []string `mapstructure:"NAMING_TONE_PATTERNS"`
+	NamingMaxCandidates int      `mapstructure:"NAMING_MAX_CANDIDATES"`
+}
+
+// FortuneConfig 每日运势相关配置
+type FortuneConfig struct {
+	FortuneJobEnabled   bool   `mapstructure:"FORTUNE_JOB_ENABLED"`
+	FortuneRunAt        string `mapstructure:"FORTUNE_RUN_AT"`
+	FortuneTimezone     string `mapstructure:"FORTUNE_TIMEZONE"`
+	FortuneAIPolish     bool   `mapstructure:"FORTUNE_AI_POLISH"`
+	FortuneBatchSize    int    `mapstructure:"FORTUNE_BATCH_SIZE"`
+	FortuneEmailSubject string `mapstructure:"FORTUNE_EMAIL_SUBJECT"`
+}
+
+// AnnualReportConfig 流年报告相关配置
+type AnnualReportConfig struct {
+	AnnualReportJobEnabled   bool   `mapstructure:"ANNUAL_REPORT_JOB_ENABLED"`
+	AnnualReportRunAt        string `mapstructure:"ANNUAL_REPORT_RUN_AT"`
+	AnnualReportTimezone     string `mapstructure:"ANNUAL_REPORT_TIMEZONE"`
+	AnnualReportLeadDays     int    `mapstructure:"ANNUAL_REPORT_LEAD_DAYS"`
+	AnnualReportConcurrency  int    `mapstructure:"ANNUAL_REPORT_CONCURRENCY"`
+	AnnualReportEmailSubject string `mapstructure:"ANNUAL_REPORT_EMAIL_SUBJECT"`
+}
+
+// ClientConfig 客户档案相关配置
+type ClientConfig struct {
+	ClientReminderJobEnabled   bool   `mapstructure:"CLIENT_REMINDER_JOB_ENABLED"`
+	ClientReminderRunAt        string `mapstructure:"CLIENT_REMINDER_RUN_AT"`
+	ClientReminderTimezone     string `mapstructure:"CLIENT_REMINDER_TIMEZONE"`
+	ClientReminderEmailSubject string `mapstructure:"CLIENT_REMINDER_EMAIL_SUBJECT"`
+}
+
+// ShareConfig 分享链接相关配置
+type ShareConfig struct {
+	ShareBaseURL            string `mapstructure:"SHARE_BASE_URL"`
+	ShareDefaultExpireHours int    `mapstructure:"SHARE_DEFAULT_EXPIRE_HOURS"`
+	ShareMaxExpireHours     int    `mapstructure:"SHARE_MAX_EXPIRE_HOURS"`
+	ShareRateLimit          int    `mapstructure:"SHARE_RATE_LIMIT"`
+	ShareRateWindowSeconds  int    `mapstructure:"SHARE_RATE_WINDOW_SECONDS"`
+}
+
+// ImportConfig 出生档案批量导入相关配置
+type ImportConfig struct {
+	ImportMaxFileSizeMB int    `mapstructure:"IMPORT_MAX_FILE_SIZE_MB"`
+	ImportMaxRows       int    `mapstructure:"IMPORT_MAX_ROWS"`
+	ImportWorkers       int    `mapstructure:"IMPORT_WORKERS"`
+	ImportBatchSize     int    `mapstructure:"IMPORT_BATCH_SIZE"`
+	ImportTimezone      string `mapstructure:"IMPORT_TIMEZONE"`
+}
+
+// PromptConfig 提示模板相关配置
+type PromptConfig struct {
+	PromptDir      string            `mapstructure:"PROMPT_DIR"`
+	PromptVersions map[string]string `mapstructure:"PROMPT_VERSIONS"`
+}
+
+// RenderConfig 命盘图片与 PDF 报告渲染相关配置
+type RenderConfig struct {
+	RenderFontPath    string  `mapstructure:"RENDER_FONT_PATH"`
+	RenderPNGScale    float64 `mapstructure:"RENDER_PNG_SCALE"`
+	RenderPDFFontPath string  `mapstructure:"RENDER_PDF_FONT_PATH"`
 }
 
 // Config 总配置结构
 type Config struct {
-	AppConfig   AppConfig      `mapstructure:"APP"`
-	DBConfig    DatabaseConfig `mapstructure:"DATABASE"`
-	LogConfig   LogConfig      `mapstructure:"LOG"`
-	RedisConfig RedisConfig    `mapstructure:"REDIS"`
-	AIConfig    AIConfig       `mapstructure:"AI"`
+	AppConfig          AppConfig          `mapstructure:"APP"`
+	DBConfig           DatabaseConfig     `mapstructure:"DATABASE"`
+	LogConfig          LogConfig          `mapstructure:"LOG"`
+	RedisConfig        RedisConfig        `mapstructure:"REDIS"`
+	AIConfig           AIConfig           `mapstructure:"AI"`
+	PromptConfig       PromptConfig       `mapstructure:"PROMPT"`
+	NamingConfig       NamingConfig       `mapstructure:"NAMING"`
+	FortuneConfig      FortuneConfig      `mapstructure:"FORTUNE"`
+	AnnualReportConfig AnnualReportConfig `mapstructure:"ANNUAL_REPORT"`
+	RenderConfig       RenderConfig       `mapstructure:"RENDER"`
+	ClientConfig       ClientConfig       `mapstructure:"CLIENT"`
+	ShareConfig        ShareConfig        `mapstructure:"SHARE"`
+	ImportConfig       ImportConfig       `mapstructure:"IMPORT"`
 }
 
 // DefaultConfigPath 默认配置文件路径
@@ -139,21 +232,24 @@ func monitorConfigChanges() {
 		}
 
 		configMutex.Lock()
-		defer configMutex.Unlock()
-
 		oldConfig := *configInstance
 		changes := make(map[string][2]any)
 
 		if !compareStructs(oldConfig, newConfig, "", changes) {
+			configMutex.Unlock()
 			log.Printf("配置类型不一致，变更被阻止")
 			return
 		}
 
 		configInstance = &newConfig
+		configMutex.Unlock()
 
 		for path, values := range changes {
 			log.Printf("配置项 [%s] 发生变化: %v -> %v", path, values[0], values[1])
 		}
+
+		// 释放锁后再通知订阅者，订阅者可在回调中调用 GetConfig
+		notifySubscribers(oldConfig, newConfig, changes)
 	})
 }
 
@@ -0,0 +1,116 @@
+// Package configs 提供应用程序配置加载和更新功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package configs
+
+import (
+	"log"
+	"strings"
+	"sync"
+)
+
+// ChangeHandler 配置变更处理函数
+// 参数：
+//   - oldConfig: 变更前的配置副本
+//   - newConfig: 变更后的配置副本
+//   - changes: 变更项，键为字段路径（如 AIConfig.OllamaModel），值为变更前后的值
+type ChangeHandler func(oldConfig, newConfig *Config, changes map[string][2]any)
+
+// subscription 配置变更订阅
+type subscription struct {
+	id       uint64
+	prefixes []string
+	handler  ChangeHandler
+}
+
+var (
+	subscriptions     []subscription // 变更订阅列表
+	subscriptionMutex sync.Mutex     // 订阅列表锁
+	subscriptionSeq   uint64         // 订阅序号
+)
+
+// Subscribe 订阅配置变更，仅当存在以指定前缀开头的变更项时回调，未指定前缀时订阅全部变更
+// 参数：
+//   - handler: 变更处理函数，在配置监听协程中依次同步调用
+//   - prefixes: 字段路径前缀，如 "AIConfig."
+//
+// 返回值：
+//   - func(): 取消订阅函数
+func Subscribe(handler ChangeHandler, prefixes ...string) func() {
+	subscriptionMutex.Lock()
+	defer subscriptionMutex.Unlock()
+
+	subscriptionSeq++
+	id := subscriptionSeq
+	subscriptions = append(subscriptions, subscription{id: id, prefixes: prefixes, handler: handler})
+
+	return func() {
+		subscriptionMutex.Lock()
+		defer subscriptionMutex.Unlock()
+
+		for i, s := range subscriptions {
+			if s.id == id {
+				subscriptions = append(subscriptions[:i:i], subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notifySubscribers 通知订阅者配置已变更
+// 参数：
+//   - oldConfig: 变更前的配置
+//   - newConfig: 变更后的配置
+//   - changes: 变更项
+func notifySubscribers(oldConfig, newConfig Config, changes map[string][2]any) {
+	if len(changes) == 0 {
+		return
+	}
+
+	subscriptionMutex.Lock()
+	list := append([]subscription(nil), subscriptions...)
+	subscriptionMutex.Unlock()
+
+	for _, s := range list {
+		if !s.matches(changes) {
+			continue
+		}
+		// 每个订阅者获得独立副本，避免相互修改
+		oldCopy, newCopy := oldConfig, newConfig
+		s.call(&oldCopy, &newCopy, changes)
+	}
+}
+
+// matches 判断变更项是否命中订阅前缀
+// 参数：
+//   - changes: 变更项
+//
+// 返回值：
+//   - bool: 是否命中
+func (s subscription) matches(changes map[string][2]any) bool {
+	if len(s.prefixes) == 0 {
+		return true
+	}
+	for path := range changes {
+		for _, prefix := range s.prefixes {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// call 调用变更处理函数，处理函数 panic 时记录日志，避免中断配置监听
+// 参数：
+//   - oldConfig: 变更前的配置
+//   - newConfig: 变更后的配置
+//   - changes: 变更项
+func (s subscription) call(oldConfig, newConfig *Config, changes map[string][2]any) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("配置变更处理失败: %v", r)
+		}
+	}()
+	s.handler(oldConfig, newConfig, changes)
+}
@@ -4,42 +4,310 @@
 package ai
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Done-0/metaphysics/configs"
 	"github.com/Done-0/metaphysics/internal/ai/provider"
+	"github.com/Done-0/metaphysics/internal/ai/router"
 	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/internal/global"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
 )
 
 var (
-	instance types.Service
+	instance *reloadableService
 	once     sync.Once
 )
 
-// New 返回 AI 服务实例
+// DEFAULT_PROVIDER_ORDER 未配置优先级时的默认 Provider 顺序
+var DEFAULT_PROVIDER_ORDER = []string{string(types.PROVIDER_DEEPSEEK), string(types.PROVIDER_OPENAI), string(types.PROVIDER_OLLAMA)}
+
+// reloadableService 可热更新的 AI 服务，每次调用取当前路由器，配置变更时整体替换，进行中的请求继续使用旧实例直至完成
+type reloadableService struct {
+	current atomic.Pointer[router.Router]
+}
+
+// New 返回 AI 服务实例，已启用的 Provider 由路由器按优先级或权重调度并在失败时转移，RULE_FALLBACK_ENABLED 开启时规则解读作为最后的兜底；
+// AI 配置变更时自动重建 Provider，返回的实例保持不变，新配置无效时保留原有路由器
 // 返回值：
 //
 //	types.Service: AI 服务接口
 func New() types.Service {
 	once.Do(func() {
+		instance = &reloadableService{}
+
 		cfg, err := configs.GetConfig()
 		if err != nil {
-			panic(fmt.Errorf("配置加载失败: %w", err))
+			logWarn("配置加载失败，使用规则解读: %v", err)
+		}
+		r, err := build(cfg)
+		if err != nil && cfg != nil {
+			// 启动时没有可保留的路由器，退回规则解读
+			logError("AI 配置无效，使用规则解读: %v", err)
+			r, err = build(nil)
+		}
+		if err != nil {
+			logError("AI 服务初始化失败: %v", err)
+		} else {
+			instance.current.Store(r)
 		}
 
-		// 按优先级选择 Provider
-		switch {
-		case cfg.AIConfig.OllamaEnabled:
-			provider, err := provider.NewOllamaProvider(cfg)
-			if err == nil {
-				instance = provider
+		configs.Subscribe(func(_, newConfig *configs.Config, _ map[string][2]any) {
+			r, err := build(newConfig)
+			if err != nil {
+				logError("AI 配置无效，继续使用原有 Provider: %v", err)
 				return
 			}
-			panic(fmt.Errorf("ollama 初始化失败: %w", err))
-		default:
-			panic("未启用任何 AI Provider")
-		}
+			instance.current.Store(r)
+			logInfo("AI 配置已变更，Provider 已重建")
+		}, "AIConfig.")
 	})
 	return instance
 }
+
+// AnalyzeBaziWithReasoning 分析八字（带推理过程）
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	opts: 分析选项
+//
+// 返回值：
+//
+//	*conversation.BaziAnalysisResponse: 分析结果（包含推理过程，结构化输出时包含各章节）
+//	error: 错误信息
+func (s *reloadableService) AnalyzeBaziWithReasoning(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions) (*conversation.BaziAnalysisResponse, error) {
+	r, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return r.AnalyzeBaziWithReasoning(ctx, c, opts)
+}
+
+// StreamAnalyzeBazi 流式分析八字
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	opts: 分析选项
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (s *reloadableService) StreamAnalyzeBazi(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions, handler types.StreamHandler) error {
+	r, err := s.load()
+	if err != nil {
+		return err
+	}
+	return r.StreamAnalyzeBazi(ctx, c, opts, handler)
+}
+
+// GenerateText 根据提示生成文本
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//
+// 返回值：
+//
+//	*types.TextResult: 生成结果，包含实际响应的 Provider
+//	error: 错误信息
+func (s *reloadableService) GenerateText(ctx context.Context, promptText string) (*types.TextResult, error) {
+	r, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return r.GenerateText(ctx, promptText)
+}
+
+// StreamGenerateText 根据提示流式生成文本
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (s *reloadableService) StreamGenerateText(ctx context.Context, promptText string, handler types.StreamHandler) error {
+	r, err := s.load()
+	if err != nil {
+		return err
+	}
+	return r.StreamGenerateText(ctx, promptText, handler)
+}
+
+// DetermineProvider 确定使用的 AI 提供商，返回优先级最高的 Provider，实际响应的 Provider 以各次调用的响应为准
+// 返回值：
+//
+//	types.Provider: AI 服务提供商
+func (s *reloadableService) DetermineProvider() types.Provider {
+	r, err := s.load()
+	if err != nil {
+		return types.PROVIDER_RULE
+	}
+	return r.DetermineProvider()
+}
+
+// Health 返回当前路由器中各 Provider 的健康状态，按优先级排列，兜底 Provider 位于最后
+// 返回值：
+//
+//	[]router.Health: 健康状态列表，AI 服务未初始化时为空
+func Health() []router.Health {
+	New()
+	r, err := instance.load()
+	if err != nil {
+		return nil
+	}
+	return r.Health()
+}
+
+// load 返回当前路由器
+// 返回值：
+//
+//	*router.Router: 路由器
+//	error: AI 服务初始化失败时返回 router.ErrNoAvailableProvider
+func (s *reloadableService) load() (*router.Router, error) {
+	r := s.current.Load()
+	if r == nil {
+		return nil, router.ErrNoAvailableProvider
+	}
+	return r, nil
+}
+
+// build 根据配置构建路由器，配置为空或未启用任何 Provider 时仅包含规则解读
+// 参数：
+//
+//	cfg: 配置信息，可为 nil
+//
+// 返回值：
+//
+//	*router.Router: 路由器
+//	error: 规则解读初始化失败、已启用的 Provider 初始化失败或路由配置无效时返回错误
+func build(cfg *configs.Config) (*router.Router, error) {
+	ruleProvider, err := provider.NewRuleProvider()
+	if err != nil {
+		return nil, fmt.Errorf("规则解读初始化失败: %w", err)
+	}
+	rule := router.Member{Service: ruleProvider, Fallback: true}
+
+	var opts router.Options
+	members := []router.Member{rule}
+	if cfg != nil {
+		aiCfg := cfg.AIConfig
+		enabled, err := buildMembers(cfg)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(enabled) == 0:
+			logWarn("未启用任何 AI Provider，使用规则解读")
+		case aiCfg.RuleFallbackEnabled:
+			members = append(enabled, rule)
+		default:
+			members = enabled
+		}
+
+		opts = router.Options{
+			Mode:             strings.ToLower(strings.TrimSpace(aiCfg.RoutingMode)),
+			StreamTimeout:    time.Duration(aiCfg.ProviderTimeoutSeconds) * time.Second,
+			BlockingTimeout:  time.Duration(aiCfg.BlockingTimeoutSeconds) * time.Second,
+			FailureThreshold: aiCfg.CircuitFailureThreshold,
+			Cooldown:         time.Duration(aiCfg.CircuitCooldownSeconds) * time.Second,
+		}
+	}
+
+	r, err := router.New(members, opts)
+	if err != nil {
+		return nil, fmt.Errorf("AI 路由初始化失败: %w", err)
+	}
+	return r, nil
+}
+
+// buildMembers 按配置的优先级初始化已启用的 Provider
+// 参数：
+//
+//	cfg: 配置信息
+//
+// 返回值：
+//
+//	[]router.Member: 路由成员
+//	error: 优先级中存在未知 Provider 或已启用的 Provider 初始化失败时返回错误
+func buildMembers(cfg *configs.Config) ([]router.Member, error) {
+	aiCfg := cfg.AIConfig
+	constructors := map[types.Provider]struct {
+		enabled bool
+		build   func(*configs.Config) (types.Service, error)
+	}{
+		types.PROVIDER_DEEPSEEK: {aiCfg.DeepseekEnabled, provider.NewDeepseekProvider},
+		types.PROVIDER_OPENAI:   {aiCfg.OpenAIEnabled, provider.NewOpenAIProvider},
+		types.PROVIDER_OLLAMA:   {aiCfg.OllamaEnabled, provider.NewOllamaProvider},
+	}
+
+	order := aiCfg.ProviderOrder
+	if len(order) == 0 {
+		order = DEFAULT_PROVIDER_ORDER
+	}
+
+	var members []router.Member
+	seen := make(map[types.Provider]bool)
+	for _, name := range order {
+		p := types.Provider(strings.ToLower(strings.TrimSpace(name)))
+		constructor, ok := constructors[p]
+		if !ok {
+			return nil, fmt.Errorf("未知的 AI Provider: %s", name)
+		}
+		if seen[p] || !constructor.enabled {
+			continue
+		}
+		seen[p] = true
+
+		service, err := constructor.build(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s 初始化失败: %w", p, err)
+		}
+		members = append(members, router.Member{Service: service, Weight: aiCfg.ProviderWeights[string(p)]})
+	}
+	return members, nil
+}
+
+// logWarn 记录 Provider 选择过程中的告警，日志未初始化时忽略
+// 参数：
+//
+//	format: 格式化字符串
+//	args: 参数
+func logWarn(format string, args ...any) {
+	if global.SysLog != nil {
+		global.SysLog.Warnf(format, args...)
+	}
+}
+
+// logError 记录 AI 服务构建失败等错误，日志未初始化时忽略
+// 参数：
+//
+//	format: 格式化字符串
+//	args: 参数
+func logError(format string, args ...any) {
+	if global.SysLog != nil {
+		global.SysLog.Errorf(format, args...)
+	}
+}
+
+// logInfo 记录 Provider 重建等信息，日志未初始化时忽略
+// 参数：
+//
+//	format: 格式化字符串
+//	args: 参数
+func logInfo(format string, args ...any) {
+	if global.SysLog != nil {
+		global.SysLog.Infof(format, args...)
+	}
+}
@@ -0,0 +1,44 @@
+// Package ai AI 服务构建测试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package ai
+
+import (
+	"testing"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/ai/types"
+)
+
+func TestBuildReturnsProviderErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*configs.AIConfig)
+	}{
+		{"已启用的 Provider 缺少密钥", func(aiCfg *configs.AIConfig) {
+			aiCfg.DeepseekEnabled = true
+		}},
+		{"优先级中存在未知 Provider", func(aiCfg *configs.AIConfig) {
+			aiCfg.ProviderOrder = []string{"deepseek", "gemini"}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configs.Config{}
+			tt.configure(&cfg.AIConfig)
+			if r, err := build(cfg); err == nil {
+				t.Fatalf("期望返回错误, got %+v", r.Health())
+			}
+		})
+	}
+}
+
+func TestBuildWithoutEnabledProviders(t *testing.T) {
+	r, err := build(&configs.Config{})
+	if err != nil {
+		t.Fatalf("构建失败: %v", err)
+	}
+	if got := r.DetermineProvider(); got != types.PROVIDER_RULE {
+		t.Errorf("未启用任何 Provider 时应使用规则解读, got %s", got)
+	}
+}
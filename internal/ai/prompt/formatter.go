@@ -5,34 +5,266 @@ package prompt
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Done-0/metaphysics/internal/ai/section"
+	"github.com/Done-0/metaphysics/internal/chart"
 	"github.com/Done-0/metaphysics/internal/utils"
 )
 
-// BuildBaziPrompt 构建八字分析提示
+// FORECAST_YEARS 八字分析中重大预测覆盖的年数，含今年
+const FORECAST_YEARS = 5
+
+// BuildBaziPrompt 构建八字分析提示，注入今年、命主年龄、当前大运等动态信息
+// 参数：
+//   - c: 八字命盘
+//
+// 返回值：
+//   - *Rendered: 渲染后的提示
+//   - error: 渲染失败时返回错误
+func BuildBaziPrompt(c *chart.Chart) (*Rendered, error) {
+	return Default().Render(PROMPT_BAZI_ANALYSIS, baziData(c))
+}
+
+// BuildBaziSectionsPrompt 构建八字结构化分析提示，要求模型按章节输出 JSON
+// 参数：
+//   - c: 八字命盘
+//   - keys: 需要输出的章节键
+//   - feedback: 上一次输出的问题，首次请求时为空
+//
+// 返回值：
+//   - *Rendered: 渲染后的提示
+//   - error: 渲染失败时返回错误
+func BuildBaziSectionsPrompt(c *chart.Chart, keys []string, feedback string) (*Rendered, error) {
+	data := baziData(c)
+	guides, err := sectionGuides(keys, data)
+	if err != nil {
+		return nil, err
+	}
+	data["Sections"] = guides
+	data["Example"] = section.Example(keys)
+	data["Feedback"] = feedback
+	return Default().Render(PROMPT_BAZI_SECTIONS, data)
+}
+
+// BuildBaziSubsetPrompt 构建八字指定章节分析提示，要求模型按章节标题输出 Markdown
+// 参数：
+//   - c: 八字命盘
+//   - keys: 需要输出的章节键
+//
+// 返回值：
+//   - *Rendered: 渲染后的提示
+//   - error: 渲染失败时返回错误
+func BuildBaziSubsetPrompt(c *chart.Chart, keys []string) (*Rendered, error) {
+	data := baziData(c)
+	guides, err := sectionGuides(keys, data)
+	if err != nil {
+		return nil, err
+	}
+	data["Sections"] = guides
+	return Default().Render(PROMPT_BAZI_SUBSET, data)
+}
+
+// BuildNameMeaningPrompt 构建姓名寓意解读提示
+// 参数：
+//   - fullName: 全名
+//   - gender: 性别
+//   - favorable: 喜用五行
+//
+// 返回值：
+//   - *Rendered: 渲染后的提示
+//   - error: 渲染失败时返回错误
+func BuildNameMeaningPrompt(fullName, gender string, favorable []string) (*Rendered, error) {
+	return Default().Render(PROMPT_NAME_MEANING, map[string]any{
+		"FullName":  fullName,
+		"Gender":    genderText(gender),
+		"Favorable": strings.Join(favorable, "、"),
+	})
+}
+
+// BuildConversationPrompt 构建继续对话提示
+// 参数：
+//   - history: 之前的对话内容
+//   - question: 用户的新问题
+//   - journalLines: 人生日志摘要，为空时不附加日志上下文
+//
+// 返回值：
+//   - *Rendered: 渲染后的提示
+//   - error: 渲染失败时返回错误
+func BuildConversationPrompt(history, question string, journalLines []string) (*Rendered, error) {
+	now := time.Now()
+	return Default().Render(PROMPT_CONVERSATION, map[string]any{
+		"History":     history,
+		"Journal":     strings.Join(journalLines, "\n"),
+		"Question":    question,
+		"Today":       now.Format("2006-01-02"),
+		"CurrentYear": now.Year(),
+	})
+}
+
+// BuildFortunePolishPrompt 构建每日运势批量润色提示
+// 参数：
+//   - summaries: 规则生成的运势文案，按顺序编号
+//
+// 返回值：
+//   - *Rendered: 渲染后的提示
+//   - error: 渲染失败时返回错误
+func BuildFortunePolishPrompt(summaries []string) (*Rendered, error) {
+	lines := make([]string, 0, len(summaries))
+	for i, summary := range summaries {
+		lines = append(lines, fmt.Sprintf("[%d] %s", i+1, summary))
+	}
+	return Default().Render(PROMPT_FORTUNE_POLISH, map[string]any{
+		"Summaries": strings.Join(lines, "\n"),
+	})
+}
+
+// BuildAnnualReportPrompt 构建流年报告提示
 // 参数：
 //   - name: 姓名
 //   - gender: 性别
-//   - birthTime: 出生时间
-//   - calendar: 日历类型 (solar/lunar)
-//   - baziInfo: 八字信息
+//   - pillars: 四柱干支（年、月、日、时）
+//   - luckPillar: 当年大运，为空时表示尚未起运
+//   - year: 报告年份
+//   - yearPillar: 流年干支
+//   - highlights: 规则引擎生成的流年与逐月要点，每项一行
 //
 // 返回值：
-//   - string: 格式化的提示文本
-func BuildBaziPrompt(name, gender string, birthTime time.Time, calendar string, baziInfo map[string]string) string {
-	var calendarType string
-	timeStr := birthTime.Format("2006-01-02 15:04:05")
+//   - *Rendered: 渲染后的提示
+//   - error: 渲染失败时返回错误
+func BuildAnnualReportPrompt(name, gender string, pillars []string, luckPillar string, year int, yearPillar string, highlights []string) (*Rendered, error) {
+	if luckPillar == "" {
+		luckPillar = "尚未起运"
+	}
+	return Default().Render(PROMPT_ANNUAL_REPORT, map[string]any{
+		"Name":       name,
+		"Gender":     genderText(gender),
+		"Pillars":    strings.Join(pillars, " "),
+		"LuckPillar": luckPillar,
+		"Year":       year,
+		"YearPillar": yearPillar,
+		"Highlights": strings.Join(highlights, "\n"),
+	})
+}
+
+// baziData 八字分析提示的模板变量
+// 参数：
+//   - c: 八字命盘
+//
+// 返回值：
+//   - map[string]any: 模板变量
+func baziData(c *chart.Chart) map[string]any {
+	now := time.Now()
+	timeStr := "未知"
+	if !c.BirthTime.IsZero() {
+		timeStr = c.BirthTime.Format("2006-01-02 15:04:05")
+	}
 
 	// 根据日历类型设置显示文本，默认使用农历
-	switch calendar {
+	var calendarType string
+	switch c.Calendar {
 	case utils.CALENDAR_SOLAR:
 		calendarType = "公历"
 	default:
 		calendarType = "农历"
 	}
 
-	return fmt.Sprintf(BAZI_ANALYSIS_PROMPT,
-		name, gender, timeStr, calendarType,
-		baziInfo["year"], baziInfo["month"], baziInfo["day"], baziInfo["hour"])
+	return map[string]any{
+		"Name":              c.Name,
+		"Gender":            genderText(c.Gender),
+		"BirthTime":         timeStr,
+		"Calendar":          calendarType,
+		"YearPillar":        c.Year.String(),
+		"MonthPillar":       c.Month.String(),
+		"DayPillar":         c.Day.String(),
+		"HourPillar":        c.Hour.String(),
+		"Today":             now.Format("2006-01-02"),
+		"CurrentYear":       now.Year(),
+		"CurrentYearPillar": utils.GetAnnualGanZhi(now),
+		"Age":               ageText(c, now),
+		"LuckPillar":        luckPillarText(c, now.Year()),
+		"ForecastStartYear": now.Year(),
+		"ForecastEndYear":   now.Year() + FORECAST_YEARS - 1,
+	}
+}
+
+// sectionGuides 渲染各章节提示模板，生成写入提示的章节说明
+// 参数：
+//   - keys: 章节键
+//   - data: 八字分析提示的模板变量
+//
+// 返回值：
+//   - string: 章节说明，每个章节以键与标题开头，其后为该章节的分析要求
+//   - error: 章节未知或渲染失败时返回错误
+func sectionGuides(keys []string, data map[string]any) (string, error) {
+	guides := make([]string, 0, len(keys))
+	for _, key := range keys {
+		def, ok := section.Lookup(key)
+		if !ok {
+			return "", fmt.Errorf("未知的分析章节: %s", key)
+		}
+		rendered, err := Default().Render(SectionPromptName(key), data)
+		if err != nil {
+			return "", err
+		}
+		guides = append(guides, fmt.Sprintf("\"%s\"：【%s】\n%s", def.Key, def.Title, strings.TrimSpace(rendered.Text)))
+	}
+	return strings.Join(guides, "\n\n"), nil
+}
+
+// genderText 性别显示文本
+// 参数：
+//   - gender: 性别 (male/female)
+//
+// 返回值：
+//   - string: 男、女，无法识别时原样返回
+func genderText(gender string) string {
+	switch gender {
+	case utils.GENDER_MALE:
+		return "男"
+	case utils.GENDER_FEMALE:
+		return "女"
+	default:
+		return gender
+	}
+}
+
+// ageText 命主当前年龄，同时给出周岁与虚岁
+// 参数：
+//   - c: 八字命盘
+//   - now: 当前时间
+//
+// 返回值：
+//   - string: 年龄描述，出生时间未知时为未知
+func ageText(c *chart.Chart, now time.Time) string {
+	if c.BirthTime.IsZero() {
+		return "未知"
+	}
+
+	birth := utils.ToSolarTime(c.BirthTime, c.Calendar)
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+	return fmt.Sprintf("%d 周岁（虚岁 %d 岁）", max(age, 0), now.Year()-birth.Year()+1)
+}
+
+// luckPillarText 命主某年所行大运
+// 参数：
+//   - c: 八字命盘
+//   - year: 公历年份
+//
+// 返回值：
+//   - string: 大运干支及起止年份，出生时间未知时为未知
+func luckPillarText(c *chart.Chart, year int) string {
+	if c.BirthTime.IsZero() {
+		return "未知"
+	}
+
+	luck := utils.FindLuckPillar(utils.CalculateLuckPillars(c.BirthTime, c.Calendar, c.Gender), year)
+	if luck == nil {
+		return "尚未起运"
+	}
+	return fmt.Sprintf("%s（%d-%d 年）", luck.GanZhi, luck.StartYear, luck.EndYear)
 }
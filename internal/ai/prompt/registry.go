@@ -0,0 +1,517 @@
+// Package prompt 提供版本化的 AI 提示模板注册表
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package prompt
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/ai/section"
+	"github.com/Done-0/metaphysics/internal/global"
+)
+
+// 提示模板名称
+const (
+	PROMPT_BAZI_ANALYSIS  = "bazi_analysis"  // 八字分析
+	PROMPT_BAZI_SECTIONS  = "bazi_sections"  // 八字结构化分析，按章节输出 JSON
+	PROMPT_BAZI_SUBSET    = "bazi_subset"    // 八字指定章节分析，按章节输出 Markdown
+	PROMPT_NAME_MEANING   = "name_meaning"   // 姓名寓意解读
+	PROMPT_CONVERSATION   = "conversation"   // 继续对话
+	PROMPT_FORTUNE_POLISH = "fortune_polish" // 每日运势批量润色
+	PROMPT_ANNUAL_REPORT  = "annual_report"  // 流年报告
+)
+
+// PROMPT_SECTION_PREFIX 章节提示模板名称前缀，模板名称为 {前缀}{章节键}，内容为该章节的分析要求
+const PROMPT_SECTION_PREFIX = "section_"
+
+// DEFAULT_PROMPT_DIR 默认自定义提示模板目录
+const DEFAULT_PROMPT_DIR = "./configs/prompts"
+
+//go:embed data/*.tmpl
+var builtinTemplates embed.FS
+
+// templateFilePattern 模板文件名格式：{名称}.v{版本}.tmpl
+var templateFilePattern = regexp.MustCompile(`^([a-z0-9_]+)\.(v[0-9]+)\.tmpl$`)
+
+// Spec 提示模板规格
+type Spec struct {
+	Variables []string // 代码提供的全部变量
+	Required  []string // 模板必须引用的变量
+}
+
+// baziVariables 八字分析类提示模板共用的变量
+var baziVariables = []string{"Name", "Gender", "BirthTime", "Calendar", "YearPillar", "MonthPillar", "DayPillar", "HourPillar",
+	"Today", "CurrentYear", "CurrentYearPillar", "Age", "LuckPillar", "ForecastStartYear", "ForecastEndYear"}
+
+// specs 各提示模板的规格，加载时据此校验模板引用的变量
+var specs = map[string]Spec{
+	PROMPT_BAZI_ANALYSIS: {
+		Variables: baziVariables,
+		Required:  []string{"YearPillar", "MonthPillar", "DayPillar", "HourPillar", "CurrentYear"},
+	},
+	PROMPT_BAZI_SECTIONS: {
+		Variables: append(slices.Clone(baziVariables), "Sections", "Example", "Feedback"),
+		Required:  []string{"YearPillar", "MonthPillar", "DayPillar", "HourPillar", "CurrentYear", "Sections", "Example"},
+	},
+	PROMPT_BAZI_SUBSET: {
+		Variables: append(slices.Clone(baziVariables), "Sections"),
+		Required:  []string{"YearPillar", "MonthPillar", "DayPillar", "HourPillar", "CurrentYear", "Sections"},
+	},
+	PROMPT_NAME_MEANING: {
+		Variables: []string{"FullName", "Gender", "Favorable"},
+		Required:  []string{"FullName"},
+	},
+	PROMPT_CONVERSATION: {
+		Variables: []string{"History", "Journal", "Question", "Today", "CurrentYear"},
+		Required:  []string{"History", "Question"},
+	},
+	PROMPT_FORTUNE_POLISH: {
+		Variables: []string{"Summaries"},
+		Required:  []string{"Summaries"},
+	},
+	PROMPT_ANNUAL_REPORT: {
+		Variables: []string{"Name", "Gender", "Pillars", "LuckPillar", "YearPillar", "Year", "Highlights"},
+		Required:  []string{"Pillars", "YearPillar", "Highlights"},
+	},
+}
+
+// init 为每个分析章节注册章节提示模板规格
+func init() {
+	for _, key := range section.Keys() {
+		specs[SectionPromptName(key)] = Spec{Variables: baziVariables}
+	}
+}
+
+// SectionPromptName 返回章节提示模板名称
+// 参数：
+//   - key: 章节键
+//
+// 返回值：
+//   - string: 模板名称
+func SectionPromptName(key string) string {
+	return PROMPT_SECTION_PREFIX + key
+}
+
+// Template 已加载的提示模板
+type Template struct {
+	Name    string             // 模板名称
+	Version string             // 模板版本
+	Source  string             // 来源，内置模板为 builtin，自定义模板为文件路径
+	tpl     *template.Template // 解析后的模板
+}
+
+// Rendered 渲染后的提示
+type Rendered struct {
+	Name    string // 模板名称
+	Version string // 模板版本
+	Text    string // 提示文本
+}
+
+// ID 返回提示模板标识，格式为 {名称}@{版本}
+// 返回值：
+//   - string: 模板标识
+func (r *Rendered) ID() string {
+	return r.Name + "@" + r.Version
+}
+
+// catalog 某一时刻加载的全部模板，重新加载时整体替换
+type catalog struct {
+	versions map[string]map[string]*Template // 名称 -> 版本 -> 模板
+	active   map[string]*Template            // 名称 -> 当前使用的模板
+}
+
+// Registry 提示模板注册表
+type Registry struct {
+	mu      sync.Mutex        // 串行化重新加载
+	dir     string            // 自定义模板目录
+	pinned  map[string]string // 指定使用的版本
+	current atomic.Pointer[catalog]
+	watcher *fsnotify.Watcher
+}
+
+var (
+	defaultRegistry *Registry
+	defaultOnce     sync.Once
+)
+
+// Default 返回全局提示模板注册表，首次调用时按配置加载并监听模板目录与配置变更
+// 返回值：
+//   - *Registry: 提示模板注册表
+func Default() *Registry {
+	defaultOnce.Do(func() {
+		dir, pinned := DEFAULT_PROMPT_DIR, map[string]string(nil)
+		if cfg, err := configs.GetConfig(); err == nil {
+			dir, pinned = cfg.PromptConfig.PromptDir, cfg.PromptConfig.PromptVersions
+		}
+
+		defaultRegistry = NewRegistry(dir, pinned)
+		if err := defaultRegistry.Watch(); err != nil {
+			logWarn("监听提示模板目录失败: %v", err)
+		}
+
+		configs.Subscribe(func(_, newConfig *configs.Config, _ map[string][2]any) {
+			defaultRegistry.Configure(newConfig.PromptConfig.PromptDir, newConfig.PromptConfig.PromptVersions)
+		}, "PromptConfig.")
+	})
+	return defaultRegistry
+}
+
+// NewRegistry 创建提示模板注册表并加载内置与自定义模板
+// 参数：
+//   - dir: 自定义模板目录，为空时仅使用内置模板
+//   - pinned: 指定使用的版本，键为模板名称
+//
+// 返回值：
+//   - *Registry: 提示模板注册表
+func NewRegistry(dir string, pinned map[string]string) *Registry {
+	r := &Registry{dir: dir, pinned: pinned}
+	r.Reload()
+	return r
+}
+
+// Configure 修改模板目录与指定版本并重新加载
+// 参数：
+//   - dir: 自定义模板目录
+//   - pinned: 指定使用的版本
+func (r *Registry) Configure(dir string, pinned map[string]string) {
+	r.mu.Lock()
+	dirChanged := r.dir != dir
+	r.dir, r.pinned = dir, pinned
+	r.mu.Unlock()
+
+	r.Reload()
+	if dirChanged {
+		if err := r.Watch(); err != nil {
+			logWarn("监听提示模板目录失败: %v", err)
+		}
+	}
+}
+
+// Reload 重新加载全部模板，无效的自定义模板会被跳过，内置模板始终可用
+func (r *Registry) Reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := &catalog{versions: make(map[string]map[string]*Template), active: make(map[string]*Template)}
+
+	// 先加载内置模板，自定义模板同名同版本时覆盖
+	entries, _ := fs.ReadDir(builtinTemplates, "data")
+	for _, entry := range entries {
+		data, err := builtinTemplates.ReadFile("data/" + entry.Name())
+		if err != nil {
+			panic(fmt.Errorf("读取内置提示模板失败: %w", err))
+		}
+		t, err := parseTemplate(entry.Name(), "builtin", string(data))
+		if err != nil {
+			panic(fmt.Errorf("内置提示模板无效: %w", err))
+		}
+		c.add(t)
+	}
+
+	if r.dir != "" {
+		entries, err := os.ReadDir(r.dir)
+		if err != nil && !os.IsNotExist(err) {
+			logWarn("读取提示模板目录失败: %v", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".tmpl" {
+				continue
+			}
+			path := filepath.Join(r.dir, entry.Name())
+			data, err := os.ReadFile(path)
+			if err != nil {
+				logWarn("读取提示模板失败: %v", err)
+				continue
+			}
+			t, err := parseTemplate(entry.Name(), path, string(data))
+			if err != nil {
+				logWarn("提示模板无效，已跳过: %v", err)
+				continue
+			}
+			c.add(t)
+		}
+	}
+
+	for name, versions := range c.versions {
+		if version := r.pinned[name]; version != "" {
+			if t, ok := versions[version]; ok {
+				c.active[name] = t
+				continue
+			}
+			logWarn("提示模板 %s 不存在版本 %s，使用最新版本", name, version)
+		}
+		c.active[name] = versions[latestVersion(versions)]
+	}
+
+	r.current.Store(c)
+}
+
+// Watch 监听自定义模板目录，文件变化时重新加载，目录不存在时不监听
+// 返回值：
+//   - error: 创建监听失败时返回错误
+func (r *Registry) Watch() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.watcher != nil {
+		r.watcher.Close()
+		r.watcher = nil
+	}
+	if r.dir == "" {
+		return nil
+	}
+	if _, err := os.Stat(r.dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("创建文件监听失败: %w", err)
+	}
+	if err := watcher.Add(r.dir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("监听目录失败: %w", err)
+	}
+	r.watcher = watcher
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Ext(event.Name) == ".tmpl" && !event.Has(fsnotify.Chmod) {
+					r.Reload()
+					logInfo("提示模板已重新加载: %s", event.Name)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logWarn("提示模板目录监听出错: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// Render 使用当前版本渲染提示模板
+// 参数：
+//   - name: 模板名称
+//   - data: 模板变量
+//
+// 返回值：
+//   - *Rendered: 渲染后的提示
+//   - error: 模板不存在或渲染失败时返回错误
+func (r *Registry) Render(name string, data map[string]any) (*Rendered, error) {
+	t, ok := r.current.Load().active[name]
+	if !ok {
+		return nil, fmt.Errorf("提示模板不存在: %s", name)
+	}
+
+	var buf bytes.Buffer
+	if err := t.tpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("渲染提示模板 %s@%s 失败: %w", t.Name, t.Version, err)
+	}
+	return &Rendered{Name: t.Name, Version: t.Version, Text: buf.String()}, nil
+}
+
+// Versions 返回模板的全部可用版本，按版本号升序
+// 参数：
+//   - name: 模板名称
+//
+// 返回值：
+//   - []string: 版本列表
+func (r *Registry) Versions(name string) []string {
+	versions := r.current.Load().versions[name]
+	list := make([]string, 0, len(versions))
+	for version := range versions {
+		list = append(list, version)
+	}
+	slices.SortFunc(list, compareVersion)
+	return list
+}
+
+// Active 返回模板当前使用的版本
+// 参数：
+//   - name: 模板名称
+//
+// 返回值：
+//   - string: 版本，模板不存在时为空
+func (r *Registry) Active(name string) string {
+	if t, ok := r.current.Load().active[name]; ok {
+		return t.Version
+	}
+	return ""
+}
+
+// add 加入模板
+// 参数：
+//   - t: 模板
+func (c *catalog) add(t *Template) {
+	if c.versions[t.Name] == nil {
+		c.versions[t.Name] = make(map[string]*Template)
+	}
+	c.versions[t.Name][t.Version] = t
+}
+
+// parseTemplate 解析并校验模板
+// 参数：
+//   - fileName: 文件名
+//   - source: 来源
+//   - text: 模板内容
+//
+// 返回值：
+//   - *Template: 模板
+//   - error: 文件名不合法、模板未知、语法错误或变量不符合规格时返回错误
+func parseTemplate(fileName, source, text string) (*Template, error) {
+	matches := templateFilePattern.FindStringSubmatch(fileName)
+	if matches == nil {
+		return nil, fmt.Errorf("%s: 文件名应为 {名称}.v{版本}.tmpl", source)
+	}
+	name, version := matches[1], matches[2]
+
+	spec, ok := specs[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: 未知的提示模板 %s", source, name)
+	}
+
+	tpl, err := template.New(fileName).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: 模板语法错误: %w", source, err)
+	}
+
+	referenced := make(map[string]bool)
+	collectFields(tpl.Tree.Root, false, referenced)
+	for field := range referenced {
+		if !slices.Contains(spec.Variables, field) {
+			return nil, fmt.Errorf("%s: 引用了未提供的变量 %s，可用变量：%v", source, field, spec.Variables)
+		}
+	}
+	for _, field := range spec.Required {
+		if !referenced[field] {
+			return nil, fmt.Errorf("%s: 缺少必需变量 %s", source, field)
+		}
+	}
+
+	return &Template{Name: name, Version: version, Source: source, tpl: tpl}, nil
+}
+
+// collectFields 收集模板引用的顶层变量
+// 参数：
+//   - node: 语法树节点
+//   - rebound: 是否位于 range/with 内，此时 . 指向其它值，仅收集 $.X 形式的引用
+//   - fields: 收集结果
+func collectFields(node parse.Node, rebound bool, fields map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectFields(child, rebound, fields)
+		}
+	case *parse.ActionNode:
+		collectFields(n.Pipe, rebound, fields)
+	case *parse.TemplateNode:
+		collectFields(n.Pipe, rebound, fields)
+	case *parse.IfNode:
+		collectFields(n.Pipe, rebound, fields)
+		collectFields(n.List, rebound, fields)
+		collectFields(n.ElseList, rebound, fields)
+	case *parse.RangeNode:
+		collectFields(n.Pipe, rebound, fields)
+		collectFields(n.List, true, fields)
+		collectFields(n.ElseList, rebound, fields)
+	case *parse.WithNode:
+		collectFields(n.Pipe, rebound, fields)
+		collectFields(n.List, true, fields)
+		collectFields(n.ElseList, rebound, fields)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectFields(cmd, rebound, fields)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectFields(arg, rebound, fields)
+		}
+	case *parse.ChainNode:
+		collectFields(n.Node, rebound, fields)
+	case *parse.FieldNode:
+		if !rebound {
+			fields[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			fields[n.Ident[1]] = true
+		}
+	}
+}
+
+// latestVersion 返回最新版本
+// 参数：
+//   - versions: 版本集合
+//
+// 返回值：
+//   - string: 最新版本
+func latestVersion(versions map[string]*Template) string {
+	var latest string
+	for version := range versions {
+		if latest == "" || compareVersion(version, latest) > 0 {
+			latest = version
+		}
+	}
+	return latest
+}
+
+// compareVersion 按版本号比较，如 v10 大于 v9
+// 参数：
+//   - a: 版本
+//   - b: 版本
+//
+// 返回值：
+//   - int: a 小于、等于、大于 b 时分别为负数、0、正数
+func compareVersion(a, b string) int {
+	na, _ := strconv.Atoi(a[1:])
+	nb, _ := strconv.Atoi(b[1:])
+	return na - nb
+}
+
+// logWarn 记录模板加载过程中的告警，日志未初始化时忽略
+// 参数：
+//   - format: 格式化字符串
+//   - args: 参数
+func logWarn(format string, args ...any) {
+	if global.SysLog != nil {
+		global.SysLog.Warnf(format, args...)
+	}
+}
+
+// logInfo 记录模板重新加载等信息，日志未初始化时忽略
+// 参数：
+//   - format: 格式化字符串
+//   - args: 参数
+func logInfo(format string, args ...any) {
+	if global.SysLog != nil {
+		global.SysLog.Infof(format, args...)
+	}
+}
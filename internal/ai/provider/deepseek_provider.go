@@ -0,0 +1,53 @@
+// Package provider 实现 deepseek AI 服务提供者
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/ai/types"
+)
+
+// deepseek 接口默认值
+const (
+	DEEPSEEK_DEFAULT_API_BASE = "https://api.deepseek.com" // 默认 API 基础 URL
+	DEEPSEEK_DEFAULT_MODEL    = "deepseek-reasoner"        // 默认模型
+)
+
+// NewDeepseekProvider deepseek 服务提供者构造器，deepseek 接口与 OpenAI 兼容，推理模型的思考过程通过 reasoning_content 单独返回
+// 参数：
+//
+//	cfg: 配置信息
+//
+// 返回值：
+//
+//	types.Service: deepseek Provider 实例
+//	error: 未配置 API 密钥时返回错误
+func NewDeepseekProvider(cfg *configs.Config) (types.Service, error) {
+	aiCfg := cfg.AIConfig
+	if strings.TrimSpace(aiCfg.DeepseekAPIKey) == "" {
+		return nil, fmt.Errorf("未配置 deepseek API 密钥")
+	}
+
+	apiBase := strings.TrimRight(strings.TrimSpace(aiCfg.DeepseekAPIBase), "/")
+	if apiBase == "" {
+		apiBase = DEEPSEEK_DEFAULT_API_BASE
+	}
+	model := aiCfg.DeepseekModel
+	if model == "" {
+		model = DEEPSEEK_DEFAULT_MODEL
+	}
+
+	return &openAIProvider{
+		provider:    types.PROVIDER_DEEPSEEK,
+		client:      newOpenAIClient(aiCfg),
+		apiBase:     apiBase,
+		apiKey:      aiCfg.DeepseekAPIKey,
+		model:       model,
+		streamUsage: true,
+		structured:  newStructuredOptions(aiCfg),
+	}, nil
+}
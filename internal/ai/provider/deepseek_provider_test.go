@@ -0,0 +1,166 @@
+// Package provider deepseek 服务提供者测试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// newDeepseekStub 创建指向桩服务的 deepseek Provider
+// 参数：
+//
+//	t: 测试上下文
+//	handler: 桩服务处理函数
+//
+// 返回值：
+//
+//	*openAIProvider: deepseek Provider
+func newDeepseekStub(t *testing.T, handler http.HandlerFunc) *openAIProvider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := &configs.Config{}
+	cfg.AIConfig.DeepseekAPIBase = srv.URL + "/"
+	cfg.AIConfig.DeepseekAPIKey = "sk-test"
+	cfg.AIConfig.ProviderTimeoutSeconds = 1
+	cfg.AIConfig.BlockingTimeoutSeconds = 1
+	service, err := NewDeepseekProvider(cfg)
+	if err != nil {
+		t.Fatalf("创建 deepseek Provider 失败: %v", err)
+	}
+	return service.(*openAIProvider)
+}
+
+// collectStream 收集流式响应的思考过程、回答内容与结束块
+// 参数：
+//
+//	t: 测试上下文
+//	p: Provider
+//	promptText: 提示文本
+//
+// 返回值：
+//
+//	string: 思考过程
+//	string: 回答内容
+//	*conversation.StreamChunk: 结束块
+func collectStream(t *testing.T, p *openAIProvider, promptText string) (string, string, *conversation.StreamChunk) {
+	t.Helper()
+	var reasoning, content strings.Builder
+	var done *conversation.StreamChunk
+	err := p.StreamGenerateText(context.Background(), promptText, func(chunk *conversation.StreamChunk) error {
+		if done != nil {
+			t.Fatalf("结束块之后仍有数据块: %+v", chunk)
+		}
+		reasoning.WriteString(chunk.Reasoning)
+		content.WriteString(chunk.Content)
+		if chunk.Done {
+			done = chunk
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("流式生成失败: %v", err)
+	}
+	if done == nil {
+		t.Fatalf("未收到结束块")
+	}
+	return reasoning.String(), content.String(), done
+}
+
+func TestDeepseekCompleteSeparatesReasoningContent(t *testing.T) {
+	p := newDeepseekStub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != openAIChatPath {
+			t.Errorf("请求路径 = %s, 期望 %s", r.URL.Path, openAIChatPath)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("请求解析失败: %v", err)
+		}
+		if req.Model != DEEPSEEK_DEFAULT_MODEL || req.Stream {
+			t.Errorf("请求 = %+v", req)
+		}
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","reasoning_content":"日主偏弱，喜印比","content":"宜从事文教行业"}}],`+
+			`"usage":{"prompt_tokens":12,"completion_tokens":30,"total_tokens":42,"completion_tokens_details":{"reasoning_tokens":18}}}`)
+	})
+
+	reasoning, content, usage, err := p.completeText(context.Background(), "分析八字", false)
+	if err != nil {
+		t.Fatalf("补全失败: %v", err)
+	}
+	if reasoning != "日主偏弱，喜印比" || content != "宜从事文教行业" {
+		t.Errorf("reasoning = %q, content = %q", reasoning, content)
+	}
+	if usage == nil || usage.TotalTokens != 42 || usage.ReasoningTokens != 18 {
+		t.Errorf("usage = %+v", usage)
+	}
+
+	result, err := p.GenerateText(context.Background(), "分析八字")
+	if err != nil {
+		t.Fatalf("生成文本失败: %v", err)
+	}
+	if result.Text != "宜从事文教行业" || result.Provider != types.PROVIDER_DEEPSEEK {
+		t.Errorf("GenerateText = %+v, 不应包含思考过程", result)
+	}
+	if result.Usage == nil || result.Usage.TotalTokens != 42 || result.Usage.ReasoningTokens != 18 {
+		t.Errorf("GenerateText 应返回服务端用量, got %+v", result.Usage)
+	}
+}
+
+func TestDeepseekStreamSeparatesReasoningContent(t *testing.T) {
+	p := newDeepseekStub(t, func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("请求解析失败: %v", err)
+		}
+		if !req.Stream || req.StreamOptions == nil || !req.StreamOptions.IncludeUsage {
+			t.Errorf("流式请求应携带 include_usage: %+v", req)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"reasoning_content\":\"日主偏弱，\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"reasoning_content\":\"喜印比\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"宜从事\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"文教行业\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":30,\"total_tokens\":42,\"completion_tokens_details\":{\"reasoning_tokens\":18}}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	reasoning, content, done := collectStream(t, p, "分析八字")
+	if reasoning != "日主偏弱，喜印比" || content != "宜从事文教行业" {
+		t.Errorf("reasoning = %q, content = %q", reasoning, content)
+	}
+	if done.Usage == nil || done.Usage.TotalTokens != 42 || done.Usage.ReasoningTokens != 18 {
+		t.Errorf("usage = %+v", done.Usage)
+	}
+}
+
+func TestDeepseekClientTimeout(t *testing.T) {
+	release := make(chan struct{})
+	p := newDeepseekStub(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+	defer close(release)
+
+	start := time.Now()
+	if _, err := p.GenerateText(context.Background(), "分析八字"); err == nil {
+		t.Fatalf("服务端无响应时应超时返回错误")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("超时耗时 %v, 未按配置的超时返回", elapsed)
+	}
+}
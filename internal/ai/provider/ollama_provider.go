@@ -6,23 +6,22 @@ package provider
 import (
 	"context"
 	"fmt"
-	"time"
 
-	"github.com/tmc/langchaingo/chains"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/ollama"
-	"github.com/tmc/langchaingo/prompts"
 
 	"github.com/Done-0/metaphysics/configs"
-	"github.com/Done-0/metaphysics/internal/ai/prompt"
+	"github.com/Done-0/metaphysics/internal/ai/section"
+	"github.com/Done-0/metaphysics/internal/ai/tokenizer"
 	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
 	"github.com/Done-0/metaphysics/pkg/vo/conversation"
 )
 
-// ollamaProvider ollama 服务提供者
+// ollamaProvider ollama 服务提供者，创建后不再变更，配置变更时由上层重建实例
 type ollamaProvider struct {
-	config *configs.Config // 配置信息
-	llm    *ollama.LLM     // ollama LLM 实例
+	llm        *ollama.LLM       // ollama LLM 实例
+	structured structuredOptions // 结构化输出选项
 }
 
 // NewOllamaProvider ollama 服务提供者构造器
@@ -35,56 +34,55 @@ type ollamaProvider struct {
 //	types.Service: ollama Provider 实例
 //	error: 错误信息
 func NewOllamaProvider(cfg *configs.Config) (types.Service, error) {
-	return &ollamaProvider{config: cfg}, nil
+	llm, err := ollama.New(
+		ollama.WithServerURL(cfg.AIConfig.OllamaAPIBase),
+		ollama.WithModel(cfg.AIConfig.OllamaModel),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("初始化 ollama LLM 失败: %w", err)
+	}
+	return &ollamaProvider{llm: llm, structured: newStructuredOptions(cfg.AIConfig)}, nil
 }
 
 // AnalyzeBaziWithReasoning 分析八字（带推理过程）
 // 参数：
 //
 //	ctx: 上下文
-//	name: 姓名
-//	gender: 性别
-//	birthTime: 出生时间
-//	calendar: 日历类型 (lunar/solar)
-//	baziInfo: 八字信息
+//	c: 八字命盘
+//	opts: 分析选项
 //
 // 返回值：
 //
-//	*conversation.BaziAnalysisResponse: 分析结果（包含推理过程）
+//	*conversation.BaziAnalysisResponse: 分析结果（包含推理过程与 Token 用量，结构化输出时包含各章节）
 //	error: 错误信息
-func (p *ollamaProvider) AnalyzeBaziWithReasoning(ctx context.Context, name, gender string, birthTime time.Time, calendar string, baziInfo map[string]string) (*conversation.BaziAnalysisResponse, error) {
-	llm, err := p.llmInstance()
-	if err != nil {
-		return nil, fmt.Errorf("获取 ollama LLM 实例失败: %w", err)
+func (p *ollamaProvider) AnalyzeBaziWithReasoning(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions) (*conversation.BaziAnalysisResponse, error) {
+	if opts.Structured {
+		return analyzeStructured(ctx, c, section.Normalize(opts.Sections), p.structured, p.completeText)
 	}
 
-	promptText := prompt.BuildBaziPrompt(name, gender, birthTime, calendar, baziInfo)
-	data := map[string]any{"prompt": promptText}
-	tpl := prompts.NewPromptTemplate("{{.prompt}}", []string{"prompt"})
-	chain := chains.NewLLMChain(llm, tpl)
-	result, err := chain.Call(ctx, data)
+	rendered, err := buildAnalyzePrompt(c, opts)
 	if err != nil {
-		return nil, fmt.Errorf("AI 分析失败: %w", err)
+		return nil, fmt.Errorf("构建分析提示失败: %w", err)
 	}
-	content, ok := result["text"].(string)
-	if !ok {
-		return nil, fmt.Errorf("AI 结果解析失败")
+	promptText := rendered.Text
+	text, usage, err := p.generate(ctx, promptText)
+	if err != nil {
+		return nil, fmt.Errorf("AI 分析失败: %w", err)
 	}
-
-	// 从baziInfo中获取八字信息
-	yearPillar := baziInfo["year"]
-	monthPillar := baziInfo["month"]
-	dayPillar := baziInfo["day"]
-	hourPillar := baziInfo["hour"]
+	reasoning, content := splitThink(text)
 
 	return &conversation.BaziAnalysisResponse{
-		Name:        name,
-		Gender:      gender,
-		YearPillar:  yearPillar,
-		MonthPillar: monthPillar,
-		DayPillar:   dayPillar,
-		HourPillar:  hourPillar,
-		Analysis:    content,
+		Name:          c.Name,
+		Gender:        c.Gender,
+		YearPillar:    c.Year.String(),
+		MonthPillar:   c.Month.String(),
+		DayPillar:     c.Day.String(),
+		HourPillar:    c.Hour.String(),
+		Analysis:      content,
+		Reasoning:     reasoning,
+		Usage:         tokenizer.Complete(usage, promptText, reasoning, content),
+		PromptVersion: rendered.ID(),
+		Sections:      splitSections(content, opts),
 	}, nil
 }
 
@@ -92,30 +90,68 @@ func (p *ollamaProvider) AnalyzeBaziWithReasoning(ctx context.Context, name, gen
 // 参数：
 //
 //	ctx: 上下文
-//	name: 姓名
-//	gender: 性别
-//	birthTime: 出生时间
-//	calendar: 日历类型 (lunar/solar)
-//	baziInfo: 八字信息
+//	c: 八字命盘
+//	opts: 分析选项，指定章节时按章节标题输出 Markdown
 //	handler: 流式响应处理函数
 //
 // 返回值：
 //
 //	error: 错误信息
-func (p *ollamaProvider) StreamAnalyzeBazi(ctx context.Context, name, gender string, birthTime time.Time, calendar string, baziInfo map[string]string, handler types.StreamHandler) error {
-	llm, err := p.llmInstance()
+func (p *ollamaProvider) StreamAnalyzeBazi(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions, handler types.StreamHandler) error {
+	rendered, err := buildAnalyzePrompt(c, opts)
 	if err != nil {
-		return fmt.Errorf("获取 ollama LLM 实例失败: %w", err)
+		return fmt.Errorf("构建分析提示失败: %w", err)
+	}
+	if err := p.StreamGenerateText(ctx, rendered.Text, withPromptVersion(handler, rendered.ID())); err != nil {
+		return fmt.Errorf("流式分析失败: %w", err)
 	}
+	return nil
+}
 
-	promptText := prompt.BuildBaziPrompt(name, gender, birthTime, calendar, baziInfo)
-	_, err = llms.GenerateFromSinglePrompt(ctx, llm, promptText, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
-		return handler(&conversation.StreamChunk{Content: string(chunk)})
+// GenerateText 根据提示生成文本，去除思考标签内的内容，仅返回回答
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//
+// 返回值：
+//
+//	*types.TextResult: 生成结果，包含模型返回的 Token 用量
+//	error: 错误信息
+func (p *ollamaProvider) GenerateText(ctx context.Context, promptText string) (*types.TextResult, error) {
+	_, content, usage, err := p.completeText(ctx, promptText, false)
+	if err != nil {
+		return nil, fmt.Errorf("AI 生成文本失败: %w", err)
+	}
+	return &types.TextResult{Text: content, Provider: types.PROVIDER_OLLAMA, Usage: usage}, nil
+}
+
+// StreamGenerateText 根据提示流式生成文本，解析 <think> 标签，思考过程与回答内容分别通过 Reasoning 与 Content 推送，Token 用量随结束块推送
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (p *ollamaProvider) StreamGenerateText(ctx context.Context, promptText string, handler types.StreamHandler) error {
+	var splitter thinkSplitter
+	text, usage, err := p.generate(ctx, promptText, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		reasoning, content := splitter.feed(string(chunk))
+		return sendThinkSplit(handler, reasoning, content)
 	}))
 	if err != nil {
-		return fmt.Errorf("流式分析失败: %w", err)
+		return fmt.Errorf("AI 流式生成文本失败: %w", err)
 	}
-	return handler(&conversation.StreamChunk{Done: true})
+	reasoning, content := splitter.flush()
+	if err := sendThinkSplit(handler, reasoning, content); err != nil {
+		return err
+	}
+
+	fullReasoning, fullContent := splitThink(text)
+	return handler(&conversation.StreamChunk{Done: true, Usage: tokenizer.Complete(usage, promptText, fullReasoning, fullContent)})
 }
 
 // DetermineProvider 确定要使用的 AI 提供商
@@ -126,22 +162,63 @@ func (p *ollamaProvider) DetermineProvider() types.Provider {
 	return types.PROVIDER_OLLAMA
 }
 
-// llmInstance 获取或初始化 ollama LLM 实例
+// completeText 非流式生成文本，拆分思考过程与回答内容
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	jsonMode: 是否要求模型只输出 JSON
+//
 // 返回值：
 //
-//	*ollama.LLM: ollama LLM 实例
+//	string: 思考过程
+//	string: 回答内容
+//	*conversation.TokenUsage: Token 用量，服务端未返回时为 nil
 //	error: 错误信息
-func (p *ollamaProvider) llmInstance() (*ollama.LLM, error) {
-	if p.llm != nil {
-		return p.llm, nil
+func (p *ollamaProvider) completeText(ctx context.Context, promptText string, jsonMode bool) (string, string, *conversation.TokenUsage, error) {
+	var options []llms.CallOption
+	if jsonMode {
+		options = append(options, llms.WithJSONMode())
 	}
-	llm, err := ollama.New(
-		ollama.WithServerURL(p.config.AIConfig.OllamaAPIBase),
-		ollama.WithModel(p.config.AIConfig.OllamaModel),
-	)
+	text, usage, err := p.generate(ctx, promptText, options...)
 	if err != nil {
-		return nil, fmt.Errorf("初始化 ollama LLM 失败: %w", err)
+		return "", "", nil, err
+	}
+	reasoning, content := splitThink(text)
+	return reasoning, content, usage, nil
+}
+
+// generate 调用模型生成文本
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	options: 调用选项
+//
+// 返回值：
+//
+//	string: 完整输出，含思考标签
+//	*conversation.TokenUsage: 模型返回的 Token 用量，未返回时为 nil
+//	error: 错误信息
+func (p *ollamaProvider) generate(ctx context.Context, promptText string, options ...llms.CallOption) (string, *conversation.TokenUsage, error) {
+	messages := []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, promptText)}
+	resp, err := p.llm.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(resp.Choices) == 0 {
+		return "", nil, fmt.Errorf("模型未返回结果")
 	}
-	p.llm = llm
-	return llm, nil
+
+	choice := resp.Choices[0]
+	promptTokens, _ := choice.GenerationInfo["PromptTokens"].(int)
+	completionTokens, _ := choice.GenerationInfo["CompletionTokens"].(int)
+	if promptTokens == 0 && completionTokens == 0 {
+		return choice.Content, nil, nil
+	}
+	return choice.Content, &conversation.TokenUsage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	}, nil
 }
@@ -0,0 +1,449 @@
+// Package provider 实现 OpenAI 兼容 AI 服务提供者
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/ai/section"
+	"github.com/Done-0/metaphysics/internal/ai/tokenizer"
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// OpenAI 兼容接口常量
+const (
+	openAIChatPath        = "/chat/completions" // 对话补全接口路径，拼接在 API 基础 URL 之后
+	openAIErrorBodyLimit  = 4 << 10             // 读取错误响应体的最大字节数
+	openAIStreamLineLimit = 1 << 20             // 流式响应单行的最大字节数
+)
+
+// openAIProvider OpenAI 兼容服务提供者，适用于 vLLM、LM Studio、各类网关以及 deepseek 等实现了 /v1/chat/completions 的服务
+type openAIProvider struct {
+	provider    types.Provider    // 提供商类型
+	client      *http.Client      // HTTP 客户端
+	apiBase     string            // API 基础 URL，如 http://localhost:8000/v1
+	apiKey      string            // API 密钥，为空时不发送 Authorization 头
+	model       string            // 模型名称
+	headers     map[string]string // 附加请求头
+	streamUsage bool              // 流式请求是否要求服务端在末尾返回 Token 用量
+	structured  structuredOptions // 结构化输出选项
+}
+
+// openAIMessage 对话消息
+type openAIMessage struct {
+	Role             string `json:"role"`                        // 角色 (user/assistant)
+	Content          string `json:"content"`                     // 回答内容
+	ReasoningContent string `json:"reasoning_content,omitempty"` // 推理模型的思考过程
+}
+
+// openAIStreamOptions 流式请求选项
+type openAIStreamOptions struct {
+	IncludeUsage bool `json:"include_usage"` // 是否在最后一个数据块返回 Token 用量
+}
+
+// openAIRequest 对话补全请求
+type openAIRequest struct {
+	Model          string                `json:"model"`                     // 模型名称
+	Messages       []openAIMessage       `json:"messages"`                  // 对话消息
+	Stream         bool                  `json:"stream"`                    // 是否流式返回
+	StreamOptions  *openAIStreamOptions  `json:"stream_options,omitempty"`  // 流式请求选项
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"` // 输出格式，JSON 模式时为 json_object
+}
+
+// openAIResponseFormat 输出格式
+type openAIResponseFormat struct {
+	Type string `json:"type"` // 格式类型 (text/json_object)
+}
+
+// openAIResponse 对话补全响应，流式响应的每个数据块结构相同，内容位于 delta
+type openAIResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"` // 完整消息（非流式）
+		Delta   openAIMessage `json:"delta"`   // 增量消息（流式）
+	} `json:"choices"`
+	Usage *openAIUsage `json:"usage,omitempty"` // Token 用量
+	Error *openAIError `json:"error,omitempty"` // 错误信息
+}
+
+// openAIUsage Token 用量
+type openAIUsage struct {
+	PromptTokens            int `json:"prompt_tokens"`     // 提示 Token 数
+	CompletionTokens        int `json:"completion_tokens"` // 生成 Token 数，含思考过程
+	TotalTokens             int `json:"total_tokens"`      // 总 Token 数
+	CompletionTokensDetails *struct {
+		ReasoningTokens int `json:"reasoning_tokens"` // 思考过程 Token 数
+	} `json:"completion_tokens_details,omitempty"`
+}
+
+// openAIError 接口错误
+type openAIError struct {
+	Message string `json:"message"` // 错误描述
+	Type    string `json:"type"`    // 错误类型
+}
+
+// NewOpenAIProvider OpenAI 兼容服务提供者构造器
+// 参数：
+//
+//	cfg: 配置信息
+//
+// 返回值：
+//
+//	types.Service: OpenAI 兼容 Provider 实例
+//	error: 未配置 API 基础 URL 或模型时返回错误
+func NewOpenAIProvider(cfg *configs.Config) (types.Service, error) {
+	aiCfg := cfg.AIConfig
+	apiBase := strings.TrimRight(strings.TrimSpace(aiCfg.OpenAIAPIBase), "/")
+	if apiBase == "" {
+		return nil, fmt.Errorf("未配置 OpenAI 兼容服务的 API 基础 URL")
+	}
+	if strings.TrimSpace(aiCfg.OpenAIModel) == "" {
+		return nil, fmt.Errorf("未配置 OpenAI 兼容服务的模型名称")
+	}
+
+	return &openAIProvider{
+		provider:    types.PROVIDER_OPENAI,
+		client:      newOpenAIClient(aiCfg),
+		apiBase:     apiBase,
+		apiKey:      strings.TrimSpace(aiCfg.OpenAIAPIKey),
+		model:       aiCfg.OpenAIModel,
+		headers:     aiCfg.OpenAIHeaders,
+		streamUsage: aiCfg.OpenAIStreamUsage,
+		structured:  newStructuredOptions(aiCfg),
+	}, nil
+}
+
+// AnalyzeBaziWithReasoning 分析八字（带推理过程）
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	opts: 分析选项
+//
+// 返回值：
+//
+//	*conversation.BaziAnalysisResponse: 分析结果（包含推理过程与 Token 用量，结构化输出时包含各章节）
+//	error: 错误信息
+func (p *openAIProvider) AnalyzeBaziWithReasoning(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions) (*conversation.BaziAnalysisResponse, error) {
+	if opts.Structured {
+		return analyzeStructured(ctx, c, section.Normalize(opts.Sections), p.structured, p.completeText)
+	}
+
+	rendered, err := buildAnalyzePrompt(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("构建分析提示失败: %w", err)
+	}
+	promptText := rendered.Text
+	message, usage, err := p.complete(ctx, promptText, false)
+	if err != nil {
+		return nil, fmt.Errorf("AI 分析失败: %w", err)
+	}
+	reasoning, content := message.split()
+
+	return &conversation.BaziAnalysisResponse{
+		Name:          c.Name,
+		Gender:        c.Gender,
+		YearPillar:    c.Year.String(),
+		MonthPillar:   c.Month.String(),
+		DayPillar:     c.Day.String(),
+		HourPillar:    c.Hour.String(),
+		Analysis:      content,
+		Reasoning:     reasoning,
+		Usage:         tokenizer.Complete(usage.toVO(), promptText, reasoning, content),
+		PromptVersion: rendered.ID(),
+		Sections:      splitSections(content, opts),
+	}, nil
+}
+
+// StreamAnalyzeBazi 流式分析八字
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	opts: 分析选项，指定章节时按章节标题输出 Markdown
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (p *openAIProvider) StreamAnalyzeBazi(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions, handler types.StreamHandler) error {
+	rendered, err := buildAnalyzePrompt(c, opts)
+	if err != nil {
+		return fmt.Errorf("构建分析提示失败: %w", err)
+	}
+	if err := p.StreamGenerateText(ctx, rendered.Text, withPromptVersion(handler, rendered.ID())); err != nil {
+		return fmt.Errorf("流式分析失败: %w", err)
+	}
+	return nil
+}
+
+// newOpenAIClient 创建 OpenAI 兼容服务共用的 HTTP 客户端，限制等待响应头的时长，不限制后续数据块的读取；
+// 非流式请求在补全完成后才返回响应头，因此取 BLOCKING_TIMEOUT_SECONDS 与 PROVIDER_TIMEOUT_SECONDS 中较长者，任一项不限制时不设上限
+// 参数：
+//
+//	aiCfg: AI 配置
+//
+// 返回值：
+//
+//	*http.Client: HTTP 客户端
+func newOpenAIClient(aiCfg configs.AIConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if aiCfg.ProviderTimeoutSeconds > 0 && aiCfg.BlockingTimeoutSeconds > 0 {
+		transport.ResponseHeaderTimeout = time.Duration(max(aiCfg.ProviderTimeoutSeconds, aiCfg.BlockingTimeoutSeconds)) * time.Second
+	}
+	return &http.Client{Transport: transport}
+}
+
+// GenerateText 根据提示生成文本，仅返回回答内容
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//
+// 返回值：
+//
+//	*types.TextResult: 生成结果，包含服务端返回的 Token 用量
+//	error: 错误信息
+func (p *openAIProvider) GenerateText(ctx context.Context, promptText string) (*types.TextResult, error) {
+	_, content, usage, err := p.completeText(ctx, promptText, false)
+	if err != nil {
+		return nil, fmt.Errorf("AI 生成文本失败: %w", err)
+	}
+	return &types.TextResult{Text: content, Provider: p.provider, Usage: usage}, nil
+}
+
+// StreamGenerateText 根据提示流式生成文本，思考过程与回答内容分别通过 Reasoning 与 Content 推送，Token 用量随结束块推送，服务端未返回时按本地估算
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (p *openAIProvider) StreamGenerateText(ctx context.Context, promptText string, handler types.StreamHandler) error {
+	resp, err := p.post(ctx, promptText, true, false)
+	if err != nil {
+		return fmt.Errorf("AI 流式生成文本失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var usage *openAIUsage
+	var splitter thinkSplitter
+	var fullReasoning, fullContent strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), openAIStreamLineLimit)
+	for scanner.Scan() {
+		// 服务端以 SSE 推送，空行分隔事件，冒号开头的行为保活注释
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk openAIResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("AI 流式响应解析失败: %w", err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("AI 流式生成文本失败: %s", chunk.Error.Message)
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		// 未开启推理解析的服务（如 vLLM）会把 <think> 标签混在回答内容中
+		delta := chunk.Choices[0].Delta
+		reasoning, content := splitter.feed(delta.Content)
+		fullReasoning.WriteString(delta.ReasoningContent + reasoning)
+		fullContent.WriteString(content)
+		if err := sendThinkSplit(handler, delta.ReasoningContent+reasoning, content); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("AI 流式响应读取失败: %w", err)
+	}
+	reasoning, content := splitter.flush()
+	fullReasoning.WriteString(reasoning)
+	fullContent.WriteString(content)
+	if err := sendThinkSplit(handler, reasoning, content); err != nil {
+		return err
+	}
+
+	// 服务端未返回用量时按本地估算
+	return handler(&conversation.StreamChunk{Done: true, Usage: tokenizer.Complete(usage.toVO(), promptText, fullReasoning.String(), fullContent.String())})
+}
+
+// DetermineProvider 确定要使用的 AI 提供商
+// 返回值：
+//
+//	types.Provider: AI 服务提供商
+func (p *openAIProvider) DetermineProvider() types.Provider {
+	return p.provider
+}
+
+// completeText 发送非流式对话补全请求，拆分思考过程与回答内容
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	jsonMode: 是否要求模型只输出 JSON
+//
+// 返回值：
+//
+//	string: 思考过程
+//	string: 回答内容
+//	*conversation.TokenUsage: Token 用量，服务端未返回时为 nil
+//	error: 错误信息
+func (p *openAIProvider) completeText(ctx context.Context, promptText string, jsonMode bool) (string, string, *conversation.TokenUsage, error) {
+	message, usage, err := p.complete(ctx, promptText, jsonMode)
+	if err != nil {
+		return "", "", nil, err
+	}
+	reasoning, content := message.split()
+	return reasoning, content, usage.toVO(), nil
+}
+
+// complete 发送非流式对话补全请求
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	jsonMode: 是否要求模型只输出 JSON
+//
+// 返回值：
+//
+//	*openAIMessage: 回答消息，含思考过程
+//	*openAIUsage: Token 用量，服务端未返回时为 nil
+//	error: 错误信息
+func (p *openAIProvider) complete(ctx context.Context, promptText string, jsonMode bool) (*openAIMessage, *openAIUsage, error) {
+	resp, err := p.post(ctx, promptText, false, jsonMode)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	var result openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, nil, fmt.Errorf("响应解析失败: %w", err)
+	}
+	if result.Error != nil {
+		return nil, nil, fmt.Errorf("%s 返回错误: %s", p.provider, result.Error.Message)
+	}
+	if len(result.Choices) == 0 {
+		return nil, nil, fmt.Errorf("%s 未返回结果", p.provider)
+	}
+	return &result.Choices[0].Message, result.Usage, nil
+}
+
+// post 发送对话补全请求，非 2xx 响应转换为错误
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	stream: 是否流式返回
+//	jsonMode: 是否要求模型只输出 JSON
+//
+// 返回值：
+//
+//	*http.Response: 成功的响应，调用方负责关闭响应体
+//	error: 错误信息
+func (p *openAIProvider) post(ctx context.Context, promptText string, stream, jsonMode bool) (*http.Response, error) {
+	payload := &openAIRequest{
+		Model:    p.model,
+		Messages: []openAIMessage{{Role: "user", Content: promptText}},
+		Stream:   stream,
+	}
+	if stream && p.streamUsage {
+		payload.StreamOptions = &openAIStreamOptions{IncludeUsage: true}
+	}
+	if jsonMode {
+		payload.ResponseFormat = &openAIResponseFormat{Type: "json_object"}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("请求序列化失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiBase+openAIChatPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	for name, value := range p.headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	if stream {
+		req.Header.Set("Accept", "text/event-stream")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("请求 %s 失败: %w", p.provider, err)
+	}
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, openAIErrorBodyLimit))
+	var result openAIResponse
+	if err := json.Unmarshal(data, &result); err == nil && result.Error != nil && result.Error.Message != "" {
+		return nil, fmt.Errorf("%s 请求失败（HTTP %d）: %s", p.provider, resp.StatusCode, result.Error.Message)
+	}
+	return nil, fmt.Errorf("%s 请求失败（HTTP %d）: %s", p.provider, resp.StatusCode, strings.TrimSpace(string(data)))
+}
+
+// split 拆分思考过程与回答内容，服务端未单独返回思考过程时解析回答中的 <think> 标签
+// 返回值：
+//
+//	string: 思考过程
+//	string: 回答内容
+func (m *openAIMessage) split() (string, string) {
+	reasoning, content := splitThink(m.Content)
+	if m.ReasoningContent != "" {
+		reasoning = strings.TrimSpace(m.ReasoningContent + "\n" + reasoning)
+	}
+	return reasoning, content
+}
+
+// toVO 将 Token 用量转换为视图对象
+// 返回值：
+//
+//	*conversation.TokenUsage: Token 用量，未返回用量时为 nil
+func (u *openAIUsage) toVO() *conversation.TokenUsage {
+	if u == nil {
+		return nil
+	}
+	usage := &conversation.TokenUsage{
+		PromptTokens:     u.PromptTokens,
+		CompletionTokens: u.CompletionTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+	if u.CompletionTokensDetails != nil {
+		usage.ReasoningTokens = u.CompletionTokensDetails.ReasoningTokens
+	}
+	return usage
+}
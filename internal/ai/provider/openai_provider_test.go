@@ -0,0 +1,289 @@
+// Package provider OpenAI 兼容服务提供者测试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// newOpenAIStub 创建指向桩服务的 OpenAI 兼容 Provider
+// 参数：
+//
+//	t: 测试上下文
+//	configure: 调整 AI 配置，可为 nil
+//	handler: 桩服务处理函数
+//
+// 返回值：
+//
+//	*openAIProvider: OpenAI 兼容 Provider
+func newOpenAIStub(t *testing.T, configure func(*configs.AIConfig), handler http.HandlerFunc) *openAIProvider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := &configs.Config{}
+	cfg.AIConfig.OpenAIAPIBase = srv.URL + "/v1"
+	cfg.AIConfig.OpenAIModel = "qwen3-8b"
+	cfg.AIConfig.OpenAIStreamUsage = true
+	if configure != nil {
+		configure(&cfg.AIConfig)
+	}
+	service, err := NewOpenAIProvider(cfg)
+	if err != nil {
+		t.Fatalf("创建 OpenAI 兼容 Provider 失败: %v", err)
+	}
+	return service.(*openAIProvider)
+}
+
+// writeSSE 以 SSE 格式逐行写出并刷新
+// 参数：
+//
+//	w: 响应写入器
+//	lines: 待写出的行，空字符串表示事件分隔
+func writeSSE(w http.ResponseWriter, lines ...string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	flusher, _ := w.(http.Flusher)
+	for _, line := range lines {
+		fmt.Fprint(w, line+"\n")
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func TestOpenAICompleteBlocking(t *testing.T) {
+	p := newOpenAIStub(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1"+openAIChatPath {
+			t.Errorf("请求 = %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("未配置 API 密钥时不应发送 Authorization, got %q", got)
+		}
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("请求解析失败: %v", err)
+		}
+		if req.Model != "qwen3-8b" || req.Stream || req.StreamOptions != nil {
+			t.Errorf("请求 = %+v", req)
+		}
+		if len(req.Messages) != 1 || req.Messages[0].Role != "user" || req.Messages[0].Content != "你好" {
+			t.Errorf("messages = %+v", req.Messages)
+		}
+		if req.ResponseFormat == nil || req.ResponseFormat.Type != "json_object" {
+			t.Errorf("JSON 模式应设置 response_format: %+v", req.ResponseFormat)
+		}
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"{\"ok\":true}"}}],"usage":{"prompt_tokens":3,"completion_tokens":5,"total_tokens":8}}`)
+	})
+
+	reasoning, content, usage, err := p.completeText(context.Background(), "你好", true)
+	if err != nil {
+		t.Fatalf("补全失败: %v", err)
+	}
+	if reasoning != "" || content != `{"ok":true}` {
+		t.Errorf("reasoning = %q, content = %q", reasoning, content)
+	}
+	if usage == nil || usage.PromptTokens != 3 || usage.CompletionTokens != 5 || usage.TotalTokens != 8 {
+		t.Errorf("usage = %+v", usage)
+	}
+}
+
+func TestOpenAICompleteSplitsThink(t *testing.T) {
+	p := newOpenAIStub(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"<think>\n先排大运\n</think>\n\n今年宜守成"}}]}`)
+	})
+
+	reasoning, content, usage, err := p.completeText(context.Background(), "流年如何", false)
+	if err != nil {
+		t.Fatalf("补全失败: %v", err)
+	}
+	if reasoning != "先排大运" || content != "今年宜守成" {
+		t.Errorf("reasoning = %q, content = %q", reasoning, content)
+	}
+	if usage != nil {
+		t.Errorf("服务端未返回用量时应为 nil, got %+v", usage)
+	}
+
+	result, err := p.GenerateText(context.Background(), "流年如何")
+	if err != nil {
+		t.Fatalf("生成文本失败: %v", err)
+	}
+	if result.Text != "今年宜守成" || result.Provider != types.PROVIDER_OPENAI || result.Usage != nil {
+		t.Errorf("GenerateText = %+v", result)
+	}
+}
+
+func TestOpenAICustomHeaders(t *testing.T) {
+	p := newOpenAIStub(t, func(aiCfg *configs.AIConfig) {
+		aiCfg.OpenAIAPIKey = " sk-test "
+		aiCfg.OpenAIHeaders = map[string]string{
+			"X-Gateway-Tenant": "metaphysics",
+			"Content-Type":     "text/plain",
+		}
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Gateway-Tenant"); got != "metaphysics" {
+			t.Errorf("X-Gateway-Tenant = %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("附加请求头不应覆盖 Content-Type, got %q", got)
+		}
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"好"}}]}`)
+	})
+
+	if _, err := p.GenerateText(context.Background(), "你好"); err != nil {
+		t.Fatalf("生成文本失败: %v", err)
+	}
+}
+
+func TestOpenAIErrorResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"JSON 错误体", http.StatusUnauthorized, `{"error":{"message":"invalid api key","type":"auth"}}`, "HTTP 401）: invalid api key"},
+		{"纯文本错误体", http.StatusBadGateway, "upstream unavailable\n", "HTTP 502）: upstream unavailable"},
+		{"超长错误体截断", http.StatusInternalServerError, strings.Repeat("x", openAIErrorBodyLimit*2), "HTTP 500）: " + strings.Repeat("x", openAIErrorBodyLimit)},
+		{"2xx 响应中的错误", http.StatusOK, `{"error":{"message":"model not found"}}`, "返回错误: model not found"},
+		{"无结果", http.StatusOK, `{"choices":[]}`, "未返回结果"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newOpenAIStub(t, nil, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			})
+
+			_, err := p.GenerateText(context.Background(), "你好")
+			if err == nil {
+				t.Fatalf("期望返回错误")
+			}
+			if !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), string(types.PROVIDER_OPENAI)) {
+				t.Errorf("err = %v, 期望包含 %q", err, tt.want)
+			}
+			if tt.status == http.StatusInternalServerError && strings.Contains(err.Error(), strings.Repeat("x", openAIErrorBodyLimit+1)) {
+				t.Errorf("错误体未按 %d 字节截断", openAIErrorBodyLimit)
+			}
+		})
+	}
+}
+
+func TestOpenAIStreamErrorStatus(t *testing.T) {
+	p := newOpenAIStub(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":{"message":"rate limited"}}`)
+	})
+
+	err := p.StreamGenerateText(context.Background(), "你好", func(chunk *conversation.StreamChunk) error {
+		t.Errorf("请求失败时不应推送数据块: %+v", chunk)
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 429）: rate limited") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestOpenAIStream(t *testing.T) {
+	p := newOpenAIStub(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "text/event-stream" {
+			t.Errorf("Accept = %q", got)
+		}
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("请求解析失败: %v", err)
+		}
+		if !req.Stream || req.StreamOptions == nil || !req.StreamOptions.IncludeUsage {
+			t.Errorf("流式请求应携带 include_usage: %+v", req)
+		}
+		writeSSE(w,
+			": keep-alive",
+			"",
+			`data: {"choices":[{"delta":{"role":"assistant","content":""}}]}`,
+			"",
+			`data:{"choices":[{"delta":{"content":"今年"}}]}`,
+			"",
+			": ping",
+			`data: {"choices":[{"delta":{"content":"宜守成"}}]}`,
+			"",
+			`data: {"choices":[],"usage":{"prompt_tokens":4,"completion_tokens":6,"total_tokens":10}}`,
+			"",
+			"data: [DONE]",
+			"",
+			`data: {"choices":[{"delta":{"content":"不应读取"}}]}`,
+		)
+	})
+
+	reasoning, content, done := collectStream(t, p, "流年如何")
+	if reasoning != "" || content != "今年宜守成" {
+		t.Errorf("reasoning = %q, content = %q", reasoning, content)
+	}
+	if done.Usage == nil || done.Usage.PromptTokens != 4 || done.Usage.CompletionTokens != 6 || done.Usage.TotalTokens != 10 {
+		t.Errorf("usage = %+v", done.Usage)
+	}
+}
+
+func TestOpenAIStreamWithoutUsage(t *testing.T) {
+	p := newOpenAIStub(t, func(aiCfg *configs.AIConfig) {
+		aiCfg.OpenAIStreamUsage = false
+	}, func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("请求解析失败: %v", err)
+		}
+		if req.StreamOptions != nil {
+			t.Errorf("关闭 OPENAI_STREAM_USAGE 时不应发送 stream_options: %+v", req.StreamOptions)
+		}
+		writeSSE(w, `data: {"choices":[{"delta":{"content":"今年宜守成"}}]}`, "")
+	})
+
+	_, content, done := collectStream(t, p, "流年如何")
+	if content != "今年宜守成" {
+		t.Errorf("content = %q", content)
+	}
+	if done.Usage == nil || done.Usage.TotalTokens == 0 {
+		t.Errorf("服务端未返回用量时应按本地估算, got %+v", done.Usage)
+	}
+}
+
+func TestOpenAIStreamSplitsThink(t *testing.T) {
+	p := newOpenAIStub(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		writeSSE(w,
+			`data: {"choices":[{"delta":{"content":"<thi"}}]}`,
+			`data: {"choices":[{"delta":{"content":"nk>\n先排"}}]}`,
+			`data: {"choices":[{"delta":{"content":"大运</th"}}]}`,
+			`data: {"choices":[{"delta":{"content":"ink>\n\n今年"}}]}`,
+			`data: {"choices":[{"delta":{"content":"宜守成"}}]}`,
+			"data: [DONE]",
+		)
+	})
+
+	reasoning, content, _ := collectStream(t, p, "流年如何")
+	if reasoning != "先排大运" || content != "今年宜守成" {
+		t.Errorf("reasoning = %q, content = %q", reasoning, content)
+	}
+}
+
+func TestOpenAIStreamErrorChunk(t *testing.T) {
+	p := newOpenAIStub(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		writeSSE(w, `data: {"error":{"message":"context length exceeded"}}`, "")
+	})
+
+	err := p.StreamGenerateText(context.Background(), "你好", func(*conversation.StreamChunk) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "context length exceeded") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Package provider 提供八字分析提示的选择与提示模板版本标记
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package provider
+
+import (
+	"github.com/Done-0/metaphysics/internal/ai/prompt"
+	"github.com/Done-0/metaphysics/internal/ai/section"
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// buildAnalyzePrompt 构建非结构化八字分析提示，指定章节时使用章节提示并要求按章节标题输出 Markdown
+// 参数：
+//
+//	c: 八字命盘
+//	opts: 分析选项
+//
+// 返回值：
+//
+//	*prompt.Rendered: 渲染后的提示
+//	error: 渲染失败时返回错误
+func buildAnalyzePrompt(c *chart.Chart, opts types.AnalyzeOptions) (*prompt.Rendered, error) {
+	if len(opts.Sections) > 0 {
+		return prompt.BuildBaziSubsetPrompt(c, section.Normalize(opts.Sections))
+	}
+	return prompt.BuildBaziPrompt(c)
+}
+
+// splitSections 将指定章节分析的 Markdown 输出拆分为章节，模型遗漏的章节不返回
+// 参数：
+//
+//	content: 回答内容
+//	opts: 分析选项
+//
+// 返回值：
+//
+//	[]*conversation.AnalysisSection: 章节列表，未指定章节时为 nil
+func splitSections(content string, opts types.AnalyzeOptions) []*conversation.AnalysisSection {
+	if len(opts.Sections) == 0 {
+		return nil
+	}
+	contents, _ := section.SplitMarkdown(content, section.Normalize(opts.Sections))
+	return section.Build(contents)
+}
+
+// withPromptVersion 包装流式响应处理函数，在结束块中标记所用提示模板版本
+// 参数：
+//
+//	handler: 流式响应处理函数
+//	version: 提示模板版本，格式为 {名称}@{版本}
+//
+// 返回值：
+//
+//	types.StreamHandler: 包装后的处理函数
+func withPromptVersion(handler types.StreamHandler, version string) types.StreamHandler {
+	return func(chunk *conversation.StreamChunk) error {
+		if chunk.Done {
+			chunk.PromptVersion = version
+		}
+		return handler(chunk)
+	}
+}
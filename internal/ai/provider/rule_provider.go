@@ -0,0 +1,156 @@
+// Package provider 实现规则解读 AI 服务提供者
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/internal/interpret"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// ruleProvider 规则解读服务提供者，根据排盘事实与固定措辞库生成解读，不依赖任何模型
+type ruleProvider struct{}
+
+// NewRuleProvider 规则解读服务提供者构造器
+// 返回值：
+//
+//	types.Service: 规则解读 Provider 实例
+//	error: 措辞库加载失败时返回错误
+func NewRuleProvider() (types.Service, error) {
+	if err := interpret.Validate(); err != nil {
+		return nil, err
+	}
+	return &ruleProvider{}, nil
+}
+
+// AnalyzeBaziWithReasoning 分析八字（规则解读）
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	opts: 分析选项
+//
+// 返回值：
+//
+//	*conversation.BaziAnalysisResponse: 分析结果
+//	error: 错误信息，规则解读的段落与分析章节不对应，结构化输出时返回 types.ErrStructuredOutputUnsupported，指定章节时返回 types.ErrSectionAnalysisUnsupported
+func (p *ruleProvider) AnalyzeBaziWithReasoning(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions) (*conversation.BaziAnalysisResponse, error) {
+	if opts.Structured {
+		return nil, types.ErrStructuredOutputUnsupported
+	}
+	if len(opts.Sections) > 0 {
+		return nil, types.ErrSectionAnalysisUnsupported
+	}
+
+	content, err := p.interpret(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conversation.BaziAnalysisResponse{
+		Name:        c.Name,
+		Gender:      c.Gender,
+		YearPillar:  c.Year.String(),
+		MonthPillar: c.Month.String(),
+		DayPillar:   c.Day.String(),
+		HourPillar:  c.Hour.String(),
+		Analysis:    content,
+		Usage:       &conversation.TokenUsage{}, // 规则解读不调用模型，不消耗 Token
+	}, nil
+}
+
+// StreamAnalyzeBazi 流式分析八字，按段落逐块推送规则解读
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	opts: 分析选项
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: 错误信息，指定章节时返回 types.ErrSectionAnalysisUnsupported
+func (p *ruleProvider) StreamAnalyzeBazi(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions, handler types.StreamHandler) error {
+	if len(opts.Sections) > 0 {
+		return types.ErrSectionAnalysisUnsupported
+	}
+
+	content, err := p.interpret(c)
+	if err != nil {
+		return err
+	}
+
+	for _, paragraph := range strings.SplitAfter(content, "\n\n") {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := handler(&conversation.StreamChunk{Content: paragraph}); err != nil {
+			return err
+		}
+	}
+	return handler(&conversation.StreamChunk{Done: true, Usage: &conversation.TokenUsage{}})
+}
+
+// GenerateText 根据提示生成文本，规则解读无法理解自由提示，始终返回不支持
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//
+// 返回值：
+//
+//	*types.TextResult: nil
+//	error: types.ErrGenerateTextUnsupported
+func (p *ruleProvider) GenerateText(ctx context.Context, promptText string) (*types.TextResult, error) {
+	return nil, types.ErrGenerateTextUnsupported
+}
+
+// StreamGenerateText 根据提示流式生成文本，规则解读无法理解自由提示，始终返回不支持
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: types.ErrGenerateTextUnsupported
+func (p *ruleProvider) StreamGenerateText(ctx context.Context, promptText string, handler types.StreamHandler) error {
+	return types.ErrGenerateTextUnsupported
+}
+
+// DetermineProvider 确定要使用的 AI 提供商
+// 返回值：
+//
+//	types.Provider: AI 服务提供商
+func (p *ruleProvider) DetermineProvider() types.Provider {
+	return types.PROVIDER_RULE
+}
+
+// interpret 生成规则解读文本
+// 参数：
+//
+//	c: 八字命盘
+//
+// 返回值：
+//
+//	string: 解读文本
+//	error: 错误信息
+func (p *ruleProvider) interpret(c *chart.Chart) (string, error) {
+	facts, err := interpret.Analyze(c)
+	if err != nil {
+		return "", fmt.Errorf("规则解读失败: %w", err)
+	}
+
+	content, err := interpret.Render(facts)
+	if err != nil {
+		return "", fmt.Errorf("规则解读失败: %w", err)
+	}
+	return content, nil
+}
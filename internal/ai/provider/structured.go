@@ -0,0 +1,136 @@
+// Package provider 提供八字结构化分析的请求、校验与重试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/ai/prompt"
+	"github.com/Done-0/metaphysics/internal/ai/section"
+	"github.com/Done-0/metaphysics/internal/ai/tokenizer"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// DEFAULT_STRUCTURED_MAX_ATTEMPTS 结构化输出校验失败时默认最多请求次数
+const DEFAULT_STRUCTURED_MAX_ATTEMPTS = 3
+
+// structuredOptions 结构化输出选项
+type structuredOptions struct {
+	jsonMode    bool // 是否启用 Provider 的 JSON 模式
+	maxAttempts int  // 最多请求次数
+}
+
+// completeFunc 单次非流式生成
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	jsonMode: 是否要求模型只输出 JSON
+//
+// 返回值：
+//
+//	string: 思考过程
+//	string: 回答内容
+//	*conversation.TokenUsage: Token 用量，服务端未返回时为 nil
+//	error: 错误信息
+type completeFunc func(ctx context.Context, promptText string, jsonMode bool) (string, string, *conversation.TokenUsage, error)
+
+// newStructuredOptions 根据配置创建结构化输出选项
+// 参数：
+//
+//	aiCfg: AI 配置
+//
+// 返回值：
+//
+//	structuredOptions: 结构化输出选项
+func newStructuredOptions(aiCfg configs.AIConfig) structuredOptions {
+	maxAttempts := aiCfg.StructuredMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DEFAULT_STRUCTURED_MAX_ATTEMPTS
+	}
+	return structuredOptions{jsonMode: aiCfg.StructuredJSONMode, maxAttempts: maxAttempts}
+}
+
+// analyzeStructured 结构化分析八字，模型输出经解析与修复后按章节校验，缺失的章节在后续请求中补齐
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	keys: 需要分析的章节键
+//	opts: 结构化输出选项
+//	complete: 单次非流式生成
+//
+// 返回值：
+//
+//	*conversation.BaziAnalysisResponse: 分析结果，Analysis 为各章节拼接的 Markdown
+//	error: 调用失败或多次请求后仍有章节缺失时返回错误
+func analyzeStructured(ctx context.Context, c *chart.Chart, keys []string, opts structuredOptions, complete completeFunc) (*conversation.BaziAnalysisResponse, error) {
+	contents := make(map[string]string, len(keys))
+	usage := &conversation.TokenUsage{}
+	var reasoning []string
+	var promptVersion, feedback string
+
+	for attempt := 1; attempt <= opts.maxAttempts; attempt++ {
+		missing := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if _, ok := contents[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+
+		rendered, err := prompt.BuildBaziSectionsPrompt(c, missing, feedback)
+		if err != nil {
+			return nil, fmt.Errorf("构建结构化分析提示失败: %w", err)
+		}
+		promptVersion = rendered.ID()
+
+		thinking, content, reported, err := complete(ctx, rendered.Text, opts.jsonMode)
+		if err != nil {
+			return nil, fmt.Errorf("AI 结构化分析失败: %w", err)
+		}
+		addUsage(usage, tokenizer.Complete(reported, rendered.Text, thinking, content))
+		if thinking != "" {
+			reasoning = append(reasoning, thinking)
+		}
+
+		parsed, err := section.Parse(content, missing)
+		for key, text := range parsed {
+			contents[key] = text
+		}
+		if err == nil {
+			sections := section.Build(contents)
+			return &conversation.BaziAnalysisResponse{
+				Name:          c.Name,
+				Gender:        c.Gender,
+				YearPillar:    c.Year.String(),
+				MonthPillar:   c.Month.String(),
+				DayPillar:     c.Day.String(),
+				HourPillar:    c.Hour.String(),
+				Analysis:      section.Markdown(sections),
+				Reasoning:     strings.Join(reasoning, "\n\n"),
+				Usage:         usage,
+				PromptVersion: promptVersion,
+				Sections:      sections,
+			}, nil
+		}
+		feedback = err.Error()
+	}
+	return nil, fmt.Errorf("结构化输出校验失败，已请求 %d 次: %s", opts.maxAttempts, feedback)
+}
+
+// addUsage 累加 Token 用量
+// 参数：
+//
+//	total: 累计用量
+//	usage: 本次用量
+func addUsage(total, usage *conversation.TokenUsage) {
+	total.PromptTokens += usage.PromptTokens
+	total.CompletionTokens += usage.CompletionTokens
+	total.ReasoningTokens += usage.ReasoningTokens
+	total.TotalTokens += usage.TotalTokens
+}
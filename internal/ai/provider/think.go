@@ -0,0 +1,138 @@
+// Package provider 提供推理模型思考标签解析
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package provider
+
+import (
+	"strings"
+
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// 思考标签，qwen3、deepseek-r1 等模型在回答内容中以该标签包裹思考过程
+const (
+	THINK_OPEN_TAG  = "<think>"
+	THINK_CLOSE_TAG = "</think>"
+)
+
+// thinkSplitter 将混有思考标签的流式文本拆分为思考过程与回答内容，可处理跨数据块截断的标签
+type thinkSplitter struct {
+	inThink bool   // 当前是否位于思考标签内
+	pending string // 可能是标签前缀、尚未确定归属的文本
+	trim    bool   // 标签切换后是否需要去除开头的换行
+}
+
+// feed 输入一段文本
+// 参数：
+//
+//	text: 流式文本片段
+//
+// 返回值：
+//
+//	string: 思考过程
+//	string: 回答内容
+func (s *thinkSplitter) feed(text string) (string, string) {
+	var reasoning, content strings.Builder
+	buf := s.pending + text
+	s.pending = ""
+
+	for buf != "" {
+		tag := THINK_OPEN_TAG
+		if s.inThink {
+			tag = THINK_CLOSE_TAG
+		}
+
+		if idx := strings.Index(buf, tag); idx >= 0 {
+			s.emit(buf[:idx], &reasoning, &content)
+			buf = buf[idx+len(tag):]
+			s.inThink = !s.inThink
+			s.trim = true
+			continue
+		}
+
+		// 末尾可能是被截断的标签，留待下一个片段
+		keep := 0
+		for k := min(len(tag)-1, len(buf)); k > 0; k-- {
+			if strings.HasSuffix(buf, tag[:k]) {
+				keep = k
+				break
+			}
+		}
+		s.emit(buf[:len(buf)-keep], &reasoning, &content)
+		s.pending = buf[len(buf)-keep:]
+		break
+	}
+	return reasoning.String(), content.String()
+}
+
+// flush 结束输入，返回剩余文本
+// 返回值：
+//
+//	string: 思考过程
+//	string: 回答内容
+func (s *thinkSplitter) flush() (string, string) {
+	var reasoning, content strings.Builder
+	s.emit(s.pending, &reasoning, &content)
+	s.pending = ""
+	return reasoning.String(), content.String()
+}
+
+// emit 按当前状态写入思考过程或回答内容
+// 参数：
+//
+//	text: 文本
+//	reasoning: 思考过程
+//	content: 回答内容
+func (s *thinkSplitter) emit(text string, reasoning, content *strings.Builder) {
+	if s.trim {
+		text = strings.TrimLeft(text, "\r\n")
+		if text == "" {
+			return
+		}
+		s.trim = false
+	}
+	if s.inThink {
+		reasoning.WriteString(text)
+	} else {
+		content.WriteString(text)
+	}
+}
+
+// sendThinkSplit 分别推送拆分后的思考过程与回答内容，为空的部分不推送
+// 参数：
+//
+//	handler: 流式响应处理函数
+//	reasoning: 思考过程
+//	content: 回答内容
+//
+// 返回值：
+//
+//	error: 错误信息
+func sendThinkSplit(handler types.StreamHandler, reasoning, content string) error {
+	if reasoning != "" {
+		if err := handler(&conversation.StreamChunk{Reasoning: reasoning}); err != nil {
+			return err
+		}
+	}
+	if content != "" {
+		return handler(&conversation.StreamChunk{Content: content})
+	}
+	return nil
+}
+
+// splitThink 拆分完整文本中的思考过程与回答内容
+// 参数：
+//
+//	text: 完整文本
+//
+// 返回值：
+//
+//	string: 思考过程
+//	string: 回答内容
+func splitThink(text string) (string, string) {
+	var s thinkSplitter
+	reasoning, content := s.feed(text)
+	restReasoning, restContent := s.flush()
+	return strings.TrimSpace(reasoning + restReasoning), strings.TrimSpace(content + restContent)
+}
@@ -0,0 +1,155 @@
+// Package router 提供多 Provider 路由、故障转移与熔断能力
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package router
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/ai/types"
+)
+
+// 熔断器状态
+const (
+	BREAKER_STATE_CLOSED    = "closed"    // 关闭，正常放行
+	BREAKER_STATE_OPEN      = "open"      // 打开，拒绝请求直至冷却结束
+	BREAKER_STATE_HALF_OPEN = "half_open" // 半开，仅放行一个探测请求
+)
+
+// Health Provider 健康状态快照
+type Health struct {
+	Provider            types.Provider `json:"provider"`             // Provider 名称
+	Weight              int            `json:"weight"`               // 路由权重
+	Fallback            bool           `json:"fallback"`             // 是否为兜底 Provider
+	State               string         `json:"state"`                // 熔断器状态
+	ConsecutiveFailures int            `json:"consecutive_failures"` // 连续失败次数
+	Successes           int64          `json:"successes"`            // 累计成功次数
+	Failures            int64          `json:"failures"`             // 累计失败次数
+	LastError           string         `json:"last_error"`           // 最近一次错误
+	LastLatencyMs       int64          `json:"last_latency_ms"`      // 最近一次成功调用耗时（毫秒）
+	LastSuccessAt       time.Time      `json:"last_success_at"`      // 最近一次成功时间
+	LastFailureAt       time.Time      `json:"last_failure_at"`      // 最近一次失败时间
+	OpenedAt            time.Time      `json:"opened_at"`            // 最近一次熔断时间
+}
+
+// breaker 单个 Provider 的熔断器与健康统计
+type breaker struct {
+	mu        sync.Mutex
+	threshold int           // 连续失败多少次后熔断
+	cooldown  time.Duration // 熔断后多久进入半开状态
+	state     string        // 当前状态
+	probing   bool          // 半开状态下是否已有探测请求在途
+	failures  int           // 连续失败次数
+	health    Health        // 健康统计
+}
+
+// newBreaker 创建熔断器
+// 参数：
+//
+//	provider: Provider 名称
+//	weight: 路由权重
+//	fallback: 是否为兜底 Provider
+//	threshold: 连续失败阈值
+//	cooldown: 熔断冷却时长
+//
+// 返回值：
+//
+//	*breaker: 熔断器
+func newBreaker(provider types.Provider, weight int, fallback bool, threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     BREAKER_STATE_CLOSED,
+		health:    Health{Provider: provider, Weight: weight, Fallback: fallback},
+	}
+}
+
+// allow 判断当前是否放行请求，冷却结束的熔断器转为半开并放行一个探测请求
+// 参数：
+//
+//	now: 当前时间
+//
+// 返回值：
+//
+//	bool: 是否放行
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BREAKER_STATE_OPEN:
+		if now.Sub(b.health.OpenedAt) < b.cooldown {
+			return false
+		}
+		b.state = BREAKER_STATE_HALF_OPEN
+		b.probing = true
+		return true
+	case BREAKER_STATE_HALF_OPEN:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// release 归还未产生结果的放行名额，如调用方主动取消或 Provider 不支持该能力
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// onSuccess 记录一次成功调用，熔断器恢复为关闭状态
+// 参数：
+//
+//	now: 当前时间
+//	latency: 调用耗时
+func (b *breaker) onSuccess(now time.Time, latency time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BREAKER_STATE_CLOSED
+	b.probing = false
+	b.failures = 0
+	b.health.Successes++
+	b.health.LastLatencyMs = latency.Milliseconds()
+	b.health.LastSuccessAt = now
+}
+
+// onFailure 记录一次失败调用，达到阈值或半开探测失败时熔断
+// 参数：
+//
+//	now: 当前时间
+//	err: 错误信息
+func (b *breaker) onFailure(now time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.health.Failures++
+	b.health.LastError = err.Error()
+	b.health.LastFailureAt = now
+	if b.state == BREAKER_STATE_HALF_OPEN || b.failures >= b.threshold {
+		b.state = BREAKER_STATE_OPEN
+		b.health.OpenedAt = now
+	}
+	b.probing = false
+}
+
+// snapshot 返回健康状态快照
+// 返回值：
+//
+//	Health: 健康状态
+func (b *breaker) snapshot() Health {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h := b.health
+	h.State = b.state
+	h.ConsecutiveFailures = b.failures
+	return h
+}
@@ -0,0 +1,416 @@
+// Package router 提供多 Provider 路由、故障转移与熔断能力
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// 路由模式
+const (
+	ROUTING_MODE_PRIORITY = "priority" // 按优先级顺序选择，失败时依次转移
+	ROUTING_MODE_WEIGHTED = "weighted" // 按权重随机选择首选 Provider，用于灰度与 A/B 分流，失败时按剩余权重与优先级转移
+)
+
+// 路由默认值
+const (
+	DEFAULT_FAILURE_THRESHOLD = 3                // 默认连续失败阈值
+	DEFAULT_COOLDOWN          = 30 * time.Second // 默认熔断冷却时长
+)
+
+// ErrNoAvailableProvider 所有 Provider 均处于熔断状态
+var ErrNoAvailableProvider = errors.New("没有可用的 AI 服务")
+
+// Member 参与路由的 Provider
+type Member struct {
+	Service  types.Service // Provider 实例
+	Weight   int           // 权重，仅加权模式生效，0 表示仅作为故障转移备选
+	Fallback bool          // 是否为兜底 Provider，由其响应时在结果中标记 Fallback
+}
+
+// Options 路由选项
+type Options struct {
+	Mode             string        // 路由模式
+	StreamTimeout    time.Duration // 流式请求等待首个数据块的时长，0 表示不限制
+	BlockingTimeout  time.Duration // 非流式请求的超时时长，结构化分析的修复与重试均计入其中，0 表示不限制
+	FailureThreshold int           // 连续失败多少次后熔断
+	Cooldown         time.Duration // 熔断后多久放行探测请求
+}
+
+// member 路由成员
+type member struct {
+	service  types.Service
+	provider types.Provider
+	weight   int
+	fallback bool
+	breaker  *breaker
+}
+
+// Router 多 Provider 路由器，实现 types.Service
+type Router struct {
+	members []*member
+	opts    Options
+}
+
+// New 创建路由器
+// 参数：
+//
+//	members: 按优先级排列的 Provider
+//	opts: 路由选项
+//
+// 返回值：
+//
+//	*Router: 路由器
+//	error: 未提供 Provider 或路由模式无效时返回错误
+func New(members []Member, opts Options) (*Router, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("未提供任何 AI Provider")
+	}
+	switch opts.Mode {
+	case "":
+		opts.Mode = ROUTING_MODE_PRIORITY
+	case ROUTING_MODE_PRIORITY, ROUTING_MODE_WEIGHTED:
+	default:
+		return nil, fmt.Errorf("无效的路由模式: %s", opts.Mode)
+	}
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = DEFAULT_FAILURE_THRESHOLD
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = DEFAULT_COOLDOWN
+	}
+
+	r := &Router{opts: opts}
+	for _, m := range members {
+		provider := m.Service.DetermineProvider()
+		weight := max(m.Weight, 0)
+		r.members = append(r.members, &member{
+			service:  m.Service,
+			provider: provider,
+			weight:   weight,
+			fallback: m.Fallback,
+			breaker:  newBreaker(provider, weight, m.Fallback, opts.FailureThreshold, opts.Cooldown),
+		})
+	}
+	return r, nil
+}
+
+// AnalyzeBaziWithReasoning 分析八字（带推理过程），失败或不支持所需能力时转移至下一个 Provider
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	opts: 分析选项
+//
+// 返回值：
+//
+//	*conversation.BaziAnalysisResponse: 分析结果，Provider 为实际响应的 Provider，由兜底 Provider 响应时 Fallback 为 true
+//	error: 错误信息
+func (r *Router) AnalyzeBaziWithReasoning(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions) (*conversation.BaziAnalysisResponse, error) {
+	var resp *conversation.BaziAnalysisResponse
+	m, err := r.call(ctx, func(ctx context.Context, m *member) error {
+		var err error
+		resp, err = m.service.AnalyzeBaziWithReasoning(ctx, c, opts)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Provider = string(m.provider)
+	resp.Fallback = m.fallback
+	return resp, nil
+}
+
+// StreamAnalyzeBazi 流式分析八字，尚未推送任何数据块时失败才会转移至下一个 Provider
+// 参数：
+//
+//	ctx: 上下文
+//	c: 八字命盘
+//	opts: 分析选项
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (r *Router) StreamAnalyzeBazi(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions, handler types.StreamHandler) error {
+	return r.stream(ctx, handler, func(ctx context.Context, m *member, handler types.StreamHandler) error {
+		return m.service.StreamAnalyzeBazi(ctx, c, opts, handler)
+	})
+}
+
+// GenerateText 根据提示生成文本，失败时转移至下一个 Provider
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//
+// 返回值：
+//
+//	*types.TextResult: 生成结果，Provider 为实际响应的 Provider，由兜底 Provider 响应时 Fallback 为 true
+//	error: 错误信息
+func (r *Router) GenerateText(ctx context.Context, promptText string) (*types.TextResult, error) {
+	var result *types.TextResult
+	m, err := r.call(ctx, func(ctx context.Context, m *member) error {
+		var err error
+		result, err = m.service.GenerateText(ctx, promptText)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Provider = m.provider
+	result.Fallback = m.fallback
+	return result, nil
+}
+
+// StreamGenerateText 根据提示流式生成文本，尚未推送任何数据块时失败才会转移至下一个 Provider
+// 参数：
+//
+//	ctx: 上下文
+//	promptText: 完整提示文本
+//	handler: 流式响应处理函数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (r *Router) StreamGenerateText(ctx context.Context, promptText string, handler types.StreamHandler) error {
+	return r.stream(ctx, handler, func(ctx context.Context, m *member, handler types.StreamHandler) error {
+		return m.service.StreamGenerateText(ctx, promptText, handler)
+	})
+}
+
+// DetermineProvider 返回优先级最高的 Provider，实际响应的 Provider 随各次调用的响应返回，并发请求之间互不影响
+// 返回值：
+//
+//	types.Provider: AI 服务提供商
+func (r *Router) DetermineProvider() types.Provider {
+	return r.members[0].provider
+}
+
+// Health 返回各 Provider 的健康状态，按优先级排列
+// 返回值：
+//
+//	[]Health: 健康状态列表
+func (r *Router) Health() []Health {
+	list := make([]Health, 0, len(r.members))
+	for _, m := range r.members {
+		list = append(list, m.breaker.snapshot())
+	}
+	return list
+}
+
+// call 依次尝试候选 Provider 直至成功
+// 参数：
+//
+//	ctx: 上下文
+//	fn: 调用单个 Provider 的函数
+//
+// 返回值：
+//
+//	*member: 实际响应的 Provider
+//	error: 所有候选均失败时返回最后一个错误
+func (r *Router) call(ctx context.Context, fn func(ctx context.Context, m *member) error) (*member, error) {
+	var lastErr error
+	for _, m := range r.candidates() {
+		if !m.breaker.allow(time.Now()) {
+			continue
+		}
+
+		start := time.Now()
+		attemptCtx, cancel := r.withTimeout(ctx)
+		err := fn(attemptCtx, m)
+		// 调用方自身的截止时间到期不算 Provider 超时，由 settle 释放熔断器
+		timedOut := attemptCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+		cancel()
+
+		if err == nil {
+			m.breaker.onSuccess(time.Now(), time.Since(start))
+			return m, nil
+		}
+		if done, err := r.settle(ctx, m, err, timedOut); done {
+			return nil, err
+		} else if lastErr == nil || !types.IsUnsupported(err) {
+			lastErr = err
+		}
+	}
+	return nil, r.exhausted(lastErr)
+}
+
+// stream 依次尝试候选 Provider 的流式调用，已向调用方推送数据块后不再转移
+// 参数：
+//
+//	ctx: 上下文
+//	handler: 调用方的流式响应处理函数
+//	fn: 调用单个 Provider 的函数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (r *Router) stream(ctx context.Context, handler types.StreamHandler, fn func(ctx context.Context, m *member, handler types.StreamHandler) error) error {
+	var lastErr error
+	for _, m := range r.candidates() {
+		if !m.breaker.allow(time.Now()) {
+			continue
+		}
+
+		start := time.Now()
+		attemptCtx, cancel := context.WithCancel(ctx)
+		var timer *time.Timer
+		var timedOut atomic.Bool
+		if r.opts.StreamTimeout > 0 {
+			timer = time.AfterFunc(r.opts.StreamTimeout, func() {
+				timedOut.Store(true)
+				cancel()
+			})
+		}
+
+		emitted := false
+		var handlerErr error
+		err := fn(attemptCtx, m, func(chunk *conversation.StreamChunk) error {
+			if !emitted {
+				emitted = true
+				if timer != nil {
+					timer.Stop()
+				}
+			}
+			chunk.Provider = string(m.provider)
+			chunk.Fallback = m.fallback
+			handlerErr = handler(chunk)
+			return handlerErr
+		})
+		if timer != nil {
+			timer.Stop()
+		}
+		cancel()
+
+		switch {
+		case err == nil:
+			m.breaker.onSuccess(time.Now(), time.Since(start))
+			return nil
+		case handlerErr != nil:
+			// 调用方处理失败（如客户端断开），与 Provider 健康无关
+			m.breaker.release()
+			return err
+		case emitted:
+			// 已推送的内容无法撤回，只能记录失败并返回
+			m.breaker.onFailure(time.Now(), err)
+			return fmt.Errorf("%s 流式响应中断: %w", m.provider, err)
+		}
+		if done, err := r.settle(ctx, m, err, timedOut.Load()); done {
+			return err
+		} else if lastErr == nil || !types.IsUnsupported(err) {
+			lastErr = err
+		}
+	}
+	return r.exhausted(lastErr)
+}
+
+// settle 处理单次调用失败，判断是否继续转移
+// 参数：
+//
+//	ctx: 调用方上下文
+//	m: 失败的 Provider
+//	err: 错误信息
+//	timedOut: 是否因超时失败
+//
+// 返回值：
+//
+//	bool: 是否停止转移并直接返回
+//	error: 包装后的错误
+func (r *Router) settle(ctx context.Context, m *member, err error, timedOut bool) (bool, error) {
+	switch {
+	case ctx.Err() != nil:
+		// 调用方已取消，不计入 Provider 失败
+		m.breaker.release()
+		return true, err
+	case types.IsUnsupported(err):
+		// 能力不支持不代表故障，直接尝试下一个
+		m.breaker.release()
+		return false, err
+	case timedOut:
+		err = fmt.Errorf("%s 响应超时: %w", m.provider, err)
+	default:
+		err = fmt.Errorf("%s 调用失败: %w", m.provider, err)
+	}
+	m.breaker.onFailure(time.Now(), err)
+	return false, err
+}
+
+// exhausted 所有候选均未成功时的错误
+// 参数：
+//
+//	lastErr: 最后一个失败错误，候选均被熔断时为 nil
+//
+// 返回值：
+//
+//	error: 错误信息
+func (r *Router) exhausted(lastErr error) error {
+	if lastErr != nil {
+		return lastErr
+	}
+	return ErrNoAvailableProvider
+}
+
+// withTimeout 为单次非流式调用设置超时
+// 参数：
+//
+//	ctx: 上下文
+//
+// 返回值：
+//
+//	context.Context: 带超时的上下文
+//	context.CancelFunc: 取消函数
+func (r *Router) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.opts.BlockingTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.opts.BlockingTimeout)
+}
+
+// candidates 返回本次请求的候选顺序，加权模式下按权重随机排列有权重的 Provider，其余按优先级追加
+// 返回值：
+//
+//	[]*member: 候选列表
+func (r *Router) candidates() []*member {
+	if r.opts.Mode != ROUTING_MODE_WEIGHTED {
+		return r.members
+	}
+
+	weighted := make([]*member, 0, len(r.members))
+	fallback := make([]*member, 0, len(r.members))
+	total := 0
+	for _, m := range r.members {
+		if m.weight > 0 {
+			weighted = append(weighted, m)
+			total += m.weight
+		} else {
+			fallback = append(fallback, m)
+		}
+	}
+
+	// 不放回的加权随机抽样
+	ordered := make([]*member, 0, len(r.members))
+	for len(weighted) > 0 {
+		pick := rand.IntN(total)
+		for i, m := range weighted {
+			if pick < m.weight {
+				ordered = append(ordered, m)
+				total -= m.weight
+				weighted = append(weighted[:i], weighted[i+1:]...)
+				break
+			}
+			pick -= m.weight
+		}
+	}
+	return append(ordered, fallback...)
+}
@@ -0,0 +1,127 @@
+// Package router 路由器超时与熔断测试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package router
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// delayService 延迟响应的 Provider，延迟期间上下文结束时返回上下文错误
+type delayService struct {
+	delay time.Duration
+}
+
+func (s *delayService) AnalyzeBaziWithReasoning(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions) (*conversation.BaziAnalysisResponse, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &conversation.BaziAnalysisResponse{Analysis: "分析"}, nil
+}
+
+func (s *delayService) StreamAnalyzeBazi(ctx context.Context, c *chart.Chart, opts types.AnalyzeOptions, handler types.StreamHandler) error {
+	return s.StreamGenerateText(ctx, "", handler)
+}
+
+func (s *delayService) GenerateText(ctx context.Context, promptText string) (*types.TextResult, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &types.TextResult{Text: "回复"}, nil
+}
+
+func (s *delayService) StreamGenerateText(ctx context.Context, promptText string, handler types.StreamHandler) error {
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+	return handler(&conversation.StreamChunk{Done: true})
+}
+
+func (s *delayService) DetermineProvider() types.Provider {
+	return types.PROVIDER_OPENAI
+}
+
+// wait 等待延迟结束或上下文结束
+// 参数：
+//
+//	ctx: 上下文
+//
+// 返回值：
+//
+//	error: 上下文先结束时返回上下文错误
+func (s *delayService) wait(ctx context.Context) error {
+	select {
+	case <-time.After(s.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newDelayRouter 创建仅包含延迟 Provider 的路由器
+// 参数：
+//
+//	t: 测试上下文
+//	delay: 响应延迟
+//	opts: 路由选项
+//
+// 返回值：
+//
+//	*Router: 路由器
+func newDelayRouter(t *testing.T, delay time.Duration, opts Options) *Router {
+	t.Helper()
+	r, err := New([]Member{{Service: &delayService{delay: delay}}}, opts)
+	if err != nil {
+		t.Fatalf("创建路由器失败: %v", err)
+	}
+	return r
+}
+
+func TestBlockingTimeoutIndependentOfStreamTimeout(t *testing.T) {
+	r := newDelayRouter(t, 50*time.Millisecond, Options{StreamTimeout: 10 * time.Millisecond, BlockingTimeout: time.Second})
+
+	if _, err := r.AnalyzeBaziWithReasoning(context.Background(), nil, types.AnalyzeOptions{}); err != nil {
+		t.Fatalf("非流式请求不应受首块超时限制: %v", err)
+	}
+	if err := r.StreamGenerateText(context.Background(), "", func(*conversation.StreamChunk) error { return nil }); err == nil {
+		t.Fatalf("流式请求应按首块超时失败")
+	}
+}
+
+func TestBlockingTimeoutCountsAsFailure(t *testing.T) {
+	r := newDelayRouter(t, time.Second, Options{BlockingTimeout: 20 * time.Millisecond})
+
+	_, err := r.GenerateText(context.Background(), "你好")
+	if err == nil || !strings.Contains(err.Error(), "响应超时") {
+		t.Fatalf("err = %v, 期望响应超时", err)
+	}
+	if health := r.Health()[0]; health.Failures != 1 {
+		t.Errorf("Provider 超时应计入失败, got %+v", health)
+	}
+}
+
+func TestCallerDeadlineNotCountedAsFailure(t *testing.T) {
+	r := newDelayRouter(t, time.Second, Options{StreamTimeout: time.Second, BlockingTimeout: time.Second, FailureThreshold: 1})
+
+	for range 2 {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := r.GenerateText(ctx, "你好")
+		cancel()
+		if err == nil {
+			t.Fatalf("调用方截止时间到期时应返回错误")
+		}
+		if strings.Contains(err.Error(), "响应超时") {
+			t.Errorf("调用方截止时间到期不应视为 Provider 超时: %v", err)
+		}
+	}
+	if health := r.Health()[0]; health.Failures != 0 || health.State != BREAKER_STATE_CLOSED {
+		t.Errorf("调用方截止时间到期不应计入失败或触发熔断, got %+v", health)
+	}
+}
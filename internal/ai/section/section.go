@@ -0,0 +1,361 @@
+// Package section 提供八字分析章节定义及结构化输出的解析、修复与校验
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package section
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// 分析章节键
+const (
+	SECTION_PERSONALITY = "personality" // 性格
+	SECTION_FAMILY      = "family"      // 六亲
+	SECTION_MARRIAGE    = "marriage"    // 婚姻
+	SECTION_HEALTH      = "health"      // 健康
+	SECTION_CAREER      = "career"      // 事业
+	SECTION_WEALTH      = "wealth"      // 财运
+	SECTION_WUXING      = "wuxing"      // 五行
+	SECTION_LUCK        = "luck"        // 大运
+	SECTION_FORECAST    = "forecast"    // 五年预测
+)
+
+// Definition 章节定义，章节的分析要求由提示模板 section_{章节键} 提供
+type Definition struct {
+	Key   string // 章节键，即 JSON 字段名
+	Title string // 章节标题
+}
+
+// Schema 八字分析的全部章节，按输出顺序排列
+var Schema = []Definition{
+	{SECTION_PERSONALITY, "性格与根性分析"},
+	{SECTION_FAMILY, "家庭与六亲关系"},
+	{SECTION_MARRIAGE, "感情婚姻趋势"},
+	{SECTION_HEALTH, "健康体质与潜在隐疾"},
+	{SECTION_CAREER, "学业/事业发展"},
+	{SECTION_WEALTH, "财运结构与财富趋势"},
+	{SECTION_WUXING, "五行结构与用神喜忌"},
+	{SECTION_LUCK, "大运走势与命运转折"},
+	{SECTION_FORECAST, "五年重大预测"},
+}
+
+// codeFencePattern Markdown 代码块标记
+var codeFencePattern = regexp.MustCompile("(?m)^\\s*```[a-zA-Z]*\\s*$")
+
+// trailingCommaPattern 对象或数组末尾多余的逗号
+var trailingCommaPattern = regexp.MustCompile(`,(\s*[}\]])`)
+
+// Keys 返回全部章节键
+// 返回值：
+//   - []string: 章节键，按输出顺序排列
+func Keys() []string {
+	keys := make([]string, 0, len(Schema))
+	for _, def := range Schema {
+		keys = append(keys, def.Key)
+	}
+	return keys
+}
+
+// Normalize 规范章节键列表：去除未知与重复的键并按章节顺序排列，为空时返回全部章节
+// 参数：
+//   - keys: 章节键
+//
+// 返回值：
+//   - []string: 规范后的章节键
+func Normalize(keys []string) []string {
+	if len(keys) == 0 {
+		return Keys()
+	}
+	normalized := make([]string, 0, len(keys))
+	for _, def := range Schema {
+		if slices.Contains(keys, def.Key) {
+			normalized = append(normalized, def.Key)
+		}
+	}
+	if len(normalized) == 0 {
+		return Keys()
+	}
+	return normalized
+}
+
+// Index 返回章节在全部章节中的顺序
+// 参数：
+//   - key: 章节键
+//
+// 返回值：
+//   - int: 顺序，从 0 开始，章节不存在时为 -1
+func Index(key string) int {
+	return slices.IndexFunc(Schema, func(def Definition) bool { return def.Key == key })
+}
+
+// Lookup 查找章节定义
+// 参数：
+//   - key: 章节键
+//
+// 返回值：
+//   - Definition: 章节定义
+//   - bool: 章节是否存在
+func Lookup(key string) (Definition, bool) {
+	if i := Index(key); i >= 0 {
+		return Schema[i], true
+	}
+	return Definition{}, false
+}
+
+// Example 生成写入提示的 JSON 输出示例
+// 参数：
+//   - keys: 章节键
+//
+// 返回值：
+//   - string: JSON 示例
+func Example(keys []string) string {
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("  \"%s\": \"……\"", key))
+	}
+	return "{\n" + strings.Join(lines, ",\n") + "\n}"
+}
+
+// Parse 解析模型输出的 JSON，必要时修复常见格式问题，并校验所需章节
+// 参数：
+//   - text: 模型输出
+//   - keys: 所需章节键
+//
+// 返回值：
+//   - map[string]string: 成功解析的章节内容，键为章节键，即使返回错误也包含已解析的章节
+//   - error: 无法解析或缺少章节时返回错误，错误信息可作为重试时的修正提示
+func Parse(text string, keys []string) (map[string]string, error) {
+	raw, err := decode(text)
+	if err != nil {
+		return map[string]string{}, err
+	}
+
+	// 兼容以 sections 包裹的输出
+	if inner, ok := raw["sections"]; ok && len(raw) == 1 {
+		switch v := inner.(type) {
+		case map[string]any:
+			raw = v
+		case []any:
+			raw = make(map[string]any, len(v))
+			for _, item := range v {
+				if obj, ok := item.(map[string]any); ok {
+					if key, ok := obj["key"].(string); ok {
+						raw[key] = obj["content"]
+					}
+				}
+			}
+		}
+	}
+
+	sections := make(map[string]string, len(keys))
+	for field, value := range raw {
+		key := normalizeKey(field)
+		if key == "" {
+			continue
+		}
+		if content := strings.TrimSpace(flatten(value)); content != "" {
+			sections[key] = content
+		}
+	}
+
+	var missing []string
+	for _, key := range keys {
+		if _, ok := sections[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	for key := range sections {
+		if !slices.Contains(keys, key) {
+			delete(sections, key)
+		}
+	}
+	if len(missing) > 0 {
+		return sections, fmt.Errorf("缺少章节或章节内容为空: %s", strings.Join(missing, ", "))
+	}
+	return sections, nil
+}
+
+// Build 按章节顺序组装章节列表
+// 参数：
+//   - contents: 章节内容，键为章节键
+//
+// 返回值：
+//   - []*conversation.AnalysisSection: 章节列表
+func Build(contents map[string]string) []*conversation.AnalysisSection {
+	sections := make([]*conversation.AnalysisSection, 0, len(contents))
+	for _, def := range Schema {
+		if content, ok := contents[def.Key]; ok {
+			sections = append(sections, &conversation.AnalysisSection{Key: def.Key, Title: def.Title, Content: content})
+		}
+	}
+	return sections
+}
+
+// Markdown 将章节拼接为 Markdown 文本，供仅展示整段分析的客户端使用
+// 参数：
+//   - sections: 章节列表
+//
+// 返回值：
+//   - string: Markdown 文本
+func Markdown(sections []*conversation.AnalysisSection) string {
+	var sb strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(fmt.Sprintf("## %s\n\n%s", s.Title, s.Content))
+	}
+	return sb.String()
+}
+
+// SplitMarkdown 按二级标题将 Markdown 文本拆分为章节，标题需与章节标题一致
+// 参数：
+//   - text: Markdown 文本
+//   - keys: 所需章节键
+//
+// 返回值：
+//   - map[string]string: 拆分出的章节内容，键为章节键，即使返回错误也包含已拆分的章节
+//   - error: 缺少章节时返回错误
+func SplitMarkdown(text string, keys []string) (map[string]string, error) {
+	sections := make(map[string]string, len(keys))
+	var current string
+	var body []string
+	flush := func() {
+		if current != "" {
+			if content := strings.TrimSpace(strings.Join(body, "\n")); content != "" {
+				sections[current] = content
+			}
+		}
+		body = body[:0]
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if heading, ok := strings.CutPrefix(strings.TrimSpace(line), "## "); ok {
+			if key := normalizeKey(strings.Trim(strings.TrimSpace(heading), "【】")); key != "" && slices.Contains(keys, key) {
+				flush()
+				current = key
+				continue
+			}
+		}
+		body = append(body, line)
+	}
+	flush()
+
+	var missing []string
+	for _, key := range keys {
+		if _, ok := sections[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return sections, fmt.Errorf("缺少章节或章节内容为空: %s", strings.Join(missing, ", "))
+	}
+	return sections, nil
+}
+
+// decode 提取并解析 JSON 对象，解析失败时修复后重试
+// 参数：
+//   - text: 模型输出
+//
+// 返回值：
+//   - map[string]any: JSON 对象
+//   - error: 无法解析时返回错误
+func decode(text string) (map[string]any, error) {
+	text = codeFencePattern.ReplaceAllString(text, "")
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end <= start {
+		return nil, fmt.Errorf("输出中未找到 JSON 对象")
+	}
+	text = text[start : end+1]
+
+	var raw map[string]any
+	err := json.Unmarshal([]byte(text), &raw)
+	if err == nil {
+		return raw, nil
+	}
+
+	repaired := trailingCommaPattern.ReplaceAllString(escapeControlChars(text), "$1")
+	if json.Unmarshal([]byte(repaired), &raw) == nil {
+		return raw, nil
+	}
+	return nil, fmt.Errorf("JSON 格式错误: %w", err)
+}
+
+// escapeControlChars 转义字符串内未转义的换行、回车与制表符，模型输出多行文本时常见该问题
+// 参数：
+//   - text: JSON 文本
+//
+// 返回值：
+//   - string: 修复后的 JSON 文本
+func escapeControlChars(text string) string {
+	var sb strings.Builder
+	inString, escaped := false, false
+	for _, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && inString:
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case inString && r == '\n':
+			sb.WriteString(`\n`)
+			continue
+		case inString && r == '\r':
+			continue
+		case inString && r == '\t':
+			sb.WriteString(`\t`)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// normalizeKey 将字段名规范为章节键，兼容模型以章节标题作为字段名
+// 参数：
+//   - field: 字段名
+//
+// 返回值：
+//   - string: 章节键，无法识别时为空
+func normalizeKey(field string) string {
+	field = strings.TrimSpace(field)
+	for _, def := range Schema {
+		if strings.EqualFold(field, def.Key) || field == def.Title {
+			return def.Key
+		}
+	}
+	return ""
+}
+
+// flatten 将章节值转换为文本，兼容模型输出字符串数组或带 content 字段的对象
+// 参数：
+//   - value: 章节值
+//
+// 返回值：
+//   - string: 章节文本
+func flatten(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if text := strings.TrimSpace(flatten(item)); text != "" {
+				parts = append(parts, text)
+			}
+		}
+		return strings.Join(parts, "\n")
+	case map[string]any:
+		if content, ok := v["content"]; ok {
+			return flatten(content)
+		}
+	}
+	return ""
+}
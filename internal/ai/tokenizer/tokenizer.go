@@ -0,0 +1,102 @@
+// Package tokenizer 提供 Provider 未返回用量时的本地 Token 估算
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package tokenizer
+
+import (
+	"math"
+	"unicode"
+
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// 各类字符的平均 Token 数，参考主流中文模型分词器的统计值
+const (
+	CJK_TOKENS_PER_RUNE   = 0.6  // 中日韩文字及全角标点
+	ASCII_TOKENS_PER_RUNE = 0.3  // 英文字母、数字与半角标点
+	OTHER_TOKENS_PER_RUNE = 1.0  // 其它字符，如 emoji 与其它文字
+	SPACE_TOKENS_PER_RUNE = 0.05 // 空白字符，多数会与相邻字符合并
+)
+
+// Estimate 估算文本的 Token 数
+// 参数：
+//
+//	text: 文本
+//
+// 返回值：
+//
+//	int: Token 数，非空文本至少为 1
+func Estimate(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	var total float64
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			total += SPACE_TOKENS_PER_RUNE
+		case r < unicode.MaxASCII:
+			total += ASCII_TOKENS_PER_RUNE
+		case unicode.Is(unicode.Han, r), unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r),
+			unicode.Is(unicode.Hangul, r), r >= 0x3000 && r <= 0x303F, r >= 0xFF00 && r <= 0xFFEF:
+			total += CJK_TOKENS_PER_RUNE
+		default:
+			total += OTHER_TOKENS_PER_RUNE
+		}
+	}
+	return max(int(math.Ceil(total)), 1)
+}
+
+// EstimateUsage 估算一次调用的 Token 用量
+// 参数：
+//
+//	prompt: 提示文本
+//	reasoning: 思考过程
+//	content: 回答内容
+//
+// 返回值：
+//
+//	*conversation.TokenUsage: Token 用量，生成 Token 数包含思考过程
+func EstimateUsage(prompt, reasoning, content string) *conversation.TokenUsage {
+	usage := &conversation.TokenUsage{
+		PromptTokens:    Estimate(prompt),
+		ReasoningTokens: Estimate(reasoning),
+	}
+	usage.CompletionTokens = usage.ReasoningTokens + Estimate(content)
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return usage
+}
+
+// Complete 补全 Provider 返回的 Token 用量，未返回时整体估算，缺少的字段按文本估算
+// 参数：
+//
+//	usage: Provider 返回的 Token 用量，可为 nil
+//	prompt: 提示文本
+//	reasoning: 思考过程
+//	content: 回答内容
+//
+// 返回值：
+//
+//	*conversation.TokenUsage: 补全后的 Token 用量
+func Complete(usage *conversation.TokenUsage, prompt, reasoning, content string) *conversation.TokenUsage {
+	if usage == nil {
+		return EstimateUsage(prompt, reasoning, content)
+	}
+
+	estimated := EstimateUsage(prompt, reasoning, content)
+	completed := *usage
+	if completed.PromptTokens == 0 {
+		completed.PromptTokens = estimated.PromptTokens
+	}
+	if completed.CompletionTokens == 0 {
+		completed.CompletionTokens = estimated.CompletionTokens
+	}
+	if completed.ReasoningTokens == 0 && reasoning != "" {
+		completed.ReasoningTokens = min(estimated.ReasoningTokens, completed.CompletionTokens)
+	}
+	if completed.TotalTokens < completed.PromptTokens+completed.CompletionTokens {
+		completed.TotalTokens = completed.PromptTokens + completed.CompletionTokens
+	}
+	return &completed
+}
@@ -5,8 +5,9 @@ package types
 
 import (
 	"context"
-	"time"
+	"errors"
 
+	"github.com/Done-0/metaphysics/internal/chart"
 	"github.com/Done-0/metaphysics/pkg/vo/conversation"
 )
 
@@ -16,8 +17,46 @@ type Provider string
 const (
 	PROVIDER_OLLAMA   Provider = "ollama"   // ollama 本地模型服务
 	PROVIDER_DEEPSEEK Provider = "deepseek" // deepseek 推理模型服务
+	PROVIDER_OPENAI   Provider = "openai"   // OpenAI 兼容服务，如 vLLM、LM Studio 与各类网关
+	PROVIDER_RULE     Provider = "rule"     // 规则解读，无需模型的离线兜底
 )
 
+// ErrGenerateTextUnsupported Provider 不支持自由文本生成
+var ErrGenerateTextUnsupported = errors.New("当前 AI 服务不支持自由文本生成")
+
+// ErrStructuredOutputUnsupported Provider 不支持结构化输出
+var ErrStructuredOutputUnsupported = errors.New("当前 AI 服务不支持结构化输出")
+
+// ErrSectionAnalysisUnsupported Provider 不支持按章节分析
+var ErrSectionAnalysisUnsupported = errors.New("当前 AI 服务不支持按章节分析")
+
+// IsUnsupported 判断错误是否表示 Provider 不具备相应能力，此类错误不代表故障
+// 参数：
+//
+//	err: 错误信息
+//
+// 返回值：
+//
+//	bool: 是否为能力不支持
+func IsUnsupported(err error) bool {
+	return errors.Is(err, ErrGenerateTextUnsupported) || errors.Is(err, ErrStructuredOutputUnsupported) ||
+		errors.Is(err, ErrSectionAnalysisUnsupported)
+}
+
+// AnalyzeOptions 八字分析选项
+type AnalyzeOptions struct {
+	Structured bool     // 是否结构化输出，模型以 JSON 返回各章节，经校验与修复后分章节返回
+	Sections   []string // 需要分析的章节键，为空时分析全部章节；指定章节时按章节返回
+}
+
+// TextResult 文本生成结果
+type TextResult struct {
+	Text     string                   // 生成的文本
+	Provider Provider                 // 实际响应的 AI 服务提供商
+	Fallback bool                     // 是否由兜底 Provider 响应
+	Usage    *conversation.TokenUsage // Token 用量，服务端未返回时为 nil
+}
+
 // StreamHandler 流式响应处理器
 type StreamHandler func(chunk *conversation.StreamChunk) error
 
@@ -26,30 +65,42 @@ type Service interface {
 	// AnalyzeBaziWithReasoning 分析八字（带推理过程）
 	// 参数：
 	//   ctx: 上下文
-	//   name: 姓名
-	//   gender: 性别
-	//   birthTime: 出生时间
-	//   calendar: 日历类型 (lunar/solar)
-	//   baziInfo: 八字信息
+	//   c: 八字命盘
+	//   opts: 分析选项
 	// 返回值：
-	//   *conversation.BaziAnalysisResponse: 分析结果（包含推理过程）
-	//   error: 错误信息
-	AnalyzeBaziWithReasoning(ctx context.Context, name, gender string, birthTime time.Time, calendar string, baziInfo map[string]string) (*conversation.BaziAnalysisResponse, error)
+	//   *conversation.BaziAnalysisResponse: 分析结果（包含推理过程，结构化输出时包含各章节）
+	//   error: 错误信息，不支持结构化输出时返回 ErrStructuredOutputUnsupported，不支持按章节分析时返回 ErrSectionAnalysisUnsupported
+	AnalyzeBaziWithReasoning(ctx context.Context, c *chart.Chart, opts AnalyzeOptions) (*conversation.BaziAnalysisResponse, error)
 
 	// StreamAnalyzeBazi 流式分析八字
 	// 参数：
 	//   ctx: 上下文
-	//   name: 姓名
-	//   gender: 性别
-	//   birthTime: 出生时间
-	//   calendar: 日历类型 (lunar/solar)
-	//   baziInfo: 八字信息
+	//   c: 八字命盘
+	//   opts: 分析选项，指定章节时按章节标题输出 Markdown，不支持结构化输出
+	//   handler: 流式响应处理函数
+	// 返回值：
+	//   error: 错误信息，不支持按章节分析时返回 ErrSectionAnalysisUnsupported
+	StreamAnalyzeBazi(ctx context.Context, c *chart.Chart, opts AnalyzeOptions, handler StreamHandler) error
+
+	// GenerateText 根据提示生成文本
+	// 参数：
+	//   ctx: 上下文
+	//   promptText: 完整提示文本
+	// 返回值：
+	//   *TextResult: 生成结果，包含实际响应的 Provider
+	//   error: 错误信息
+	GenerateText(ctx context.Context, promptText string) (*TextResult, error)
+
+	// StreamGenerateText 根据提示流式生成文本
+	// 参数：
+	//   ctx: 上下文
+	//   promptText: 完整提示文本
 	//   handler: 流式响应处理函数
 	// 返回值：
 	//   error: 错误信息
-	StreamAnalyzeBazi(ctx context.Context, name, gender string, birthTime time.Time, calendar string, baziInfo map[string]string, handler StreamHandler) error
+	StreamGenerateText(ctx context.Context, promptText string, handler StreamHandler) error
 
-	// DetermineProvider 确定使用的 AI 提供商
+	// DetermineProvider 确定使用的 AI 提供商，路由器返回首选 Provider，实际响应的 Provider 以各次调用的响应为准
 	// 返回值：
 	//   Provider: AI 服务提供商
 	DetermineProvider() Provider
@@ -0,0 +1,229 @@
+// Package chart 提供八字命盘的计算与模型转换
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package chart
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/model/bazi"
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// Chart 八字命盘
+type Chart struct {
+	Name      string    `json:"name"`       // 姓名
+	Gender    string    `json:"gender"`     // 性别 (male/female)
+	BirthTime time.Time `json:"birth_time"` // 出生时间
+	Calendar  string    `json:"calendar"`   // 日历类型 (lunar/solar)
+
+	Year  Pillar `json:"year"`  // 年柱
+	Month Pillar `json:"month"` // 月柱
+	Day   Pillar `json:"day"`   // 日柱
+	Hour  Pillar `json:"hour"`  // 时柱
+}
+
+// Calculate 根据出生信息排盘
+// 参数：
+//   - name: 姓名
+//   - gender: 性别 (male/female)
+//   - birthTime: 出生时间
+//   - calendar: 日历类型 (lunar/solar)
+//
+// 返回值：
+//   - *Chart: 八字命盘
+//   - error: 排盘结果无效时返回错误
+func Calculate(name, gender string, birthTime time.Time, calendar string) (*Chart, error) {
+	pillars, err := parsePillars(utils.CalculatePillars(birthTime, calendar))
+	if err != nil {
+		return nil, fmt.Errorf("排盘失败: %w", err)
+	}
+
+	return &Chart{
+		Name:      name,
+		Gender:    gender,
+		BirthTime: birthTime,
+		Calendar:  calendar,
+		Year:      pillars[0],
+		Month:     pillars[1],
+		Day:       pillars[2],
+		Hour:      pillars[3],
+	}, nil
+}
+
+// FromPillars 根据四柱干支构建命盘，不含出生信息
+// 参数：
+//   - pillars: 四柱干支（年、月、日、时）
+//
+// 返回值：
+//   - *Chart: 八字命盘
+//   - error: 干支无效时返回错误
+func FromPillars(pillars []string) (*Chart, error) {
+	parsed, err := parsePillars(pillars)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chart{Year: parsed[0], Month: parsed[1], Day: parsed[2], Hour: parsed[3]}, nil
+}
+
+// FromModel 将八字模型转换为命盘
+// 参数：
+//   - m: 八字模型
+//
+// 返回值：
+//   - *Chart: 八字命盘
+func FromModel(m *bazi.Bazi) *Chart {
+	return &Chart{
+		Name:      m.Name,
+		Gender:    m.Gender,
+		BirthTime: m.BirthTime,
+		Calendar:  m.Calendar,
+		Year:      modelPillar(m.YearGan, m.YearZhi, m.YearPillar),
+		Month:     modelPillar(m.MonthGan, m.MonthZhi, m.MonthPillar),
+		Day:       modelPillar(m.DayGan, m.DayZhi, m.DayPillar),
+		Hour:      modelPillar(m.HourGan, m.HourZhi, m.HourPillar),
+	}
+}
+
+// ToModel 将命盘转换为新的八字模型
+// 返回值：
+//   - *bazi.Bazi: 八字模型
+func (c *Chart) ToModel() *bazi.Bazi {
+	m := new(bazi.Bazi)
+	c.ApplyTo(m)
+	return m
+}
+
+// ApplyTo 将命盘的出生信息与四柱写入已有的八字模型，保留主键与归属等字段
+// 参数：
+//   - m: 八字模型
+func (c *Chart) ApplyTo(m *bazi.Bazi) {
+	m.Name = c.Name
+	m.Gender = c.Gender
+	m.BirthTime = c.BirthTime
+	m.Calendar = c.Calendar
+	m.BirthTimeUnknown = c.BirthTime.IsZero()
+
+	m.YearPillar, m.YearGan, m.YearZhi = c.Year.String(), c.Year.Stem.String(), c.Year.Branch.String()
+	m.MonthPillar, m.MonthGan, m.MonthZhi = c.Month.String(), c.Month.Stem.String(), c.Month.Branch.String()
+	m.DayPillar, m.DayGan, m.DayZhi = c.Day.String(), c.Day.Stem.String(), c.Day.Branch.String()
+	m.HourPillar, m.HourGan, m.HourZhi = c.Hour.String(), c.Hour.Stem.String(), c.Hour.Branch.String()
+}
+
+// Snapshot 将命盘序列化为快照，用于在对话等历史记录中保留排盘当时的命盘
+// 返回值：
+//   - string: JSON 快照
+//   - error: 序列化失败时返回错误
+func (c *Chart) Snapshot() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("序列化命盘快照失败: %w", err)
+	}
+	return string(data), nil
+}
+
+// FromSnapshot 从快照还原命盘
+// 参数：
+//   - snapshot: JSON 快照
+//
+// 返回值：
+//   - *Chart: 八字命盘
+//   - error: 快照无效时返回错误
+func FromSnapshot(snapshot string) (*Chart, error) {
+	c := new(Chart)
+	if err := json.Unmarshal([]byte(snapshot), c); err != nil {
+		return nil, fmt.Errorf("解析命盘快照失败: %w", err)
+	}
+	if !c.Valid() {
+		return nil, fmt.Errorf("命盘快照无效")
+	}
+	return c, nil
+}
+
+// Pillars 获取四柱
+// 返回值：
+//   - []Pillar: 四柱（年、月、日、时）
+func (c *Chart) Pillars() []Pillar {
+	return []Pillar{c.Year, c.Month, c.Day, c.Hour}
+}
+
+// PillarStrings 获取四柱干支文字
+// 返回值：
+//   - []string: 四柱干支（年、月、日、时）
+func (c *Chart) PillarStrings() []string {
+	return []string{c.Year.String(), c.Month.String(), c.Day.String(), c.Hour.String()}
+}
+
+// Stems 获取四柱天干文字
+// 返回值：
+//   - []string: 天干（年、月、日、时）
+func (c *Chart) Stems() []string {
+	return []string{c.Year.Stem.String(), c.Month.Stem.String(), c.Day.Stem.String(), c.Hour.Stem.String()}
+}
+
+// Branches 获取四柱地支文字
+// 返回值：
+//   - []string: 地支（年、月、日、时）
+func (c *Chart) Branches() []string {
+	return []string{c.Year.Branch.String(), c.Month.Branch.String(), c.Day.Branch.String(), c.Hour.Branch.String()}
+}
+
+// DayMaster 获取日主
+// 返回值：
+//   - Stem: 日干
+func (c *Chart) DayMaster() Stem {
+	return c.Day.Stem
+}
+
+// Valid 判断四柱是否完整有效
+// 返回值：
+//   - bool: 是否有效
+func (c *Chart) Valid() bool {
+	for _, p := range c.Pillars() {
+		if !p.Valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePillars 解析四柱干支
+// 参数：
+//   - pillars: 四柱干支（年、月、日、时）
+//
+// 返回值：
+//   - []Pillar: 四柱
+//   - error: 数量不足或干支无效时返回错误
+func parsePillars(pillars []string) ([]Pillar, error) {
+	if len(pillars) != 4 {
+		return nil, fmt.Errorf("四柱数量错误: %d", len(pillars))
+	}
+
+	parsed := make([]Pillar, 0, 4)
+	for _, s := range pillars {
+		p, err := ParsePillar(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// modelPillar 从模型字段构建干支柱，单独的干支字段缺失时从柱文字拆分
+// 参数：
+//   - gan: 天干字段
+//   - zhi: 地支字段
+//   - ganZhi: 柱字段
+//
+// 返回值：
+//   - Pillar: 干支柱
+func modelPillar(gan, zhi, ganZhi string) Pillar {
+	if gan == "" || zhi == "" {
+		gan, zhi = utils.SplitGanZhi(ganZhi)
+	}
+	return Pillar{Stem: Stem(gan), Branch: Branch(zhi)}
+}
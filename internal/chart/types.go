@@ -0,0 +1,200 @@
+// Package chart 提供八字命盘的领域模型，定义天干、地支、干支柱等值类型
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package chart
+
+import (
+	"fmt"
+
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// Polarity 阴阳
+type Polarity string
+
+// 阴阳常量
+const (
+	POLARITY_YANG Polarity = "阳" // 阳
+	POLARITY_YIN  Polarity = "阴" // 阴
+)
+
+// STEMS 十天干，按序排列
+var STEMS = []Stem{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+
+// BRANCHES 十二地支，按序排列
+var BRANCHES = []Branch{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+
+// Stem 天干
+type Stem string
+
+// Index 获取天干序号
+// 返回值：
+//   - int: 序号（0-9），无效天干返回 -1
+func (s Stem) Index() int {
+	for i, stem := range STEMS {
+		if stem == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// Valid 判断是否为有效天干
+// 返回值：
+//   - bool: 是否有效
+func (s Stem) Valid() bool {
+	return s.Index() >= 0
+}
+
+// Element 获取天干五行
+// 返回值：
+//   - string: 五行，无效天干返回空字符串
+func (s Stem) Element() string {
+	return utils.GetGanWuxing(string(s))
+}
+
+// Polarity 获取天干阴阳，奇数位为阳
+// 返回值：
+//   - Polarity: 阴阳，无效天干返回空
+func (s Stem) Polarity() Polarity {
+	return polarityOf(s.Index())
+}
+
+// TenGod 以本天干为日主，获取另一天干的十神
+// 参数：
+//   - other: 另一天干
+//
+// 返回值：
+//   - string: 十神
+func (s Stem) TenGod(other Stem) string {
+	return utils.GetTenGod(string(s), string(other))
+}
+
+// String 返回天干文字
+// 返回值：
+//   - string: 天干
+func (s Stem) String() string {
+	return string(s)
+}
+
+// Branch 地支
+type Branch string
+
+// Index 获取地支序号
+// 返回值：
+//   - int: 序号（0-11），无效地支返回 -1
+func (b Branch) Index() int {
+	for i, branch := range BRANCHES {
+		if branch == b {
+			return i
+		}
+	}
+	return -1
+}
+
+// Valid 判断是否为有效地支
+// 返回值：
+//   - bool: 是否有效
+func (b Branch) Valid() bool {
+	return b.Index() >= 0
+}
+
+// Element 获取地支五行
+// 返回值：
+//   - string: 五行，无效地支返回空字符串
+func (b Branch) Element() string {
+	return utils.GetZhiWuxing(string(b))
+}
+
+// Polarity 获取地支阴阳，奇数位为阳
+// 返回值：
+//   - Polarity: 阴阳，无效地支返回空
+func (b Branch) Polarity() Polarity {
+	return polarityOf(b.Index())
+}
+
+// HiddenStems 获取地支藏干（本气、中气、余气）
+// 返回值：
+//   - []Stem: 藏干
+func (b Branch) HiddenStems() []Stem {
+	hide := utils.GetHideGan(string(b))
+	stems := make([]Stem, 0, len(hide))
+	for _, gan := range hide {
+		stems = append(stems, Stem(gan))
+	}
+	return stems
+}
+
+// String 返回地支文字
+// 返回值：
+//   - string: 地支
+func (b Branch) String() string {
+	return string(b)
+}
+
+// Pillar 干支柱
+type Pillar struct {
+	Stem   Stem   `json:"stem"`   // 天干
+	Branch Branch `json:"branch"` // 地支
+}
+
+// ParsePillar 解析干支文字
+// 参数：
+//   - ganZhi: 干支，如 甲子
+//
+// 返回值：
+//   - Pillar: 干支柱
+//   - error: 干支无效时返回错误
+func ParsePillar(ganZhi string) (Pillar, error) {
+	gan, zhi := utils.SplitGanZhi(ganZhi)
+	p := Pillar{Stem: Stem(gan), Branch: Branch(zhi)}
+	if !p.Valid() {
+		return Pillar{}, fmt.Errorf("无效的干支: %q", ganZhi)
+	}
+	return p, nil
+}
+
+// Valid 判断干支柱是否有效，阴阳不同的组合不构成六十甲子
+// 返回值：
+//   - bool: 是否有效
+func (p Pillar) Valid() bool {
+	return p.Stem.Valid() && p.Branch.Valid() && p.Stem.Polarity() == p.Branch.Polarity()
+}
+
+// IsZero 判断是否为空干支柱
+// 返回值：
+//   - bool: 是否为空
+func (p Pillar) IsZero() bool {
+	return p.Stem == "" && p.Branch == ""
+}
+
+// NaYin 获取干支纳音
+// 返回值：
+//   - string: 纳音五行，如 海中金
+func (p Pillar) NaYin() string {
+	return utils.GetNaYin(p.String())
+}
+
+// String 返回干支文字
+// 返回值：
+//   - string: 干支
+func (p Pillar) String() string {
+	return string(p.Stem) + string(p.Branch)
+}
+
+// polarityOf 根据序号判断阴阳
+// 参数：
+//   - index: 天干或地支序号
+//
+// 返回值：
+//   - Polarity: 阴阳
+func polarityOf(index int) Polarity {
+	switch {
+	case index < 0:
+		return ""
+	case index%2 == 0:
+		return POLARITY_YANG
+	default:
+		return POLARITY_YIN
+	}
+}
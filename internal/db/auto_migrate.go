@@ -9,8 +9,29 @@ import (
 
 	"github.com/Done-0/metaphysics/internal/global"
 	"github.com/Done-0/metaphysics/internal/model"
+	"github.com/Done-0/metaphysics/internal/model/conversation"
 )
 
+// legacyIndex 已更名的历史索引
+type legacyIndex struct {
+	model any    // 索引所属模型
+	name  string // 历史索引名
+}
+
+// legacyIndexes 已更名的历史索引列表，AutoMigrate 只创建新索引而不删除旧索引，需在迁移后显式删除
+var legacyIndexes = []legacyIndex{
+	{&conversation.Conversation{}, "idx_user_id"},
+	{&conversation.Conversation{}, "idx_bazi_id"},
+	{&conversation.Conversation{}, "idx_deleted_at"},
+	{&conversation.Message{}, "idx_conversation_id"},
+	{&conversation.Message{}, "idx_user_id"},
+	{&conversation.Message{}, "idx_session_id"},
+	{&conversation.Message{}, "idx_request_id"},
+	{&conversation.Message{}, "idx_response_id"},
+	{&conversation.Message{}, "idx_deleted_at"},
+	{&conversation.MessageCounter{}, "idx_user_id"},
+}
+
 // autoMigrate 执行数据库表结构自动迁移
 func autoMigrate() error {
 	// 执行表结构迁移
@@ -21,8 +42,28 @@ func autoMigrate() error {
 		return fmt.Errorf("数据库自动迁移失败 %w", err)
 	}
 
+	if err := dropLegacyIndexes(); err != nil {
+		return fmt.Errorf("数据库自动迁移失败 %w", err)
+	}
+
 	log.Println("数据库自动迁移成功...")
 	global.SysLog.Info("数据库自动迁移成功...")
 
 	return nil
 }
+
+// dropLegacyIndexes 删除已更名的历史索引，避免新旧索引并存
+// 返回值：
+//   - error: 删除过程中的错误
+func dropLegacyIndexes() error {
+	migrator := global.DB.Migrator()
+	for _, index := range legacyIndexes {
+		if !migrator.HasIndex(index.model, index.name) {
+			continue
+		}
+		if err := migrator.DropIndex(index.model, index.name); err != nil {
+			return fmt.Errorf("删除历史索引 %s 失败: %w", index.name, err)
+		}
+	}
+	return nil
+}
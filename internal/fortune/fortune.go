@@ -0,0 +1,325 @@
+// Package fortune 提供基于岁运干支与原局关系的运势规则评分
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package fortune
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// 运势维度
+const (
+	DIMENSION_CAREER       = "career"       // 事业
+	DIMENSION_WEALTH       = "wealth"       // 财运
+	DIMENSION_RELATIONSHIP = "relationship" // 感情
+	DIMENSION_HEALTH       = "health"       // 健康
+)
+
+// 评分常量
+const (
+	baseScore        = 60  // 各维度基础分
+	minScore         = 0   // 最低分
+	maxScore         = 100 // 最高分
+	favorableBonus   = 6   // 流日天干为喜用时的加分
+	unfavorablePenal = -6  // 流日天干为忌神时的扣分
+)
+
+// dimensionNames 维度中文名
+var dimensionNames = map[string]string{
+	DIMENSION_CAREER:       "事业",
+	DIMENSION_WEALTH:       "财运",
+	DIMENSION_RELATIONSHIP: "感情",
+	DIMENSION_HEALTH:       "健康",
+}
+
+// DIMENSIONS 维度顺序
+var DIMENSIONS = []string{DIMENSION_CAREER, DIMENSION_WEALTH, DIMENSION_RELATIONSHIP, DIMENSION_HEALTH}
+
+// tenGodEffects 流日十神对各维度的影响（事业、财运、感情、健康）
+var tenGodEffects = map[string][4]int{
+	"比肩": {0, -5, 5, 5},
+	"劫财": {-5, -12, -3, 0},
+	"食神": {5, 5, 5, 10},
+	"伤官": {-5, 5, -10, -5},
+	"偏财": {5, 15, 5, 0},
+	"正财": {5, 12, 10, 0},
+	"七杀": {5, -5, -5, -10},
+	"正官": {15, 0, 5, 0},
+	"偏印": {0, -5, -5, -3},
+	"正印": {10, 0, 5, 10},
+}
+
+// tenGodHints 流日十神提示语
+var tenGodHints = map[string]string{
+	"比肩": "同辈助力，宜合作，忌独断",
+	"劫财": "易有破耗与竞争，谨慎理财",
+	"食神": "心情舒畅，宜创作与享受",
+	"伤官": "锋芒外露，言多易失，宜收敛",
+	"偏财": "偏财机会显现，可留意额外收入",
+	"正财": "正财稳健，宜务实经营",
+	"七杀": "压力与挑战并存，宜稳守",
+	"正官": "规则与贵人并至，利于公事",
+	"偏印": "思虑偏多，宜独处研习",
+	"正印": "贵人扶持，宜学习与休养",
+}
+
+// palaceDimensions 原局各柱被流日引动时影响的维度（年、月、日、时）
+var palaceDimensions = [][]string{
+	{DIMENSION_RELATIONSHIP},
+	{DIMENSION_CAREER},
+	{DIMENSION_RELATIONSHIP, DIMENSION_HEALTH},
+	{DIMENSION_WEALTH},
+}
+
+// relationEffects 地支关系的分值
+var relationEffects = map[string]int{
+	utils.RELATION_ZHI_HE:    8,
+	utils.RELATION_ZHI_CHONG: -10,
+	utils.RELATION_ZHI_XING:  -6,
+	utils.RELATION_ZHI_HAI:   -4,
+	utils.RELATION_FU_YIN:    -3,
+}
+
+// Chart 原局信息
+type Chart struct {
+	Gans []string // 四柱天干（年、月、日、时）
+	Zhis []string // 四柱地支（年、月、日、时）
+}
+
+// Result 每日运势评分结果
+type Result struct {
+	Date         string            `json:"date"`         // 日期
+	DayPillar    string            `json:"day_pillar"`   // 岁运干支，每日运势中为流日干支
+	TenGod       string            `json:"ten_god"`      // 流日天干十神
+	Scores       map[string]int    `json:"scores"`       // 各维度得分
+	Overall      int               `json:"overall"`      // 综合得分
+	Level        string            `json:"level"`        // 综合等级
+	Interactions []string          `json:"interactions"` // 流日与原局的关系
+	Comments     map[string]string `json:"comments"`     // 各维度评语
+	Summary      string            `json:"summary"`      // 规则生成的运势文案
+}
+
+// Calculate 计算某日运势
+// 参数：
+//   - chart: 原局信息
+//   - date: 日期
+//
+// 返回值：
+//   - *Result: 运势评分结果
+//   - error: 原局信息不完整时返回错误
+func Calculate(chart *Chart, date time.Time) (*Result, error) {
+	result, err := EvaluatePillar(chart, "流日", utils.GetDailyGanZhi(date))
+	if err != nil {
+		return nil, err
+	}
+
+	result.Date = date.Format(time.DateOnly)
+	result.Summary = buildSummary(result)
+	return result, nil
+}
+
+// EvaluatePillar 评估任一岁运干支（流年、流月、流日）对原局各维度的影响
+// 参数：
+//   - chart: 原局信息
+//   - source: 岁运名称，如 流月
+//   - ganZhi: 岁运干支
+//
+// 返回值：
+//   - *Result: 评分结果，不含日期与文案
+//   - error: 原局信息不完整时返回错误
+func EvaluatePillar(chart *Chart, source, ganZhi string) (*Result, error) {
+	if chart == nil || len(chart.Gans) != 4 || len(chart.Zhis) != 4 {
+		return nil, fmt.Errorf("原局信息不完整")
+	}
+
+	gan, zhi := utils.SplitGanZhi(ganZhi)
+	dayMaster := chart.Gans[2]
+	analysis := utils.AnalyzeWuxing(chart.Gans, chart.Zhis)
+
+	scores := make(map[string]int, len(DIMENSIONS))
+	for _, d := range DIMENSIONS {
+		scores[d] = baseScore
+	}
+
+	// 天干十神为主，地支本气十神减半
+	tenGod := utils.GetTenGod(dayMaster, gan)
+	applyTenGod(scores, tenGod, 1)
+	if hide := utils.GetHideGan(zhi); len(hide) > 0 {
+		applyTenGod(scores, utils.GetTenGod(dayMaster, hide[0]), 2)
+	}
+
+	// 天干五行喜忌
+	wuxing := utils.GetGanWuxing(gan)
+	switch {
+	case containsString(analysis.Favorable, wuxing):
+		for _, d := range DIMENSIONS {
+			scores[d] += favorableBonus
+		}
+	case containsString(analysis.Unfavorable, wuxing):
+		for _, d := range DIMENSIONS {
+			scores[d] += unfavorablePenal
+		}
+	}
+
+	// 地支与原局各支的刑冲合害
+	natal := make([]string, 0, 4)
+	for i := range chart.Gans {
+		natal = append(natal, chart.Gans[i]+chart.Zhis[i])
+	}
+	interactions := []string{}
+	for _, it := range utils.FindPillarInteractions(source, ganZhi, natal) {
+		delta, ok := relationEffects[it.Relation]
+		if !ok {
+			continue
+		}
+		for i, name := range utils.NATAL_PILLAR_NAMES {
+			if name != it.Target {
+				continue
+			}
+			for _, d := range palaceDimensions[i] {
+				scores[d] += delta
+			}
+		}
+		interactions = append(interactions, it.String())
+	}
+
+	total := 0
+	for _, d := range DIMENSIONS {
+		scores[d] = clamp(scores[d])
+		total += scores[d]
+	}
+	overall := total / len(DIMENSIONS)
+
+	result := &Result{
+		DayPillar:    ganZhi,
+		TenGod:       tenGod,
+		Scores:       scores,
+		Overall:      overall,
+		Level:        GetLevel(overall),
+		Interactions: interactions,
+		Comments:     make(map[string]string, len(DIMENSIONS)),
+	}
+	for _, d := range DIMENSIONS {
+		result.Comments[d] = fmt.Sprintf("%s%s（%d 分）", dimensionNames[d], GetLevel(scores[d]), scores[d])
+	}
+
+	return result, nil
+}
+
+// GetTenGodHint 获取岁运天干十神的提示语
+// 参数：
+//   - tenGod: 十神
+//
+// 返回值：
+//   - string: 提示语
+func GetTenGodHint(tenGod string) string {
+	return tenGodHints[tenGod]
+}
+
+// GetLevel 根据得分获取等级
+// 参数：
+//   - score: 得分
+//
+// 返回值：
+//   - string: 等级
+func GetLevel(score int) string {
+	switch {
+	case score >= 80:
+		return "大吉"
+	case score >= 65:
+		return "吉"
+	case score >= 50:
+		return "平"
+	case score >= 35:
+		return "小凶"
+	default:
+		return "凶"
+	}
+}
+
+// GetDimensionName 获取维度中文名
+// 参数：
+//   - dimension: 维度
+//
+// 返回值：
+//   - string: 中文名
+func GetDimensionName(dimension string) string {
+	return dimensionNames[dimension]
+}
+
+// applyTenGod 按十神调整各维度得分
+// 参数：
+//   - scores: 各维度得分
+//   - tenGod: 十神
+//   - divisor: 衰减系数
+func applyTenGod(scores map[string]int, tenGod string, divisor int) {
+	effects, ok := tenGodEffects[tenGod]
+	if !ok {
+		return
+	}
+	for i, d := range DIMENSIONS {
+		scores[d] += effects[i] / divisor
+	}
+}
+
+// buildSummary 生成规则运势文案
+// 参数：
+//   - r: 运势评分结果
+//
+// 返回值：
+//   - string: 运势文案
+func buildSummary(r *Result) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("今日%s日，综合运势%s（%d 分）。", r.DayPillar, r.Level, r.Overall))
+	if hint, ok := tenGodHints[r.TenGod]; ok {
+		sb.WriteString(fmt.Sprintf("%s当值，%s。", r.TenGod, hint))
+	}
+	if len(r.Interactions) > 0 {
+		sb.WriteString(strings.Join(r.Interactions, "，"))
+		sb.WriteString("。")
+	}
+
+	comments := make([]string, 0, len(DIMENSIONS))
+	for _, d := range DIMENSIONS {
+		comments = append(comments, r.Comments[d])
+	}
+	sb.WriteString(strings.Join(comments, "；"))
+	sb.WriteString("。")
+	return sb.String()
+}
+
+// clamp 将得分限制在 0-100
+// 参数：
+//   - score: 原始得分
+//
+// 返回值：
+//   - int: 限制后的得分
+func clamp(score int) int {
+	if score < minScore {
+		return minScore
+	}
+	if score > maxScore {
+		return maxScore
+	}
+	return score
+}
+
+// containsString 判断字符串列表是否包含目标字符串
+// 参数：
+//   - list: 字符串列表
+//   - target: 目标字符串
+//
+// 返回值：
+//   - bool: 是否包含
+func containsString(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,434 @@
+// Package importer 提供出生档案批量导入，包含 CSV/XLSX 读取、列映射、逐行校验与并发排盘
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package importer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/model/bazi"
+)
+
+// 可映射的字段
+const (
+	FIELD_NAME        = "name"        // 姓名
+	FIELD_GENDER      = "gender"      // 性别
+	FIELD_BIRTH_TIME  = "birth_time"  // 出生时间（日期与时刻在同一列）
+	FIELD_BIRTH_DATE  = "birth_date"  // 出生日期（与出生时刻分列时使用）
+	FIELD_BIRTH_CLOCK = "birth_clock" // 出生时刻（与出生日期分列时使用）
+	FIELD_CALENDAR    = "calendar"    // 历法
+	FIELD_RELATION    = "relation"    // 与用户的关系
+	FIELD_LABEL       = "label"       // 档案标签
+	FIELD_NOTES       = "notes"       // 备注
+	FIELD_TAGS        = "tags"        // 标签
+)
+
+// 取值限制
+const (
+	MIN_BIRTH_YEAR  = 1900  // 支持的最早出生年份
+	MAX_BIRTH_YEAR  = 2100  // 支持的最晚出生年份
+	NAME_MAX_LENGTH = 50    // 姓名最大长度（字符）
+	MAX_TAGS        = 10    // 最多标签数
+	TAG_MAX_LENGTH  = 20    // 单个标签最大长度（字符）
+	excelEpochDays  = 25569 // Excel 序列号 1970-01-01 对应的天数（1900 日期系统）
+)
+
+// defaultAliases 各字段默认识别的表头，匹配时忽略大小写与首尾空白
+var defaultAliases = map[string][]string{
+	FIELD_NAME:        {"姓名", "名字", "name"},
+	FIELD_GENDER:      {"性别", "乾坤", "gender", "sex"},
+	FIELD_BIRTH_TIME:  {"出生时间", "生辰", "birth_time", "birthtime", "birth time"},
+	FIELD_BIRTH_DATE:  {"出生日期", "生日", "birth_date", "birthdate", "birth date", "date"},
+	FIELD_BIRTH_CLOCK: {"出生时刻", "时间", "birth_clock", "birth hour", "time"},
+	FIELD_CALENDAR:    {"历法", "日历", "日历类型", "calendar"},
+	FIELD_RELATION:    {"关系", "relation"},
+	FIELD_LABEL:       {"档案名", "档案标签", "label"},
+	FIELD_NOTES:       {"备注", "notes", "note", "remark"},
+	FIELD_TAGS:        {"标签", "分类", "tags", "tag"},
+}
+
+// birthTimeLayouts 出生时间支持的格式
+var birthTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006-1-2 15:04",
+	"2006/1/2 15:04",
+	"2006年1月2日 15:04",
+	"2006年1月2日15时04分",
+	"2006年1月2日15时",
+}
+
+// birthDateLayouts 出生日期支持的格式
+var birthDateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"2006-1-2",
+	"2006/1/2",
+	"2006.1.2",
+	"20060102",
+	"2006年1月2日",
+}
+
+// birthClockLayouts 出生时刻支持的格式
+var birthClockLayouts = []string{
+	"15:04:05",
+	"15:04",
+	"15时04分",
+	"15时",
+}
+
+// 取值别名
+var (
+	genderValues = map[string]string{
+		"男": "male", "乾": "male", "m": "male", "male": "male",
+		"女": "female", "坤": "female", "f": "female", "female": "female",
+	}
+	calendarValues = map[string]string{
+		"公历": "solar", "阳历": "solar", "新历": "solar", "solar": "solar", "gregorian": "solar",
+		"农历": "lunar", "阴历": "lunar", "旧历": "lunar", "lunar": "lunar",
+	}
+	relationValues = map[string]string{
+		"配偶": bazi.RELATION_SPOUSE, bazi.RELATION_SPOUSE: bazi.RELATION_SPOUSE,
+		"子女": bazi.RELATION_CHILD, bazi.RELATION_CHILD: bazi.RELATION_CHILD,
+		"客户": bazi.RELATION_CLIENT, bazi.RELATION_CLIENT: bazi.RELATION_CLIENT,
+		"其他": bazi.RELATION_OTHER, bazi.RELATION_OTHER: bazi.RELATION_OTHER,
+		"本人": bazi.RELATION_SELF, bazi.RELATION_SELF: bazi.RELATION_SELF,
+	}
+)
+
+// Options 行解析选项
+type Options struct {
+	Mapping         map[string]string // 字段到表头的自定义映射，未指定的字段使用默认表头
+	DefaultCalendar string            // 历法列缺失或为空时使用的历法
+	DefaultRelation string            // 关系列缺失或为空时使用的关系
+	Location        *time.Location    // 出生时间未带时区时使用的时区
+}
+
+// Record 校验通过的一行出生档案
+type Record struct {
+	Row       int       // 表格行号，从 1 开始，含表头
+	Name      string    // 姓名
+	Gender    string    // 性别 (male/female)
+	BirthTime time.Time // 出生时间
+	Calendar  string    // 历法 (lunar/solar)
+	Relation  string    // 与用户的关系
+	Label     string    // 档案标签
+	Notes     string    // 备注
+	Tags      []string  // 标签
+}
+
+// RowError 单行导入错误
+type RowError struct {
+	Row     int    `json:"row"`     // 表格行号
+	Message string `json:"message"` // 错误信息
+}
+
+// Columns 表头解析结果，字段到列下标的映射
+type Columns map[string]int
+
+// ResolveColumns 根据表头与自定义映射确定各字段所在列
+// 参数：
+//   - header: 表头行
+//   - mapping: 字段到表头的自定义映射
+//
+// 返回值：
+//   - Columns: 字段到列下标的映射
+//   - error: 映射了未知字段、指定的表头不存在或缺少必填列时返回错误
+func ResolveColumns(header []string, mapping map[string]string) (Columns, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		key := normalize(h)
+		if _, ok := index[key]; !ok && key != "" {
+			index[key] = i
+		}
+	}
+
+	columns := make(Columns)
+	for field, aliases := range defaultAliases {
+		if custom, ok := mapping[field]; ok && strings.TrimSpace(custom) != "" {
+			i, found := index[normalize(custom)]
+			if !found {
+				return nil, fmt.Errorf("表头中不存在列 %q", custom)
+			}
+			columns[field] = i
+			continue
+		}
+		for _, alias := range aliases {
+			if i, found := index[normalize(alias)]; found {
+				columns[field] = i
+				break
+			}
+		}
+	}
+	for field := range mapping {
+		if _, ok := defaultAliases[field]; !ok {
+			return nil, fmt.Errorf("未知的映射字段 %q，可选字段: %s", field, strings.Join(Fields(), ", "))
+		}
+	}
+
+	// 出生时间可以是单列，也可以是日期与时刻分列，同时存在时以单列为准
+	_, hasTime := columns[FIELD_BIRTH_TIME]
+	_, hasDate := columns[FIELD_BIRTH_DATE]
+	_, hasClock := columns[FIELD_BIRTH_CLOCK]
+	if hasTime {
+		delete(columns, FIELD_BIRTH_DATE)
+		delete(columns, FIELD_BIRTH_CLOCK)
+	}
+
+	var missing []string
+	for _, field := range []string{FIELD_NAME, FIELD_GENDER} {
+		if _, ok := columns[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if !hasTime && !(hasDate && hasClock) {
+		missing = append(missing, FIELD_BIRTH_TIME)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("缺少必填列: %s", strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// Fields 返回全部可映射字段，按字母序排列
+// 返回值：
+//   - []string: 字段列表
+func Fields() []string {
+	fields := make([]string, 0, len(defaultAliases))
+	for field := range defaultAliases {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ParseRow 校验并解析一行数据
+// 参数：
+//   - rowNo: 表格行号
+//   - row: 行数据
+//   - columns: 字段到列下标的映射
+//   - opts: 解析选项
+//
+// 返回值：
+//   - *Record: 出生档案
+//   - error: 校验失败时返回错误，错误信息可直接展示给用户
+func ParseRow(rowNo int, row []string, columns Columns, opts *Options) (*Record, error) {
+	cell := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	record := &Record{Row: rowNo, Label: cell(FIELD_LABEL), Notes: cell(FIELD_NOTES)}
+
+	record.Name = cell(FIELD_NAME)
+	if record.Name == "" {
+		return nil, fmt.Errorf("姓名不能为空")
+	}
+	if len([]rune(record.Name)) > NAME_MAX_LENGTH {
+		return nil, fmt.Errorf("姓名不能超过 %d 个字符", NAME_MAX_LENGTH)
+	}
+
+	gender, ok := genderValues[normalize(cell(FIELD_GENDER))]
+	if !ok {
+		return nil, fmt.Errorf("性别 %q 无效，应为 男/女 或 male/female", cell(FIELD_GENDER))
+	}
+	record.Gender = gender
+
+	record.Calendar = opts.DefaultCalendar
+	if value := cell(FIELD_CALENDAR); value != "" {
+		calendar, ok := calendarValues[normalize(value)]
+		if !ok {
+			return nil, fmt.Errorf("历法 %q 无效，应为 公历/农历 或 solar/lunar", value)
+		}
+		record.Calendar = calendar
+	}
+
+	record.Relation = opts.DefaultRelation
+	if value := cell(FIELD_RELATION); value != "" {
+		relation, ok := relationValues[normalize(value)]
+		if !ok {
+			return nil, fmt.Errorf("关系 %q 无效", value)
+		}
+		record.Relation = relation
+	}
+	if record.Relation == bazi.RELATION_SELF {
+		return nil, fmt.Errorf("批量导入不支持本人档案")
+	}
+
+	location := opts.Location
+	if location == nil {
+		location = time.Local
+	}
+	var err error
+	if _, ok := columns[FIELD_BIRTH_TIME]; ok {
+		record.BirthTime, err = parseBirthTime(cell(FIELD_BIRTH_TIME), location)
+	} else {
+		record.BirthTime, err = parseBirthDateClock(cell(FIELD_BIRTH_DATE), cell(FIELD_BIRTH_CLOCK), location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if year := record.BirthTime.Year(); year < MIN_BIRTH_YEAR || year > MAX_BIRTH_YEAR {
+		return nil, fmt.Errorf("出生年份需在 %d 至 %d 之间", MIN_BIRTH_YEAR, MAX_BIRTH_YEAR)
+	}
+
+	for _, tag := range strings.FieldsFunc(cell(FIELD_TAGS), isTagSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			if len([]rune(tag)) > TAG_MAX_LENGTH {
+				return nil, fmt.Errorf("标签 %q 不能超过 %d 个字符", tag, TAG_MAX_LENGTH)
+			}
+			record.Tags = append(record.Tags, tag)
+		}
+	}
+	if len(record.Tags) > MAX_TAGS {
+		return nil, fmt.Errorf("标签不能超过 %d 个", MAX_TAGS)
+	}
+
+	return record, nil
+}
+
+// IsBlank 判断是否为空行
+// 参数：
+//   - row: 行数据
+//
+// 返回值：
+//   - bool: 全部单元格为空时返回 true
+func IsBlank(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBirthTime 解析单列出生时间，支持常见文本格式与 Excel 日期序列号
+// 参数：
+//   - value: 单元格内容
+//   - location: 未带时区时使用的时区
+//
+// 返回值：
+//   - time.Time: 出生时间
+//   - error: 无法解析时返回错误
+func parseBirthTime(value string, location *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("出生时间不能为空")
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		return fromExcelSerial(serial, location), nil
+	}
+	for _, layout := range birthTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range birthDateLayouts {
+		if _, err := time.ParseInLocation(layout, value, location); err == nil {
+			return time.Time{}, fmt.Errorf("出生时间 %q 缺少时刻，排盘需精确到时辰", value)
+		}
+	}
+	return time.Time{}, fmt.Errorf("出生时间 %q 格式无效，应为 YYYY-MM-DD HH:MM", value)
+}
+
+// parseBirthDateClock 解析分列的出生日期与出生时刻
+// 参数：
+//   - dateValue: 出生日期单元格
+//   - clockValue: 出生时刻单元格
+//   - location: 时区
+//
+// 返回值：
+//   - time.Time: 出生时间
+//   - error: 无法解析时返回错误
+func parseBirthDateClock(dateValue, clockValue string, location *time.Location) (time.Time, error) {
+	if dateValue == "" {
+		return time.Time{}, fmt.Errorf("出生日期不能为空")
+	}
+	if clockValue == "" {
+		return time.Time{}, fmt.Errorf("出生时刻不能为空，排盘需精确到时辰")
+	}
+
+	var date time.Time
+	if serial, err := strconv.ParseFloat(dateValue, 64); err == nil && !strings.ContainsAny(dateValue, "-/.") && len(dateValue) != len("20060102") {
+		date = fromExcelSerial(math.Floor(serial), location)
+	} else {
+		parsed := false
+		for _, layout := range birthDateLayouts {
+			if t, err := time.ParseInLocation(layout, dateValue, location); err == nil {
+				date, parsed = t, true
+				break
+			}
+		}
+		if !parsed {
+			return time.Time{}, fmt.Errorf("出生日期 %q 格式无效，应为 YYYY-MM-DD", dateValue)
+		}
+	}
+
+	var offset time.Duration
+	if fraction, err := strconv.ParseFloat(clockValue, 64); err == nil && fraction >= 0 && fraction < 1 {
+		offset = time.Duration(math.Round(fraction*86400)) * time.Second
+	} else {
+		parsed := false
+		for _, layout := range birthClockLayouts {
+			if t, err := time.Parse(layout, clockValue); err == nil {
+				offset = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+				parsed = true
+				break
+			}
+		}
+		if !parsed {
+			return time.Time{}, fmt.Errorf("出生时刻 %q 格式无效，应为 HH:MM", clockValue)
+		}
+	}
+
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, location).Add(offset), nil
+}
+
+// fromExcelSerial 将 Excel 日期序列号转换为时间，按墙上时间解释，精确到秒
+// 参数：
+//   - serial: 序列号，整数部分为天数，小数部分为当日时刻
+//   - location: 时区
+//
+// 返回值：
+//   - time.Time: 时间
+func fromExcelSerial(serial float64, location *time.Location) time.Time {
+	seconds := int64(math.Round((serial - excelEpochDays) * 86400))
+	utc := time.Unix(seconds, 0).UTC()
+	return time.Date(utc.Year(), utc.Month(), utc.Day(), utc.Hour(), utc.Minute(), utc.Second(), 0, location)
+}
+
+// normalize 统一大小写与空白，用于表头与取值匹配
+// 参数：
+//   - s: 原始字符串
+//
+// 返回值：
+//   - string: 规范化后的字符串
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(s, "\uFEFF")))
+}
+
+// isTagSeparator 判断是否为标签分隔符，兼容中英文逗号、顿号、分号与竖线
+// 参数：
+//   - r: 字符
+//
+// 返回值：
+//   - bool: 是否为分隔符
+func isTagSeparator(r rune) bool {
+	switch r {
+	case ',', '，', '、', ';', '；', '|':
+		return true
+	}
+	return false
+}
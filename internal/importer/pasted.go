@@ -0,0 +1,374 @@
+// Package importer 提供出生档案批量导入，包含 CSV/XLSX 读取、列映射、逐行校验与并发排盘
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// 粘贴命盘的格式
+const (
+	PASTE_FORMAT_TEXT = "text" // 纯文本
+	PASTE_FORMAT_JSON = "json" // 排盘软件导出的 JSON
+)
+
+// PASTE_MAX_LENGTH 粘贴内容的最大长度（字节）
+const PASTE_MAX_LENGTH = 64 << 10
+
+const (
+	stemChars   = "甲乙丙丁戊己庚辛壬癸"
+	branchChars = "子丑寅卯辰巳午未申酉戌亥"
+)
+
+var (
+	ganZhiPattern   = regexp.MustCompile("[" + stemChars + "][" + branchChars + "]")
+	labeledPattern  = regexp.MustCompile("([年月日时])柱\\s*[:：]?\\s*([" + stemChars + "])\\s*([" + branchChars + "])")
+	namePattern     = regexp.MustCompile(`(?:姓名|名字|命主)\s*[:：]?\s*([\p{Han}A-Za-z·]{1,20})`)
+	genderPattern   = regexp.MustCompile(`(?:性别\s*[:：]?\s*([男女]))|(乾造|坤造|男命|女命)`)
+	datePattern     = regexp.MustCompile(`(\d{4})\s*[年\-/.]\s*(\d{1,2})\s*[月\-/.]\s*(\d{1,2})\s*日?`)
+	clockPattern    = regexp.MustCompile(`^\s*[T\s]?\s*(\d{1,2})\s*(?:[:：时點点]\s*(\d{1,2})?)`)
+	hourZhiPattern  = regexp.MustCompile("^\\s*([" + branchChars + "])时")
+	labelNoise      = strings.NewReplacer("乾造", "", "坤造", "", "男命", "", "女命", "", "八字", "", "四柱", "", "天干", "", "地支", "", "干", "", "支", "", ":", "", "：", "")
+	traditionalForm = strings.NewReplacer("時", "时", "運", "运", "曆", "历", "陽", "阳", "陰", "阴", "舊", "旧")
+)
+
+// ignoredLineMarkers 含有干支但不属于原局四柱的行
+var ignoredLineMarkers = []string{"流年", "流月", "流日", "小运", "胎元", "胎息", "命宫", "身宫", "起运", "交运", "空亡", "纳音"}
+
+// hourBranchClock 时辰对应的记录时刻（取时辰正中）
+var hourBranchClock = map[string]int{
+	"子": 0, "丑": 2, "寅": 4, "卯": 6, "辰": 8, "巳": 10,
+	"午": 12, "未": 14, "申": 16, "酉": 18, "戌": 20, "亥": 22,
+}
+
+// PastedChart 从粘贴文本或排盘软件导出内容中识别出的命盘
+type PastedChart struct {
+	Name      string    // 姓名，未识别时为空
+	Gender    string    // 性别 (male/female)，未识别时为空
+	Pillars   []string  // 四柱干支（年、月、日、时），未识别时为空
+	BirthTime time.Time // 出生时间，未识别或缺少时刻时为零值
+	Calendar  string    // 出生时间的历法 (lunar/solar)
+	Luck      []string  // 大运干支
+	Warnings  []string  // 识别过程中的提示
+}
+
+// PasteOptions 粘贴内容缺少的信息使用的默认值
+type PasteOptions struct {
+	Name     string         // 默认姓名
+	Gender   string         // 默认性别
+	Calendar string         // 出生时间未注明历法时使用的历法
+	Location *time.Location // 出生时间使用的时区
+}
+
+// DetectPasteFormat 根据内容判断粘贴格式
+// 参数：
+//   - content: 粘贴内容
+//
+// 返回值：
+//   - string: 粘贴格式
+func DetectPasteFormat(content string) string {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(content, "\uFEFF"))
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		return PASTE_FORMAT_JSON
+	}
+	return PASTE_FORMAT_TEXT
+}
+
+// ParsePastedText 从纯文本中识别命盘，兼容单行四柱、年月日时柱分行标注与天干地支分两行排列的版式
+// 参数：
+//   - content: 粘贴的文本，如 "乾造 甲子 丙寅 戊辰 庚申" 与随后的大运行
+//   - opts: 默认值
+//
+// 返回值：
+//   - *PastedChart: 识别出的命盘
+//   - error: 未识别到四柱时返回错误
+func ParsePastedText(content string, opts *PasteOptions) (*PastedChart, error) {
+	p := &PastedChart{}
+	labeled := make(map[string]string, 4)
+	lines := strings.Split(traditionalForm.Replace(strings.TrimPrefix(content, "\uFEFF")), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+
+		if p.Name == "" {
+			if m := namePattern.FindStringSubmatch(line); m != nil {
+				p.Name = m[1]
+			}
+		}
+		if p.Gender == "" {
+			if m := genderPattern.FindStringSubmatch(line); m != nil {
+				p.Gender = genderValues[string([]rune(m[1] + m[2])[0])]
+			}
+		}
+
+		switch {
+		case strings.Contains(line, "大运"):
+			p.Luck = append(p.Luck, ganZhiPattern.FindAllString(line, -1)...)
+			continue
+		case containsAny(line, ignoredLineMarkers):
+			continue
+		}
+
+		if t, calendar, hasClock, ok := parseBirthLine(line, opts); ok {
+			// 同时给出公历与农历时以公历为准
+			if p.BirthTime.IsZero() || (p.Calendar != utils.CALENDAR_SOLAR && calendar == utils.CALENDAR_SOLAR) {
+				if hasClock {
+					p.BirthTime, p.Calendar = t, calendar
+				} else {
+					p.Warnings = append(p.Warnings, fmt.Sprintf("出生时间 %q 缺少时刻，按出生时间未知保存", line))
+				}
+			}
+		}
+
+		for _, m := range labeledPattern.FindAllStringSubmatch(line, -1) {
+			labeled[m[1]] = m[2] + m[3]
+		}
+		if len(p.Pillars) == 0 && len(labeled) == 0 {
+			if pairs := ganZhiPattern.FindAllString(line, -1); len(pairs) >= 4 {
+				p.Pillars = pairs[:4]
+				continue
+			}
+			// 天干一行、地支一行的竖排版式
+			if stems := onlyChars(line, stemChars); len(stems) == 4 && i+1 < len(lines) {
+				if branches := onlyChars(strings.TrimSpace(lines[i+1]), branchChars); len(branches) == 4 {
+					for j := range stems {
+						p.Pillars = append(p.Pillars, stems[j]+branches[j])
+					}
+					i++
+				}
+			}
+		}
+	}
+
+	if len(labeled) == 4 {
+		p.Pillars = []string{labeled["年"], labeled["月"], labeled["日"], labeled["时"]}
+	}
+
+	return p, p.complete(opts)
+}
+
+// ToChart 将识别结果转换为命盘，有精确出生时间时按出生时间排盘，否则仅保留四柱并将出生时间标记为未知
+// 返回值：
+//   - *chart.Chart: 八字命盘
+//   - error: 信息不足或排盘失败时返回错误
+func (p *PastedChart) ToChart() (*chart.Chart, error) {
+	if p.BirthTime.IsZero() {
+		c, err := chart.FromPillars(p.Pillars)
+		if err != nil {
+			return nil, err
+		}
+		c.Name, c.Gender, c.Calendar = p.Name, p.Gender, p.Calendar
+		return c, nil
+	}
+
+	c, err := chart.Calculate(p.Name, p.Gender, p.BirthTime, p.Calendar)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Pillars) == 0 {
+		return c, nil
+	}
+
+	pasted, err := chart.FromPillars(p.Pillars)
+	if err != nil {
+		return nil, err
+	}
+	calculated := strings.Join(c.PillarStrings(), " ")
+	if given := strings.Join(pasted.PillarStrings(), " "); given != calculated {
+		// 各排盘软件对早晚子时、真太阳时的处理不同，以用户粘贴的四柱为准
+		p.Warnings = append(p.Warnings, fmt.Sprintf("按出生时间排得 %s，与粘贴的四柱 %s 不一致，已保留粘贴的四柱", calculated, given))
+		c.Year, c.Month, c.Day, c.Hour = pasted.Year, pasted.Month, pasted.Day, pasted.Hour
+	}
+	return c, nil
+}
+
+// complete 补全默认值并校验识别结果
+// 参数：
+//   - opts: 默认值
+//
+// 返回值：
+//   - error: 缺少四柱与出生时间或性别无法确定时返回错误
+func (p *PastedChart) complete(opts *PasteOptions) error {
+	if p.Name == "" {
+		p.Name = opts.Name
+	}
+	if p.Calendar == "" {
+		p.Calendar = opts.Calendar
+	}
+	if p.Calendar == "" {
+		p.Calendar = utils.CALENDAR_SOLAR
+	}
+
+	if len(p.Pillars) != 0 {
+		if _, err := chart.FromPillars(p.Pillars); err != nil {
+			return err
+		}
+	} else if p.BirthTime.IsZero() {
+		return fmt.Errorf("未识别到完整的四柱干支或出生时间")
+	}
+
+	if p.Gender == "" {
+		p.Gender = opts.Gender
+	}
+	if p.Gender == "" && len(p.Pillars) == 4 {
+		if gender := genderFromLuck(p.Pillars, p.Luck); gender != "" {
+			p.Gender = gender
+			p.Warnings = append(p.Warnings, "未注明乾造/坤造，已根据大运顺逆推断性别")
+		}
+	}
+	if p.Gender == "" {
+		return fmt.Errorf("未识别到性别（乾造/坤造），请指定性别")
+	}
+	if !p.BirthTime.IsZero() && (p.BirthTime.Year() < MIN_BIRTH_YEAR || p.BirthTime.Year() > MAX_BIRTH_YEAR) {
+		return fmt.Errorf("出生年份需在 %d-%d 之间", MIN_BIRTH_YEAR, MAX_BIRTH_YEAR)
+	}
+	if len([]rune(p.Name)) > NAME_MAX_LENGTH {
+		return fmt.Errorf("姓名不能超过 %d 个字符", NAME_MAX_LENGTH)
+	}
+	return nil
+}
+
+// parseBirthLine 识别含公历或农历数字日期的行，时刻可为 HH:MM、X时X分或地支时辰
+// 参数：
+//   - line: 文本行
+//   - opts: 默认值
+//
+// 返回值：
+//   - time.Time: 出生时间，缺少时刻时为当日零点
+//   - string: 历法
+//   - bool: 是否包含时刻
+//   - bool: 是否为出生时间行
+func parseBirthLine(line string, opts *PasteOptions) (time.Time, string, bool, bool) {
+	loc := datePattern.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return time.Time{}, "", false, false
+	}
+
+	calendar := opts.Calendar
+	switch {
+	case strings.Contains(line, "农历") || strings.Contains(line, "阴历") || strings.Contains(line, "旧历"):
+		calendar = utils.CALENDAR_LUNAR
+	case strings.Contains(line, "公历") || strings.Contains(line, "阳历") || strings.Contains(line, "新历"):
+		calendar = utils.CALENDAR_SOLAR
+	}
+	if calendar == "" {
+		calendar = utils.CALENDAR_SOLAR
+	}
+
+	year, _ := strconv.Atoi(line[loc[2]:loc[3]])
+	month, _ := strconv.Atoi(line[loc[4]:loc[5]])
+	day, _ := strconv.Atoi(line[loc[6]:loc[7]])
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, "", false, false
+	}
+
+	location := opts.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	hour, minute, hasClock := 0, 0, false
+	rest := line[loc[1]:]
+	if m := clockPattern.FindStringSubmatch(rest); m != nil {
+		hour, _ = strconv.Atoi(m[1])
+		if m[2] != "" {
+			minute, _ = strconv.Atoi(m[2])
+		}
+		hasClock = hour < 24 && minute < 60
+	} else if m := hourZhiPattern.FindStringSubmatch(rest); m != nil {
+		hour, hasClock = hourBranchClock[m[1]], true
+	}
+	if !hasClock {
+		hour, minute = 0, 0
+	}
+
+	return time.Date(year, time.Month(month), day, hour, minute, 0, 0, location), calendar, hasClock, true
+}
+
+// genderFromLuck 根据大运顺逆推断性别：阳年男命、阴年女命顺行，反之逆行
+// 参数：
+//   - pillars: 四柱干支
+//   - luck: 大运干支
+//
+// 返回值：
+//   - string: 性别，无法推断时为空
+func genderFromLuck(pillars, luck []string) string {
+	if len(luck) == 0 {
+		return ""
+	}
+	month, err := chart.ParsePillar(pillars[1])
+	if err != nil {
+		return ""
+	}
+	first, err := chart.ParsePillar(luck[0])
+	if err != nil {
+		return ""
+	}
+
+	step := func(p chart.Pillar, offset int) string {
+		return string([]rune(stemChars)[(p.Stem.Index()+offset+10)%10]) + string([]rune(branchChars)[(p.Branch.Index()+offset+12)%12])
+	}
+	var forward bool
+	switch first.String() {
+	case step(month, 1):
+		forward = true
+	case step(month, -1):
+		forward = false
+	default:
+		return ""
+	}
+
+	year, _ := chart.ParsePillar(pillars[0])
+	if forward == (year.Stem.Polarity() == chart.POLARITY_YANG) {
+		return utils.GENDER_MALE
+	}
+	return utils.GENDER_FEMALE
+}
+
+// onlyChars 去除标注与空白后，若剩余字符全部属于给定字符集则逐字返回
+// 参数：
+//   - line: 文本行
+//   - charset: 字符集
+//
+// 返回值：
+//   - []string: 字符列表，存在其他字符时为空
+func onlyChars(line, charset string) []string {
+	var chars []string
+	for _, r := range labelNoise.Replace(line) {
+		switch {
+		case r == ' ' || r == '\t' || r == '　' || r == '|' || r == ',' || r == '，':
+		case strings.ContainsRune(charset, r):
+			chars = append(chars, string(r))
+		default:
+			return nil
+		}
+	}
+	return chars
+}
+
+// containsAny 判断字符串是否包含任一子串
+// 参数：
+//   - s: 字符串
+//   - subs: 子串列表
+//
+// 返回值：
+//   - bool: 是否包含
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
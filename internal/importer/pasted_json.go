@@ -0,0 +1,425 @@
+// Package importer 提供出生档案批量导入，包含 CSV/XLSX 读取、列映射、逐行校验与并发排盘
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// MAX_PASTED_CHARTS 单次粘贴导入的最大命盘数
+const MAX_PASTED_CHARTS = 100
+
+// 排盘软件导出 JSON 中各字段的常见键名，已去除大小写、下划线与连字符，按后缀匹配
+var (
+	jsonContainerKeys = []string{"data", "list", "records", "charts", "items", "result", "results", "rows"}
+	jsonNameKeys      = []string{"姓名", "xingming", "username", "realname", "fullname"}
+	jsonGenderKeys    = []string{"gender", "sex", "性别", "xingbie", "qiankun", "乾坤"}
+	jsonCalendarKeys  = []string{"calendar", "calendartype", "历法", "datetype"}
+	jsonSolarKeys     = []string{"birthtime", "birthday", "birthdate", "birth", "solar", "solardate", "solartime", "gongli", "公历", "阳历", "出生时间", "出生日期", "datetime"}
+	jsonLunarKeys     = []string{"lunar", "lunardate", "lunartime", "nongli", "农历", "阴历"}
+	jsonPillarsKeys   = []string{"bazi", "sizhu", "pillars", "eightchar", "bz", "四柱", "八字"}
+	jsonLuckKeys      = []string{"dayun", "luck", "luckpillars", "dayunlist", "大运"}
+	jsonPositionKeys  = [4][]string{
+		{"year", "nian", "年"},
+		{"month", "yue", "月"},
+		{"day", "ri", "日"},
+		{"hour", "time", "shi", "时"},
+	}
+	jsonPillarSuffixes = []string{"pillar", "gz", "ganzhi", "zhu", "柱", ""}
+	jsonStemSuffixes   = []string{"gan", "tiangan", "stem", "干", "天干"}
+	jsonBranchSuffixes = []string{"zhi", "dizhi", "branch", "支", "地支"}
+)
+
+// jsonEntry 展开后的 JSON 字段
+type jsonEntry struct {
+	key   string // 规范化后的字段路径，各级键名直接拼接
+	value any    // 字段值，对象会被继续展开，数组保持原样
+}
+
+// ParsePastedJSON 识别排盘软件导出的 JSON，支持单个命盘、命盘数组以及 data/list 等字段包裹的命盘列表
+// 参数：
+//   - content: JSON 内容
+//   - opts: 默认值
+//
+// 返回值：
+//   - []*PastedChart: 识别出的命盘
+//   - error: JSON 无效或任一命盘无法识别时返回错误
+func ParsePastedJSON(content string, opts *PasteOptions) ([]*PastedChart, error) {
+	decoder := json.NewDecoder(strings.NewReader(strings.TrimPrefix(content, "\uFEFF")))
+	decoder.UseNumber()
+
+	var root any
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("JSON 格式无效: %w", err)
+	}
+
+	objects := chartObjects(root)
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("JSON 中未找到命盘信息")
+	}
+	if len(objects) > MAX_PASTED_CHARTS {
+		return nil, fmt.Errorf("单次最多导入 %d 份命盘", MAX_PASTED_CHARTS)
+	}
+
+	charts := make([]*PastedChart, 0, len(objects))
+	for i, object := range objects {
+		p, err := parseJSONChart(object, opts)
+		if err != nil {
+			if len(objects) == 1 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("第 %d 份命盘: %w", i+1, err)
+		}
+		charts = append(charts, p)
+	}
+	return charts, nil
+}
+
+// chartObjects 查找 JSON 中的命盘对象
+// 参数：
+//   - v: JSON 值
+//
+// 返回值：
+//   - []map[string]any: 命盘对象列表
+func chartObjects(v any) []map[string]any {
+	switch value := v.(type) {
+	case []any:
+		var objects []map[string]any
+		for _, item := range value {
+			objects = append(objects, chartObjects(item)...)
+		}
+		return objects
+	case map[string]any:
+		if looksLikeChart(value) {
+			return []map[string]any{value}
+		}
+		var objects []map[string]any
+		for key, child := range value {
+			if matchKey(normalizeKey(key), jsonContainerKeys) {
+				objects = append(objects, chartObjects(child)...)
+			}
+		}
+		return objects
+	default:
+		return nil
+	}
+}
+
+// looksLikeChart 判断对象是否包含四柱或出生时间字段
+// 参数：
+//   - m: JSON 对象
+//
+// 返回值：
+//   - bool: 是否为命盘对象
+func looksLikeChart(m map[string]any) bool {
+	for _, e := range flattenJSON(m, "") {
+		if _, ok := jsonPillars(e); ok {
+			return true
+		}
+		if matchKey(e.key, jsonSolarKeys) || matchKey(e.key, jsonLunarKeys) {
+			return true
+		}
+		for i := range jsonPositionKeys {
+			if matchKey(e.key, pillarKeys(i)) && ganZhiPattern.MatchString(jsonString(e.value)) {
+				return true
+			}
+		}
+		if n, ok := e.value.(json.Number); ok && strings.HasSuffix(e.key, "year") {
+			if year, err := n.Int64(); err == nil && year >= MIN_BIRTH_YEAR && year <= MAX_BIRTH_YEAR {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pillarKeys 获取某一柱的候选键名，如 yearpillar、nianzhu、年柱
+// 参数：
+//   - position: 柱位序号（0 年、1 月、2 日、3 时）
+//
+// 返回值：
+//   - []string: 候选键名
+func pillarKeys(position int) []string {
+	var keys []string
+	for _, prefix := range jsonPositionKeys[position] {
+		keys = append(keys, withPrefix(prefix, jsonPillarSuffixes)...)
+	}
+	return keys
+}
+
+// parseJSONChart 从单个命盘对象中识别姓名、性别、四柱、出生时间与大运
+// 参数：
+//   - m: 命盘对象
+//   - opts: 默认值
+//
+// 返回值：
+//   - *PastedChart: 识别出的命盘
+//   - error: 信息不足时返回错误
+func parseJSONChart(m map[string]any, opts *PasteOptions) (*PastedChart, error) {
+	p := &PastedChart{}
+	entries := flattenJSON(m, "")
+	positions := make([]string, 4)
+	stems, branches := make([]string, 4), make([]string, 4)
+	components := make(map[string]int)
+
+	for _, e := range entries {
+		text := jsonString(e.value)
+
+		switch {
+		case p.Name == "" && (e.key == "name" || matchKey(e.key, jsonNameKeys)) && text != "":
+			p.Name = strings.TrimSpace(text)
+		case p.Gender == "" && matchKey(e.key, jsonGenderKeys):
+			p.Gender = jsonGender(text)
+		case matchKey(e.key, jsonCalendarKeys):
+			if calendar, ok := calendarValues[normalize(text)]; ok && p.BirthTime.IsZero() {
+				p.Calendar = calendar
+			}
+		case matchKey(e.key, jsonLuckKeys):
+			if len(p.Luck) == 0 {
+				p.Luck = ganZhiPattern.FindAllString(flattenText(e.value), -1)
+			}
+		}
+
+		if p.Pillars == nil {
+			if pillars, ok := jsonPillars(e); ok {
+				p.Pillars = pillars
+			}
+		}
+
+		lunar := matchKey(e.key, jsonLunarKeys)
+		if (lunar || matchKey(e.key, jsonSolarKeys)) && (p.BirthTime.IsZero() || (!lunar && p.Calendar == utils.CALENDAR_LUNAR)) {
+			if t, calendar, hasClock, ok := parseBirthLine(text, opts); ok && hasClock {
+				if lunar {
+					calendar = utils.CALENDAR_LUNAR
+				}
+				p.BirthTime, p.Calendar = t, calendar
+			}
+		}
+
+		for i := range jsonPositionKeys {
+			// 兼容 yearPillar、yearGan 以及 yearPillar: {gan, zhi} 等写法
+			for _, base := range pillarKeys(i) {
+				if positions[i] == "" && strings.HasSuffix(e.key, base) && len([]rune(text)) == 2 && ganZhiPattern.MatchString(text) {
+					positions[i] = text
+				}
+				if stems[i] == "" && len([]rune(text)) == 1 && strings.Contains(stemChars, text) && matchKey(e.key, withPrefix(base, jsonStemSuffixes)) {
+					stems[i] = text
+				}
+				if branches[i] == "" && len([]rune(text)) == 1 && strings.Contains(branchChars, text) && matchKey(e.key, withPrefix(base, jsonBranchSuffixes)) {
+					branches[i] = text
+				}
+			}
+		}
+
+		if n, ok := e.value.(json.Number); ok {
+			if value, err := n.Int64(); err == nil {
+				for _, component := range []string{"year", "month", "day", "hour", "minute"} {
+					if _, seen := components[component]; !seen && strings.HasSuffix(e.key, component) {
+						components[component] = int(value)
+					}
+				}
+			}
+		}
+	}
+
+	if p.Pillars == nil {
+		for i := range positions {
+			if positions[i] == "" && stems[i] != "" && branches[i] != "" {
+				positions[i] = stems[i] + branches[i]
+			}
+		}
+		if positions[0] != "" && positions[1] != "" && positions[2] != "" && positions[3] != "" {
+			p.Pillars = positions
+		}
+	}
+
+	if p.BirthTime.IsZero() {
+		year, month, day := components["year"], components["month"], components["day"]
+		if hour, ok := components["hour"]; ok && year >= MIN_BIRTH_YEAR && year <= MAX_BIRTH_YEAR && month >= 1 && month <= 12 && day >= 1 && day <= 31 && hour >= 0 && hour < 24 {
+			location := opts.Location
+			if location == nil {
+				location = time.Local
+			}
+			p.BirthTime = time.Date(year, time.Month(month), day, hour, components["minute"], 0, 0, location)
+		}
+	}
+
+	return p, p.complete(opts)
+}
+
+// jsonPillars 从四柱字段中提取四柱，字段值可为干支字符串、干支数组或含天干地支的对象数组
+// 参数：
+//   - e: JSON 字段
+//
+// 返回值：
+//   - []string: 四柱干支
+//   - bool: 是否识别成功
+func jsonPillars(e jsonEntry) ([]string, bool) {
+	if !matchKey(e.key, jsonPillarsKeys) {
+		return nil, false
+	}
+	pairs := ganZhiPattern.FindAllString(flattenText(e.value), -1)
+	if len(pairs) < 4 {
+		return nil, false
+	}
+	return pairs[:4], true
+}
+
+// jsonGender 识别性别取值，数值按 1 为男、0 或 2 为女
+// 参数：
+//   - value: 字段值
+//
+// 返回值：
+//   - string: 性别，无法识别时为空
+func jsonGender(value string) string {
+	value = normalize(value)
+	switch value {
+	case "1":
+		return utils.GENDER_MALE
+	case "0", "2":
+		return utils.GENDER_FEMALE
+	}
+	if gender, ok := genderValues[value]; ok {
+		return gender
+	}
+	if value != "" {
+		return genderValues[string([]rune(value)[0])]
+	}
+	return ""
+}
+
+// flattenJSON 展开嵌套对象，结果按字段路径由短到长排序，使外层字段优先
+// 参数：
+//   - m: JSON 对象
+//   - prefix: 字段路径前缀
+//
+// 返回值：
+//   - []jsonEntry: 展开后的字段
+func flattenJSON(m map[string]any, prefix string) []jsonEntry {
+	var entries []jsonEntry
+	for key, value := range m {
+		path := prefix + normalizeKey(key)
+		if child, ok := value.(map[string]any); ok {
+			entries = append(entries, jsonEntry{key: path, value: value})
+			entries = append(entries, flattenJSON(child, path)...)
+			continue
+		}
+		entries = append(entries, jsonEntry{key: path, value: value})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if len(entries[i].key) != len(entries[j].key) {
+			return len(entries[i].key) < len(entries[j].key)
+		}
+		return entries[i].key < entries[j].key
+	})
+	return entries
+}
+
+// flattenText 将数组或对象中的全部字符串按原顺序拼接，用于提取干支
+// 参数：
+//   - v: JSON 值
+//
+// 返回值：
+//   - string: 拼接后的文本
+func flattenText(v any) string {
+	switch value := v.(type) {
+	case []any:
+		parts := make([]string, 0, len(value))
+		for _, item := range value {
+			parts = append(parts, flattenText(item))
+		}
+		return strings.Join(parts, " ")
+	case map[string]any:
+		// 对象内字段无序，仅取天干地支或干支字段，保证顺序正确
+		for _, key := range []string{"ganzhi", "gz", "pillar", "name"} {
+			for k, item := range value {
+				if normalizeKey(k) == key {
+					return jsonString(item)
+				}
+			}
+		}
+		var stem, branch string
+		for k, item := range value {
+			switch {
+			case matchKey(normalizeKey(k), jsonStemSuffixes):
+				stem = jsonString(item)
+			case matchKey(normalizeKey(k), jsonBranchSuffixes):
+				branch = jsonString(item)
+			}
+		}
+		return stem + branch
+	default:
+		return jsonString(v)
+	}
+}
+
+// jsonString 将标量字段值转换为字符串
+// 参数：
+//   - v: JSON 值
+//
+// 返回值：
+//   - string: 字符串，非标量时为空
+func jsonString(v any) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		if value {
+			return "1"
+		}
+		return "0"
+	default:
+		return ""
+	}
+}
+
+// normalizeKey 规范化 JSON 键名，忽略大小写、下划线、连字符与空白
+// 参数：
+//   - key: 键名
+//
+// 返回值：
+//   - string: 规范化后的键名
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "", ".", "").Replace(strings.ToLower(key))
+}
+
+// withPrefix 为候选键名添加前缀
+// 参数：
+//   - prefix: 前缀
+//   - keys: 候选键名
+//
+// 返回值：
+//   - []string: 添加前缀后的键名
+func withPrefix(prefix string, keys []string) []string {
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, prefix+key)
+	}
+	return result
+}
+
+// matchKey 判断字段路径是否以任一键名结尾
+// 参数：
+//   - key: 规范化后的字段路径
+//   - keys: 候选键名
+//
+// 返回值：
+//   - bool: 是否匹配
+func matchKey(key string, keys []string) bool {
+	for _, k := range keys {
+		if strings.HasSuffix(key, k) {
+			return true
+		}
+	}
+	return false
+}
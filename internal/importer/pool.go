@@ -0,0 +1,107 @@
+// Package importer 提供出生档案批量导入，包含 CSV/XLSX 读取、列映射、逐行校验与并发排盘
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package importer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Done-0/metaphysics/internal/chart"
+)
+
+// 并发默认值
+const (
+	DEFAULT_WORKERS    = 4   // 默认排盘并发数
+	DEFAULT_BATCH_SIZE = 200 // 默认每批入库数量
+)
+
+// Outcome 单行排盘结果，Err 不为空时 Chart 为空
+type Outcome struct {
+	Record *Record      // 出生档案
+	Chart  *chart.Chart // 排盘结果
+	Err    error        // 排盘错误
+}
+
+// Process 使用有界协程池并发排盘，按批次回调结果，回调在同一协程中串行执行
+// 上下文取消后不再派发新的行，已派发的行处理完后返回上下文错误
+// 参数：
+//   - ctx: 上下文
+//   - records: 待排盘的出生档案
+//   - workers: 并发数，小于等于 0 时使用默认值
+//   - batchSize: 每批数量，小于等于 0 时使用默认值
+//   - handle: 批次回调
+//
+// 返回值：
+//   - error: 上下文取消时返回上下文错误
+func Process(ctx context.Context, records []*Record, workers, batchSize int, handle func(batch []*Outcome)) error {
+	if workers <= 0 {
+		workers = DEFAULT_WORKERS
+	}
+	if batchSize <= 0 {
+		batchSize = DEFAULT_BATCH_SIZE
+	}
+
+	jobs := make(chan *Record)
+	results := make(chan *Outcome, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for record := range jobs {
+				results <- calculate(record)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, record := range records {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- record:
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	batch := make([]*Outcome, 0, batchSize)
+	for outcome := range results {
+		batch = append(batch, outcome)
+		if len(batch) == batchSize {
+			handle(batch)
+			batch = make([]*Outcome, 0, batchSize)
+		}
+	}
+	if len(batch) > 0 {
+		handle(batch)
+	}
+
+	return ctx.Err()
+}
+
+// calculate 为单行排盘，排盘库对极端日期可能 panic，此处转为行错误
+// 参数：
+//   - record: 出生档案
+//
+// 返回值：
+//   - *Outcome: 排盘结果
+func calculate(record *Record) (outcome *Outcome) {
+	outcome = &Outcome{Record: record}
+	defer func() {
+		if r := recover(); r != nil {
+			outcome.Chart, outcome.Err = nil, fmt.Errorf("排盘失败: %v", r)
+		}
+	}()
+
+	outcome.Chart, outcome.Err = chart.Calculate(record.Name, record.Gender, record.BirthTime, record.Calendar)
+	return outcome
+}
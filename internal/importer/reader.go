@@ -0,0 +1,275 @@
+// Package importer 提供出生档案批量导入，包含 CSV/XLSX 读取、列映射、逐行校验与并发排盘
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// 支持的文件格式
+const (
+	FORMAT_CSV  = ".csv"  // 逗号分隔文本
+	FORMAT_XLSX = ".xlsx" // Excel 工作簿
+)
+
+// xlsx 内部文件路径
+const (
+	xlsxWorkbook      = "xl/workbook.xml"
+	xlsxWorkbookRels  = "xl/_rels/workbook.xml.rels"
+	xlsxSharedStrings = "xl/sharedStrings.xml"
+	xlsxDefaultSheet  = "xl/worksheets/sheet1.xml"
+)
+
+// ReadRows 读取 CSV 或 XLSX 文件的全部行，XLSX 仅读取第一个工作表
+// 返回的行下标与表格行号一一对应（第 i 个元素为第 i+1 行），空行保留为空切片
+// 参数：
+//   - fileName: 文件名，用于按扩展名识别格式
+//   - r: 文件内容
+//   - maxBytes: 允许读取的最大字节数，小于等于 0 表示不限
+//
+// 返回值：
+//   - [][]string: 全部行
+//   - error: 格式不支持、文件过大或解析失败时返回错误
+func ReadRows(fileName string, r io.Reader, maxBytes int64) ([][]string, error) {
+	if maxBytes > 0 {
+		r = io.LimitReader(r, maxBytes+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("文件大小超过 %d 字节", maxBytes)
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case FORMAT_CSV:
+		return readCSV(data)
+	case FORMAT_XLSX:
+		return readXLSX(data)
+	default:
+		return nil, fmt.Errorf("不支持的文件格式，仅支持 CSV 与 XLSX")
+	}
+}
+
+// readCSV 解析 CSV，兼容 UTF-8 BOM 与列数不一致的行
+// 参数：
+//   - data: 文件内容
+//
+// 返回值：
+//   - [][]string: 全部行
+//   - error: 解析失败时返回错误
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\uFEFF"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析 CSV 失败: %w", err)
+	}
+	return rows, nil
+}
+
+// xlsxWorkbookXML 工作簿中的工作表列表
+type xlsxWorkbookXML struct {
+	Sheets []struct {
+		RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelsXML 工作簿关系
+type xlsxRelsXML struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText 富文本或纯文本字符串，富文本由多个片段拼接
+type xlsxText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String 拼接文本
+// 返回值：
+//   - string: 文本内容
+func (t xlsxText) String() string {
+	if len(t.R) == 0 {
+		return t.T
+	}
+	var sb strings.Builder
+	for _, r := range t.R {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+// xlsxSharedStringsXML 共享字符串表
+type xlsxSharedStringsXML struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxSheetXML 工作表数据
+type xlsxSheetXML struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R  string   `xml:"r,attr"`
+			T  string   `xml:"t,attr"`
+			V  string   `xml:"v"`
+			IS xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX 解析 XLSX 第一个工作表，日期单元格保留为 Excel 序列号，由行解析时识别
+// 参数：
+//   - data: 文件内容
+//
+// 返回值：
+//   - [][]string: 全部行
+//   - error: 解析失败时返回错误
+func readXLSX(data []byte) ([][]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("解析 XLSX 失败: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	var shared xlsxSharedStringsXML
+	if f, ok := files[xlsxSharedStrings]; ok {
+		if err := decodeZipXML(f, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetFile, ok := files[firstSheetPath(files)]
+	if !ok {
+		return nil, fmt.Errorf("XLSX 中没有工作表")
+	}
+	var sheet xlsxSheetXML
+	if err := decodeZipXML(sheetFile, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		rowNo := row.R
+		if rowNo <= 0 {
+			rowNo = len(rows) + 1
+		}
+		for len(rows) < rowNo {
+			rows = append(rows, nil)
+		}
+
+		var cells []string
+		for i, cell := range row.Cells {
+			col := i
+			if cell.R != "" {
+				col = columnIndex(cell.R)
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+
+			switch cell.T {
+			case "s":
+				idx, err := strconv.Atoi(cell.V)
+				if err != nil || idx < 0 || idx >= len(shared.Items) {
+					return nil, fmt.Errorf("XLSX 单元格 %s 引用了无效的共享字符串", cell.R)
+				}
+				cells[col] = shared.Items[idx].String()
+			case "inlineStr":
+				cells[col] = cell.IS.String()
+			default:
+				cells[col] = cell.V
+			}
+		}
+		rows[rowNo-1] = cells
+	}
+
+	return rows, nil
+}
+
+// firstSheetPath 根据工作簿与关系文件定位第一个工作表，缺失时回退为 sheet1.xml
+// 参数：
+//   - files: 压缩包内文件
+//
+// 返回值：
+//   - string: 工作表路径
+func firstSheetPath(files map[string]*zip.File) string {
+	var workbook xlsxWorkbookXML
+	var rels xlsxRelsXML
+	wf, ok1 := files[xlsxWorkbook]
+	rf, ok2 := files[xlsxWorkbookRels]
+	if !ok1 || !ok2 || decodeZipXML(wf, &workbook) != nil || decodeZipXML(rf, &rels) != nil || len(workbook.Sheets) == 0 {
+		return xlsxDefaultSheet
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+	return xlsxDefaultSheet
+}
+
+// decodeZipXML 解码压缩包内的 XML 文件
+// 参数：
+//   - f: 压缩包内文件
+//   - v: 解码目标
+//
+// 返回值：
+//   - error: 解码失败时返回错误
+func decodeZipXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("读取 %s 失败: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(rc).Decode(v); err != nil {
+		return fmt.Errorf("解析 %s 失败: %w", f.Name, err)
+	}
+	return nil
+}
+
+// columnIndex 将单元格引用（如 "AB12"）的列字母转换为从 0 开始的列下标
+// 参数：
+//   - ref: 单元格引用
+//
+// 返回值：
+//   - int: 列下标
+func columnIndex(ref string) int {
+	col := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+	}
+	return col - 1
+}
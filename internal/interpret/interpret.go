@@ -0,0 +1,314 @@
+// Package interpret 提供基于排盘事实与固定措辞库的规则解读
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package interpret
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// 大运走势常量
+const (
+	LUCK_FAVORABLE   = "favorable"   // 喜用
+	LUCK_UNFAVORABLE = "unfavorable" // 忌神
+	LUCK_NEUTRAL     = "neutral"     // 喜忌参半
+
+	maxLuckPillars = 8 // 解读的大运步数
+	excessCount    = 3 // 十神达到该数量视为偏多
+)
+
+// tendencyLabels 大运喜忌的中文名称
+var tendencyLabels = map[string]string{
+	LUCK_FAVORABLE:   "喜用",
+	LUCK_UNFAVORABLE: "忌神",
+	LUCK_NEUTRAL:     "喜忌参半",
+}
+
+// tenGodGroups 十神类别，用于判断某类十神是否不显
+var tenGodGroups = []struct {
+	name    string
+	members []string
+}{
+	{"财星", []string{"正财", "偏财"}},
+	{"官杀", []string{"正官", "七杀"}},
+	{"印星", []string{"正印", "偏印"}},
+	{"食伤", []string{"食神", "伤官"}},
+	{"比劫", []string{"比肩", "劫财"}},
+}
+
+// tenGodOrder 十神输出顺序
+var tenGodOrder = []string{"比肩", "劫财", "食神", "伤官", "偏财", "正财", "七杀", "正官", "偏印", "正印"}
+
+// Facts 排盘事实
+type Facts struct {
+	Name      string                `json:"name"`       // 姓名
+	Gender    string                `json:"gender"`     // 性别
+	BirthTime time.Time             `json:"birth_time"` // 出生时间
+	Calendar  string                `json:"calendar"`   // 日历类型
+	Pillars   []string              `json:"pillars"`    // 四柱干支（年、月、日、时）
+	Gans      []string              `json:"gans"`       // 四柱天干
+	Zhis      []string              `json:"zhis"`       // 四柱地支
+	Wuxing    *utils.WuxingAnalysis `json:"wuxing"`     // 五行强弱与喜忌
+	Pattern   string                `json:"pattern"`    // 格局
+	TenGods   map[string]int        `json:"ten_gods"`   // 十神数量（天干与地支本气，不含日主）
+	Luck      []*LuckFact           `json:"luck"`       // 大运
+}
+
+// LuckFact 大运事实
+type LuckFact struct {
+	Pillar   *utils.LuckPillar `json:"pillar"`   // 大运
+	TenGod   string            `json:"ten_god"`  // 大运天干十神
+	Tendency string            `json:"tendency"` // 喜忌 (favorable/unfavorable/neutral)
+}
+
+// Analyze 根据命盘计算解读所需的排盘事实
+// 参数：
+//   - c: 八字命盘
+//
+// 返回值：
+//   - *Facts: 排盘事实
+//   - error: 四柱不完整时返回错误
+func Analyze(c *chart.Chart) (*Facts, error) {
+	if c == nil || !c.Valid() {
+		return nil, fmt.Errorf("四柱信息不完整")
+	}
+
+	name, gender, birthTime, calendar := c.Name, c.Gender, c.BirthTime, c.Calendar
+	gans, zhis := c.Stems(), c.Branches()
+	dayMaster := gans[2]
+	facts := &Facts{
+		Name:      name,
+		Gender:    gender,
+		BirthTime: birthTime,
+		Calendar:  calendar,
+		Gans:      gans,
+		Zhis:      zhis,
+		Pillars:   c.PillarStrings(),
+		Wuxing:    utils.AnalyzeWuxing(gans, zhis),
+		TenGods:   make(map[string]int, len(tenGodOrder)),
+	}
+	// 天干（除日主）与地支本气的十神
+	for i, gan := range gans {
+		if i == 2 {
+			continue
+		}
+		facts.TenGods[utils.GetTenGod(dayMaster, gan)]++
+	}
+	for _, zhi := range zhis {
+		if hide := utils.GetHideGan(zhi); len(hide) > 0 {
+			facts.TenGods[utils.GetTenGod(dayMaster, hide[0])]++
+		}
+	}
+
+	// 以月令本气定格局
+	if hide := utils.GetHideGan(zhis[1]); len(hide) > 0 {
+		switch tenGod := utils.GetTenGod(dayMaster, hide[0]); tenGod {
+		case "比肩":
+			facts.Pattern = "建禄格"
+		case "劫财":
+			facts.Pattern = "月刃格"
+		default:
+			facts.Pattern = tenGod + "格"
+		}
+	}
+
+	if !birthTime.IsZero() {
+		pillars := utils.CalculateLuckPillars(birthTime, calendar, gender)
+		for i, p := range pillars {
+			if i >= maxLuckPillars {
+				break
+			}
+			facts.Luck = append(facts.Luck, &LuckFact{
+				Pillar:   p,
+				TenGod:   utils.GetTenGod(dayMaster, p.Gan),
+				Tendency: luckTendency(facts.Wuxing, p),
+			})
+		}
+	}
+
+	return facts, nil
+}
+
+// Render 将排盘事实组合为固定措辞的解读文本（Markdown）
+// 参数：
+//   - facts: 排盘事实
+//
+// 返回值：
+//   - string: 解读文本
+//   - error: 措辞库加载失败时返回错误
+func Render(facts *Facts) (string, error) {
+	lib, err := loadLibrary()
+	if err != nil {
+		return "", err
+	}
+
+	w := facts.Wuxing
+	var sb strings.Builder
+
+	sb.WriteString("## 命局概览\n\n")
+	sb.WriteString(fmt.Sprintf("八字：%s。日主%s%s，", strings.Join(facts.Pillars, " "), w.DayMaster, w.DayMasterWuxing))
+	counts := make([]string, 0, len(utils.WUXING_LIST))
+	for _, wx := range utils.WUXING_LIST {
+		counts = append(counts, fmt.Sprintf("%s %.1f", wx, w.Counts[wx]))
+	}
+	sb.WriteString(fmt.Sprintf("五行力量：%s。\n\n", strings.Join(counts, "、")))
+
+	sb.WriteString("## 日主特质\n\n")
+	sb.WriteString(lib.DayMaster[w.DayMaster])
+	sb.WriteString("\n\n")
+
+	strength, label := "weak", "身弱"
+	if w.IsStrong {
+		strength, label = "strong", "身强"
+	}
+	sb.WriteString("## 身强身弱\n\n")
+	sb.WriteString(fmt.Sprintf("生扶力量 %.1f，克泄耗力量 %.1f，判为%s。%s\n\n", w.SupportScore, w.DrainScore, label, lib.Strength[strength]))
+
+	if phrase, ok := lib.Pattern[facts.Pattern]; ok {
+		sb.WriteString("## 格局\n\n")
+		sb.WriteString(fmt.Sprintf("%s：%s\n\n", facts.Pattern, phrase))
+	}
+
+	sb.WriteString("## 十神分布\n\n")
+	distribution := make([]string, 0, len(tenGodOrder))
+	for _, tenGod := range tenGodOrder {
+		if n := facts.TenGods[tenGod]; n > 0 {
+			distribution = append(distribution, fmt.Sprintf("%s %d", tenGod, n))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("天干与地支本气十神：%s。\n", strings.Join(distribution, "、")))
+	for _, tenGod := range tenGodOrder {
+		if facts.TenGods[tenGod] >= excessCount {
+			sb.WriteString(fmt.Sprintf("- %s\n", lib.TenGodExcess[tenGod]))
+		}
+	}
+	for _, group := range tenGodGroups {
+		present := false
+		for _, m := range group.members {
+			if facts.TenGods[m] > 0 {
+				present = true
+				break
+			}
+		}
+		if !present {
+			sb.WriteString(fmt.Sprintf("- %s\n", lib.TenGodAbsent[group.name]))
+		}
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## 喜用神\n\n")
+	sb.WriteString(fmt.Sprintf("喜用五行：%s；忌讳五行：%s。\n\n", strings.Join(w.Favorable, "、"), strings.Join(w.Unfavorable, "、")))
+	for _, wx := range w.Favorable {
+		sb.WriteString(fmt.Sprintf("- %s\n", lib.Favorable[wx]))
+	}
+	for _, wx := range w.Missing {
+		sb.WriteString(fmt.Sprintf("- %s\n", lib.Missing[wx]))
+	}
+	sb.WriteString("\n")
+
+	if len(facts.Luck) > 0 {
+		sb.WriteString("## 大运走势\n\n")
+		for _, l := range facts.Luck {
+			sb.WriteString(fmt.Sprintf("- %d-%d 岁（%d-%d）%s运，%s：%s\n",
+				l.Pillar.StartAge, l.Pillar.EndAge, l.Pillar.StartYear, l.Pillar.EndYear,
+				l.Pillar.GanZhi, l.TenGod, lib.Luck[l.Tendency]))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## 综合建议\n\n")
+	sb.WriteString(lib.Advice[strength])
+	sb.WriteString("\n\n")
+	sb.WriteString(lib.Disclaimer)
+	sb.WriteString("\n")
+
+	return sb.String(), nil
+}
+
+// Annotate 生成排盘要点，每条一行，不含解读措辞，供报告等场景直接展示
+// 参数：
+//   - facts: 排盘事实
+//
+// 返回值：
+//   - []string: 排盘要点
+func Annotate(facts *Facts) []string {
+	w := facts.Wuxing
+	label := "身弱"
+	if w.IsStrong {
+		label = "身强"
+	}
+
+	counts := make([]string, 0, len(utils.WUXING_LIST))
+	for _, wx := range utils.WUXING_LIST {
+		counts = append(counts, fmt.Sprintf("%s %.1f", wx, w.Counts[wx]))
+	}
+	distribution := make([]string, 0, len(tenGodOrder))
+	for _, tenGod := range tenGodOrder {
+		if n := facts.TenGods[tenGod]; n > 0 {
+			distribution = append(distribution, fmt.Sprintf("%s %d", tenGod, n))
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("八字：%s", strings.Join(facts.Pillars, " ")),
+		fmt.Sprintf("日主：%s%s，%s（生扶 %.1f，克泄耗 %.1f）", w.DayMaster, w.DayMasterWuxing, label, w.SupportScore, w.DrainScore),
+		fmt.Sprintf("五行力量：%s", strings.Join(counts, "、")),
+	}
+	if facts.Pattern != "" {
+		lines = append(lines, fmt.Sprintf("格局：%s", facts.Pattern))
+	}
+	lines = append(lines,
+		fmt.Sprintf("十神分布：%s", strings.Join(distribution, "、")),
+		fmt.Sprintf("喜用五行：%s；忌讳五行：%s", strings.Join(w.Favorable, "、"), strings.Join(w.Unfavorable, "、")),
+	)
+	if len(w.Missing) > 0 {
+		lines = append(lines, fmt.Sprintf("缺失五行：%s", strings.Join(w.Missing, "、")))
+	}
+	for _, l := range facts.Luck {
+		lines = append(lines, fmt.Sprintf("大运 %d-%d 岁（%d-%d）%s，%s，%s",
+			l.Pillar.StartAge, l.Pillar.EndAge, l.Pillar.StartYear, l.Pillar.EndYear,
+			l.Pillar.GanZhi, l.TenGod, tendencyLabels[l.Tendency]))
+	}
+	return lines
+}
+
+// luckTendency 判断大运喜忌
+// 参数：
+//   - w: 五行分析结果
+//   - p: 大运
+//
+// 返回值：
+//   - string: 喜忌 (favorable/unfavorable/neutral)
+func luckTendency(w *utils.WuxingAnalysis, p *utils.LuckPillar) string {
+	ganGood := containsString(w.Favorable, utils.GetGanWuxing(p.Gan))
+	zhiGood := containsString(w.Favorable, utils.GetZhiWuxing(p.Zhi))
+	switch {
+	case ganGood && zhiGood:
+		return LUCK_FAVORABLE
+	case !ganGood && !zhiGood:
+		return LUCK_UNFAVORABLE
+	default:
+		return LUCK_NEUTRAL
+	}
+}
+
+// containsString 判断字符串列表是否包含目标字符串
+// 参数：
+//   - list: 字符串列表
+//   - target: 目标字符串
+//
+// 返回值：
+//   - bool: 是否包含
+func containsString(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+// Package interpret 提供规则解读所用的固定措辞库
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package interpret
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+//go:embed data/phrases.json
+var phraseData []byte
+
+// library 措辞库，按条件组织的固定文案
+type library struct {
+	DayMaster    map[string]string `json:"day_master"`     // 日主特质，按天干
+	Strength     map[string]string `json:"strength"`       // 身强身弱
+	Pattern      map[string]string `json:"pattern"`        // 格局
+	TenGodExcess map[string]string `json:"ten_god_excess"` // 十神偏多
+	TenGodAbsent map[string]string `json:"ten_god_absent"` // 十神类别不显
+	Favorable    map[string]string `json:"favorable"`      // 喜用五行建议
+	Missing      map[string]string `json:"missing"`        // 缺失五行
+	Luck         map[string]string `json:"luck"`           // 大运喜忌
+	Advice       map[string]string `json:"advice"`         // 综合建议
+	Disclaimer   string            `json:"disclaimer"`     // 结尾说明
+}
+
+var (
+	phrases     *library
+	phrasesErr  error
+	phrasesOnce sync.Once
+)
+
+// loadLibrary 加载内嵌措辞库
+// 返回值：
+//   - *library: 措辞库
+//   - error: 解析过程中的错误
+func loadLibrary() (*library, error) {
+	phrasesOnce.Do(func() {
+		l := new(library)
+		if err := json.Unmarshal(phraseData, l); err != nil {
+			phrasesErr = fmt.Errorf("解析解读措辞库失败: %w", err)
+			return
+		}
+		phrases = l
+	})
+	return phrases, phrasesErr
+}
+
+// Validate 校验措辞库可用
+// 返回值：
+//   - error: 措辞库无法解析时返回错误
+func Validate() error {
+	_, err := loadLibrary()
+	return err
+}
@@ -0,0 +1,96 @@
+// Package ratelimit 提供按客户端 IP 限流的中间件
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/global"
+)
+
+// KEY_PREFIX 限流计数器键前缀
+const KEY_PREFIX = "RATE_LIMIT:"
+
+// localCounter 未配置 Redis 时使用的进程内固定窗口计数器
+type localCounter struct {
+	mu      sync.Mutex
+	window  int64            // 当前窗口序号
+	counter map[string]int64 // 当前窗口内各客户端的请求数
+}
+
+// incr 累加客户端在当前窗口的请求数，进入新窗口时清空旧计数
+// 参数：
+//   - key: 客户端标识
+//   - window: 当前窗口序号
+//
+// 返回值：
+//   - int64: 累加后的请求数
+func (c *localCounter) incr(key string, window int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.window != window {
+		c.window = window
+		c.counter = make(map[string]int64)
+	}
+	c.counter[key]++
+	return c.counter[key]
+}
+
+// New 创建限流中间件，按客户端 IP 在固定时间窗口内计数，超出限制时返回 429
+// 多实例部署时通过 Redis 共享计数，未配置 Redis 或 Redis 不可用时退化为进程内计数
+// 参数：
+//   - name: 限流器名称，用于区分不同接口的计数
+//   - limit: 每个窗口允许的最大请求数，小于等于 0 时不限流
+//   - window: 时间窗口
+//
+// 返回值：
+//   - gin.HandlerFunc: 限流中间件
+func New(name string, limit int, window time.Duration) gin.HandlerFunc {
+	local := &localCounter{}
+
+	return func(c *gin.Context) {
+		if limit <= 0 || window <= 0 {
+			c.Next()
+			return
+		}
+
+		now := time.Now()
+		windowNo := now.UnixNano() / int64(window)
+		client := c.ClientIP()
+
+		var count int64
+		if global.RedisClient != nil {
+			key := fmt.Sprintf("%s%s:%s:%d", KEY_PREFIX, name, client, windowNo)
+			n, err := global.RedisClient.Incr(c.Request.Context(), key).Result()
+			if err == nil {
+				if n == 1 {
+					global.RedisClient.Expire(c.Request.Context(), key, window)
+				}
+				count = n
+			}
+		}
+		if count == 0 {
+			count = local.incr(client, windowNo)
+		}
+
+		if count > int64(limit) {
+			retryAfter := time.Duration((windowNo+1)*int64(window) - now.UnixNano())
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"code":    429,
+				"message": "请求过于频繁，请稍后再试",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -9,28 +9,44 @@ import (
 	"github.com/Done-0/metaphysics/internal/model/base"
 )
 
-// Bazi 八字记录，存储用户的八字信息
+// 档案关系常量
+const (
+	RELATION_SELF   = "self"   // 本人
+	RELATION_SPOUSE = "spouse" // 配偶
+	RELATION_CHILD  = "child"  // 子女
+	RELATION_CLIENT = "client" // 客户
+	RELATION_OTHER  = "other"  // 其他
+)
+
+// Bazi 八字记录，存储用户的八字信息，一个用户可保存多份带标签的档案
 type Bazi struct {
 	base.Base
 
+	// 档案信息
+	UserID   int64  `json:"user_id" gorm:"index"`                        // 所属用户 ID
+	Relation string `json:"relation" gorm:"size:20;default:other;index"` // 与用户的关系 (self/spouse/child/client/other)
+	Label    string `json:"label" gorm:"size:50"`                        // 档案标签，如 "大女儿"
+	Notes    string `json:"notes" gorm:"type:text"`                      // 备注
+	Tags     string `json:"tags" gorm:"size:200"`                        // 标签，逗号分隔
+
 	// 基本信息
-	UserID    int64     `json:"user_id" gorm:"index"`                                                       // 用户 ID
-	Name      string    `json:"name" gorm:"size:50"`                                                        // 姓名
-	Gender    string    `json:"gender" gorm:"size:10;default:male;check:gender IN ('male', 'female')"`      // 性别 (male/female)
-	BirthTime time.Time `json:"birth_time"`                                                                 // 出生时间
-	Calendar  string    `json:"calendar" gorm:"size:10;default:lunar;check:calendar IN ('lunar', 'solar')"` // 日历类型 (lunar/solar)
+	Name             string    `json:"name" gorm:"size:50"`                                                        // 姓名
+	Gender           string    `json:"gender" gorm:"size:10;default:male;check:gender IN ('male', 'female')"`      // 性别 (male/female)
+	BirthTime        time.Time `json:"birth_time" gorm:"index"`                                                    // 出生时间，出生时间未知时为零值
+	BirthTimeUnknown bool      `json:"birth_time_unknown" gorm:"default:false"`                                    // 出生时间是否未知，仅凭四柱导入的档案为 true
+	Calendar         string    `json:"calendar" gorm:"size:10;default:lunar;check:calendar IN ('lunar', 'solar')"` // 日历类型 (lunar/solar)
 
 	// 四柱干支
-	YearPillar  string `json:"year_pillar" gorm:"size:20"`  // 年柱（干支）
-	MonthPillar string `json:"month_pillar" gorm:"size:20"` // 月柱（干支）
-	DayPillar   string `json:"day_pillar" gorm:"size:20"`   // 日柱（干支）
-	HourPillar  string `json:"hour_pillar" gorm:"size:20"`  // 时柱（干支）
+	YearPillar  string `json:"year_pillar" gorm:"size:20"`      // 年柱（干支）
+	MonthPillar string `json:"month_pillar" gorm:"size:20"`     // 月柱（干支）
+	DayPillar   string `json:"day_pillar" gorm:"size:20;index"` // 日柱（干支）
+	HourPillar  string `json:"hour_pillar" gorm:"size:20"`      // 时柱（干支）
 
 	// 天干
-	YearGan  string `json:"year_gan" gorm:"size:10"`  // 年干
-	MonthGan string `json:"month_gan" gorm:"size:10"` // 月干
-	DayGan   string `json:"day_gan" goarm:"size:10"`  // 日干
-	HourGan  string `json:"hour_gan" gorm:"size:10"`  // 时干
+	YearGan  string `json:"year_gan" gorm:"size:10"`      // 年干
+	MonthGan string `json:"month_gan" gorm:"size:10"`     // 月干
+	DayGan   string `json:"day_gan" gorm:"size:10;index"` // 日干
+	HourGan  string `json:"hour_gan" gorm:"size:10"`      // 时干
 
 	// 地支
 	YearZhi  string `json:"year_zhi" gorm:"size:10"`  // 年支
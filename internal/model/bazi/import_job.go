@@ -0,0 +1,37 @@
+// Package bazi 八字模型，定义了八字计算和存储的相关结构体
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package bazi
+
+import (
+	"github.com/Done-0/metaphysics/internal/model/base"
+)
+
+// 批量导入任务状态常量
+const (
+	IMPORT_STATUS_RUNNING   = "running"   // 处理中
+	IMPORT_STATUS_COMPLETED = "completed" // 已完成
+	IMPORT_STATUS_FAILED    = "failed"    // 处理失败
+)
+
+// BaziImportJob 出生档案批量导入任务，记录进度与逐行错误，供客户端轮询
+type BaziImportJob struct {
+	base.Base
+
+	UserID    int64  `json:"user_id" gorm:"index"`                  // 所属用户 ID
+	FileName  string `json:"file_name" gorm:"size:255"`             // 导入文件名
+	Status    string `json:"status" gorm:"size:20;default:running"` // 状态 (running/completed/failed)
+	Total     int    `json:"total" gorm:"default:0"`                // 数据行数，不含表头与空行
+	Processed int    `json:"processed" gorm:"default:0"`            // 已处理行数
+	Succeeded int    `json:"succeeded" gorm:"default:0"`            // 成功导入行数
+	Failed    int    `json:"failed" gorm:"default:0"`               // 失败行数
+	Errors    string `json:"errors" gorm:"type:text"`               // 逐行错误（JSON 数组），按行号升序
+	Message   string `json:"message" gorm:"size:500"`               // 任务失败原因
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (BaziImportJob) TableName() string {
+	return "bazi_import_jobs"
+}
@@ -0,0 +1,91 @@
+// Package client 客户档案模型，定义咨询师的客户案例、备注、咨询记录与跟进提醒
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package client
+
+import (
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/model/base"
+)
+
+// 客户案例状态常量
+const (
+	STATUS_ACTIVE   = "active"   // 跟进中
+	STATUS_ARCHIVED = "archived" // 已归档
+)
+
+// ClientCase 客户案例，基于八字档案记录客户的联系方式与标签，归属咨询师账号
+type ClientCase struct {
+	base.Base
+
+	UserID int64  `json:"user_id" gorm:"index"`                                                              // 咨询师用户 ID
+	BaziID int64  `json:"bazi_id" gorm:"index"`                                                              // 八字 ID
+	Name   string `json:"name" gorm:"size:50"`                                                               // 客户姓名
+	Phone  string `json:"phone" gorm:"size:30"`                                                              // 电话
+	Email  string `json:"email" gorm:"size:100"`                                                             // 邮箱
+	WeChat string `json:"wechat" gorm:"column:wechat;size:50"`                                               // 微信
+	Tags   string `json:"tags" gorm:"size:200"`                                                              // 标签，逗号分隔
+	Status string `json:"status" gorm:"size:20;default:active;index;check:status IN ('active', 'archived')"` // 状态 (active/archived)
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (ClientCase) TableName() string {
+	return "client_cases"
+}
+
+// ClientNote 客户备注，创建与修改时间由 base.Base 记录
+type ClientNote struct {
+	base.Base
+
+	UserID  int64  `json:"user_id" gorm:"index"`     // 咨询师用户 ID
+	CaseID  int64  `json:"case_id" gorm:"index"`     // 客户案例 ID
+	Content string `json:"content" gorm:"type:text"` // 备注内容
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (ClientNote) TableName() string {
+	return "client_notes"
+}
+
+// ClientSession 咨询记录，可关联一次 AI 对话
+type ClientSession struct {
+	base.Base
+
+	UserID         int64     `json:"user_id" gorm:"index"`         // 咨询师用户 ID
+	CaseID         int64     `json:"case_id" gorm:"index"`         // 客户案例 ID
+	ConversationID int64     `json:"conversation_id" gorm:"index"` // 关联的对话 ID，0 表示无
+	SessionTime    time.Time `json:"session_time"`                 // 咨询时间
+	Topic          string    `json:"topic" gorm:"size:100"`        // 咨询主题
+	Summary        string    `json:"summary" gorm:"type:text"`     // 咨询纪要
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (ClientSession) TableName() string {
+	return "client_sessions"
+}
+
+// ClientReminder 跟进提醒
+type ClientReminder struct {
+	base.Base
+
+	UserID   int64     `json:"user_id" gorm:"index"`          // 咨询师用户 ID
+	CaseID   int64     `json:"case_id" gorm:"index"`          // 客户案例 ID
+	RemindAt time.Time `json:"remind_at" gorm:"index"`        // 提醒时间
+	Content  string    `json:"content" gorm:"size:500"`       // 提醒内容
+	Done     bool      `json:"done" gorm:"default:false"`     // 是否已完成
+	Notified bool      `json:"notified" gorm:"default:false"` // 是否已发送提醒邮件
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (ClientReminder) TableName() string {
+	return "client_reminders"
+}
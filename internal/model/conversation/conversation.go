@@ -11,15 +11,17 @@ import (
 
 // Conversation 对话记录
 type Conversation struct {
-	ID          int64          `gorm:"primaryKey;autoIncrement" json:"id"`     // 主键ID
-	UserID      int64          `gorm:"index:idx_user_id" json:"user_id"`       // 用户ID
-	Title       string         `gorm:"size:255" json:"title"`                  // 对话标题
-	SessionID   string         `gorm:"size:64;uniqueIndex" json:"session_id"`  // 会话ID
-	FirstPrompt string         `gorm:"type:text" json:"first_prompt"`          // 首次提示语
-	GmtCreate   time.Time      `gorm:"autoCreateTime" json:"gmt_create"`       // 创建时间
-	GmtModified time.Time      `gorm:"autoUpdateTime" json:"gmt_modified"`     // 修改时间
-	Deleted     bool           `gorm:"default:false" json:"deleted"`           // 是否删除
-	DeletedAt   gorm.DeletedAt `gorm:"index:idx_deleted_at" json:"deleted_at"` // 删除时间
+	ID           int64          `gorm:"primaryKey;autoIncrement" json:"id"`                  // 主键ID
+	UserID       int64          `gorm:"index:idx_conversation_user_id" json:"user_id"`       // 用户ID
+	Title        string         `gorm:"size:255" json:"title"`                               // 对话标题
+	SessionID    string         `gorm:"size:64;uniqueIndex" json:"session_id"`               // 会话ID
+	FirstPrompt  string         `gorm:"type:text" json:"first_prompt"`                       // 首次提示语
+	BaziID       int64          `gorm:"index:idx_conversation_bazi_id" json:"bazi_id"`       // 关联的八字 ID
+	BaziSnapshot string         `gorm:"type:text" json:"bazi_snapshot"`                      // 对话创建时的命盘快照（JSON），八字修改或删除后历史对话仍保持一致
+	GmtCreate    time.Time      `gorm:"autoCreateTime" json:"gmt_create"`                    // 创建时间
+	GmtModified  time.Time      `gorm:"autoUpdateTime" json:"gmt_modified"`                  // 修改时间
+	Deleted      bool           `gorm:"default:false" json:"deleted"`                        // 是否删除
+	DeletedAt    gorm.DeletedAt `gorm:"index:idx_conversation_deleted_at" json:"deleted_at"` // 删除时间
 }
 
 // TableName 表名
@@ -29,20 +31,26 @@ func (Conversation) TableName() string {
 
 // Message 消息记录
 type Message struct {
-	ID             int64          `gorm:"primaryKey;autoIncrement" json:"id"`               // 主键ID
-	ConversationID int64          `gorm:"index:idx_conversation_id" json:"conversation_id"` // 对话ID
-	UserID         int64          `gorm:"index:idx_user_id" json:"user_id"`                 // 用户ID
-	SessionID      string         `gorm:"size:64;index:idx_session_id" json:"session_id"`   // 会话ID
-	Role           string         `gorm:"size:20" json:"role"`                              // 角色（USER/ASSISTANT）
-	Content        string         `gorm:"type:text" json:"content"`                         // 消息内容
-	RequestID      int            `gorm:"index:idx_request_id" json:"request_id"`           // 请求消息ID
-	ResponseID     int            `gorm:"index:idx_response_id" json:"response_id"`         // 响应消息ID
-	ParentID       int            `json:"parent_id"`                                        // 父消息ID
-	TokenUsage     int            `json:"token_usage"`                                      // Token使用量
-	GmtCreate      time.Time      `gorm:"autoCreateTime" json:"gmt_create"`                 // 创建时间
-	GmtModified    time.Time      `gorm:"autoUpdateTime" json:"gmt_modified"`               // 修改时间
-	Deleted        bool           `gorm:"default:false" json:"deleted"`                     // 是否删除
-	DeletedAt      gorm.DeletedAt `gorm:"index:idx_deleted_at" json:"deleted_at"`           // 删除时间
+	ID                  int64          `gorm:"primaryKey;autoIncrement" json:"id"`                       // 主键ID
+	ConversationID      int64          `gorm:"index:idx_message_conversation_id" json:"conversation_id"` // 对话ID
+	UserID              int64          `gorm:"index:idx_message_user_id" json:"user_id"`                 // 用户ID
+	SessionID           string         `gorm:"size:64;index:idx_message_session_id" json:"session_id"`   // 会话ID
+	Role                string         `gorm:"size:20" json:"role"`                                      // 角色（USER/ASSISTANT）
+	Content             string         `gorm:"type:text" json:"content"`                                 // 消息内容
+	ThinkingContent     string         `gorm:"type:text" json:"thinking_content"`                        // 推理模型的思考过程
+	ThinkingElapsedSecs int            `json:"thinking_elapsed_secs"`                                    // 思考耗时（秒）
+	PromptVersion       string         `gorm:"size:64" json:"prompt_version"`                            // 生成时使用的提示模板版本，格式为 {名称}@{版本}
+	RequestID           int            `gorm:"index:idx_message_request_id" json:"request_id"`           // 请求消息ID
+	ResponseID          int            `gorm:"index:idx_message_response_id" json:"response_id"`         // 响应消息ID
+	ParentID            int            `json:"parent_id"`                                                // 父消息ID
+	TokenUsage          int            `json:"token_usage"`                                              // Token使用量，提示与生成之和
+	PromptTokens        int            `json:"prompt_tokens"`                                            // 提示 Token 数
+	CompletionTokens    int            `json:"completion_tokens"`                                        // 生成 Token 数，含思考过程
+	ReasoningTokens     int            `json:"reasoning_tokens"`                                         // 思考过程 Token 数
+	GmtCreate           time.Time      `gorm:"autoCreateTime" json:"gmt_create"`                         // 创建时间
+	GmtModified         time.Time      `gorm:"autoUpdateTime" json:"gmt_modified"`                       // 修改时间
+	Deleted             bool           `gorm:"default:false" json:"deleted"`                             // 是否删除
+	DeletedAt           gorm.DeletedAt `gorm:"index:idx_message_deleted_at" json:"deleted_at"`           // 删除时间
 }
 
 // TableName 表名
@@ -50,13 +58,35 @@ func (Message) TableName() string {
 	return "t_message"
 }
 
+// MessageSection 消息章节，结构化分析的各章节单独存储，便于分章节展示与重新生成
+type MessageSection struct {
+	ID             int64          `gorm:"primaryKey;autoIncrement" json:"id"`                             // 主键ID
+	MessageID      int64          `gorm:"uniqueIndex:idx_message_section_key" json:"message_id"`          // 所属消息ID
+	ConversationID int64          `gorm:"index:idx_message_section_conversation" json:"conversation_id"`  // 对话ID
+	UserID         int64          `json:"user_id"`                                                        // 用户ID
+	SectionKey     string         `gorm:"size:32;uniqueIndex:idx_message_section_key" json:"section_key"` // 章节键
+	Title          string         `gorm:"size:64" json:"title"`                                           // 章节标题
+	Content        string         `gorm:"type:text" json:"content"`                                       // 章节内容（Markdown）
+	Sort           int            `json:"sort"`                                                           // 章节顺序，从 0 开始
+	PromptVersion  string         `gorm:"size:64" json:"prompt_version"`                                  // 生成时使用的提示模板版本
+	GmtCreate      time.Time      `gorm:"autoCreateTime" json:"gmt_create"`                               // 创建时间
+	GmtModified    time.Time      `gorm:"autoUpdateTime" json:"gmt_modified"`                             // 修改时间
+	Deleted        bool           `gorm:"default:false" json:"deleted"`                                   // 是否删除
+	DeletedAt      gorm.DeletedAt `gorm:"index:idx_message_section_deleted_at" json:"deleted_at"`         // 删除时间
+}
+
+// TableName 表名
+func (MessageSection) TableName() string {
+	return "t_message_section"
+}
+
 // MessageCounter 消息计数器
 type MessageCounter struct {
-	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`     // 主键ID
-	UserID      int64     `gorm:"uniqueIndex:idx_user_id" json:"user_id"` // 用户ID
-	NextID      int       `json:"next_id"`                                // 下一个消息ID
-	GmtCreate   time.Time `gorm:"autoCreateTime" json:"gmt_create"`       // 创建时间
-	GmtModified time.Time `gorm:"autoUpdateTime" json:"gmt_modified"`     // 修改时间
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`                     // 主键ID
+	UserID      int64     `gorm:"uniqueIndex:idx_message_counter_user_id" json:"user_id"` // 用户ID
+	NextID      int       `json:"next_id"`                                                // 下一个消息ID
+	GmtCreate   time.Time `gorm:"autoCreateTime" json:"gmt_create"`                       // 创建时间
+	GmtModified time.Time `gorm:"autoUpdateTime" json:"gmt_modified"`                     // 修改时间
 }
 
 // TableName 表名
@@ -0,0 +1,29 @@
+// Package event 人生事件模型，记录与八字关联的已发生事件
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package event
+
+import (
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/model/base"
+)
+
+// LifeEvent 人生事件记录
+type LifeEvent struct {
+	base.Base
+
+	UserID      int64     `json:"user_id" gorm:"index"`                                                                                         // 用户 ID
+	BaziID      int64     `json:"bazi_id" gorm:"index"`                                                                                         // 八字 ID
+	EventType   string    `json:"event_type" gorm:"size:20;check:event_type IN ('marriage', 'childbirth', 'career', 'illness', 'bereavement')"` // 事件类型
+	EventDate   time.Time `json:"event_date"`                                                                                                   // 事件日期
+	Title       string    `json:"title" gorm:"size:100"`                                                                                        // 标题
+	Description string    `json:"description" gorm:"size:500"`                                                                                  // 描述
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (LifeEvent) TableName() string {
+	return "life_events"
+}
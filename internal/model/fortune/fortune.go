@@ -0,0 +1,58 @@
+// Package fortune 每日运势模型，定义运势订阅与每日运势记录
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package fortune
+
+import (
+	"github.com/Done-0/metaphysics/internal/model/base"
+)
+
+// FortuneSubscription 每日运势订阅
+type FortuneSubscription struct {
+	base.Base
+
+	UserID       int64 `json:"user_id" gorm:"uniqueIndex"`         // 用户 ID
+	BaziID       int64 `json:"bazi_id" gorm:"index"`               // 八字 ID
+	EmailEnabled bool  `json:"email_enabled" gorm:"default:false"` // 是否接收邮件推送
+	Active       bool  `json:"active" gorm:"default:true"`         // 是否启用
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (FortuneSubscription) TableName() string {
+	return "fortune_subscriptions"
+}
+
+// DailyFortune 每日运势记录
+type DailyFortune struct {
+	base.Base
+
+	// 基本信息
+	UserID      int64  `json:"user_id" gorm:"uniqueIndex:idx_user_date"`              // 用户 ID
+	BaziID      int64  `json:"bazi_id" gorm:"index"`                                  // 八字 ID
+	FortuneDate string `json:"fortune_date" gorm:"size:10;uniqueIndex:idx_user_date"` // 日期 (YYYY-MM-DD)
+	DayPillar   string `json:"day_pillar" gorm:"size:20"`                             // 流日干支
+	TenGod      string `json:"ten_god" gorm:"size:10"`                                // 流日天干十神
+
+	// 评分
+	OverallScore      int    `json:"overall_score"`        // 综合得分
+	Level             string `json:"level" gorm:"size:10"` // 综合等级
+	CareerScore       int    `json:"career_score"`         // 事业得分
+	WealthScore       int    `json:"wealth_score"`         // 财运得分
+	RelationshipScore int    `json:"relationship_score"`   // 感情得分
+	HealthScore       int    `json:"health_score"`         // 健康得分
+
+	// 文案
+	Interactions string `json:"interactions" gorm:"type:text"` // 流日与原局的关系，逐行记录
+	Summary      string `json:"summary" gorm:"type:text"`      // 规则生成的运势文案
+	Polished     string `json:"polished" gorm:"type:text"`     // AI 润色后的运势文案
+	Emailed      bool   `json:"emailed" gorm:"default:false"`  // 是否已发送邮件
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (DailyFortune) TableName() string {
+	return "daily_fortunes"
+}
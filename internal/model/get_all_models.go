@@ -5,6 +5,13 @@ package model
 
 import (
 	"github.com/Done-0/metaphysics/internal/model/bazi"
+	"github.com/Done-0/metaphysics/internal/model/client"
+	"github.com/Done-0/metaphysics/internal/model/conversation"
+	"github.com/Done-0/metaphysics/internal/model/event"
+	"github.com/Done-0/metaphysics/internal/model/fortune"
+	"github.com/Done-0/metaphysics/internal/model/journal"
+	"github.com/Done-0/metaphysics/internal/model/report"
+	"github.com/Done-0/metaphysics/internal/model/share"
 	"github.com/Done-0/metaphysics/internal/model/user"
 )
 
@@ -13,7 +20,23 @@ import (
 //   - []any: 所有需要注册到数据库的模型列表
 func GetAllModels() []any {
 	return []any{
-		&bazi.Bazi{},       // 八字模型
-		&user.User{},       // 用户模型
+		&bazi.Bazi{},                       // 八字模型
+		&bazi.BaziImportJob{},              // 八字批量导入任务模型
+		&user.User{},                       // 用户模型
+		&event.LifeEvent{},                 // 人生事件模型
+		&journal.JournalEntry{},            // 人生日志模型
+		&fortune.FortuneSubscription{},     // 每日运势订阅模型
+		&fortune.DailyFortune{},            // 每日运势模型
+		&report.AnnualReportSubscription{}, // 流年报告订阅模型
+		&report.AnnualReport{},             // 流年报告模型
+		&client.ClientCase{},               // 客户案例模型
+		&client.ClientNote{},               // 客户备注模型
+		&client.ClientSession{},            // 咨询记录模型
+		&client.ClientReminder{},           // 跟进提醒模型
+		&share.ShareLink{},                 // 分享链接模型
+		&conversation.Conversation{},       // 对话模型
+		&conversation.Message{},            // 消息模型
+		&conversation.MessageSection{},     // 消息章节模型
+		&conversation.MessageCounter{},     // 消息计数器模型
 	}
 }
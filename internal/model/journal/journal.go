@@ -0,0 +1,37 @@
+// Package journal 人生日志模型，记录用户日志及其对应的岁运干支
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package journal
+
+import (
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/model/base"
+)
+
+// JournalEntry 人生日志记录
+type JournalEntry struct {
+	base.Base
+
+	// 基本信息
+	UserID    int64     `json:"user_id" gorm:"index"`     // 用户 ID
+	BaziID    int64     `json:"bazi_id" gorm:"index"`     // 八字 ID
+	EntryDate time.Time `json:"entry_date" gorm:"index"`  // 日志日期
+	Title     string    `json:"title" gorm:"size:100"`    // 标题
+	Content   string    `json:"content" gorm:"type:text"` // 内容
+	Tags      string    `json:"tags" gorm:"size:200"`     // 标签，逗号分隔
+
+	// 岁运注解
+	LuckPillar   string `json:"luck_pillar" gorm:"size:20"`    // 当时所行大运
+	YearPillar   string `json:"year_pillar" gorm:"size:20"`    // 流年干支
+	MonthPillar  string `json:"month_pillar" gorm:"size:20"`   // 流月干支
+	DayPillar    string `json:"day_pillar" gorm:"size:20"`     // 流日干支
+	Interactions string `json:"interactions" gorm:"type:text"` // 岁运与原局的刑冲合害，逐行记录
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
@@ -0,0 +1,61 @@
+// Package report 流年报告模型，定义报告订阅与版本化的报告记录
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	"github.com/Done-0/metaphysics/internal/model/base"
+)
+
+// 报告状态
+const (
+	STATUS_PENDING   = "pending"   // 生成中
+	STATUS_COMPLETED = "completed" // 已完成
+	STATUS_FAILED    = "failed"    // 生成失败
+)
+
+// AnnualReportSubscription 流年报告订阅
+type AnnualReportSubscription struct {
+	base.Base
+
+	UserID       int64 `json:"user_id" gorm:"uniqueIndex"`        // 用户 ID
+	BaziID       int64 `json:"bazi_id" gorm:"index"`              // 八字 ID
+	EmailEnabled bool  `json:"email_enabled" gorm:"default:true"` // 生成后是否邮件通知
+	Active       bool  `json:"active" gorm:"default:true"`        // 是否启用
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AnnualReportSubscription) TableName() string {
+	return "annual_report_subscriptions"
+}
+
+// AnnualReport 流年报告，同一用户同一流年每次生成递增版本号
+type AnnualReport struct {
+	base.Base
+
+	// 基本信息
+	UserID  int64  `json:"user_id" gorm:"uniqueIndex:idx_user_year_version"` // 用户 ID
+	BaziID  int64  `json:"bazi_id" gorm:"index"`                             // 八字 ID
+	Year    int    `json:"year" gorm:"uniqueIndex:idx_user_year_version"`    // 流年所在公历年份
+	Version int    `json:"version" gorm:"uniqueIndex:idx_user_year_version"` // 版本号，从 1 开始
+	Status  string `json:"status" gorm:"size:20;default:pending"`            // 状态 (pending/completed/failed)
+
+	// 岁运
+	YearPillar string `json:"year_pillar" gorm:"size:20"` // 流年干支
+	LuckPillar string `json:"luck_pillar" gorm:"size:20"` // 当年所行大运
+
+	// 内容
+	Highlights   string `json:"highlights" gorm:"type:text"`    // 规则分析结果（JSON）
+	Content      string `json:"content" gorm:"type:text"`       // AI 撰写的报告正文
+	ErrorMessage string `json:"error_message" gorm:"type:text"` // 失败原因
+	Notified     bool   `json:"notified" gorm:"default:false"`  // 是否已通知用户
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (AnnualReport) TableName() string {
+	return "annual_reports"
+}
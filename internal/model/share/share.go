@@ -0,0 +1,37 @@
+// Package share 分享链接模型，定义命盘与分析结果的公开只读分享
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package share
+
+import (
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/model/base"
+)
+
+// 分享对象类型常量
+const (
+	TARGET_CHART    = "chart"    // 八字命盘
+	TARGET_ANALYSIS = "analysis" // 已完成的分析对话
+)
+
+// ShareLink 分享链接，链接令牌携带本记录 ID 并由服务端签名，撤销后令牌立即失效
+type ShareLink struct {
+	base.Base
+
+	UserID     int64     `json:"user_id" gorm:"index"`                                                  // 分享者用户 ID
+	TargetType string    `json:"target_type" gorm:"size:20;check:target_type IN ('chart', 'analysis')"` // 分享对象类型 (chart/analysis)
+	TargetID   int64     `json:"target_id" gorm:"index"`                                                // 分享对象 ID，八字 ID 或对话 ID
+	MessageID  int64     `json:"message_id" gorm:"default:0"`                                           // 分享的分析消息 ID，仅分析分享有效，分享视图只展示该消息及其请求
+	ExpireAt   time.Time `json:"expire_at"`                                                             // 过期时间
+	ShowName   bool      `json:"show_name" gorm:"default:false"`                                        // 是否展示姓名与精确出生时间，默认隐去姓名并将出生时间粗化为日期与时辰
+	Revoked    bool      `json:"revoked" gorm:"default:false"`                                          // 是否已撤销
+	ViewCount  int64     `json:"view_count" gorm:"default:0"`                                           // 访问次数
+}
+
+// TableName 指定表名
+// 返回值：
+//   - string: 表名
+func (ShareLink) TableName() string {
+	return "share_links"
+}
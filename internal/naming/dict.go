@@ -0,0 +1,105 @@
+// Package naming 提供起名用字字典
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package naming
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// 字的性别倾向
+const (
+	CHAR_GENDER_MALE    = "m" // 偏男性
+	CHAR_GENDER_FEMALE  = "f" // 偏女性
+	CHAR_GENDER_NEUTRAL = "n" // 男女通用
+)
+
+//go:embed data/characters.json
+var dictData []byte
+
+// Character 起名用字
+type Character struct {
+	Char    string `json:"char"`    // 汉字
+	Pinyin  string `json:"pinyin"`  // 带调拼音
+	Tone    int    `json:"tone"`    // 声调（1-4）
+	Wuxing  string `json:"wuxing"`  // 字义五行
+	Strokes int    `json:"strokes"` // 康熙笔画
+	Gender  string `json:"gender"`  // 性别倾向 (m/f/n)
+	Meaning string `json:"meaning"` // 字义
+}
+
+// dictionary 字典数据
+type dictionary struct {
+	Characters       []*Character `json:"characters"`        // 名字用字
+	Surnames         []*Character `json:"surnames"`          // 姓氏用字
+	CompoundSurnames []string     `json:"compound_surnames"` // 复姓
+}
+
+var (
+	dict        *dictionary
+	charIndex   map[string]*Character
+	surnameIdx  map[string]*Character
+	dictErr     error
+	dictLoading sync.Once
+)
+
+// loadDictionary 加载内嵌字典
+// 返回值：
+//   - *dictionary: 字典数据
+//   - error: 解析过程中的错误
+func loadDictionary() (*dictionary, error) {
+	dictLoading.Do(func() {
+		d := new(dictionary)
+		if err := json.Unmarshal(dictData, d); err != nil {
+			dictErr = fmt.Errorf("解析起名字典失败: %w", err)
+			return
+		}
+
+		charIndex = make(map[string]*Character, len(d.Characters))
+		for _, c := range d.Characters {
+			charIndex[c.Char] = c
+		}
+		surnameIdx = make(map[string]*Character, len(d.Surnames))
+		for _, c := range d.Surnames {
+			surnameIdx[c.Char] = c
+		}
+		dict = d
+	})
+	return dict, dictErr
+}
+
+// LookupCharacter 查询字典中的汉字，优先查名字用字，其次查姓氏用字
+// 参数：
+//   - char: 汉字
+//
+// 返回值：
+//   - *Character: 字典条目
+//   - bool: 是否存在
+func LookupCharacter(char string) (*Character, bool) {
+	if _, err := loadDictionary(); err != nil {
+		return nil, false
+	}
+	if c, ok := charIndex[char]; ok {
+		return c, true
+	}
+	c, ok := surnameIdx[char]
+	return c, ok
+}
+
+// lookupSurname 查询姓氏用字，优先查姓氏用字，其次查名字用字
+// 参数：
+//   - char: 汉字
+//
+// 返回值：
+//   - *Character: 字典条目
+//   - bool: 是否存在
+func lookupSurname(char string) (*Character, bool) {
+	if c, ok := surnameIdx[char]; ok {
+		return c, true
+	}
+	c, ok := charIndex[char]
+	return c, ok
+}
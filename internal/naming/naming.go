@@ -0,0 +1,435 @@
+// Package naming 提供基于八字喜用五行的起名引擎
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package naming
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// 平仄常量
+const (
+	TONE_PING     = "平" // 平声（一、二声）
+	TONE_ZE       = "仄" // 仄声（三、四声）
+	TONE_WILDCARD = "*" // 任意声调
+
+	GENDER_MALE   = "male"   // 男
+	GENDER_FEMALE = "female" // 女
+
+	defaultMaxCandidates = 20 // 默认候选名数量
+	maxSameFirstChar     = 3  // 同一首字最多出现次数，保证候选多样性
+)
+
+// 评分权重
+const (
+	scoreFavorableFirst  = 30.0 // 命中第一喜用五行
+	scoreFavorableSecond = 20.0 // 命中第二喜用五行
+	scoreFavorableOther  = 10.0 // 命中其他喜用五行
+	scoreGenerating      = 5.0  // 姓名用字五行相生
+	scoreControlling     = -5.0
+	scoreShuLiGood       = 15.0
+	scoreShuLiHalf       = 5.0
+	scoreShuLiBad        = -15.0
+)
+
+// Options 起名选项
+type Options struct {
+	Surname      string   // 姓氏
+	Gender       string   // 性别 (male/female)
+	Favorable    []string // 喜用五行（按优先级排序）
+	GivenLength  int      // 名字字数（1 或 2，0 表示均可）
+	Blacklist    []string // 额外禁用字或姓名片段
+	TonePatterns []string // 平仄模式，非空时覆盖配置
+	Limit        int      // 返回数量，0 表示使用配置
+}
+
+// Candidate 候选姓名
+type Candidate struct {
+	FullName  string   `json:"full_name"`  // 全名
+	GivenName string   `json:"given_name"` // 名字
+	Pinyin    string   `json:"pinyin"`     // 拼音
+	Tones     string   `json:"tones"`      // 平仄
+	Wuxing    []string `json:"wuxing"`     // 名字用字五行
+	Strokes   []int    `json:"strokes"`    // 名字用字康熙笔画
+	Meaning   string   `json:"meaning"`    // 字义
+	Wuge      *Wuge    `json:"wuge"`       // 五格数理
+	Score     float64  `json:"score"`      // 综合评分
+}
+
+// Generator 起名引擎
+type Generator struct {
+	blacklist     []string // 禁用字或姓名片段
+	tonePatterns  []string // 允许的平仄模式
+	maxCandidates int      // 默认候选名数量
+}
+
+// New 创建起名引擎
+// 参数：
+//   - cfg: 起名配置
+//
+// 返回值：
+//   - *Generator: 起名引擎
+func New(cfg configs.NamingConfig) *Generator {
+	maxCandidates := cfg.NamingMaxCandidates
+	if maxCandidates <= 0 {
+		maxCandidates = defaultMaxCandidates
+	}
+
+	return &Generator{
+		blacklist:     cfg.NamingBlacklist,
+		tonePatterns:  cfg.NamingTonePatterns,
+		maxCandidates: maxCandidates,
+	}
+}
+
+// Generate 生成候选姓名
+// 参数：
+//   - opts: 起名选项
+//
+// 返回值：
+//   - []*Candidate: 按评分降序排列的候选姓名
+//   - error: 生成过程中的错误
+func (g *Generator) Generate(opts *Options) ([]*Candidate, error) {
+	d, err := loadDictionary()
+	if err != nil {
+		return nil, err
+	}
+
+	surnameChars, err := g.parseSurname(d, opts.Surname)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Favorable) == 0 {
+		return nil, fmt.Errorf("缺少喜用五行，无法起名")
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = g.maxCandidates
+	}
+
+	patterns := g.tonePatterns
+	if len(opts.TonePatterns) > 0 {
+		patterns = opts.TonePatterns
+	}
+	blacklist := append(append([]string{}, g.blacklist...), opts.Blacklist...)
+
+	// 仅保留五行为喜用、性别合适且不在禁用列表中的字
+	pool := make([]*Character, 0, len(d.Characters))
+	for _, c := range d.Characters {
+		if !containsString(opts.Favorable, c.Wuxing) || !matchGender(c, opts.Gender) || isBlacklisted(c.Char, blacklist) {
+			continue
+		}
+		pool = append(pool, c)
+	}
+
+	var combos [][]*Character
+	if opts.GivenLength != 2 {
+		for _, c := range pool {
+			combos = append(combos, []*Character{c})
+		}
+	}
+	if opts.GivenLength != 1 {
+		for _, first := range pool {
+			for _, second := range pool {
+				if first.Char == second.Char {
+					continue
+				}
+				combos = append(combos, []*Character{first, second})
+			}
+		}
+	}
+
+	candidates := make([]*Candidate, 0, len(combos))
+	for _, given := range combos {
+		candidate := g.buildCandidate(surnameChars, given, opts)
+		if isBlacklisted(candidate.FullName, blacklist) {
+			continue
+		}
+		if !matchTonePatterns(candidate.Tones, patterns) {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	// 控制同一首字出现次数，避免候选过于单一
+	result := make([]*Candidate, 0, limit)
+	firstCharCount := make(map[string]int)
+	for _, c := range candidates {
+		first := string([]rune(c.GivenName)[0])
+		if firstCharCount[first] >= maxSameFirstChar {
+			continue
+		}
+		firstCharCount[first]++
+		result = append(result, c)
+		if len(result) >= limit {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// parseSurname 解析姓氏
+// 参数：
+//   - d: 字典
+//   - surname: 姓氏
+//
+// 返回值：
+//   - []*Character: 姓氏用字
+//   - error: 解析过程中的错误
+func (g *Generator) parseSurname(d *dictionary, surname string) ([]*Character, error) {
+	runes := []rune(strings.TrimSpace(surname))
+	switch {
+	case len(runes) == 0:
+		return nil, fmt.Errorf("姓氏不能为空")
+	case len(runes) == 2 && !containsString(d.CompoundSurnames, string(runes)):
+		return nil, fmt.Errorf("暂不支持的复姓: %s", surname)
+	case len(runes) > 2:
+		return nil, fmt.Errorf("姓氏长度不正确: %s", surname)
+	}
+
+	chars := make([]*Character, 0, len(runes))
+	for _, r := range runes {
+		c, ok := lookupSurname(string(r))
+		if !ok {
+			return nil, fmt.Errorf("字典中暂未收录姓氏用字: %s", string(r))
+		}
+		chars = append(chars, c)
+	}
+	return chars, nil
+}
+
+// buildCandidate 构建候选姓名并评分
+// 参数：
+//   - surname: 姓氏用字
+//   - given: 名字用字
+//   - opts: 起名选项
+//
+// 返回值：
+//   - *Candidate: 候选姓名
+func (g *Generator) buildCandidate(surname, given []*Character, opts *Options) *Candidate {
+	all := append(append([]*Character{}, surname...), given...)
+
+	var fullName, givenName strings.Builder
+	pinyins := make([]string, 0, len(all))
+	tones := make([]string, 0, len(all))
+	for _, c := range all {
+		fullName.WriteString(c.Char)
+		pinyins = append(pinyins, c.Pinyin)
+		tones = append(tones, toneCategory(c.Tone))
+	}
+
+	surnameStrokes := make([]int, 0, len(surname))
+	for _, c := range surname {
+		surnameStrokes = append(surnameStrokes, c.Strokes)
+	}
+
+	wuxing := make([]string, 0, len(given))
+	givenStrokes := make([]int, 0, len(given))
+	meanings := make([]string, 0, len(given))
+	for _, c := range given {
+		givenName.WriteString(c.Char)
+		wuxing = append(wuxing, c.Wuxing)
+		givenStrokes = append(givenStrokes, c.Strokes)
+		meanings = append(meanings, fmt.Sprintf("%s：%s", c.Char, c.Meaning))
+	}
+
+	wuge := CalculateWuge(surnameStrokes, givenStrokes)
+
+	candidate := &Candidate{
+		FullName:  fullName.String(),
+		GivenName: givenName.String(),
+		Pinyin:    strings.Join(pinyins, " "),
+		Tones:     strings.Join(tones, ""),
+		Wuxing:    wuxing,
+		Strokes:   givenStrokes,
+		Meaning:   strings.Join(meanings, "；"),
+		Wuge:      wuge,
+	}
+	candidate.Score = scoreWuxing(surname, given, opts) + scoreWuge(wuge)
+
+	return candidate
+}
+
+// scoreWuxing 计算名字用字五行得分
+// 参数：
+//   - surname: 姓氏用字
+//   - given: 名字用字
+//   - opts: 起名选项
+//
+// 返回值：
+//   - float64: 五行得分
+func scoreWuxing(surname, given []*Character, opts *Options) float64 {
+	score := 0.0
+	for _, c := range given {
+		switch {
+		case len(opts.Favorable) > 0 && c.Wuxing == opts.Favorable[0]:
+			score += scoreFavorableFirst
+		case len(opts.Favorable) > 1 && c.Wuxing == opts.Favorable[1]:
+			score += scoreFavorableSecond
+		case containsString(opts.Favorable, c.Wuxing):
+			score += scoreFavorableOther
+		}
+	}
+
+	// 姓名相邻用字五行相生加分、相克减分
+	chain := append([]*Character{surname[len(surname)-1]}, given...)
+	for i := 1; i < len(chain); i++ {
+		prev, cur := chain[i-1].Wuxing, chain[i].Wuxing
+		switch {
+		case utils.GetGeneratedWuxing(prev) == cur:
+			score += scoreGenerating
+		case utils.GetControlledWuxing(prev) == cur || utils.GetControllingWuxing(prev) == cur:
+			score += scoreControlling
+		}
+	}
+
+	return score
+}
+
+// scoreWuge 计算五格数理得分，外格权重减半
+// 参数：
+//   - w: 五格数理
+//
+// 返回值：
+//   - float64: 五格得分
+func scoreWuge(w *Wuge) float64 {
+	score := 0.0
+	for _, luck := range []string{w.RenLuck, w.DiLuck, w.ZongLuck} {
+		score += luckScore(luck)
+	}
+	return score + luckScore(w.WaiLuck)/2
+}
+
+// luckScore 数理吉凶对应分值
+// 参数：
+//   - luck: 吉凶
+//
+// 返回值：
+//   - float64: 分值
+func luckScore(luck string) float64 {
+	switch luck {
+	case LUCK_GOOD:
+		return scoreShuLiGood
+	case LUCK_HALF:
+		return scoreShuLiHalf
+	default:
+		return scoreShuLiBad
+	}
+}
+
+// toneCategory 声调转平仄
+// 参数：
+//   - tone: 声调（1-4）
+//
+// 返回值：
+//   - string: 平或仄
+func toneCategory(tone int) string {
+	if tone <= 2 {
+		return TONE_PING
+	}
+	return TONE_ZE
+}
+
+// matchTonePatterns 判断平仄是否符合模式
+// 参数：
+//   - tones: 姓名平仄
+//   - patterns: 允许的平仄模式
+//
+// 返回值：
+//   - bool: 是否符合
+func matchTonePatterns(tones string, patterns []string) bool {
+	toneRunes := []rune(tones)
+
+	// 未配置模式时仅排除三字及以上全同调
+	if len(patterns) == 0 {
+		if len(toneRunes) < 3 {
+			return true
+		}
+		for _, r := range toneRunes[1:] {
+			if r != toneRunes[0] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, pattern := range patterns {
+		patternRunes := []rune(pattern)
+		if len(patternRunes) != len(toneRunes) {
+			continue
+		}
+		matched := true
+		for i, r := range patternRunes {
+			if string(r) != TONE_WILDCARD && r != toneRunes[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGender 判断用字是否适合该性别
+// 参数：
+//   - c: 用字
+//   - gender: 性别
+//
+// 返回值：
+//   - bool: 是否适合
+func matchGender(c *Character, gender string) bool {
+	switch gender {
+	case GENDER_MALE:
+		return c.Gender != CHAR_GENDER_FEMALE
+	case GENDER_FEMALE:
+		return c.Gender != CHAR_GENDER_MALE
+	default:
+		return true
+	}
+}
+
+// isBlacklisted 判断文本是否命中禁用列表
+// 参数：
+//   - text: 待检查文本
+//   - blacklist: 禁用字或姓名片段
+//
+// 返回值：
+//   - bool: 是否命中
+func isBlacklisted(text string, blacklist []string) bool {
+	for _, item := range blacklist {
+		if item != "" && strings.Contains(text, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString 判断切片是否包含字符串
+// 参数：
+//   - list: 字符串切片
+//   - target: 目标字符串
+//
+// 返回值：
+//   - bool: 是否包含
+func containsString(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
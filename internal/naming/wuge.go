@@ -0,0 +1,123 @@
+// Package naming 提供五格剖象计算
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package naming
+
+// 数理吉凶常量
+const (
+	LUCK_GOOD   = "吉"  // 吉
+	LUCK_HALF   = "半吉" // 半吉
+	LUCK_BAD    = "凶"  // 凶
+	maxShuLiNum = 81   // 八十一数理上限
+)
+
+// goodNumbers 八十一数理中的吉数
+var goodNumbers = map[int]bool{
+	1: true, 3: true, 5: true, 6: true, 7: true, 8: true, 11: true, 13: true, 15: true, 16: true,
+	17: true, 18: true, 21: true, 23: true, 24: true, 25: true, 29: true, 31: true, 32: true, 33: true,
+	35: true, 37: true, 39: true, 41: true, 45: true, 47: true, 48: true, 52: true, 57: true, 61: true,
+	63: true, 65: true, 67: true, 68: true, 81: true,
+}
+
+// halfGoodNumbers 八十一数理中的半吉数
+var halfGoodNumbers = map[int]bool{
+	27: true, 30: true, 38: true, 49: true, 51: true, 55: true, 58: true, 71: true, 73: true, 75: true,
+}
+
+// Wuge 五格数理
+type Wuge struct {
+	Tian     int    `json:"tian"`      // 天格
+	Ren      int    `json:"ren"`       // 人格
+	Di       int    `json:"di"`        // 地格
+	Wai      int    `json:"wai"`       // 外格
+	Zong     int    `json:"zong"`      // 总格
+	RenLuck  string `json:"ren_luck"`  // 人格吉凶
+	DiLuck   string `json:"di_luck"`   // 地格吉凶
+	WaiLuck  string `json:"wai_luck"`  // 外格吉凶
+	ZongLuck string `json:"zong_luck"` // 总格吉凶
+}
+
+// CalculateWuge 计算五格
+// 参数：
+//   - surnameStrokes: 姓氏各字康熙笔画（单姓一个，复姓两个）
+//   - givenStrokes: 名字各字康熙笔画（单名一个，双名两个）
+//
+// 返回值：
+//   - *Wuge: 五格数理
+func CalculateWuge(surnameStrokes, givenStrokes []int) *Wuge {
+	if len(surnameStrokes) == 0 || len(givenStrokes) == 0 {
+		return &Wuge{}
+	}
+
+	isCompound := len(surnameStrokes) > 1
+	isDouble := len(givenStrokes) > 1
+	lastSurname := surnameStrokes[len(surnameStrokes)-1]
+	firstGiven := givenStrokes[0]
+
+	w := new(Wuge)
+
+	// 天格：单姓加一，复姓相加
+	if isCompound {
+		w.Tian = surnameStrokes[0] + surnameStrokes[1]
+	} else {
+		w.Tian = surnameStrokes[0] + 1
+	}
+
+	// 人格：姓氏末字加名字首字
+	w.Ren = lastSurname + firstGiven
+
+	// 地格：双名相加，单名加一
+	if isDouble {
+		w.Di = givenStrokes[0] + givenStrokes[1]
+	} else {
+		w.Di = firstGiven + 1
+	}
+
+	// 总格：全部笔画之和
+	for _, s := range surnameStrokes {
+		w.Zong += s
+	}
+	for _, s := range givenStrokes {
+		w.Zong += s
+	}
+
+	// 外格：按单复姓、单双名分别计算
+	switch {
+	case isCompound && isDouble:
+		w.Wai = surnameStrokes[0] + givenStrokes[1]
+	case isCompound:
+		w.Wai = surnameStrokes[0] + 1
+	case isDouble:
+		w.Wai = givenStrokes[1] + 1
+	default:
+		w.Wai = 2
+	}
+
+	w.RenLuck = GetShuLiLuck(w.Ren)
+	w.DiLuck = GetShuLiLuck(w.Di)
+	w.WaiLuck = GetShuLiLuck(w.Wai)
+	w.ZongLuck = GetShuLiLuck(w.Zong)
+
+	return w
+}
+
+// GetShuLiLuck 获取八十一数理吉凶
+// 参数：
+//   - num: 数理
+//
+// 返回值：
+//   - string: 吉凶
+func GetShuLiLuck(num int) string {
+	for num > maxShuLiNum {
+		num -= maxShuLiNum - 1
+	}
+
+	switch {
+	case goodNumbers[num]:
+		return LUCK_GOOD
+	case halfGoodNumbers[num]:
+		return LUCK_HALF
+	default:
+		return LUCK_BAD
+	}
+}
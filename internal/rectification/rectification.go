@@ -0,0 +1,327 @@
+// Package rectification 提供基于人生事件的出生时辰校正
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package rectification
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// 人生事件类型常量
+const (
+	EVENT_TYPE_MARRIAGE    = "marriage"    // 婚姻
+	EVENT_TYPE_CHILDBIRTH  = "childbirth"  // 生育
+	EVENT_TYPE_CAREER      = "career"      // 事业变动
+	EVENT_TYPE_ILLNESS     = "illness"     // 疾病
+	EVENT_TYPE_BEREAVEMENT = "bereavement" // 丧亲
+)
+
+// 引动来源与权重
+const (
+	SOURCE_LUCK   = "大运" // 大运
+	SOURCE_ANNUAL = "流年" // 流年
+
+	luckSourceWeight   = 0.7 // 大运引动权重
+	annualSourceWeight = 1.0 // 流年引动权重
+	starWeight         = 0.6 // 岁运透出事件星的权重
+	palaceStarWeight   = 0.4 // 被引动宫位坐事件星的权重
+)
+
+// hourBranches 十二时辰及代表时刻（取时辰中间整点，子时取 0 点避免跨日）
+var hourBranches = []struct {
+	Zhi       string
+	Hour      int
+	TimeRange string
+}{
+	{"子", 0, "23:00-00:59"}, {"丑", 2, "01:00-02:59"}, {"寅", 4, "03:00-04:59"}, {"卯", 6, "05:00-06:59"},
+	{"辰", 8, "07:00-08:59"}, {"巳", 10, "09:00-10:59"}, {"午", 12, "11:00-12:59"}, {"未", 14, "13:00-14:59"},
+	{"申", 16, "15:00-16:59"}, {"酉", 18, "17:00-18:59"}, {"戌", 20, "19:00-20:59"}, {"亥", 22, "21:00-22:59"},
+}
+
+// relationWeights 干支关系权重
+var relationWeights = map[string]float64{
+	utils.RELATION_FU_YIN:    0.8,
+	utils.RELATION_ZHI_CHONG: 1.0,
+	utils.RELATION_ZHI_HE:    0.8,
+	utils.RELATION_ZHI_XING:  0.6,
+	utils.RELATION_ZHI_HAI:   0.4,
+	utils.RELATION_GAN_HE:    0.5,
+	utils.RELATION_GAN_CHONG: 0.5,
+}
+
+// palaceWeights 各类事件对应的宫位权重（年、月、日、时）
+var palaceWeights = map[string][]float64{
+	EVENT_TYPE_MARRIAGE:    {0.2, 0.3, 1.0, 0.5}, // 日支为夫妻宫
+	EVENT_TYPE_CHILDBIRTH:  {0.2, 0.2, 0.5, 1.0}, // 时柱为子女宫
+	EVENT_TYPE_CAREER:      {0.3, 1.0, 0.3, 0.6}, // 月柱为事业门户
+	EVENT_TYPE_ILLNESS:     {0.3, 0.3, 1.0, 0.7}, // 日柱为自身
+	EVENT_TYPE_BEREAVEMENT: {1.0, 0.8, 0.3, 0.4}, // 年月为祖上父母
+}
+
+// eventStars 各类事件对应的十神（按性别区分）
+var eventStars = map[string]map[string][]string{
+	EVENT_TYPE_MARRIAGE: {
+		utils.GENDER_MALE:   {"正财", "偏财"},
+		utils.GENDER_FEMALE: {"正官", "七杀"},
+	},
+	EVENT_TYPE_CHILDBIRTH: {
+		utils.GENDER_MALE:   {"正官", "七杀"},
+		utils.GENDER_FEMALE: {"食神", "伤官"},
+	},
+	EVENT_TYPE_CAREER: {
+		utils.GENDER_MALE:   {"正官", "七杀", "正印"},
+		utils.GENDER_FEMALE: {"正官", "七杀", "正印"},
+	},
+	EVENT_TYPE_ILLNESS: {
+		utils.GENDER_MALE:   {"七杀", "伤官", "偏印"},
+		utils.GENDER_FEMALE: {"七杀", "伤官", "偏印"},
+	},
+	EVENT_TYPE_BEREAVEMENT: {
+		utils.GENDER_MALE:   {"偏财", "正印", "劫财"},
+		utils.GENDER_FEMALE: {"偏财", "正印", "劫财"},
+	},
+}
+
+// Event 人生事件
+type Event struct {
+	Type string    // 事件类型
+	Date time.Time // 事件日期
+}
+
+// Input 时辰校正输入
+type Input struct {
+	BirthTime time.Time // 出生时间（时辰未知，仅日期有效）
+	Calendar  string    // 日历类型 (lunar/solar)
+	Gender    string    // 性别 (male/female)
+	Events    []*Event  // 已发生的人生事件
+}
+
+// Evidence 单条引动证据
+type Evidence struct {
+	EventType string  `json:"event_type"` // 事件类型
+	EventDate string  `json:"event_date"` // 事件日期
+	Source    string  `json:"source"`     // 引动来源（大运/流年）
+	GanZhi    string  `json:"gan_zhi"`    // 引动干支
+	Target    string  `json:"target"`     // 被引动的柱
+	Detail    string  `json:"detail"`     // 说明
+	Weight    float64 `json:"weight"`     // 得分
+}
+
+// Candidate 候选时辰
+type Candidate struct {
+	HourZhi    string      `json:"hour_zhi"`    // 时支
+	HourPillar string      `json:"hour_pillar"` // 时柱
+	TimeRange  string      `json:"time_range"`  // 时间范围
+	Score      float64     `json:"score"`       // 总分
+	Confidence float64     `json:"confidence"`  // 相对可信度（0-1）
+	Explained  int         `json:"explained"`   // 有引动证据的事件数
+	Evidences  []*Evidence `json:"evidences"`   // 引动证据
+}
+
+// chart 候选命盘
+type chart struct {
+	dayGan  string
+	pillars []string
+	luck    []*utils.LuckPillar
+}
+
+// Rectify 对十二时辰逐一起盘，按岁运与原局的刑冲合害解释事件的程度排序
+// 参数：
+//   - in: 校正输入
+//
+// 返回值：
+//   - []*Candidate: 按得分降序排列的候选时辰
+//   - error: 校正过程中的错误
+func Rectify(in *Input) ([]*Candidate, error) {
+	if in == nil || len(in.Events) == 0 {
+		return nil, errors.New("至少需要一条人生事件")
+	}
+	for _, e := range in.Events {
+		if _, ok := palaceWeights[e.Type]; !ok {
+			return nil, fmt.Errorf("不支持的事件类型: %s", e.Type)
+		}
+	}
+
+	if in.BirthTime.IsZero() {
+		return nil, errors.New("出生时间未知，无法校正时辰")
+	}
+
+	year, month, day := in.BirthTime.Date()
+	candidates := make([]*Candidate, 0, len(hourBranches))
+	var total float64
+	for _, hb := range hourBranches {
+		birthTime := time.Date(year, month, day, hb.Hour, 0, 0, 0, in.BirthTime.Location())
+		pillars := utils.CalculatePillars(birthTime, in.Calendar)
+		c := &chart{
+			dayGan:  string([]rune(pillars[2])[0]),
+			pillars: pillars,
+			luck:    utils.CalculateLuckPillars(birthTime, in.Calendar, in.Gender),
+		}
+
+		candidate := &Candidate{
+			HourZhi:    hb.Zhi,
+			HourPillar: pillars[3],
+			TimeRange:  hb.TimeRange,
+			Evidences:  []*Evidence{},
+		}
+		for _, e := range in.Events {
+			evidences := scoreEvent(c, e, in.Gender)
+			if len(evidences) > 0 {
+				candidate.Explained++
+			}
+			for _, ev := range evidences {
+				candidate.Score += ev.Weight
+			}
+			candidate.Evidences = append(candidate.Evidences, evidences...)
+		}
+		total += candidate.Score
+		candidates = append(candidates, candidate)
+	}
+
+	for _, c := range candidates {
+		if total > 0 {
+			c.Confidence = round(c.Score / total)
+		}
+		c.Score = round(c.Score)
+		for _, ev := range c.Evidences {
+			ev.Weight = round(ev.Weight)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Explained != candidates[j].Explained {
+			return candidates[i].Explained > candidates[j].Explained
+		}
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	return candidates, nil
+}
+
+// scoreEvent 计算单个事件在候选命盘中的引动证据
+// 参数：
+//   - c: 候选命盘
+//   - e: 人生事件
+//   - gender: 性别
+//
+// 返回值：
+//   - []*Evidence: 引动证据
+func scoreEvent(c *chart, e *Event, gender string) []*Evidence {
+	type source struct {
+		name   string
+		ganZhi string
+		weight float64
+	}
+
+	sources := []source{{SOURCE_ANNUAL, utils.GetAnnualGanZhi(e.Date), annualSourceWeight}}
+	if luck := utils.FindLuckPillar(c.luck, e.Date.Year()); luck != nil {
+		sources = append(sources, source{SOURCE_LUCK, luck.GanZhi, luckSourceWeight})
+	}
+
+	date := e.Date.Format(time.DateOnly)
+	stars := eventStars[e.Type][gender]
+	palaces := palaceWeights[e.Type]
+
+	var evidences []*Evidence
+	for _, src := range sources {
+		// 岁运天干透出事件星
+		srcGan, _ := utils.SplitGanZhi(src.ganZhi)
+		if tenGod := utils.GetTenGod(c.dayGan, srcGan); containsString(stars, tenGod) {
+			evidences = append(evidences, &Evidence{
+				EventType: e.Type,
+				EventDate: date,
+				Source:    src.name,
+				GanZhi:    src.ganZhi,
+				Detail:    fmt.Sprintf("%s%s透出%s", src.name, src.ganZhi, tenGod),
+				Weight:    starWeight * src.weight,
+			})
+		}
+
+		// 岁运与原局各柱的刑冲合害
+		for i, pillar := range c.pillars {
+			relations := utils.GetGanZhiRelations(src.ganZhi, pillar)
+			if len(relations) == 0 {
+				continue
+			}
+
+			for _, rel := range relations {
+				evidences = append(evidences, &Evidence{
+					EventType: e.Type,
+					EventDate: date,
+					Source:    src.name,
+					GanZhi:    src.ganZhi,
+					Target:    utils.NATAL_PILLAR_NAMES[i],
+					Detail:    fmt.Sprintf("%s%s与%s%s%s", src.name, src.ganZhi, utils.NATAL_PILLAR_NAMES[i], pillar, rel),
+					Weight:    relationWeights[rel] * palaces[i] * src.weight,
+				})
+			}
+
+			// 被引动的宫位坐事件星，事件应验更明显
+			if tenGod := pillarTenGod(c.dayGan, pillar, i); containsString(stars, tenGod) {
+				evidences = append(evidences, &Evidence{
+					EventType: e.Type,
+					EventDate: date,
+					Source:    src.name,
+					GanZhi:    src.ganZhi,
+					Target:    utils.NATAL_PILLAR_NAMES[i],
+					Detail:    fmt.Sprintf("%s%s坐%s被引动", utils.NATAL_PILLAR_NAMES[i], pillar, tenGod),
+					Weight:    palaceStarWeight * palaces[i] * src.weight,
+				})
+			}
+		}
+	}
+
+	return evidences
+}
+
+// pillarTenGod 获取某柱的十神，日柱取日支本气，其余取天干
+// 参数：
+//   - dayGan: 日主天干
+//   - pillar: 干支
+//   - index: 柱序号（0-3）
+//
+// 返回值：
+//   - string: 十神
+func pillarTenGod(dayGan, pillar string, index int) string {
+	gan, zhi := utils.SplitGanZhi(pillar)
+	if index == 2 {
+		hide := utils.GetHideGan(zhi)
+		if len(hide) == 0 {
+			return ""
+		}
+		return utils.GetTenGod(dayGan, hide[0])
+	}
+	return utils.GetTenGod(dayGan, gan)
+}
+
+// round 保留两位小数
+// 参数：
+//   - v: 原始值
+//
+// 返回值：
+//   - float64: 保留两位小数后的值
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// containsString 判断字符串列表是否包含目标字符串
+// 参数：
+//   - list: 字符串列表
+//   - target: 目标字符串
+//
+// 返回值：
+//   - bool: 是否包含
+func containsString(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
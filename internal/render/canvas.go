@@ -0,0 +1,83 @@
+// Package render 提供与输出格式无关的画布，可输出为 SVG、PNG 或绘制到 PDF
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package render
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// 文字对齐方式
+const (
+	ANCHOR_START  = "start"  // 左对齐
+	ANCHOR_MIDDLE = "middle" // 居中
+	ANCHOR_END    = "end"    // 右对齐
+)
+
+// Rect 矩形，Fill 或 Stroke 为 nil 时不绘制对应部分
+type Rect struct {
+	X, Y, W, H  float64     // 左上角坐标与宽高
+	Fill        color.Color // 填充色
+	Stroke      color.Color // 边框色
+	StrokeWidth float64     // 边框宽度
+}
+
+// Line 水平或垂直线段
+type Line struct {
+	X1, Y1, X2, Y2 float64     // 起止坐标
+	Color          color.Color // 颜色
+	Width          float64     // 线宽
+}
+
+// Text 单行文字，Y 为基线位置
+type Text struct {
+	X, Y    float64     // 锚点坐标
+	Size    float64     // 字号
+	Color   color.Color // 颜色
+	Anchor  string      // 对齐方式
+	Bold    bool        // 是否加粗
+	Content string      // 文字内容
+}
+
+// Canvas 画布，按添加顺序依次绘制矩形、线段和文字
+type Canvas struct {
+	Width      float64     // 宽度
+	Height     float64     // 高度
+	Background color.Color // 背景色
+	Rects      []*Rect     // 矩形
+	Lines      []*Line     // 线段
+	Texts      []*Text     // 文字
+}
+
+// AddRect 添加矩形
+// 参数：
+//   - r: 矩形
+func (c *Canvas) AddRect(r *Rect) {
+	c.Rects = append(c.Rects, r)
+}
+
+// AddLine 添加线段
+// 参数：
+//   - l: 线段
+func (c *Canvas) AddLine(l *Line) {
+	c.Lines = append(c.Lines, l)
+}
+
+// AddText 添加文字
+// 参数：
+//   - t: 文字
+func (c *Canvas) AddText(t *Text) {
+	c.Texts = append(c.Texts, t)
+}
+
+// hexColor 将颜色转换为 #RRGGBB 形式
+// 参数：
+//   - c: 颜色
+//
+// 返回值：
+//   - string: 十六进制颜色
+func hexColor(c color.Color) string {
+	r, g, b := rgb(c)
+	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
+}
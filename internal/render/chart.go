@@ -0,0 +1,239 @@
+// Package render 提供八字命盘的版式绘制
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// 命盘版式尺寸
+const (
+	chartWidth       = 720.0 // 画布宽度
+	chartMargin      = 24.0  // 页边距
+	labelWidth       = 72.0  // 行标题列宽
+	tableTop         = 104.0 // 四柱表格顶部
+	luckMaxCount     = 8     // 大运最多展示步数
+	luckStripHeight  = 130.0 // 大运条高度
+	legendHeight     = 48.0  // 图例高度
+	hiddenStemSpace  = 44.0  // 藏干间距
+	pillarFontSize   = 40.0  // 四柱干支字号
+	luckFontSize     = 24.0  // 大运干支字号
+	captionFontSize  = 12.0  // 说明文字字号
+	headerFontSize   = 15.0  // 表头字号
+	hiddenFontSize   = 18.0  // 藏干字号
+	titleFontSize    = 26.0  // 标题字号
+	subtitleFontSize = 14.0  // 副标题字号
+)
+
+// 配色
+var (
+	colorPaper  = color.RGBA{0xFB, 0xF7, 0xEE, 0xFF} // 底色
+	colorHeader = color.RGBA{0xF1, 0xE6, 0xD0, 0xFF} // 表头底色
+	colorBorder = color.RGBA{0x8B, 0x5A, 0x2B, 0xFF} // 外框
+	colorGrid   = color.RGBA{0xD9, 0xCB, 0xB0, 0xFF} // 网格线
+	colorInk    = color.RGBA{0x33, 0x2B, 0x22, 0xFF} // 正文
+	colorMuted  = color.RGBA{0x8A, 0x7F, 0x72, 0xFF} // 说明文字
+
+	// elementColors 五行配色：木青、火赤、土黄、金白（以金色表现）、水黑（以深蓝表现）
+	elementColors = map[string]color.RGBA{
+		utils.WUXING_WOOD:  {0x2E, 0x8B, 0x57, 0xFF},
+		utils.WUXING_FIRE:  {0xD7, 0x26, 0x3D, 0xFF},
+		utils.WUXING_EARTH: {0xA0, 0x6A, 0x1C, 0xFF},
+		utils.WUXING_METAL: {0xC9, 0x9A, 0x06, 0xFF},
+		utils.WUXING_WATER: {0x1F, 0x4E, 0x9C, 0xFF},
+	}
+)
+
+// chartRow 四柱表格的一行
+type chartRow struct {
+	label  string                                                   // 行标题
+	height float64                                                  // 行高
+	draw   func(cv *Canvas, p chart.Pillar, i int, cx, top float64) // 绘制单元格
+}
+
+// BuildChart 绘制八字命盘画布
+// 参数：
+//   - c: 八字命盘
+//   - luckPillars: 大运列表，为空时不绘制大运条
+//
+// 返回值：
+//   - *Canvas: 画布
+//   - error: 命盘无效时返回错误
+func BuildChart(c *chart.Chart, luckPillars []*utils.LuckPillar) (*Canvas, error) {
+	if c == nil || !c.Valid() {
+		return nil, fmt.Errorf("四柱信息不完整")
+	}
+
+	if len(luckPillars) > luckMaxCount {
+		luckPillars = luckPillars[:luckMaxCount]
+	}
+
+	dayMaster := c.DayMaster()
+	rows := []*chartRow{
+		{label: "十神", height: 32, draw: func(cv *Canvas, p chart.Pillar, i int, cx, top float64) {
+			tenGod := dayMaster.TenGod(p.Stem)
+			if i == 2 {
+				tenGod = "日主"
+			}
+			cv.AddText(&Text{X: cx, Y: top + 22, Size: subtitleFontSize, Color: colorInk, Anchor: ANCHOR_MIDDLE, Content: tenGod})
+		}},
+		{label: "天干", height: 64, draw: func(cv *Canvas, p chart.Pillar, i int, cx, top float64) {
+			cv.AddText(&Text{X: cx, Y: top + 48, Size: pillarFontSize, Color: elementColor(p.Stem.Element()), Anchor: ANCHOR_MIDDLE, Bold: true, Content: p.Stem.String()})
+		}},
+		{label: "地支", height: 64, draw: func(cv *Canvas, p chart.Pillar, i int, cx, top float64) {
+			cv.AddText(&Text{X: cx, Y: top + 48, Size: pillarFontSize, Color: elementColor(p.Branch.Element()), Anchor: ANCHOR_MIDDLE, Bold: true, Content: p.Branch.String()})
+		}},
+		{label: "藏干", height: 64, draw: func(cv *Canvas, p chart.Pillar, i int, cx, top float64) {
+			hidden := p.Branch.HiddenStems()
+			start := cx - hiddenStemSpace*float64(len(hidden)-1)/2
+			for j, stem := range hidden {
+				x := start + hiddenStemSpace*float64(j)
+				cv.AddText(&Text{X: x, Y: top + 28, Size: hiddenFontSize, Color: elementColor(stem.Element()), Anchor: ANCHOR_MIDDLE, Content: stem.String()})
+				cv.AddText(&Text{X: x, Y: top + 50, Size: captionFontSize, Color: colorMuted, Anchor: ANCHOR_MIDDLE, Content: dayMaster.TenGod(stem)})
+			}
+		}},
+		{label: "纳音", height: 32, draw: func(cv *Canvas, p chart.Pillar, i int, cx, top float64) {
+			cv.AddText(&Text{X: cx, Y: top + 22, Size: subtitleFontSize, Color: colorInk, Anchor: ANCHOR_MIDDLE, Content: p.NaYin()})
+		}},
+	}
+
+	const headerHeight = 36.0
+	tableHeight := headerHeight
+	for _, row := range rows {
+		tableHeight += row.height
+	}
+	tableBottom := tableTop + tableHeight
+
+	height := tableBottom + legendHeight + chartMargin
+	if len(luckPillars) > 0 {
+		height += luckStripHeight + 40
+	}
+
+	cv := &Canvas{Width: chartWidth, Height: height, Background: colorPaper}
+	cv.AddRect(&Rect{X: 8, Y: 8, W: chartWidth - 16, H: height - 16, Stroke: colorBorder, StrokeWidth: 2})
+
+	drawTitle(cv, c)
+
+	// 四柱表格
+	left := chartMargin
+	right := chartWidth - chartMargin
+	columnWidth := (right - left - labelWidth) / 4
+	cv.AddRect(&Rect{X: left, Y: tableTop, W: right - left, H: headerHeight, Fill: colorHeader})
+	cv.AddRect(&Rect{X: left, Y: tableTop, W: right - left, H: tableHeight, Stroke: colorBorder, StrokeWidth: 1.5})
+
+	pillars := c.Pillars()
+	headers := []string{"年柱", "月柱", "日柱", "时柱"}
+	for i := range pillars {
+		x := left + labelWidth + columnWidth*float64(i)
+		cv.AddLine(&Line{X1: x, Y1: tableTop, X2: x, Y2: tableBottom, Color: colorGrid, Width: 1})
+		cv.AddText(&Text{X: x + columnWidth/2, Y: tableTop + 24, Size: headerFontSize, Color: colorInk, Anchor: ANCHOR_MIDDLE, Bold: true, Content: headers[i]})
+	}
+
+	top := tableTop + headerHeight
+	for _, row := range rows {
+		cv.AddLine(&Line{X1: left, Y1: top, X2: right, Y2: top, Color: colorGrid, Width: 1})
+		cv.AddText(&Text{X: left + labelWidth/2, Y: top + row.height/2 + 5, Size: subtitleFontSize, Color: colorMuted, Anchor: ANCHOR_MIDDLE, Content: row.label})
+		for i, p := range pillars {
+			row.draw(cv, p, i, left+labelWidth+columnWidth*float64(i)+columnWidth/2, top)
+		}
+		top += row.height
+	}
+
+	bottom := tableBottom
+	if len(luckPillars) > 0 {
+		bottom = drawLuckStrip(cv, dayMaster, luckPillars, tableBottom+40)
+	}
+	drawLegend(cv, bottom+32)
+
+	return cv, nil
+}
+
+// drawTitle 绘制标题与出生信息
+// 参数：
+//   - cv: 画布
+//   - c: 八字命盘
+func drawTitle(cv *Canvas, c *chart.Chart) {
+	title := c.Name
+	if title == "" {
+		title = "八字命盘"
+	}
+	cv.AddText(&Text{X: chartMargin, Y: 56, Size: titleFontSize, Color: colorInk, Bold: true, Content: title})
+
+	subtitle := "乾造"
+	if c.Gender == utils.GENDER_FEMALE {
+		subtitle = "坤造"
+	}
+	if !c.BirthTime.IsZero() {
+		calendar := "公历"
+		if c.Calendar == utils.CALENDAR_LUNAR {
+			calendar = "农历"
+		}
+		subtitle += " · " + calendar + " " + c.BirthTime.Format("2006-01-02 15:04")
+	}
+	cv.AddText(&Text{X: chartMargin, Y: 84, Size: subtitleFontSize, Color: colorMuted, Content: subtitle})
+}
+
+// drawLuckStrip 绘制大运条
+// 参数：
+//   - cv: 画布
+//   - dayMaster: 日主
+//   - luckPillars: 大运列表
+//   - top: 顶部位置
+//
+// 返回值：
+//   - float64: 大运条底部位置
+func drawLuckStrip(cv *Canvas, dayMaster chart.Stem, luckPillars []*utils.LuckPillar, top float64) float64 {
+	left := chartMargin
+	right := chartWidth - chartMargin
+	cv.AddText(&Text{X: left, Y: top - 12, Size: headerFontSize, Color: colorInk, Bold: true, Content: "大运"})
+	cv.AddRect(&Rect{X: left, Y: top, W: right - left, H: luckStripHeight, Stroke: colorBorder, StrokeWidth: 1.5})
+
+	cellWidth := (right - left) / float64(len(luckPillars))
+	for i, lp := range luckPillars {
+		x := left + cellWidth*float64(i)
+		cx := x + cellWidth/2
+		if i > 0 {
+			cv.AddLine(&Line{X1: x, Y1: top, X2: x, Y2: top + luckStripHeight, Color: colorGrid, Width: 1})
+		}
+
+		stem, branch := chart.Stem(lp.Gan), chart.Branch(lp.Zhi)
+		cv.AddText(&Text{X: cx, Y: top + 20, Size: captionFontSize, Color: colorMuted, Anchor: ANCHOR_MIDDLE, Content: strconv.Itoa(lp.StartAge) + "岁"})
+		cv.AddText(&Text{X: cx, Y: top + 36, Size: captionFontSize, Color: colorMuted, Anchor: ANCHOR_MIDDLE, Content: strconv.Itoa(lp.StartYear)})
+		cv.AddText(&Text{X: cx, Y: top + 66, Size: luckFontSize, Color: elementColor(stem.Element()), Anchor: ANCHOR_MIDDLE, Bold: true, Content: stem.String()})
+		cv.AddText(&Text{X: cx, Y: top + 96, Size: luckFontSize, Color: elementColor(branch.Element()), Anchor: ANCHOR_MIDDLE, Bold: true, Content: branch.String()})
+		cv.AddText(&Text{X: cx, Y: top + 118, Size: captionFontSize, Color: colorInk, Anchor: ANCHOR_MIDDLE, Content: dayMaster.TenGod(stem)})
+	}
+	return top + luckStripHeight
+}
+
+// drawLegend 绘制五行配色图例
+// 参数：
+//   - cv: 画布
+//   - baseline: 文字基线位置
+func drawLegend(cv *Canvas, baseline float64) {
+	x := chartMargin
+	for _, element := range utils.WUXING_LIST {
+		cv.AddRect(&Rect{X: x, Y: baseline - 11, W: 12, H: 12, Fill: elementColor(element)})
+		cv.AddText(&Text{X: x + 18, Y: baseline, Size: captionFontSize, Color: colorMuted, Content: element})
+		x += 48
+	}
+}
+
+// elementColor 获取五行配色
+// 参数：
+//   - element: 五行
+//
+// 返回值：
+//   - color.Color: 配色，未知五行返回正文色
+func elementColor(element string) color.Color {
+	if c, ok := elementColors[element]; ok {
+		return c
+	}
+	return colorInk
+}
@@ -0,0 +1,107 @@
+// Package render 提供将画布以矢量形式绘制到 PDF 页面的能力
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package render
+
+import (
+	"image/color"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// POINTS_PER_MM 每毫米对应的磅数
+const POINTS_PER_MM = 72 / 25.4
+
+// DrawPDF 将画布按指定宽度绘制到 PDF 当前页，调用方需预先注册 fontFamily 对应的中文字体
+// 参数：
+//   - pdf: PDF 文档
+//   - fontFamily: 已注册的字体名称
+//   - x, y: 左上角位置（毫米）
+//   - width: 绘制宽度（毫米），高度按画布比例计算
+//
+// 返回值：
+//   - float64: 绘制高度（毫米）
+func (c *Canvas) DrawPDF(pdf *fpdf.Fpdf, fontFamily string, x, y, width float64) float64 {
+	scale := width / c.Width
+	height := c.Height * scale
+
+	if c.Background != nil {
+		setFillColor(pdf, c.Background)
+		pdf.Rect(x, y, width, height, "F")
+	}
+
+	for _, r := range c.Rects {
+		style := ""
+		if r.Fill != nil {
+			setFillColor(pdf, r.Fill)
+			style += "F"
+		}
+		if r.Stroke != nil && r.StrokeWidth > 0 {
+			setDrawColor(pdf, r.Stroke)
+			pdf.SetLineWidth(r.StrokeWidth * scale)
+			style += "D"
+		}
+		if style != "" {
+			pdf.Rect(x+r.X*scale, y+r.Y*scale, r.W*scale, r.H*scale, style)
+		}
+	}
+
+	for _, l := range c.Lines {
+		setDrawColor(pdf, l.Color)
+		pdf.SetLineWidth(l.Width * scale)
+		pdf.Line(x+l.X1*scale, y+l.Y1*scale, x+l.X2*scale, y+l.Y2*scale)
+	}
+
+	for _, t := range c.Texts {
+		pdf.SetFont(fontFamily, "", t.Size*scale*POINTS_PER_MM)
+		r, g, b := rgb(t.Color)
+		pdf.SetTextColor(r, g, b)
+
+		tx := x + t.X*scale
+		switch t.Anchor {
+		case ANCHOR_MIDDLE:
+			tx -= pdf.GetStringWidth(t.Content) / 2
+		case ANCHOR_END:
+			tx -= pdf.GetStringWidth(t.Content)
+		}
+		ty := y + t.Y*scale
+
+		pdf.Text(tx, ty, t.Content)
+		if t.Bold {
+			pdf.Text(tx+0.15, ty, t.Content)
+		}
+	}
+
+	return height
+}
+
+// setFillColor 设置 PDF 填充色
+// 参数：
+//   - pdf: PDF 文档
+//   - c: 颜色
+func setFillColor(pdf *fpdf.Fpdf, c color.Color) {
+	r, g, b := rgb(c)
+	pdf.SetFillColor(r, g, b)
+}
+
+// setDrawColor 设置 PDF 描边色
+// 参数：
+//   - pdf: PDF 文档
+//   - c: 颜色
+func setDrawColor(pdf *fpdf.Fpdf, c color.Color) {
+	r, g, b := rgb(c)
+	pdf.SetDrawColor(r, g, b)
+}
+
+// rgb 获取颜色的 8 位 RGB 分量
+// 参数：
+//   - c: 颜色
+//
+// 返回值：
+//   - int: 红
+//   - int: 绿
+//   - int: 蓝
+func rgb(c color.Color) (int, int, int) {
+	r, g, b, _ := c.RGBA()
+	return int(r >> 8), int(g >> 8), int(b >> 8)
+}
@@ -0,0 +1,183 @@
+// Package render 提供画布的 PNG 光栅化，纯 Go 实现，不依赖外部程序
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// 字体缓存，同一路径的字体文件只解析一次
+var (
+	fontCache   = make(map[string]*opentype.Font)
+	fontCacheMu sync.Mutex
+)
+
+// LoadFont 加载字体文件，支持 TTF/OTF 与 TTC 字体集合（取第一个字体）
+// 参数：
+//   - path: 字体文件路径
+//
+// 返回值：
+//   - *opentype.Font: 字体
+//   - error: 加载过程中的错误
+func LoadFont(path string) (*opentype.Font, error) {
+	if path == "" {
+		return nil, fmt.Errorf("未配置中文字体")
+	}
+
+	fontCacheMu.Lock()
+	defer fontCacheMu.Unlock()
+
+	if f, ok := fontCache[path]; ok {
+		return f, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取字体文件失败: %w", err)
+	}
+
+	f, err := opentype.Parse(data)
+	if err != nil {
+		collection, collectionErr := opentype.ParseCollection(data)
+		if collectionErr != nil {
+			return nil, fmt.Errorf("解析字体文件失败: %w", err)
+		}
+		if f, err = collection.Font(0); err != nil {
+			return nil, fmt.Errorf("解析字体集合失败: %w", err)
+		}
+	}
+
+	fontCache[path] = f
+	return f, nil
+}
+
+// PNG 将画布光栅化为 PNG 图片
+// 参数：
+//   - f: 文字使用的字体，需包含中文字形
+//   - scale: 缩放倍数，小于 1 时按 1 处理
+//
+// 返回值：
+//   - []byte: PNG 图片
+//   - error: 光栅化过程中的错误
+func (c *Canvas) PNG(f *opentype.Font, scale float64) ([]byte, error) {
+	if f == nil {
+		return nil, fmt.Errorf("未配置中文字体")
+	}
+	if scale < 1 {
+		scale = 1
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, px(c.Width, scale), px(c.Height, scale)))
+	if c.Background != nil {
+		draw.Draw(img, img.Bounds(), image.NewUniform(c.Background), image.Point{}, draw.Src)
+	}
+
+	for _, r := range c.Rects {
+		if r.Fill != nil {
+			fillRect(img, r.X, r.Y, r.X+r.W, r.Y+r.H, scale, r.Fill)
+		}
+		if r.Stroke != nil && r.StrokeWidth > 0 {
+			half := r.StrokeWidth / 2
+			fillRect(img, r.X-half, r.Y-half, r.X+r.W+half, r.Y+half, scale, r.Stroke)
+			fillRect(img, r.X-half, r.Y+r.H-half, r.X+r.W+half, r.Y+r.H+half, scale, r.Stroke)
+			fillRect(img, r.X-half, r.Y-half, r.X+half, r.Y+r.H+half, scale, r.Stroke)
+			fillRect(img, r.X+r.W-half, r.Y-half, r.X+r.W+half, r.Y+r.H+half, scale, r.Stroke)
+		}
+	}
+
+	// 画布只使用水平与垂直线段，按细长矩形填充即可
+	for _, l := range c.Lines {
+		half := l.Width / 2
+		fillRect(img, math.Min(l.X1, l.X2)-half, math.Min(l.Y1, l.Y2)-half, math.Max(l.X1, l.X2)+half, math.Max(l.Y1, l.Y2)+half, scale, l.Color)
+	}
+
+	faces := make(map[float64]font.Face)
+	defer func() {
+		for _, face := range faces {
+			face.Close()
+		}
+	}()
+
+	for _, t := range c.Texts {
+		face, ok := faces[t.Size]
+		if !ok {
+			var err error
+			face, err = opentype.NewFace(f, &opentype.FaceOptions{Size: t.Size * scale, DPI: 72, Hinting: font.HintingFull})
+			if err != nil {
+				return nil, fmt.Errorf("创建字体失败: %w", err)
+			}
+			faces[t.Size] = face
+		}
+		drawText(img, face, t, scale)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("编码 PNG 失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawText 绘制单行文字，加粗通过横向偏移重复绘制模拟
+// 参数：
+//   - img: 目标图片
+//   - face: 字体
+//   - t: 文字
+//   - scale: 缩放倍数
+func drawText(img draw.Image, face font.Face, t *Text, scale float64) {
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(t.Color), Face: face}
+
+	x := fixed.Int26_6(t.X * scale * 64)
+	switch t.Anchor {
+	case ANCHOR_MIDDLE:
+		x -= d.MeasureString(t.Content) / 2
+	case ANCHOR_END:
+		x -= d.MeasureString(t.Content)
+	}
+	y := fixed.Int26_6(t.Y * scale * 64)
+
+	d.Dot = fixed.Point26_6{X: x, Y: y}
+	d.DrawString(t.Content)
+	if t.Bold {
+		d.Dot = fixed.Point26_6{X: x + fixed.Int26_6(scale*64), Y: y}
+		d.DrawString(t.Content)
+	}
+}
+
+// fillRect 按画布坐标填充矩形区域
+// 参数：
+//   - img: 目标图片
+//   - x0, y0, x1, y1: 画布坐标
+//   - scale: 缩放倍数
+//   - c: 颜色
+func fillRect(img draw.Image, x0, y0, x1, y1, scale float64, c color.Color) {
+	rect := image.Rect(px(x0, scale), px(y0, scale), px(x1, scale), px(y1, scale))
+	if rect.Empty() {
+		rect.Max = rect.Min.Add(image.Pt(1, 1))
+	}
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+// px 将画布坐标换算为像素
+// 参数：
+//   - v: 画布坐标
+//   - scale: 缩放倍数
+//
+// 返回值：
+//   - int: 像素坐标
+func px(v, scale float64) int {
+	return int(math.Round(v * scale))
+}
@@ -0,0 +1,73 @@
+// Package render 提供画布的 SVG 输出
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// SVG_FONT_FAMILY SVG 文字字体，按常见中文字体依次回退
+const SVG_FONT_FAMILY = "'Noto Serif CJK SC', 'Source Han Serif SC', 'Songti SC', SimSun, serif"
+
+// SVG 将画布输出为 SVG 文档
+// 返回值：
+//   - []byte: SVG 文档
+func (c *Canvas) SVG() []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="%s">`,
+		num(c.Width), num(c.Height), num(c.Width), num(c.Height), SVG_FONT_FAMILY)
+	buf.WriteByte('\n')
+
+	if c.Background != nil {
+		fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(c.Background))
+	}
+
+	for _, r := range c.Rects {
+		fill, stroke := "none", ""
+		if r.Fill != nil {
+			fill = hexColor(r.Fill)
+		}
+		if r.Stroke != nil && r.StrokeWidth > 0 {
+			stroke = fmt.Sprintf(` stroke="%s" stroke-width="%s"`, hexColor(r.Stroke), num(r.StrokeWidth))
+		}
+		fmt.Fprintf(&buf, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"%s/>`+"\n",
+			num(r.X), num(r.Y), num(r.W), num(r.H), fill, stroke)
+	}
+
+	for _, l := range c.Lines {
+		fmt.Fprintf(&buf, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+			num(l.X1), num(l.Y1), num(l.X2), num(l.Y2), hexColor(l.Color), num(l.Width))
+	}
+
+	for _, t := range c.Texts {
+		anchor := t.Anchor
+		if anchor == "" {
+			anchor = ANCHOR_START
+		}
+		weight := ""
+		if t.Bold {
+			weight = ` font-weight="bold"`
+		}
+		fmt.Fprintf(&buf, `<text x="%s" y="%s" font-size="%s" fill="%s" text-anchor="%s"%s>`,
+			num(t.X), num(t.Y), num(t.Size), hexColor(t.Color), anchor, weight)
+		xml.EscapeText(&buf, []byte(t.Content))
+		buf.WriteString("</text>\n")
+	}
+
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
+
+// num 格式化坐标，去除多余的小数位
+// 参数：
+//   - v: 数值
+//
+// 返回值：
+//   - string: 格式化结果
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
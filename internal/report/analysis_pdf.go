@@ -0,0 +1,281 @@
+// Package report 提供命理分析 PDF 报告的排版，包含封面、命盘、排盘要点与分析章节
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/internal/render"
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// PDF 版式常量（单位：毫米，字号单位：磅）
+const (
+	PDF_FONT_FAMILY = "cjk" // 嵌入字体名称
+
+	pdfMargin        = 18.0 // 页边距
+	pdfLineHeight    = 6.4  // 正文行高
+	pdfBodySize      = 11.0 // 正文字号
+	pdfHeadingSize   = 15.0 // 章节标题字号
+	pdfSubtitleSize  = 12.0 // 小标题字号
+	pdfCoverTitle    = 30.0 // 封面标题字号
+	pdfCoverSubtitle = 14.0 // 封面副标题字号
+)
+
+// PDF_FALLBACK_FONT_PATHS 未配置字体或配置的字体不可用时依次尝试的系统中文字体，均为 TrueType 轮廓的 TTF 文件
+var PDF_FALLBACK_FONT_PATHS = []string{
+	"/usr/share/fonts/truetype/noto/NotoSansSC-Regular.ttf",              // Debian/Ubuntu 手动安装的 Noto Sans SC
+	"/usr/share/fonts/truetype/droid/DroidSansFallbackFull.ttf",          // Debian/Ubuntu fonts-droid-fallback
+	"/usr/share/fonts/google-droid-sans-fonts/DroidSansFallbackFull.ttf", // Fedora/RHEL google-droid-sans-fonts
+	"/usr/share/fonts/TTF/DroidSansFallbackFull.ttf",                     // Arch Linux
+	"/System/Library/Fonts/Supplemental/Arial Unicode.ttf",               // macOS
+	"C:\\Windows\\Fonts\\simhei.ttf",                                     // Windows 黑体
+}
+
+// ErrPDFFontUnavailable 配置的字体与系统中文字体均不可用，无法生成 PDF 报告
+var ErrPDFFontUnavailable = errors.New("未找到可用的 PDF 中文字体")
+
+// AnalysisDocument 命理分析报告内容
+type AnalysisDocument struct {
+	Title       string         // 报告标题
+	Chart       *chart.Chart   // 八字命盘
+	Canvas      *render.Canvas // 命盘画布
+	Annotations []string       // 排盘要点
+	Sections    []*Section     // AI 分析章节
+	GeneratedAt time.Time      // 生成时间
+}
+
+// ResolvePDFFont 确定 PDF 报告使用的中文字体，配置的字体不存在或无法按 TrueType 加载时依次尝试 PDF_FALLBACK_FONT_PATHS
+// 参数：
+//   - configured: 配置的字体路径，可为空
+//
+// 返回值：
+//   - string: 实际使用的字体路径
+//   - error: 均不可用时返回 ErrPDFFontUnavailable，错误信息包含已尝试的路径及各自的失败原因
+func ResolvePDFFont(configured string) (string, error) {
+	candidates := PDF_FALLBACK_FONT_PATHS
+	if configured != "" {
+		candidates = append([]string{configured}, PDF_FALLBACK_FONT_PATHS...)
+	}
+	tried := make([]string, 0, len(candidates))
+	for _, path := range candidates {
+		err := checkPDFFont(path)
+		if err == nil {
+			return path, nil
+		}
+		tried = append(tried, fmt.Sprintf("%s（%v）", path, err))
+	}
+	return "", fmt.Errorf("%w，已尝试：%s；请安装 TrueType 中文字体并配置 RENDER.RENDER_PDF_FONT_PATH", ErrPDFFontUnavailable, strings.Join(tried, "、"))
+}
+
+// checkPDFFont 检查字体能否作为 TrueType 字体嵌入 PDF，TTC 字体集与 CFF 轮廓的 OTF 字体无法加载
+// 参数：
+//   - path: 字体路径
+//
+// 返回值：
+//   - error: 字体不存在或无法加载时返回错误
+func checkPDFFont(path string) (err error) {
+	fontData, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("文件不存在")
+		}
+		return fmt.Errorf("读取失败: %w", err)
+	}
+	if len(fontData) < 4 {
+		return errors.New("不是 TrueType 字体")
+	}
+	switch string(fontData[:4]) {
+	case "ttcf":
+		return errors.New("不支持 TTC 字体集")
+	case "OTTO":
+		return errors.New("不支持 CFF 轮廓的 OTF 字体")
+	case "\x00\x01\x00\x00", "true":
+	default:
+		return errors.New("不是 TrueType 字体")
+	}
+
+	// 字体表损坏时解析过程可能 panic，按加载失败处理
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("加载失败: %v", r)
+		}
+	}()
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(PDF_FONT_FAMILY, "", fontData)
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("加载失败: %w", err)
+	}
+	return nil
+}
+
+// BuildAnalysisPDF 生成命理分析 PDF 报告，字体以子集形式嵌入
+// 参数：
+//   - doc: 报告内容
+//   - fontPath: 中文字体路径，需为 TrueType 轮廓的 TTF 文件，通常由 ResolvePDFFont 确定
+//
+// 返回值：
+//   - []byte: PDF 文件内容
+//   - error: 生成过程中的错误
+func BuildAnalysisPDF(doc *AnalysisDocument, fontPath string) ([]byte, error) {
+	if fontPath == "" {
+		return nil, ErrPDFFontUnavailable
+	}
+	fontData, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("读取 PDF 字体失败: %w", err)
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(doc.Title, true)
+	pdf.SetCreator("metaphysics", true)
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin+4)
+	pdf.AddUTF8FontFromBytes(PDF_FONT_FAMILY, "", fontData)
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("加载 PDF 字体失败: %w", err)
+	}
+
+	pdf.SetFooterFunc(func() {
+		if pdf.PageNo() == 1 {
+			return
+		}
+		pdf.SetY(-pdfMargin + 4)
+		pdf.SetFont(PDF_FONT_FAMILY, "", 9)
+		pdf.SetTextColor(0x8A, 0x7F, 0x72)
+		pdf.CellFormat(0, 6, fmt.Sprintf("%s · 第 %d 页", doc.Title, pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	writeCover(pdf, doc)
+
+	pdf.AddPage()
+	writeHeading(pdf, "命盘")
+	pageWidth, _ := pdf.GetPageSize()
+	height := doc.Canvas.DrawPDF(pdf, PDF_FONT_FAMILY, pdfMargin, pdf.GetY(), pageWidth-2*pdfMargin)
+	pdf.SetY(pdf.GetY() + height + 6)
+
+	if len(doc.Annotations) > 0 {
+		writeHeading(pdf, "排盘要点")
+		for _, line := range doc.Annotations {
+			writeParagraph(pdf, "• "+line, 0)
+		}
+	}
+
+	for _, section := range doc.Sections {
+		pdf.AddPage()
+		writeHeading(pdf, section.Title)
+		for _, line := range section.Lines {
+			writeLine(pdf, line)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("生成 PDF 失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCover 绘制封面
+// 参数：
+//   - pdf: PDF 文档
+//   - doc: 报告内容
+func writeCover(pdf *fpdf.Fpdf, doc *AnalysisDocument) {
+	pdf.AddPage()
+	pageWidth, pageHeight := pdf.GetPageSize()
+
+	pdf.SetDrawColor(0x8B, 0x5A, 0x2B)
+	pdf.SetLineWidth(0.8)
+	pdf.Rect(pdfMargin/2, pdfMargin/2, pageWidth-pdfMargin, pageHeight-pdfMargin, "D")
+
+	pdf.SetY(pageHeight * 0.3)
+	pdf.SetFont(PDF_FONT_FAMILY, "", pdfCoverTitle)
+	pdf.SetTextColor(0x33, 0x2B, 0x22)
+	pdf.CellFormat(0, 16, doc.Title, "", 1, "C", false, 0, "")
+
+	c := doc.Chart
+	pdf.Ln(10)
+	pdf.SetFont(PDF_FONT_FAMILY, "", pdfCoverSubtitle+6)
+	pdf.CellFormat(0, 12, strings.Join(c.PillarStrings(), "  "), "", 1, "C", false, 0, "")
+
+	pdf.Ln(8)
+	pdf.SetFont(PDF_FONT_FAMILY, "", pdfCoverSubtitle)
+	pdf.SetTextColor(0x8A, 0x7F, 0x72)
+	subject := "乾造"
+	if c.Gender == utils.GENDER_FEMALE {
+		subject = "坤造"
+	}
+	if c.Name != "" {
+		subject = c.Name + " · " + subject
+	}
+	pdf.CellFormat(0, 9, subject, "", 1, "C", false, 0, "")
+	if !c.BirthTime.IsZero() {
+		calendar := "公历"
+		if c.Calendar == utils.CALENDAR_LUNAR {
+			calendar = "农历"
+		}
+		pdf.CellFormat(0, 9, calendar+" "+c.BirthTime.Format("2006-01-02 15:04"), "", 1, "C", false, 0, "")
+	}
+
+	pdf.SetY(pageHeight - pdfMargin*2.5)
+	pdf.SetFont(PDF_FONT_FAMILY, "", 10)
+	pdf.CellFormat(0, 6, "生成时间："+doc.GeneratedAt.Format("2006-01-02 15:04"), "", 1, "C", false, 0, "")
+}
+
+// writeHeading 绘制章节标题
+// 参数：
+//   - pdf: PDF 文档
+//   - title: 标题
+func writeHeading(pdf *fpdf.Fpdf, title string) {
+	pdf.SetFont(PDF_FONT_FAMILY, "", pdfHeadingSize)
+	pdf.SetTextColor(0x8B, 0x5A, 0x2B)
+	pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+
+	pageWidth, _ := pdf.GetPageSize()
+	y := pdf.GetY()
+	pdf.SetDrawColor(0xD9, 0xCB, 0xB0)
+	pdf.SetLineWidth(0.4)
+	pdf.Line(pdfMargin, y, pageWidth-pdfMargin, y)
+	pdf.Ln(4)
+}
+
+// writeLine 按 Markdown 行类型绘制正文行
+// 参数：
+//   - pdf: PDF 文档
+//   - line: 已清理的文本行
+func writeLine(pdf *fpdf.Fpdf, line string) {
+	switch {
+	case line == "":
+		pdf.Ln(pdfLineHeight / 2)
+	case strings.HasPrefix(line, "#"):
+		pdf.Ln(1)
+		pdf.SetFont(PDF_FONT_FAMILY, "", pdfSubtitleSize)
+		pdf.SetTextColor(0x33, 0x2B, 0x22)
+		pdf.MultiCell(0, pdfLineHeight+1, strings.TrimSpace(strings.TrimLeft(line, "#")), "", "L", false)
+	case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+		writeParagraph(pdf, "• "+strings.TrimSpace(line[2:]), 4)
+	default:
+		writeParagraph(pdf, line, 0)
+	}
+}
+
+// writeParagraph 绘制正文段落，超出宽度时自动换行
+// 参数：
+//   - pdf: PDF 文档
+//   - text: 文本
+//   - indent: 左缩进（毫米）
+func writeParagraph(pdf *fpdf.Fpdf, text string, indent float64) {
+	pdf.SetFont(PDF_FONT_FAMILY, "", pdfBodySize)
+	pdf.SetTextColor(0x33, 0x2B, 0x22)
+	pdf.SetX(pdfMargin + indent)
+	pdf.MultiCell(0, pdfLineHeight, text, "", "L", false)
+}
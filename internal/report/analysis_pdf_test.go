@@ -0,0 +1,54 @@
+// Package report PDF 字体选择测试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// writeFont 在临时目录写入字体文件
+// 参数：
+//   - t: 测试上下文
+//   - name: 文件名
+//   - data: 文件内容
+//
+// 返回值：
+//   - string: 文件路径
+func writeFont(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("写入字体文件失败: %v", err)
+	}
+	return path
+}
+
+func TestResolvePDFFontSkipsUnloadableFonts(t *testing.T) {
+	ttf := writeFont(t, "regular.ttf", goregular.TTF)
+	ttc := writeFont(t, "collection.ttc", append([]byte("ttcf"), goregular.TTF[4:]...))
+	otf := writeFont(t, "cff.otf", append([]byte("OTTO"), goregular.TTF[4:]...))
+	broken := writeFont(t, "broken.ttf", goregular.TTF[:64])
+
+	original := PDF_FALLBACK_FONT_PATHS
+	t.Cleanup(func() { PDF_FALLBACK_FONT_PATHS = original })
+	PDF_FALLBACK_FONT_PATHS = []string{filepath.Join(t.TempDir(), "missing.ttf"), otf, broken, ttf}
+
+	got, err := ResolvePDFFont(ttc)
+	if err != nil {
+		t.Fatalf("应回退到可加载的字体: %v", err)
+	}
+	if got != ttf {
+		t.Errorf("ResolvePDFFont = %s, 期望 %s", got, ttf)
+	}
+
+	PDF_FALLBACK_FONT_PATHS = []string{otf, broken}
+	if _, err := ResolvePDFFont(ttc); !errors.Is(err, ErrPDFFontUnavailable) {
+		t.Errorf("err = %v, 期望 ErrPDFFontUnavailable", err)
+	}
+}
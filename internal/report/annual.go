@@ -0,0 +1,129 @@
+// Package report 提供流年报告的规则分析，为 AI 撰写报告准备逐月要点
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	lunarCalendar "github.com/6tail/lunar-go/calendar"
+
+	"github.com/Done-0/metaphysics/internal/fortune"
+	"github.com/Done-0/metaphysics/internal/utils"
+)
+
+// Annual 流年规则分析结果
+type Annual struct {
+	Year             int               `json:"year"`              // 流年所在公历年份
+	YearPillar       string            `json:"year_pillar"`       // 流年干支
+	YearTenGod       string            `json:"year_ten_god"`      // 流年天干十神
+	YearScore        int               `json:"year_score"`        // 流年综合得分
+	YearLevel        string            `json:"year_level"`        // 流年综合等级
+	YearInteractions []string          `json:"year_interactions"` // 流年与原局的关系
+	LuckPillar       string            `json:"luck_pillar"`       // 当年所行大运
+	Months           []*MonthHighlight `json:"months"`            // 逐月要点
+}
+
+// MonthHighlight 流月要点
+type MonthHighlight struct {
+	Index        int            `json:"index"`        // 月序，立春所在月为 1
+	GanZhi       string         `json:"gan_zhi"`      // 流月干支
+	JieName      string         `json:"jie_name"`     // 起始节令
+	StartDate    string         `json:"start_date"`   // 起始日期
+	EndDate      string         `json:"end_date"`     // 结束日期
+	TenGod       string         `json:"ten_god"`      // 流月天干十神
+	Scores       map[string]int `json:"scores"`       // 各维度得分
+	Overall      int            `json:"overall"`      // 综合得分
+	Level        string         `json:"level"`        // 综合等级
+	Interactions []string       `json:"interactions"` // 流月与原局的关系
+}
+
+// String 格式化为一行逐月要点
+// 返回值：
+//   - string: 逐月要点
+func (m *MonthHighlight) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d月 %s（%s 至 %s，%s起）：%s当值，综合%s（%d 分）",
+		m.Index, m.GanZhi, m.StartDate, m.EndDate, m.JieName, m.TenGod, m.Level, m.Overall))
+	for _, d := range fortune.DIMENSIONS {
+		sb.WriteString(fmt.Sprintf("，%s %d", fortune.GetDimensionName(d), m.Scores[d]))
+	}
+	if len(m.Interactions) > 0 {
+		sb.WriteString("；")
+		sb.WriteString(strings.Join(m.Interactions, "，"))
+	}
+	return sb.String()
+}
+
+// BuildAnnual 计算某一流年的规则分析
+// 参数：
+//   - chart: 原局信息
+//   - luckPillars: 大运列表，可为空
+//   - year: 流年所在公历年份
+//
+// 返回值：
+//   - *Annual: 流年规则分析结果
+//   - error: 原局信息不完整时返回错误
+func BuildAnnual(chart *fortune.Chart, luckPillars []*utils.LuckPillar, year int) (*Annual, error) {
+	months := utils.GetMonthlyPillars(year)
+	yearPillar := utils.GetAnnualGanZhi(months[0].Start.AddDate(0, 0, 1))
+
+	yearResult, err := fortune.EvaluatePillar(chart, "流年", yearPillar)
+	if err != nil {
+		return nil, err
+	}
+
+	annual := &Annual{
+		Year:             year,
+		YearPillar:       yearPillar,
+		YearTenGod:       yearResult.TenGod,
+		YearScore:        yearResult.Overall,
+		YearLevel:        yearResult.Level,
+		YearInteractions: yearResult.Interactions,
+		Months:           make([]*MonthHighlight, 0, len(months)),
+	}
+	if luck := utils.FindLuckPillar(luckPillars, year); luck != nil {
+		annual.LuckPillar = luck.GanZhi
+	}
+
+	for i, month := range months {
+		result, err := fortune.EvaluatePillar(chart, "流月", month.GanZhi)
+		if err != nil {
+			return nil, err
+		}
+		annual.Months = append(annual.Months, &MonthHighlight{
+			Index:        i + 1,
+			GanZhi:       month.GanZhi,
+			JieName:      month.JieName,
+			StartDate:    month.Start.Format(time.DateOnly),
+			EndDate:      month.End.Format(time.DateOnly),
+			TenGod:       result.TenGod,
+			Scores:       result.Scores,
+			Overall:      result.Overall,
+			Level:        result.Level,
+			Interactions: result.Interactions,
+		})
+	}
+
+	return annual, nil
+}
+
+// DueYear 判断当前是否处于农历新年前的报告生成窗口
+// 参数：
+//   - now: 当前时间，按其所在时区计算日期
+//   - leadDays: 提前天数
+//
+// 返回值：
+//   - int: 即将到来的流年所在公历年份
+//   - bool: 是否处于生成窗口
+func DueYear(now time.Time, leadDays int) (int, bool) {
+	nextYear := lunarCalendar.NewSolarFromDate(now).GetLunar().GetYear() + 1
+	newYear := utils.GetLunarNewYear(nextYear)
+	newYearDate := time.Date(newYear.Year(), newYear.Month(), newYear.Day(), 0, 0, 0, 0, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	days := int(newYearDate.Sub(today).Hours() / 24)
+	return nextYear, days > 0 && days <= leadDays
+}
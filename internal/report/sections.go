@@ -0,0 +1,164 @@
+// Package report 提供 AI 分析文本的分段解析，将 Markdown 拆分为带标题的章节
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	"regexp"
+	"strings"
+)
+
+// 默认章节标题
+const (
+	SECTION_PREFACE = "总述"   // 首个标题前的内容
+	SECTION_DEFAULT = "命理分析" // 无法识别标题时的整体章节
+)
+
+var (
+	// bracketHeadingPattern 匹配形如 "1️⃣【性格与根性分析】" 或 "### 【性格与根性分析】" 的章节标题，前缀需含序号或 #
+	bracketHeadingPattern = regexp.MustCompile(`^([#*\s\d.、\x{FE0F}\x{20E3}\x{1F51F}]*)【([^】]+)】(.*)$`)
+	// markdownHeadingPattern 匹配一、二级 Markdown 标题
+	markdownHeadingPattern = regexp.MustCompile(`^#{1,2}\s+(.+)$`)
+	// horizontalRulePattern 匹配 Markdown 分隔线
+	horizontalRulePattern = regexp.MustCompile(`^([-*_])\s*(\s*[-*_]){2,}$`)
+	// emphasisReplacer 去除 Markdown 强调标记
+	emphasisReplacer = strings.NewReplacer("**", "", "__", "", "`", "")
+)
+
+// Section 分析章节
+type Section struct {
+	Title string   `json:"title"` // 章节标题
+	Lines []string `json:"lines"` // 正文行，已去除 Markdown 强调标记与 emoji
+}
+
+// ParseSections 将 AI 分析文本拆分为章节，优先识别【】标题，其次识别 Markdown 标题
+// 参数：
+//   - content: 分析文本
+//
+// 返回值：
+//   - []*Section: 章节列表，至少包含一个章节
+func ParseSections(content string) []*Section {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	sections := splitSections(lines, func(line string) (string, string, bool) {
+		m := bracketHeadingPattern.FindStringSubmatch(line)
+		if m == nil || !strings.ContainsAny(m[1], "#0123456789\U0001F51F") {
+			return "", "", false
+		}
+		return m[2], m[3], true
+	})
+	if len(sections) == 0 {
+		sections = splitSections(lines, func(line string) (string, string, bool) {
+			m := markdownHeadingPattern.FindStringSubmatch(line)
+			if m == nil {
+				return "", "", false
+			}
+			return m[1], "", true
+		})
+	}
+	if len(sections) == 0 {
+		section := &Section{Title: SECTION_DEFAULT}
+		for _, line := range lines {
+			section.Lines = append(section.Lines, cleanLine(line))
+		}
+		return []*Section{trimSection(section)}
+	}
+	return sections
+}
+
+// splitSections 按标题匹配函数拆分章节
+// 参数：
+//   - lines: 文本行
+//   - heading: 标题匹配函数，返回标题、标题行剩余内容与是否匹配
+//
+// 返回值：
+//   - []*Section: 章节列表，未识别到任何标题时返回空
+func splitSections(lines []string, heading func(line string) (string, string, bool)) []*Section {
+	var sections []*Section
+	current := &Section{Title: SECTION_PREFACE}
+	found := false
+
+	for _, line := range lines {
+		title, rest, ok := heading(strings.TrimSpace(line))
+		if !ok {
+			current.Lines = append(current.Lines, cleanLine(line))
+			continue
+		}
+
+		found = true
+		if current = trimSection(current); len(current.Lines) > 0 {
+			sections = append(sections, current)
+		}
+		current = &Section{Title: cleanLine(title)}
+		if rest = strings.TrimLeft(cleanLine(rest), "：:"); rest != "" {
+			current.Lines = append(current.Lines, rest)
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	if current = trimSection(current); len(current.Lines) > 0 || current.Title != SECTION_PREFACE {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// trimSection 去除章节首尾空行
+// 参数：
+//   - s: 章节
+//
+// 返回值：
+//   - *Section: 章节
+func trimSection(s *Section) *Section {
+	start, end := 0, len(s.Lines)
+	for start < end && s.Lines[start] == "" {
+		start++
+	}
+	for end > start && s.Lines[end-1] == "" {
+		end--
+	}
+	s.Lines = s.Lines[start:end]
+	return s
+}
+
+// cleanLine 去除 Markdown 强调标记、引用符号、分隔线与 emoji，PDF 字体通常不含 emoji 字形
+// 参数：
+//   - line: 文本行
+//
+// 返回值：
+//   - string: 清理后的文本行
+func cleanLine(line string) string {
+	line = emphasisReplacer.Replace(strings.TrimSpace(line))
+	line = strings.TrimSpace(strings.TrimLeft(line, ">"))
+	if horizontalRulePattern.MatchString(line) {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, r := range line {
+		if isEmoji(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// isEmoji 判断字符是否为 emoji 或其修饰符
+// 参数：
+//   - r: 字符
+//
+// 返回值：
+//   - bool: 是否为 emoji
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return true
+	case r >= 0x2600 && r <= 0x27BF:
+		return true
+	case r == 0xFE0F || r == 0x20E3 || r == 0x200D:
+		return true
+	}
+	return false
+}
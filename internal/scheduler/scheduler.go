@@ -0,0 +1,159 @@
+// Package scheduler 提供按日执行的定时任务调度
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Done-0/metaphysics/internal/global"
+)
+
+// 调度相关常量
+const (
+	LOCK_KEY_PREFIX = "scheduler:lock:" // 分布式锁键前缀
+	LOCK_TTL        = 23 * time.Hour    // 锁过期时间，保证多实例部署时每日仅执行一次
+)
+
+// Job 定时任务函数
+type Job func(ctx context.Context, runAt time.Time) error
+
+// dailyJob 每日任务
+type dailyJob struct {
+	name     string         // 任务名称
+	hour     int            // 执行时刻（时）
+	minute   int            // 执行时刻（分）
+	location *time.Location // 时区
+	job      Job            // 任务函数
+}
+
+// Scheduler 定时任务调度器
+type Scheduler struct {
+	jobs []*dailyJob
+	wg   sync.WaitGroup
+}
+
+// New 创建调度器
+// 返回值：
+//   - *Scheduler: 调度器
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// AddDailyJob 注册每日任务
+// 参数：
+//   - name: 任务名称，用作分布式锁键
+//   - at: 执行时刻，格式 HH:MM
+//   - location: 时区，为 nil 时使用本地时区
+//   - job: 任务函数
+//
+// 返回值：
+//   - error: 执行时刻格式错误
+func (s *Scheduler) AddDailyJob(name, at string, location *time.Location, job Job) error {
+	t, err := time.Parse("15:04", at)
+	if err != nil {
+		return fmt.Errorf("任务 %s 执行时刻格式错误: %w", name, err)
+	}
+	if location == nil {
+		location = time.Local
+	}
+
+	s.jobs = append(s.jobs, &dailyJob{
+		name:     name,
+		hour:     t.Hour(),
+		minute:   t.Minute(),
+		location: location,
+		job:      job,
+	})
+	return nil
+}
+
+// Start 启动全部任务，ctx 取消后停止调度
+// 参数：
+//   - ctx: 调度上下文
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func(j *dailyJob) {
+			defer s.wg.Done()
+			s.loop(ctx, j)
+		}(j)
+	}
+}
+
+// Wait 等待全部任务退出
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// loop 按日循环执行任务
+// 参数：
+//   - ctx: 调度上下文
+//   - j: 每日任务
+func (s *Scheduler) loop(ctx context.Context, j *dailyJob) {
+	for {
+		next := nextRun(time.Now().In(j.location), j.hour, j.minute)
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.run(ctx, j, next)
+		}
+	}
+}
+
+// run 获取当日锁后执行任务
+// 参数：
+//   - ctx: 调度上下文
+//   - j: 每日任务
+//   - runAt: 本次计划执行时刻
+func (s *Scheduler) run(ctx context.Context, j *dailyJob, runAt time.Time) {
+	if global.RedisClient != nil {
+		key := LOCK_KEY_PREFIX + j.name + ":" + runAt.Format(time.DateOnly)
+		ok, err := global.RedisClient.SetNX(ctx, key, time.Now().Unix(), LOCK_TTL).Result()
+		if err != nil {
+			global.SysLog.Errorf("任务 %s 获取锁失败: %v", j.name, err)
+			return
+		}
+		if !ok {
+			global.SysLog.Infof("任务 %s 今日已由其他实例执行，跳过", j.name)
+			return
+		}
+	}
+
+	global.SysLog.Infof("任务 %s 开始执行", j.name)
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			global.SysLog.Errorf("任务 %s 执行异常: %v", j.name, r)
+		}
+	}()
+
+	if err := j.job(ctx, runAt); err != nil {
+		global.SysLog.Errorf("任务 %s 执行失败: %v", j.name, err)
+		return
+	}
+	global.SysLog.Infof("任务 %s 执行完成，耗时 %s", j.name, time.Since(start))
+}
+
+// nextRun 计算下一次执行时刻
+// 参数：
+//   - now: 当前时间
+//   - hour: 时
+//   - minute: 分
+//
+// 返回值：
+//   - time.Time: 下一次执行时刻
+func nextRun(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -15,45 +15,46 @@ const (
 	CALENDAR_SOLAR = "solar" // 公历
 )
 
-// CalculateBazi 计算八字
+// CalculatePillars 计算四柱干支
 // 参数：
 //   - birthTime: 出生时间
 //   - calendar: 日历类型 (lunar/solar)
 //
 // 返回值：
-//   - map[string]string: 八字信息
-func CalculateBazi(birthTime time.Time, calendar string) map[string]string {
-	var lunar *lunarCalendar.Lunar
+//   - []string: 四柱干支（年、月、日、时）
+func CalculatePillars(birthTime time.Time, calendar string) []string {
+	eightChar := getLunar(birthTime, calendar).GetEightChar()
+	return []string{eightChar.GetYear(), eightChar.GetMonth(), eightChar.GetDay(), eightChar.GetTime()}
+}
+
+// ToSolarTime 将出生时间统一转换为公历时间
+// 参数：
+//   - birthTime: 出生时间
+//   - calendar: 日历类型 (lunar/solar)
+//
+// 返回值：
+//   - time.Time: 公历时间，公历输入原样返回
+func ToSolarTime(birthTime time.Time, calendar string) time.Time {
+	if calendar == CALENDAR_SOLAR {
+		return birthTime
+	}
+	return solarToTime(getLunar(birthTime, calendar).GetSolar())
+}
 
-	// 根据日历类型获取农历对象，默认使用农历
+// getLunar 根据日历类型获取农历对象，默认使用农历
+// 参数：
+//   - birthTime: 出生时间
+//   - calendar: 日历类型 (lunar/solar)
+//
+// 返回值：
+//   - *lunarCalendar.Lunar: 农历对象
+func getLunar(birthTime time.Time, calendar string) *lunarCalendar.Lunar {
 	switch calendar {
 	case CALENDAR_SOLAR:
-		lunar = lunarCalendar.NewSolarFromDate(birthTime).GetLunar()
+		return lunarCalendar.NewSolarFromDate(birthTime).GetLunar()
 	default:
 		year, month, day := birthTime.Date()
 		hour, minute, second := birthTime.Clock()
-		lunar = lunarCalendar.NewLunar(year, int(month), day, hour, minute, second)
-	}
-
-	eightChar := lunar.GetEightChar()
-
-	// 四柱\天干\地支
-	result := map[string]string{
-		"year":  eightChar.GetYear(),
-		"month": eightChar.GetMonth(),
-		"day":   eightChar.GetDay(),
-		"hour":  eightChar.GetTime(),
-
-		"year_gan":  eightChar.GetYearGan(),
-		"month_gan": eightChar.GetMonthGan(),
-		"day_gan":   eightChar.GetDayGan(),
-		"hour_gan":  eightChar.GetTimeGan(),
-
-		"year_zhi":  eightChar.GetYearZhi(),
-		"month_zhi": eightChar.GetMonthZhi(),
-		"day_zhi":   eightChar.GetDayZhi(),
-		"hour_zhi":  eightChar.GetTimeZhi(),
+		return lunarCalendar.NewLunar(year, int(month), day, hour, minute, second)
 	}
-
-	return result
 }
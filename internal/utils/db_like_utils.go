@@ -0,0 +1,36 @@
+// Package utils 提供 LIKE 模糊查询的转义工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// likeEscaper 转义 LIKE 模式中的转义字符与通配符，转义字符须最先处理
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike 转义用户输入中的 %、_ 与 \，使其在 LIKE 模式中按字面匹配，需配合 LikeEscapeClause 使用
+// 参数：
+//   - s: 用户输入
+//
+// 返回值：
+//   - string: 转义后的文本
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// LikeEscapeClause 返回指定反斜杠为转义字符的 ESCAPE 子句，MySQL 字符串字面量中的反斜杠需再转义一次
+// 参数：
+//   - db: 数据库连接
+//
+// 返回值：
+//   - string: ESCAPE 子句
+func LikeEscapeClause(db *gorm.DB) string {
+	if db.Dialector.Name() == "mysql" {
+		return `ESCAPE '\\'`
+	}
+	return `ESCAPE '\'`
+}
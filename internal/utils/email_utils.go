@@ -6,6 +6,7 @@ package utils
 import (
 	"crypto/tls"
 	"fmt"
+	"io"
 	"math/rand"
 	"time"
 
@@ -40,6 +41,39 @@ var emailServers = map[string]struct {
 //   - bool: 发送成功返回 true，失败返回 false
 //   - error: 执行过程中的错误
 func SendEmail(content string, toEmails []string) (bool, error) {
+	return SendEmailWithSubject(EMAIL_SUBJECT, content, toEmails)
+}
+
+// EmailAttachment 邮件附件
+type EmailAttachment struct {
+	FileName string // 文件名
+	Content  []byte // 文件内容
+}
+
+// SendEmailWithSubject 使用指定主题发送邮件到指定邮箱
+// 参数：
+//   - subject: 邮件主题
+//   - content: 邮件内容
+//   - toEmails: 目标邮箱
+//
+// 返回值：
+//   - bool: 发送成功返回 true，失败返回 false
+//   - error: 执行过程中的错误
+func SendEmailWithSubject(subject, content string, toEmails []string) (bool, error) {
+	return SendEmailWithAttachments(subject, content, toEmails)
+}
+
+// SendEmailWithAttachments 使用指定主题发送带附件的邮件到指定邮箱
+// 参数：
+//   - subject: 邮件主题
+//   - content: 邮件内容
+//   - toEmails: 目标邮箱
+//   - attachments: 附件
+//
+// 返回值：
+//   - bool: 发送成功返回 true，失败返回 false
+//   - error: 执行过程中的错误
+func SendEmailWithAttachments(subject, content string, toEmails []string, attachments ...*EmailAttachment) (bool, error) {
 	config, err := configs.GetConfig()
 	if err != nil {
 		global.SysLog.Errorf("加载邮件配置失败: %v", err)
@@ -54,8 +88,15 @@ func SendEmail(content string, toEmails []string) (bool, error) {
 	m := gomail.NewMessage()
 	m.SetHeader("From", config.AppConfig.Email.FromEmail)
 	m.SetHeader("To", toEmails...)
-	m.SetHeader("Subject", EMAIL_SUBJECT)
+	m.SetHeader("Subject", subject)
 	m.SetBody("text/plain", content)
+	for _, attachment := range attachments {
+		data := attachment.Content
+		m.Attach(attachment.FileName, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}))
+	}
 
 	// 配置发送器
 	d := gomail.NewDialer(
@@ -0,0 +1,227 @@
+// Package utils 提供干支刑冲合害关系判断功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"fmt"
+
+	"github.com/6tail/lunar-go/LunarUtil"
+)
+
+// 干支关系常量
+const (
+	RELATION_GAN_HE    = "天干五合" // 天干五合
+	RELATION_GAN_CHONG = "天干相冲" // 天干相冲
+	RELATION_ZHI_HE    = "地支六合" // 地支六合
+	RELATION_ZHI_CHONG = "地支六冲" // 地支六冲
+	RELATION_ZHI_XING  = "地支相刑" // 地支相刑
+	RELATION_ZHI_HAI   = "地支相害" // 地支相害
+	RELATION_FU_YIN    = "伏吟"   // 干支完全相同
+)
+
+// NATAL_PILLAR_NAMES 原局四柱名称（年、月、日、时）
+var NATAL_PILLAR_NAMES = []string{"年柱", "月柱", "日柱", "时柱"}
+
+// PillarInteraction 岁运干支与原局某柱之间的关系
+type PillarInteraction struct {
+	Source       string `json:"source"`         // 来源（大运/流年/流月/流日）
+	SourceGanZhi string `json:"source_gan_zhi"` // 来源干支
+	Target       string `json:"target"`         // 原局柱名
+	TargetGanZhi string `json:"target_gan_zhi"` // 原局干支
+	Relation     string `json:"relation"`       // 关系
+}
+
+// String 返回关系描述，如 "流年庚子与日柱壬午地支六冲"
+// 返回值：
+//   - string: 关系描述
+func (p *PillarInteraction) String() string {
+	return fmt.Sprintf("%s%s与%s%s%s", p.Source, p.SourceGanZhi, p.Target, p.TargetGanZhi, p.Relation)
+}
+
+// zhiXing 地支相刑（寅巳申、丑戌未三刑，子卯相刑，辰午酉亥自刑）
+var zhiXing = map[string][]string{
+	"寅": {"巳", "申"},
+	"巳": {"寅", "申"},
+	"申": {"寅", "巳"},
+	"丑": {"戌", "未"},
+	"戌": {"丑", "未"},
+	"未": {"丑", "戌"},
+	"子": {"卯"},
+	"卯": {"子"},
+	"辰": {"辰"},
+	"午": {"午"},
+	"酉": {"酉"},
+	"亥": {"亥"},
+}
+
+// zhiHai 地支六害
+var zhiHai = map[string]string{
+	"子": "未", "未": "子",
+	"丑": "午", "午": "丑",
+	"寅": "巳", "巳": "寅",
+	"卯": "辰", "辰": "卯",
+	"申": "亥", "亥": "申",
+	"酉": "戌", "戌": "酉",
+}
+
+// SplitGanZhi 拆分干支
+// 参数：
+//   - ganZhi: 干支，如 "甲子"
+//
+// 返回值：
+//   - string: 天干
+//   - string: 地支
+func SplitGanZhi(ganZhi string) (string, string) {
+	runes := []rune(ganZhi)
+	if len(runes) != 2 {
+		return "", ""
+	}
+	return string(runes[0]), string(runes[1])
+}
+
+// GetGanRelations 获取两个天干之间的关系
+// 参数：
+//   - a: 天干
+//   - b: 天干
+//
+// 返回值：
+//   - []string: 关系列表
+func GetGanRelations(a, b string) []string {
+	ia, ib := ganIndex(a), ganIndex(b)
+	if ia < 0 || ib < 0 {
+		return nil
+	}
+
+	var relations []string
+	if LunarUtil.HE_GAN_5[ia] == b {
+		relations = append(relations, RELATION_GAN_HE)
+	}
+	// 甲庚、乙辛、丙壬、丁癸相冲，戊己居中无冲
+	if LunarUtil.CHONG_GAN_4[ia] == b {
+		relations = append(relations, RELATION_GAN_CHONG)
+	}
+	return relations
+}
+
+// GetZhiRelations 获取两个地支之间的关系
+// 参数：
+//   - a: 地支
+//   - b: 地支
+//
+// 返回值：
+//   - []string: 关系列表
+func GetZhiRelations(a, b string) []string {
+	ia, ib := zhiIndex(a), zhiIndex(b)
+	if ia < 0 || ib < 0 {
+		return nil
+	}
+
+	var relations []string
+	if LunarUtil.HE_ZHI_6[ia] == b {
+		relations = append(relations, RELATION_ZHI_HE)
+	}
+	if LunarUtil.CHONG[ia] == b {
+		relations = append(relations, RELATION_ZHI_CHONG)
+	}
+	if containsZhi(zhiXing[a], b) {
+		relations = append(relations, RELATION_ZHI_XING)
+	}
+	if zhiHai[a] == b {
+		relations = append(relations, RELATION_ZHI_HAI)
+	}
+	return relations
+}
+
+// GetGanZhiRelations 获取两柱干支之间的全部关系
+// 参数：
+//   - a: 干支
+//   - b: 干支
+//
+// 返回值：
+//   - []string: 关系列表，干支完全相同时仅返回伏吟
+func GetGanZhiRelations(a, b string) []string {
+	if a == "" || b == "" {
+		return nil
+	}
+	if a == b {
+		return []string{RELATION_FU_YIN}
+	}
+
+	ganA, zhiA := SplitGanZhi(a)
+	ganB, zhiB := SplitGanZhi(b)
+	return append(GetGanRelations(ganA, ganB), GetZhiRelations(zhiA, zhiB)...)
+}
+
+// FindPillarInteractions 查找某一岁运干支与原局四柱之间的全部关系
+// 参数：
+//   - source: 来源名称（大运/流年/流月/流日）
+//   - sourceGanZhi: 来源干支
+//   - natal: 原局四柱干支（年、月、日、时）
+//
+// 返回值：
+//   - []*PillarInteraction: 关系列表
+func FindPillarInteractions(source, sourceGanZhi string, natal []string) []*PillarInteraction {
+	var interactions []*PillarInteraction
+	for i, pillar := range natal {
+		if i >= len(NATAL_PILLAR_NAMES) {
+			break
+		}
+		for _, rel := range GetGanZhiRelations(sourceGanZhi, pillar) {
+			interactions = append(interactions, &PillarInteraction{
+				Source:       source,
+				SourceGanZhi: sourceGanZhi,
+				Target:       NATAL_PILLAR_NAMES[i],
+				TargetGanZhi: pillar,
+				Relation:     rel,
+			})
+		}
+	}
+	return interactions
+}
+
+// ganIndex 获取天干序号（甲为 0）
+// 参数：
+//   - gan: 天干
+//
+// 返回值：
+//   - int: 序号，未知天干返回 -1
+func ganIndex(gan string) int {
+	for i, g := range LunarUtil.GAN[1:] {
+		if g == gan {
+			return i
+		}
+	}
+	return -1
+}
+
+// zhiIndex 获取地支序号（子为 0）
+// 参数：
+//   - zhi: 地支
+//
+// 返回值：
+//   - int: 序号，未知地支返回 -1
+func zhiIndex(zhi string) int {
+	for i, z := range LunarUtil.ZHI[1:] {
+		if z == zhi {
+			return i
+		}
+	}
+	return -1
+}
+
+// containsZhi 判断地支列表是否包含目标地支
+// 参数：
+//   - list: 地支列表
+//   - target: 目标地支
+//
+// 返回值：
+//   - bool: 是否包含
+func containsZhi(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+// Package utils 提供后台任务相关工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewJobContext 为后台任务创建 Gin 上下文，使 mapper 与 service 可在 HTTP 请求之外复用
+// 参数：
+//   - ctx: 任务上下文
+//
+// 返回值：
+//   - *gin.Context: 不绑定任何请求与响应的 Gin 上下文
+func NewJobContext(ctx context.Context) *gin.Context {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	return &gin.Context{Request: req}
+}
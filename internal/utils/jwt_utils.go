@@ -5,6 +5,7 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ var (
 	// 密钥和有效期配置
 	accessSecret      = []byte("jank-blog-secret")         // Access Token 使用的密钥
 	refreshSecret     = []byte("jank-blog-refresh-secret") // Refresh Token 使用的密钥
+	shareSecret       = []byte("jank-blog-share-secret")   // 分享链接使用的密钥
 	accessExpireTime  = time.Hour * 2                      // Access Token 有效期
 	refreshExpireTime = time.Hour * 48                     // Refresh Token 有效期
 	clockSkew         = 5 * time.Second                    // 允许的时间偏差量
@@ -139,6 +141,54 @@ func ParseAccountFromJWT(tokenString string) (int64, error) {
 	return int64(userID), nil
 }
 
+// GenerateShareToken 生成分享链接令牌，令牌仅携带分享记录 ID，撤销状态以数据库为准
+// 参数：
+//   - shareID: 分享记录 ID
+//   - expireAt: 过期时间
+//
+// 返回值：
+//   - string: 分享令牌
+//   - error: 生成过程中的错误
+func GenerateShareToken(shareID int64, expireAt time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"share_id": strconv.FormatInt(shareID, 10),
+		"exp":      expireAt.UTC().Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(shareSecret)
+}
+
+// ParseShareToken 验证分享链接令牌并提取分享记录 ID
+// 参数：
+//   - tokenString: 分享令牌
+//
+// 返回值：
+//   - int64: 分享记录 ID
+//   - error: 令牌无效或已过期
+func ParseShareToken(tokenString string) (int64, error) {
+	token, err := validateToken(tokenString, shareSecret)
+	if err != nil {
+		return 0, fmt.Errorf("分享链接无效或已过期")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return 0, fmt.Errorf("分享链接无效")
+	}
+
+	// 以字符串存储 ID，避免 snowflake ID 经 JSON 数字解析后丢失精度
+	idString, ok := claims["share_id"].(string)
+	if !ok {
+		return 0, fmt.Errorf("分享链接缺少 share_id")
+	}
+	shareID, err := strconv.ParseInt(idString, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("分享链接无效")
+	}
+
+	return shareID, nil
+}
+
 // generateToken 通用的 token 生成函数
 // 参数：
 //   - userID: 用户ID
@@ -0,0 +1,167 @@
+// Package utils 提供大运与流年计算相关功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"time"
+
+	lunarCalendar "github.com/6tail/lunar-go/calendar"
+)
+
+// 性别常量
+const (
+	GENDER_MALE   = "male"   // 男
+	GENDER_FEMALE = "female" // 女
+)
+
+// LuckPillar 大运
+type LuckPillar struct {
+	GanZhi    string `json:"gan_zhi"`    // 大运干支
+	Gan       string `json:"gan"`        // 大运天干
+	Zhi       string `json:"zhi"`        // 大运地支
+	StartYear int    `json:"start_year"` // 起始年份（公历）
+	EndYear   int    `json:"end_year"`   // 结束年份（公历）
+	StartAge  int    `json:"start_age"`  // 起始虚岁
+	EndAge    int    `json:"end_age"`    // 结束虚岁
+}
+
+// CalculateLuckPillars 计算大运，不含起运前的童限
+// 参数：
+//   - birthTime: 出生时间
+//   - calendar: 日历类型 (lunar/solar)
+//   - gender: 性别 (male/female)
+//
+// 返回值：
+//   - []*LuckPillar: 按时间排序的大运列表，出生时间未知时为空
+func CalculateLuckPillars(birthTime time.Time, calendar, gender string) []*LuckPillar {
+	// 出生时间未知时无法确定起运
+	if birthTime.IsZero() {
+		return nil
+	}
+
+	yunGender := 0
+	if gender == GENDER_MALE {
+		yunGender = 1
+	}
+
+	daYuns := getLunar(birthTime, calendar).GetEightChar().GetYun(yunGender).GetDaYun()
+	pillars := make([]*LuckPillar, 0, len(daYuns))
+	for _, daYun := range daYuns {
+		ganZhi := daYun.GetGanZhi()
+		if ganZhi == "" {
+			continue
+		}
+		gan, zhi := SplitGanZhi(ganZhi)
+		pillars = append(pillars, &LuckPillar{
+			GanZhi:    ganZhi,
+			Gan:       gan,
+			Zhi:       zhi,
+			StartYear: daYun.GetStartYear(),
+			EndYear:   daYun.GetEndYear(),
+			StartAge:  daYun.GetStartAge(),
+			EndAge:    daYun.GetEndAge(),
+		})
+	}
+	return pillars
+}
+
+// FindLuckPillar 查找某年所行大运
+// 参数：
+//   - pillars: 大运列表
+//   - year: 公历年份
+//
+// 返回值：
+//   - *LuckPillar: 所行大运，尚未起运时返回 nil
+func FindLuckPillar(pillars []*LuckPillar, year int) *LuckPillar {
+	for _, p := range pillars {
+		if year >= p.StartYear && year <= p.EndYear {
+			return p
+		}
+	}
+	return nil
+}
+
+// GetAnnualGanZhi 获取某一时刻的流年干支，以立春交节时刻为界
+// 参数：
+//   - t: 公历时间
+//
+// 返回值：
+//   - string: 流年干支
+func GetAnnualGanZhi(t time.Time) string {
+	return lunarCalendar.NewSolarFromDate(t).GetLunar().GetYearInGanZhiExact()
+}
+
+// GetMonthlyGanZhi 获取某一时刻的流月干支，以节令交节时刻为界
+// 参数：
+//   - t: 公历时间
+//
+// 返回值：
+//   - string: 流月干支
+func GetMonthlyGanZhi(t time.Time) string {
+	return lunarCalendar.NewSolarFromDate(t).GetLunar().GetMonthInGanZhiExact()
+}
+
+// GetDailyGanZhi 获取某一时刻的流日干支
+// 参数：
+//   - t: 公历时间
+//
+// 返回值：
+//   - string: 流日干支
+func GetDailyGanZhi(t time.Time) string {
+	return lunarCalendar.NewSolarFromDate(t).GetLunar().GetDayInGanZhiExact()
+}
+
+// MonthlyPillar 流月
+type MonthlyPillar struct {
+	GanZhi  string    `json:"gan_zhi"`  // 流月干支
+	JieName string    `json:"jie_name"` // 起始节令
+	Start   time.Time `json:"start"`    // 起始交节时刻
+	End     time.Time `json:"end"`      // 结束交节时刻
+}
+
+// GetLunarNewYear 获取农历某年正月初一对应的公历日期
+// 参数：
+//   - lunarYear: 农历年份
+//
+// 返回值：
+//   - time.Time: 正月初一零时（本地时区）
+func GetLunarNewYear(lunarYear int) time.Time {
+	solar := lunarCalendar.NewLunarFromYmd(lunarYear, 1, 1).GetSolar()
+	return time.Date(solar.GetYear(), time.Month(solar.GetMonth()), solar.GetDay(), 0, 0, 0, 0, time.Local)
+}
+
+// GetMonthlyPillars 获取某一流年自立春起的十二个流月
+// 参数：
+//   - year: 流年所在的公历年份
+//
+// 返回值：
+//   - []*MonthlyPillar: 十二个流月，按时间顺序排列
+func GetMonthlyPillars(year int) []*MonthlyPillar {
+	// 从当年 2 月 1 日向后查找立春，之后逐节顺推
+	jie := lunarCalendar.NewSolarFromYmd(year, 2, 1).GetLunar().GetNextJie()
+	pillars := make([]*MonthlyPillar, 0, 12)
+	for i := 0; i < 12; i++ {
+		start := jie.GetSolar()
+		next := start.NextDay(1).GetLunar().GetNextJie()
+		pillars = append(pillars, &MonthlyPillar{
+			GanZhi:  start.NextDay(1).GetLunar().GetMonthInGanZhiExact(),
+			JieName: jie.GetName(),
+			Start:   solarToTime(start),
+			End:     solarToTime(next.GetSolar()),
+		})
+		jie = next
+	}
+	return pillars
+}
+
+// solarToTime 将公历对象转换为本地时区时间
+// 参数：
+//   - solar: 公历对象
+//
+// 返回值：
+//   - time.Time: 时间
+func solarToTime(solar *lunarCalendar.Solar) time.Time {
+	return time.Date(solar.GetYear(), time.Month(solar.GetMonth()), solar.GetDay(),
+		solar.GetHour(), solar.GetMinute(), solar.GetSecond(), 0, time.Local)
+}
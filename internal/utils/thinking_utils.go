@@ -0,0 +1,83 @@
+// Package utils 提供推理模型思考耗时统计工具
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import "time"
+
+// ThinkingTimer 思考耗时计时器，从开始请求计时，收到首个回答内容时结束
+type ThinkingTimer struct {
+	startAt  time.Time // 开始时间
+	endAt    time.Time // 思考结束时间
+	thinking bool      // 是否收到过思考过程
+}
+
+// NewThinkingTimer 创建并启动思考耗时计时器
+// 返回值：
+//
+//	*ThinkingTimer: 计时器
+func NewThinkingTimer() *ThinkingTimer {
+	return &ThinkingTimer{startAt: time.Now()}
+}
+
+// Observe 记录一个流式数据块
+// 参数：
+//
+//	reasoning: 数据块中的思考过程
+//	content: 数据块中的回答内容
+//
+// 返回值：
+//
+//	bool: 思考是否在该数据块结束
+func (t *ThinkingTimer) Observe(reasoning, content string) bool {
+	if reasoning != "" {
+		t.thinking = true
+	}
+	if content != "" {
+		return t.end()
+	}
+	return false
+}
+
+// Finish 结束计时，流结束时仍在思考的以结束时间为准
+// 返回值：
+//
+//	bool: 思考是否在此时结束
+func (t *ThinkingTimer) Finish() bool {
+	return t.end()
+}
+
+// Thinking 是否收到过思考过程
+// 返回值：
+//
+//	bool: 是否收到过思考过程
+func (t *ThinkingTimer) Thinking() bool {
+	return t.thinking
+}
+
+// Seconds 思考耗时秒数，未收到思考过程时为 0
+// 返回值：
+//
+//	int: 思考耗时（秒）
+func (t *ThinkingTimer) Seconds() int {
+	if !t.thinking {
+		return 0
+	}
+	endAt := t.endAt
+	if endAt.IsZero() {
+		endAt = time.Now()
+	}
+	return int(endAt.Sub(t.startAt).Seconds())
+}
+
+// end 记录思考结束时间
+// 返回值：
+//
+//	bool: 是否本次结束
+func (t *ThinkingTimer) end() bool {
+	if !t.thinking || !t.endAt.IsZero() {
+		return false
+	}
+	t.endAt = time.Now()
+	return true
+}
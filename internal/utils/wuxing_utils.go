@@ -0,0 +1,228 @@
+// Package utils 提供五行与十神分析相关功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package utils
+
+import (
+	"sort"
+
+	"github.com/6tail/lunar-go/LunarUtil"
+)
+
+// 五行常量
+const (
+	WUXING_WOOD  = "木" // 木
+	WUXING_FIRE  = "火" // 火
+	WUXING_EARTH = "土" // 土
+	WUXING_METAL = "金" // 金
+	WUXING_WATER = "水" // 水
+)
+
+// WUXING_LIST 五行相生顺序
+var WUXING_LIST = []string{WUXING_WOOD, WUXING_FIRE, WUXING_EARTH, WUXING_METAL, WUXING_WATER}
+
+// 藏干权重（本气、中气、余气）与月令加权
+var (
+	hideGanWeights   = []float64{1.0, 0.5, 0.3}
+	monthBranchRatio = 1.5
+)
+
+// WuxingAnalysis 五行分析结果
+type WuxingAnalysis struct {
+	DayMaster       string             `json:"day_master"`        // 日主天干
+	DayMasterWuxing string             `json:"day_master_wuxing"` // 日主五行
+	Counts          map[string]float64 `json:"counts"`            // 各五行加权得分
+	SupportScore    float64            `json:"support_score"`     // 生扶日主的力量（印比）
+	DrainScore      float64            `json:"drain_score"`       // 克泄耗日主的力量（食伤财官）
+	IsStrong        bool               `json:"is_strong"`         // 日主是否偏旺
+	Missing         []string           `json:"missing"`           // 原局缺失的五行
+	Favorable       []string           `json:"favorable"`         // 喜用五行（按优先级排序）
+	Unfavorable     []string           `json:"unfavorable"`       // 忌讳五行
+}
+
+// GetGanWuxing 获取天干五行
+// 参数：
+//   - gan: 天干
+//
+// 返回值：
+//   - string: 五行，未知天干返回空字符串
+func GetGanWuxing(gan string) string {
+	return LunarUtil.WU_XING_GAN[gan]
+}
+
+// GetZhiWuxing 获取地支五行
+// 参数：
+//   - zhi: 地支
+//
+// 返回值：
+//   - string: 五行，未知地支返回空字符串
+func GetZhiWuxing(zhi string) string {
+	return LunarUtil.WU_XING_ZHI[zhi]
+}
+
+// GetHideGan 获取地支藏干，按本气、中气、余气排序
+// 参数：
+//   - zhi: 地支
+//
+// 返回值：
+//   - []string: 藏干列表
+func GetHideGan(zhi string) []string {
+	return LunarUtil.ZHI_HIDE_GAN[zhi]
+}
+
+// GetTenGod 获取某天干相对日主的十神
+// 参数：
+//   - dayGan: 日主天干
+//   - gan: 目标天干
+//
+// 返回值：
+//   - string: 十神名称，无法识别时返回空字符串
+func GetTenGod(dayGan, gan string) string {
+	return LunarUtil.SHI_SHEN[dayGan+gan]
+}
+
+// GetNaYin 获取干支纳音
+// 参数：
+//   - ganZhi: 干支，如 甲子
+//
+// 返回值：
+//   - string: 纳音五行，无效干支返回空字符串
+func GetNaYin(ganZhi string) string {
+	return LunarUtil.NAYIN[ganZhi]
+}
+
+// GetGeneratingWuxing 获取生我的五行（印）
+// 参数：
+//   - wuxing: 五行
+//
+// 返回值：
+//   - string: 生我者
+func GetGeneratingWuxing(wuxing string) string {
+	return shiftWuxing(wuxing, 4)
+}
+
+// GetGeneratedWuxing 获取我生的五行（食伤）
+// 参数：
+//   - wuxing: 五行
+//
+// 返回值：
+//   - string: 我生者
+func GetGeneratedWuxing(wuxing string) string {
+	return shiftWuxing(wuxing, 1)
+}
+
+// GetControlledWuxing 获取我克的五行（财）
+// 参数：
+//   - wuxing: 五行
+//
+// 返回值：
+//   - string: 我克者
+func GetControlledWuxing(wuxing string) string {
+	return shiftWuxing(wuxing, 2)
+}
+
+// GetControllingWuxing 获取克我的五行（官杀）
+// 参数：
+//   - wuxing: 五行
+//
+// 返回值：
+//   - string: 克我者
+func GetControllingWuxing(wuxing string) string {
+	return shiftWuxing(wuxing, 3)
+}
+
+// AnalyzeWuxing 分析原局五行强弱与喜忌
+// 参数：
+//   - gans: 天干列表（年、月、日、时）
+//   - zhis: 地支列表（年、月、日、时）
+//
+// 返回值：
+//   - *WuxingAnalysis: 五行分析结果
+func AnalyzeWuxing(gans, zhis []string) *WuxingAnalysis {
+	counts := make(map[string]float64, len(WUXING_LIST))
+	for _, wx := range WUXING_LIST {
+		counts[wx] = 0
+	}
+
+	// 天干按本五行计分
+	for _, gan := range gans {
+		if wx := GetGanWuxing(gan); wx != "" {
+			counts[wx] += 1.0
+		}
+	}
+
+	// 地支按藏干计分，月令加权
+	for i, zhi := range zhis {
+		ratio := 1.0
+		if i == 1 {
+			ratio = monthBranchRatio
+		}
+		for j, hide := range GetHideGan(zhi) {
+			if j >= len(hideGanWeights) {
+				break
+			}
+			if wx := GetGanWuxing(hide); wx != "" {
+				counts[wx] += hideGanWeights[j] * ratio
+			}
+		}
+	}
+
+	analysis := &WuxingAnalysis{
+		Counts:  counts,
+		Missing: []string{},
+	}
+	if len(gans) > 2 {
+		analysis.DayMaster = gans[2]
+		analysis.DayMasterWuxing = GetGanWuxing(gans[2])
+	}
+
+	for _, wx := range WUXING_LIST {
+		if counts[wx] == 0 {
+			analysis.Missing = append(analysis.Missing, wx)
+		}
+	}
+
+	self := analysis.DayMasterWuxing
+	if self == "" {
+		return analysis
+	}
+
+	// 生扶：比劫 + 印；克泄耗：食伤 + 财 + 官杀
+	analysis.SupportScore = counts[self] + counts[GetGeneratingWuxing(self)]
+	analysis.DrainScore = counts[GetGeneratedWuxing(self)] + counts[GetControlledWuxing(self)] + counts[GetControllingWuxing(self)]
+	analysis.IsStrong = analysis.SupportScore >= analysis.DrainScore
+
+	var favorable, unfavorable []string
+	if analysis.IsStrong {
+		favorable = []string{GetGeneratedWuxing(self), GetControlledWuxing(self), GetControllingWuxing(self)}
+		unfavorable = []string{self, GetGeneratingWuxing(self)}
+	} else {
+		favorable = []string{GetGeneratingWuxing(self), self}
+		unfavorable = []string{GetGeneratedWuxing(self), GetControlledWuxing(self), GetControllingWuxing(self)}
+	}
+
+	// 越稀缺的喜用五行越需要补足，排在前面
+	sort.SliceStable(favorable, func(i, j int) bool {
+		return counts[favorable[i]] < counts[favorable[j]]
+	})
+	analysis.Favorable = favorable
+	analysis.Unfavorable = unfavorable
+
+	return analysis
+}
+
+// shiftWuxing 按相生顺序偏移五行
+// 参数：
+//   - wuxing: 起始五行
+//   - step: 偏移步数
+//
+// 返回值：
+//   - string: 偏移后的五行，未知五行返回空字符串
+func shiftWuxing(wuxing string, step int) string {
+	for i, wx := range WUXING_LIST {
+		if wx == wuxing {
+			return WUXING_LIST[(i+step)%len(WUXING_LIST)]
+		}
+	}
+	return ""
+}
@@ -1,9 +1,16 @@
 package main
 
 import (
+	"os"
+
 	"github.com/Done-0/metaphysics/cmd"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == cmd.IMPORT_COMMAND {
+		cmd.Import(os.Args[2:])
+		return
+	}
+
 	cmd.Start()
 }
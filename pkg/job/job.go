@@ -0,0 +1,174 @@
+// Package job 提供后台定时任务注册功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/global"
+	"github.com/Done-0/metaphysics/internal/report"
+	"github.com/Done-0/metaphysics/internal/scheduler"
+	"github.com/Done-0/metaphysics/internal/utils"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	clientMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/client/impl"
+	conversationMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/conversation/impl"
+	fortuneMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/fortune/impl"
+	reportMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/report/impl"
+	userMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/user/impl"
+	clientImpl "github.com/Done-0/metaphysics/pkg/serve/service/client/impl"
+	fortuneImpl "github.com/Done-0/metaphysics/pkg/serve/service/fortune/impl"
+	reportImpl "github.com/Done-0/metaphysics/pkg/serve/service/report/impl"
+)
+
+// 任务名称
+const (
+	JOB_DAILY_FORTUNE   = "daily_fortune"   // 每日运势任务
+	JOB_ANNUAL_REPORT   = "annual_report"   // 流年报告任务
+	JOB_CLIENT_REMINDER = "client_reminder" // 客户跟进提醒任务
+)
+
+// New 注册全部定时任务
+// 参数：
+//   - s: 调度器
+//   - config: 应用配置
+//
+// 返回值：
+//   - error: 注册过程中的错误
+func New(s *scheduler.Scheduler, config *configs.Config) error {
+	if config.FortuneConfig.FortuneJobEnabled {
+		if err := registerDailyFortune(s, config); err != nil {
+			return err
+		}
+	}
+
+	if config.AnnualReportConfig.AnnualReportJobEnabled {
+		if err := registerAnnualReport(s, config); err != nil {
+			return err
+		}
+	}
+
+	if config.ClientConfig.ClientReminderJobEnabled {
+		if err := registerClientReminder(s, config); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// registerDailyFortune 注册每日运势任务
+// 参数：
+//   - s: 调度器
+//   - config: 应用配置
+//
+// 返回值：
+//   - error: 注册过程中的错误
+func registerDailyFortune(s *scheduler.Scheduler, config *configs.Config) error {
+	location, err := loadLocation(config.FortuneConfig.FortuneTimezone)
+	if err != nil {
+		return fmt.Errorf("每日运势时区配置错误: %w", err)
+	}
+
+	service := fortuneImpl.NewFortuneService(
+		baziMapperImpl.NewBaziMapper(),
+		fortuneMapperImpl.NewFortuneMapper(),
+		userMapperImpl.NewUserMapper(),
+	)
+
+	return s.AddDailyJob(JOB_DAILY_FORTUNE, config.FortuneConfig.FortuneRunAt, location, func(ctx context.Context, runAt time.Time) error {
+		count, err := service.GenerateDailyFortunes(utils.NewJobContext(ctx), runAt)
+		if err != nil {
+			return err
+		}
+		global.SysLog.Infof("每日运势生成完成，共 %d 条", count)
+		return nil
+	})
+}
+
+// registerAnnualReport 注册流年报告任务，每日检查是否处于农历新年前的生成窗口
+// 参数：
+//   - s: 调度器
+//   - config: 应用配置
+//
+// 返回值：
+//   - error: 注册过程中的错误
+func registerAnnualReport(s *scheduler.Scheduler, config *configs.Config) error {
+	location, err := loadLocation(config.AnnualReportConfig.AnnualReportTimezone)
+	if err != nil {
+		return fmt.Errorf("流年报告时区配置错误: %w", err)
+	}
+
+	service := reportImpl.NewReportService(
+		baziMapperImpl.NewBaziMapper(),
+		reportMapperImpl.NewReportMapper(),
+		userMapperImpl.NewUserMapper(),
+		conversationMapperImpl.NewConversationMapper(),
+	)
+
+	return s.AddDailyJob(JOB_ANNUAL_REPORT, config.AnnualReportConfig.AnnualReportRunAt, location, func(ctx context.Context, runAt time.Time) error {
+		cfg, err := configs.GetConfig()
+		if err != nil {
+			return fmt.Errorf("获取配置失败: %w", err)
+		}
+
+		year, due := report.DueYear(runAt, cfg.AnnualReportConfig.AnnualReportLeadDays)
+		if !due {
+			return nil
+		}
+
+		count, err := service.GenerateAnnualReports(utils.NewJobContext(ctx), year)
+		if err != nil {
+			return err
+		}
+		global.SysLog.Infof("%d 年流年报告生成完成，共 %d 份", year, count)
+		return nil
+	})
+}
+
+// registerClientReminder 注册客户跟进提醒任务，每日向咨询师汇总发送到期的跟进提醒
+// 参数：
+//   - s: 调度器
+//   - config: 应用配置
+//
+// 返回值：
+//   - error: 注册过程中的错误
+func registerClientReminder(s *scheduler.Scheduler, config *configs.Config) error {
+	location, err := loadLocation(config.ClientConfig.ClientReminderTimezone)
+	if err != nil {
+		return fmt.Errorf("客户跟进提醒时区配置错误: %w", err)
+	}
+
+	service := clientImpl.NewClientService(
+		baziMapperImpl.NewBaziMapper(),
+		clientMapperImpl.NewClientMapper(),
+		conversationMapperImpl.NewConversationMapper(),
+		userMapperImpl.NewUserMapper(),
+	)
+
+	return s.AddDailyJob(JOB_CLIENT_REMINDER, config.ClientConfig.ClientReminderRunAt, location, func(ctx context.Context, runAt time.Time) error {
+		count, err := service.SendDueReminders(utils.NewJobContext(ctx), runAt)
+		if err != nil {
+			return err
+		}
+		global.SysLog.Infof("客户跟进提醒发送完成，共 %d 封", count)
+		return nil
+	})
+}
+
+// loadLocation 加载时区，名称为空时使用本地时区
+// 参数：
+//   - name: 时区名称
+//
+// 返回值：
+//   - *time.Location: 时区
+//   - error: 时区名称无效
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(name)
+}
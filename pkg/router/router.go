@@ -40,4 +40,25 @@ func New(app *gin.Engine) {
 
 	// 注册对话相关的路由
 	routes.RegisterConversationRoutes(api1)
+
+	// 注册起名相关的路由
+	routes.RegisterNamingRoutes(api1)
+
+	// 注册人生事件相关的路由
+	routes.RegisterEventRoutes(api1)
+
+	// 注册人生日志相关的路由
+	routes.RegisterJournalRoutes(api1)
+
+	// 注册每日运势相关的路由
+	routes.RegisterFortuneRoutes(api1)
+
+	// 注册流年报告相关的路由
+	routes.RegisterReportRoutes(api1)
+
+	// 注册客户档案相关的路由
+	routes.RegisterClientRoutes(api1)
+
+	// 注册分享链接相关的路由
+	routes.RegisterShareRoutes(api1)
 }
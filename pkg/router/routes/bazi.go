@@ -23,8 +23,18 @@ func RegisterBaziRoutes(r *gin.RouterGroup) {
 	// 八字路由组
 	baziGroup := r.Group("/bazi")
 	{
-		baziGroup.POST("/calculate", controller.CalculateOneBazi)
+		baziGroup.POST("/calculate", auth_middleware.AuthMiddleware(), controller.CalculateOneBazi)
 		baziGroup.GET("/record", auth_middleware.AuthMiddleware(), controller.GetOneBazi)
 		baziGroup.GET("/records", auth_middleware.AuthMiddleware(), controller.GetBaziList)
+		baziGroup.GET("/:id/image", auth_middleware.AuthMiddleware(), controller.RenderBaziImage)
+		baziGroup.POST("/update", auth_middleware.AuthMiddleware(), controller.UpdateBazi)
+		baziGroup.POST("/delete", auth_middleware.AuthMiddleware(), controller.DeleteBazi)
+		baziGroup.POST("/restore", auth_middleware.AuthMiddleware(), controller.RestoreBazi)
+		baziGroup.POST("/purge", auth_middleware.AuthMiddleware(), controller.PurgeBazi)
+		baziGroup.POST("/duplicate", auth_middleware.AuthMiddleware(), controller.DuplicateBazi)
+		baziGroup.GET("/trash", auth_middleware.AuthMiddleware(), controller.GetBaziTrash)
+		baziGroup.POST("/import", auth_middleware.AuthMiddleware(), controller.ImportBazi)
+		baziGroup.GET("/import/job", auth_middleware.AuthMiddleware(), controller.GetImportJob)
+		baziGroup.POST("/import/text", auth_middleware.AuthMiddleware(), controller.ImportBaziText)
 	}
 }
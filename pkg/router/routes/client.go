@@ -0,0 +1,49 @@
+// Package routes 提供客户档案相关路由
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "github.com/Done-0/metaphysics/internal/middleware/auth"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/client"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	clientMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/client/impl"
+	conversationMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/conversation/impl"
+	userMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/user/impl"
+	clientImpl "github.com/Done-0/metaphysics/pkg/serve/service/client/impl"
+)
+
+// RegisterClientRoutes 注册客户档案相关路由
+// 参数：
+//   - r: Gin 路由组
+func RegisterClientRoutes(r *gin.RouterGroup) {
+	baziMapper := baziMapperImpl.NewBaziMapper()
+	clientMapper := clientMapperImpl.NewClientMapper()
+	conversationMapper := conversationMapperImpl.NewConversationMapper()
+	userMapper := userMapperImpl.NewUserMapper()
+	service := clientImpl.NewClientService(baziMapper, clientMapper, conversationMapper, userMapper)
+	controller := client.NewClientController(service)
+
+	// 客户档案路由组
+	clientGroup := r.Group("/client", auth_middleware.AuthMiddleware())
+	{
+		clientGroup.POST("/create", controller.CreateOneCase)
+		clientGroup.POST("/update", controller.UpdateOneCase)
+		clientGroup.POST("/delete", controller.DeleteOneCase)
+		clientGroup.GET("/list", controller.SearchCases)
+		clientGroup.GET("/detail", controller.GetCaseDetail)
+
+		clientGroup.POST("/note/create", controller.CreateOneNote)
+		clientGroup.POST("/note/delete", controller.DeleteOneNote)
+
+		clientGroup.POST("/session/create", controller.CreateOneSession)
+		clientGroup.POST("/session/delete", controller.DeleteOneSession)
+
+		clientGroup.POST("/reminder/create", controller.CreateOneReminder)
+		clientGroup.POST("/reminder/complete", controller.CompleteOneReminder)
+		clientGroup.POST("/reminder/delete", controller.DeleteOneReminder)
+		clientGroup.GET("/reminder/upcoming", controller.GetUpcomingReminders)
+	}
+}
@@ -27,9 +27,13 @@ func RegisterConversationRoutes(r *gin.RouterGroup) {
 		// 八字分析
 		conversationGroup.GET("/bazi/analyze", controller.AnalyzeBazi)
 		conversationGroup.GET("/bazi/analyze/stream", controller.StreamAnalyzeBazi)
+		conversationGroup.POST("/messages/:id/sections/:key/regenerate", controller.RegenerateSection)
 
 		// 对话
 		conversationGroup.POST("/continue", controller.ContinueConversation)
 		conversationGroup.POST("/continue/stream", controller.StreamContinueConversation)
+
+		// AI 服务健康状态
+		conversationGroup.GET("/ai/health", controller.AIHealth)
 	}
 }
@@ -0,0 +1,33 @@
+// Package routes 提供人生事件相关路由
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "github.com/Done-0/metaphysics/internal/middleware/auth"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/event"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	eventMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/event/impl"
+	eventImpl "github.com/Done-0/metaphysics/pkg/serve/service/event/impl"
+)
+
+// RegisterEventRoutes 注册人生事件相关路由
+// 参数：
+//   - r: Gin 路由组
+func RegisterEventRoutes(r *gin.RouterGroup) {
+	baziMapper := baziMapperImpl.NewBaziMapper()
+	eventMapper := eventMapperImpl.NewEventMapper()
+	service := eventImpl.NewEventService(baziMapper, eventMapper)
+	controller := event.NewEventController(service)
+
+	// 人生事件路由组
+	eventGroup := r.Group("/event", auth_middleware.AuthMiddleware())
+	{
+		eventGroup.POST("/create", controller.CreateOneEvent)
+		eventGroup.GET("/list", controller.GetEventList)
+		eventGroup.POST("/delete", controller.DeleteOneEvent)
+		eventGroup.GET("/rectify", controller.RectifyBirthTime)
+	}
+}
@@ -0,0 +1,34 @@
+// Package routes 提供每日运势相关路由
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "github.com/Done-0/metaphysics/internal/middleware/auth"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/fortune"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	fortuneMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/fortune/impl"
+	userMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/user/impl"
+	fortuneImpl "github.com/Done-0/metaphysics/pkg/serve/service/fortune/impl"
+)
+
+// RegisterFortuneRoutes 注册每日运势相关路由
+// 参数：
+//   - r: Gin 路由组
+func RegisterFortuneRoutes(r *gin.RouterGroup) {
+	baziMapper := baziMapperImpl.NewBaziMapper()
+	fortuneMapper := fortuneMapperImpl.NewFortuneMapper()
+	userMapper := userMapperImpl.NewUserMapper()
+	service := fortuneImpl.NewFortuneService(baziMapper, fortuneMapper, userMapper)
+	controller := fortune.NewFortuneController(service)
+
+	// 每日运势路由组
+	fortuneGroup := r.Group("/fortune", auth_middleware.AuthMiddleware())
+	{
+		fortuneGroup.GET("/today", controller.GetTodayFortune)
+		fortuneGroup.POST("/subscribe", controller.Subscribe)
+		fortuneGroup.POST("/unsubscribe", controller.Unsubscribe)
+	}
+}
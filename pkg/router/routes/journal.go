@@ -0,0 +1,33 @@
+// Package routes 提供人生日志相关路由
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "github.com/Done-0/metaphysics/internal/middleware/auth"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/journal"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	journalMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/journal/impl"
+	journalImpl "github.com/Done-0/metaphysics/pkg/serve/service/journal/impl"
+)
+
+// RegisterJournalRoutes 注册人生日志相关路由
+// 参数：
+//   - r: Gin 路由组
+func RegisterJournalRoutes(r *gin.RouterGroup) {
+	baziMapper := baziMapperImpl.NewBaziMapper()
+	journalMapper := journalMapperImpl.NewJournalMapper()
+	service := journalImpl.NewJournalService(baziMapper, journalMapper)
+	controller := journal.NewJournalController(service)
+
+	// 人生日志路由组
+	journalGroup := r.Group("/journal", auth_middleware.AuthMiddleware())
+	{
+		journalGroup.POST("/create", controller.CreateOneEntry)
+		journalGroup.GET("/list", controller.SearchEntries)
+		journalGroup.POST("/delete", controller.DeleteOneEntry)
+		journalGroup.GET("/export", controller.ExportEntries)
+	}
+}
@@ -0,0 +1,28 @@
+// Package routes 提供起名相关路由
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "github.com/Done-0/metaphysics/internal/middleware/auth"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/naming"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	namingImpl "github.com/Done-0/metaphysics/pkg/serve/service/naming/impl"
+)
+
+// RegisterNamingRoutes 注册起名相关路由
+// 参数：
+//   - r: Gin 路由组
+func RegisterNamingRoutes(r *gin.RouterGroup) {
+	mapper := baziMapperImpl.NewBaziMapper()
+	service := namingImpl.NewNamingService(mapper)
+	controller := naming.NewNamingController(service)
+
+	// 起名路由组
+	namingGroup := r.Group("/naming", auth_middleware.AuthMiddleware())
+	{
+		namingGroup.POST("/suggest", controller.SuggestNames)
+	}
+}
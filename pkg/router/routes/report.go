@@ -0,0 +1,45 @@
+// Package routes 提供流年报告相关路由
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	auth_middleware "github.com/Done-0/metaphysics/internal/middleware/auth"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/report"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	conversationMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/conversation/impl"
+	reportMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/report/impl"
+	userMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/user/impl"
+	reportImpl "github.com/Done-0/metaphysics/pkg/serve/service/report/impl"
+)
+
+// RegisterReportRoutes 注册流年报告相关路由
+// 参数：
+//   - r: Gin 路由组
+func RegisterReportRoutes(r *gin.RouterGroup) {
+	baziMapper := baziMapperImpl.NewBaziMapper()
+	reportMapper := reportMapperImpl.NewReportMapper()
+	userMapper := userMapperImpl.NewUserMapper()
+	conversationMapper := conversationMapperImpl.NewConversationMapper()
+	service := reportImpl.NewReportService(baziMapper, reportMapper, userMapper, conversationMapper)
+	controller := report.NewReportController(service)
+
+	// 流年报告路由组
+	reportGroup := r.Group("/report/annual", auth_middleware.AuthMiddleware())
+	{
+		reportGroup.POST("/subscribe", controller.Subscribe)
+		reportGroup.POST("/unsubscribe", controller.Unsubscribe)
+		reportGroup.GET("/get", controller.GetAnnualReport)
+		reportGroup.GET("/list", controller.ListAnnualReports)
+		reportGroup.POST("/generate", controller.GenerateAnnualReport)
+	}
+
+	// 命理分析报告路由组
+	analysisGroup := r.Group("/report/analysis", auth_middleware.AuthMiddleware())
+	{
+		analysisGroup.GET("/pdf", controller.ExportAnalysisPDF)
+		analysisGroup.POST("/email", controller.EmailAnalysisPDF)
+	}
+}
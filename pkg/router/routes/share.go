@@ -0,0 +1,58 @@
+// Package routes 提供分享链接相关路由
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package routes
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/configs"
+	auth_middleware "github.com/Done-0/metaphysics/internal/middleware/auth"
+	"github.com/Done-0/metaphysics/internal/middleware/ratelimit"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/share"
+	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
+	conversationMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/conversation/impl"
+	shareMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/share/impl"
+	shareImpl "github.com/Done-0/metaphysics/pkg/serve/service/share/impl"
+)
+
+// 分享访问限流默认值，配置缺失时使用
+const (
+	SHARE_RATE_LIMIT          = 30 // 每个 IP 在窗口内允许的请求数
+	SHARE_RATE_WINDOW_SECONDS = 60 // 限流窗口（秒）
+	SHARE_RATE_LIMITER_NAME   = "share_view"
+)
+
+// RegisterShareRoutes 注册分享链接相关路由
+// 参数：
+//   - r: Gin 路由组
+func RegisterShareRoutes(r *gin.RouterGroup) {
+	baziMapper := baziMapperImpl.NewBaziMapper()
+	conversationMapper := conversationMapperImpl.NewConversationMapper()
+	shareMapper := shareMapperImpl.NewShareMapper()
+	service := shareImpl.NewShareService(baziMapper, conversationMapper, shareMapper)
+	controller := share.NewShareController(service)
+
+	limit, windowSeconds := SHARE_RATE_LIMIT, SHARE_RATE_WINDOW_SECONDS
+	if cfg, err := configs.GetConfig(); err == nil {
+		if cfg.ShareConfig.ShareRateLimit > 0 {
+			limit = cfg.ShareConfig.ShareRateLimit
+		}
+		if cfg.ShareConfig.ShareRateWindowSeconds > 0 {
+			windowSeconds = cfg.ShareConfig.ShareRateWindowSeconds
+		}
+	}
+
+	// 分享链接路由组
+	shareGroup := r.Group("/share")
+	{
+		shareGroup.POST("/create", auth_middleware.AuthMiddleware(), controller.CreateOneShare)
+		shareGroup.GET("/list", auth_middleware.AuthMiddleware(), controller.GetShareList)
+		shareGroup.POST("/revoke", auth_middleware.AuthMiddleware(), controller.RevokeOneShare)
+
+		// 公开访问，无需登录，按 IP 限流
+		shareGroup.GET("/view", ratelimit.New(SHARE_RATE_LIMITER_NAME, limit, time.Duration(windowSeconds)*time.Second), controller.ViewShare)
+	}
+}
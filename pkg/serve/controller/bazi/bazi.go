@@ -4,6 +4,7 @@
 package bazi
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -34,11 +35,12 @@ func NewBaziController(baziService baziSrv.BaziService) *BaziController {
 
 // CalculateBazi 计算八字
 // @Summary 计算八字
-// @Description 根据用户提供的出生日期时间计算八字
+// @Description 根据用户提供的出生日期时间计算八字，并保存为当前用户的档案（本人、配偶、子女、客户等）
 // @Tags 八字
 // @Accept json
 // @Produce json
-// @Param request body dto.BaziRequest true "八字计算请求"
+// @Security BearerAuth
+// @Param request body dto.CalculateBaziRequest true "八字计算请求"
 // @Success 200 {object} vo.Result{data=baziVo.BaziResponse} "成功"
 // @Failure 400 {object} vo.Result "参数错误"
 // @Failure 500 {object} vo.Result "服务器内部错误"
@@ -67,10 +69,11 @@ func (c *BaziController) CalculateOneBazi(ctx *gin.Context) {
 
 // GetBaziRecord 获取八字记录
 // @Summary 获取八字记录
-// @Description 根据ID获取八字记录
+// @Description 根据ID获取当前用户的八字记录
 // @Tags 八字
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path int64 true "八字记录ID"
 // @Success 200 {object} vo.Result{data=baziVo.BaziResponse} "成功"
 // @Failure 400 {object} vo.Result "参数错误"
@@ -101,12 +104,26 @@ func (c *BaziController) GetOneBazi(ctx *gin.Context) {
 
 // GetBaziRecordList 获取八字记录列表
 // @Summary 获取八字记录列表
-// @Description 分页获取八字记录列表
+// @Description 检索当前用户的八字记录，支持按日主、干支、性别、出生日期、日历、标签与姓名过滤；传入页码时按页分页，否则按游标分页
 // @Tags 八字
 // @Accept json
 // @Produce json
-// @Param page_no query int false "页码，默认为1"
+// @Security BearerAuth
+// @Param page_no query int false "页码，大于 0 时按页分页"
 // @Param page_size query int false "每页记录数，默认为10，最大为100"
+// @Param cursor query string false "游标，取上一页返回的 nextCursor"
+// @Param relation query string false "档案关系 (self/spouse/child/client/other)"
+// @Param keyword query string false "姓名或标签关键字"
+// @Param gender query string false "性别 (male/female)"
+// @Param calendar query string false "日历类型 (lunar/solar)"
+// @Param day_master query string false "日主，如 甲"
+// @Param pillar query string false "干支，如 甲子"
+// @Param pillar_position query string false "干支所在柱位 (year/month/day/hour)，不传匹配任意一柱"
+// @Param birth_start query string false "出生日期起 (YYYY-MM-DD)"
+// @Param birth_end query string false "出生日期止 (YYYY-MM-DD)"
+// @Param tags query []string false "标签，需全部包含" collectionFormat(multi)
+// @Param sort_by query string false "排序字段 (created/modified/birth_time/name)，默认 created"
+// @Param order query string false "排序方向 (asc/desc)，默认 desc"
 // @Success 200 {object} vo.Result{data=baziVo.BaziRecordListResponse} "成功"
 // @Failure 400 {object} vo.Result "参数错误"
 // @Failure 500 {object} vo.Result "服务器内部错误"
@@ -132,3 +149,364 @@ func (c *BaziController) GetBaziList(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
 }
+
+// RenderBaziImage 生成命盘图片
+// @Summary 生成命盘图片
+// @Description 将八字记录绘制为命盘图片，包含四柱五行配色、藏干、十神、纳音与大运
+// @Tags 八字
+// @Produce image/svg+xml,image/png
+// @Param id path string true "八字 ID"
+// @Param format query string false "图片格式 (svg/png)，默认 svg"
+// @Success 200 {file} file "命盘图片"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/{id}/image [get]
+func (c *BaziController) RenderBaziImage(ctx *gin.Context) {
+	req := new(dto.RenderBaziImageRequest)
+	if err := ctx.ShouldBindUri(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.baziService.RenderBaziImage(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", response.FileName))
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.Data(http.StatusOK, response.ContentType, response.Content)
+}
+
+// UpdateBazi 修改八字
+// @Summary 修改八字
+// @Description 修改八字的姓名、性别、出生信息、标签与备注，出生信息按新数据重新排盘；已有对话保留创建时的命盘快照
+// @Tags 八字
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdateBaziRequest true "修改八字请求"
+// @Success 200 {object} vo.Result{data=baziVo.BaziResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/update [post]
+func (c *BaziController) UpdateBazi(ctx *gin.Context) {
+	req := new(dto.UpdateBaziRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.baziService.UpdateBazi(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// DeleteBazi 删除八字
+// @Summary 删除八字
+// @Description 将八字移入回收站，可从回收站恢复
+// @Tags 八字
+// @Accept json
+// @Produce json
+// @Param request body dto.BaziIDRequest true "删除八字请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/delete [post]
+func (c *BaziController) DeleteBazi(ctx *gin.Context) {
+	req := new(dto.BaziIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.baziService.DeleteBazi(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "八字已移入回收站"))
+}
+
+// RestoreBazi 恢复八字
+// @Summary 恢复八字
+// @Description 从回收站恢复八字
+// @Tags 八字
+// @Accept json
+// @Produce json
+// @Param request body dto.BaziIDRequest true "恢复八字请求"
+// @Success 200 {object} vo.Result{data=baziVo.BaziResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/restore [post]
+func (c *BaziController) RestoreBazi(ctx *gin.Context) {
+	req := new(dto.BaziIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.baziService.RestoreBazi(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// PurgeBazi 永久删除八字
+// @Summary 永久删除八字
+// @Description 永久删除回收站中的八字，删除后无法恢复
+// @Tags 八字
+// @Accept json
+// @Produce json
+// @Param request body dto.BaziIDRequest true "永久删除八字请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/purge [post]
+func (c *BaziController) PurgeBazi(ctx *gin.Context) {
+	req := new(dto.BaziIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.baziService.PurgeBazi(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "八字已永久删除"))
+}
+
+// DuplicateBazi 复制八字
+// @Summary 复制八字
+// @Description 复制八字为新的档案，本人档案的副本归为其他关系
+// @Tags 八字
+// @Accept json
+// @Produce json
+// @Param request body dto.BaziIDRequest true "复制八字请求"
+// @Success 200 {object} vo.Result{data=baziVo.BaziResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/duplicate [post]
+func (c *BaziController) DuplicateBazi(ctx *gin.Context) {
+	req := new(dto.BaziIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.baziService.DuplicateBazi(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// GetBaziTrash 获取回收站列表
+// @Summary 获取回收站列表
+// @Description 分页获取当前用户回收站中的八字
+// @Tags 八字
+// @Accept json
+// @Produce json
+// @Param page_no query int false "页码"
+// @Param page_size query int false "每页记录数"
+// @Success 200 {object} vo.Result{data=baziVo.BaziListResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/trash [get]
+func (c *BaziController) GetBaziTrash(ctx *gin.Context) {
+	req := new(dto.GetBaziTrashRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.baziService.GetBaziTrash(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// ImportBazi 批量导入出生档案
+// @Summary 批量导入出生档案
+// @Description 上传 CSV 或 XLSX 文件批量导入出生档案，首行为表头，可通过列映射指定各字段所在列；逐行校验后返回行错误报告与导入任务 ID，排盘与入库在后台分批进行
+// @Tags 八字
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV 或 XLSX 文件"
+// @Param mapping formData string false "列映射（JSON 对象），如 {\"name\":\"客户姓名\",\"birth_time\":\"出生时间\"}"
+// @Param calendar formData string false "默认历法 (lunar/solar)，默认 solar"
+// @Param relation formData string false "默认关系 (spouse/child/client/other)，默认 client"
+// @Param timezone formData string false "出生时间时区，如 Asia/Shanghai"
+// @Success 200 {object} vo.Result{data=baziVo.BaziImportResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/import [post]
+func (c *BaziController) ImportBazi(ctx *gin.Context) {
+	req := new(dto.ImportBaziRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, "请上传导入文件")))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, "读取导入文件失败")))
+		return
+	}
+	defer file.Close()
+
+	response, err := c.baziService.ImportBazi(ctx, req, fileHeader.Filename, file)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// GetImportJob 查询批量导入进度
+// @Summary 查询批量导入进度
+// @Description 根据导入任务 ID 查询处理进度、成功与失败数量及逐行错误报告
+// @Tags 八字
+// @Accept json
+// @Produce json
+// @Param job_id query string true "导入任务 ID"
+// @Success 200 {object} vo.Result{data=baziVo.BaziImportJobResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/import/job [get]
+func (c *BaziController) GetImportJob(ctx *gin.Context) {
+	req := new(dto.GetImportJobRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.baziService.GetImportJob(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// ImportBaziText 粘贴导入命盘
+// @Summary 粘贴导入命盘
+// @Description 识别从其他排盘软件复制的四柱文本（如 "乾造 甲子 丙寅 戊辰 庚申" 及大运行）或导出的 JSON，提取性别、四柱与出生时间后保存为八字档案；仅有四柱时出生时间标记为未知
+// @Tags 八字
+// @Accept json
+// @Produce json
+// @Param request body dto.ImportBaziTextRequest true "粘贴导入请求"
+// @Success 200 {object} vo.Result{data=baziVo.BaziTextImportResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/bazi/import/text [post]
+func (c *BaziController) ImportBaziText(ctx *gin.Context) {
+	req := new(dto.ImportBaziTextRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.baziService.ImportBaziText(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
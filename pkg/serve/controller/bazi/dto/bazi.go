@@ -9,10 +9,38 @@ import (
 
 // CalculateBaziRequest 八字计算请求参数
 type CalculateBaziRequest struct {
-	Name      string    `json:"name" form:"name" query:"name" binding:"required"`                               // 姓名
-	Gender    string    `json:"gender" form:"gender" query:"gender" binding:"required"`                         // 性别
-	BirthTime time.Time `json:"birth_time" form:"birth_time" query:"birth_time" binding:"required"`             // 出生时间
-	Calendar  string    `json:"calendar" form:"calendar" query:"calendar" binding:"required,oneof=lunar solar"` // 日历类型 (lunar/solar)
+	Name      string    `json:"name" form:"name" query:"name" binding:"required"`                                                   // 姓名
+	Gender    string    `json:"gender" form:"gender" query:"gender" binding:"required"`                                             // 性别
+	BirthTime time.Time `json:"birth_time" form:"birth_time" query:"birth_time" binding:"required"`                                 // 出生时间
+	Calendar  string    `json:"calendar" form:"calendar" query:"calendar" binding:"required,oneof=lunar solar"`                     // 日历类型 (lunar/solar)
+	Relation  string    `json:"relation" form:"relation" query:"relation" binding:"omitempty,oneof=self spouse child client other"` // 与用户的关系，不传时首份档案为本人，其余为其他
+	Label     string    `json:"label" form:"label" query:"label" binding:"max=50"`                                                  // 档案标签
+	Notes     string    `json:"notes" form:"notes" query:"notes" binding:"max=2000"`                                                // 备注
+	Tags      []string  `json:"tags" form:"tags" query:"tags" binding:"max=10,dive,max=20"`                                         // 标签
+}
+
+// UpdateBaziRequest 修改八字请求参数，出生信息变更后重新排盘
+type UpdateBaziRequest struct {
+	ID        int64     `json:"id,string" form:"id" binding:"required"`                                            // 八字 ID
+	Name      string    `json:"name" form:"name" binding:"required"`                                               // 姓名
+	Gender    string    `json:"gender" form:"gender" binding:"required,oneof=male female"`                         // 性别
+	BirthTime time.Time `json:"birth_time" form:"birth_time" binding:"required"`                                   // 出生时间
+	Calendar  string    `json:"calendar" form:"calendar" binding:"required,oneof=lunar solar"`                     // 日历类型 (lunar/solar)
+	Relation  string    `json:"relation" form:"relation" binding:"omitempty,oneof=self spouse child client other"` // 与用户的关系，不传时保持不变
+	Label     *string   `json:"label" form:"label" binding:"omitempty,max=50"`                                     // 档案标签，不传时保持不变
+	Notes     *string   `json:"notes" form:"notes" binding:"omitempty,max=2000"`                                   // 备注，不传时保持不变
+	Tags      *[]string `json:"tags" form:"tags" binding:"omitempty,max=10,dive,max=20"`                           // 标签，不传时保持不变，传空数组时清空
+}
+
+// BaziIDRequest 按 ID 操作八字的请求参数，用于删除、恢复、永久删除与复制
+type BaziIDRequest struct {
+	ID int64 `json:"id,string" form:"id" binding:"required"` // 八字 ID
+}
+
+// GetBaziTrashRequest 获取回收站列表请求参数
+type GetBaziTrashRequest struct {
+	PageNo   int `json:"page_no" form:"page_no" query:"page_no"`       // 页码
+	PageSize int `json:"page_size" form:"page_size" query:"page_size"` // 每页数量
 }
 
 // GetOneBaziRequest 获取八字请求参数
@@ -20,8 +48,53 @@ type GetOneBaziRequest struct {
 	ID int64 `json:"id,string" form:"id" query:"id" binding:"required"` // 八字 ID
 }
 
-// GetBaziListRequest 获取八字列表请求参数
+// GetBaziListRequest 获取八字列表请求参数，传入页码时按页分页，否则按游标分页
 type GetBaziListRequest struct {
-	PageNo   int `json:"page_no" form:"page_no" query:"page_no"`       // 页码
-	PageSize int `json:"page_size" form:"page_size" query:"page_size"` // 每页数量
+	PageNo         int       `json:"page_no" form:"page_no" query:"page_no"`                                                                       // 页码，大于 0 时按页分页
+	PageSize       int       `json:"page_size" form:"page_size" query:"page_size" binding:"omitempty,max=100"`                                     // 每页数量，默认 10，最大 100
+	Cursor         string    `json:"cursor" form:"cursor" query:"cursor"`                                                                          // 游标，取上一页返回的 next_cursor
+	Relation       string    `json:"relation" form:"relation" query:"relation" binding:"omitempty,oneof=self spouse child client other"`           // 按档案关系过滤
+	Keyword        string    `json:"keyword" form:"keyword" query:"keyword"`                                                                       // 姓名或标签关键字
+	Gender         string    `json:"gender" form:"gender" query:"gender" binding:"omitempty,oneof=male female"`                                    // 性别
+	Calendar       string    `json:"calendar" form:"calendar" query:"calendar" binding:"omitempty,oneof=lunar solar"`                              // 日历类型
+	DayMaster      string    `json:"day_master" form:"day_master" query:"day_master"`                                                              // 日主（日干）
+	Pillar         string    `json:"pillar" form:"pillar" query:"pillar"`                                                                          // 干支，默认匹配任意一柱
+	PillarPosition string    `json:"pillar_position" form:"pillar_position" query:"pillar_position" binding:"omitempty,oneof=year month day hour"` // 干支所在柱位
+	BirthStart     time.Time `json:"birth_start" form:"birth_start" query:"birth_start" time_format:"2006-01-02"`                                  // 出生日期起（含）
+	BirthEnd       time.Time `json:"birth_end" form:"birth_end" query:"birth_end" time_format:"2006-01-02"`                                        // 出生日期止（含）
+	Tags           []string  `json:"tags" form:"tags" query:"tags"`                                                                                // 标签，需全部包含
+	SortBy         string    `json:"sort_by" form:"sort_by" query:"sort_by" binding:"omitempty,oneof=created modified birth_time name"`            // 排序字段，默认 created
+	Order          string    `json:"order" form:"order" query:"order" binding:"omitempty,oneof=asc desc"`                                          // 排序方向，默认 desc
+}
+
+// RenderBaziImageRequest 生成命盘图片请求参数
+type RenderBaziImageRequest struct {
+	ID     int64  `json:"id,string" uri:"id" binding:"required"`                                 // 八字 ID
+	Format string `json:"format" form:"format" query:"format" binding:"omitempty,oneof=svg png"` // 图片格式 (svg/png)，默认 svg
+}
+
+// ImportBaziRequest 批量导入出生档案请求参数，文件通过 multipart 表单的 file 字段上传
+type ImportBaziRequest struct {
+	Mapping  string `json:"mapping" form:"mapping"`                                                       // 列映射（JSON 对象），如 {"name":"客户姓名"}，未指定的字段按默认表头识别
+	Calendar string `json:"calendar" form:"calendar" binding:"omitempty,oneof=lunar solar"`               // 历法列缺失或为空时使用的历法，默认 solar
+	Relation string `json:"relation" form:"relation" binding:"omitempty,oneof=spouse child client other"` // 关系列缺失或为空时使用的关系，默认 client
+	Timezone string `json:"timezone" form:"timezone"`                                                     // 出生时间未带时区时使用的时区，如 Asia/Shanghai
+}
+
+// ImportBaziTextRequest 粘贴导入命盘请求参数
+type ImportBaziTextRequest struct {
+	Content  string `json:"content" form:"content" binding:"required"`                                         // 粘贴内容，纯文本四柱或排盘软件导出的 JSON
+	Format   string `json:"format" form:"format" binding:"omitempty,oneof=text json"`                          // 内容格式，不传时自动识别
+	Name     string `json:"name" form:"name" binding:"omitempty,max=50"`                                       // 内容中未识别到姓名时使用的姓名
+	Gender   string `json:"gender" form:"gender" binding:"omitempty,oneof=male female"`                        // 内容中未注明乾造/坤造时使用的性别
+	Calendar string `json:"calendar" form:"calendar" binding:"omitempty,oneof=lunar solar"`                    // 出生时间未注明历法时使用的历法，默认 solar
+	Timezone string `json:"timezone" form:"timezone"`                                                          // 出生时间使用的时区，如 Asia/Shanghai
+	Relation string `json:"relation" form:"relation" binding:"omitempty,oneof=self spouse child client other"` // 与用户的关系
+	Label    string `json:"label" form:"label" binding:"omitempty,max=50"`                                     // 档案标签
+	Notes    string `json:"notes" form:"notes" binding:"omitempty,max=2000"`                                   // 备注
+}
+
+// GetImportJobRequest 查询批量导入任务请求参数
+type GetImportJobRequest struct {
+	JobID int64 `json:"job_id,string" form:"job_id" query:"job_id" binding:"required"` // 导入任务 ID
 }
@@ -0,0 +1,472 @@
+// Package client 提供客户档案相关的控制器功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package client
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "github.com/Done-0/metaphysics/internal/error"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/client/dto"
+	clientSrv "github.com/Done-0/metaphysics/pkg/serve/service/client"
+	"github.com/Done-0/metaphysics/pkg/vo"
+)
+
+// ClientController 客户档案控制器
+type ClientController struct {
+	clientService clientSrv.ClientService
+}
+
+// NewClientController 创建客户档案控制器
+// 参数：
+//   - clientService: 客户档案服务
+//
+// 返回值：
+//   - *ClientController: 客户档案控制器
+func NewClientController(clientService clientSrv.ClientService) *ClientController {
+	return &ClientController{
+		clientService: clientService,
+	}
+}
+
+// CreateOneCase 创建客户案例
+// @Summary 创建客户案例
+// @Description 基于当前用户的八字档案创建客户案例，记录联系方式与标签
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateClientCaseRequest true "创建客户案例请求"
+// @Success 200 {object} vo.Result{data=clientVO.ClientCaseResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/create [post]
+func (c *ClientController) CreateOneCase(ctx *gin.Context) {
+	req := new(dto.CreateClientCaseRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.CreateOneCase(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// UpdateOneCase 修改客户案例
+// @Summary 修改客户案例
+// @Description 修改客户姓名、联系方式、标签与状态
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdateClientCaseRequest true "修改客户案例请求"
+// @Success 200 {object} vo.Result{data=clientVO.ClientCaseResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/update [post]
+func (c *ClientController) UpdateOneCase(ctx *gin.Context) {
+	req := new(dto.UpdateClientCaseRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.UpdateOneCase(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// DeleteOneCase 删除客户案例
+// @Summary 删除客户案例
+// @Description 删除客户案例，其备注、咨询记录与跟进提醒不再展示
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.ClientCaseIDRequest true "删除客户案例请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/delete [post]
+func (c *ClientController) DeleteOneCase(ctx *gin.Context) {
+	req := new(dto.ClientCaseIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.clientService.DeleteOneCase(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "客户案例删除成功"))
+}
+
+// SearchCases 检索客户案例
+// @Summary 检索客户案例
+// @Description 按关键字、状态与标签检索当前用户的客户案例，按最近动态倒序分页
+// @Tags 客户档案
+// @Produce json
+// @Param keyword query string false "关键字，匹配姓名、电话、邮箱与微信"
+// @Param status query string false "状态 (active/archived)"
+// @Param tags query []string false "标签，需全部包含"
+// @Param page_no query int false "页码，默认为1"
+// @Param page_size query int false "每页记录数，默认为10，最大为100"
+// @Success 200 {object} vo.Result{data=clientVO.ClientCaseListResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/list [get]
+func (c *ClientController) SearchCases(ctx *gin.Context) {
+	req := new(dto.SearchClientCaseRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.SearchCases(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// GetCaseDetail 获取客户案例详情
+// @Summary 获取客户案例详情
+// @Description 获取客户案例及其四柱、备注、咨询记录与跟进提醒
+// @Tags 客户档案
+// @Produce json
+// @Param id query string true "客户案例 ID"
+// @Success 200 {object} vo.Result{data=clientVO.ClientCaseDetailResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/detail [get]
+func (c *ClientController) GetCaseDetail(ctx *gin.Context) {
+	req := new(dto.ClientCaseIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.GetCaseDetail(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// CreateOneNote 添加客户备注
+// @Summary 添加客户备注
+// @Description 为客户案例添加一条带时间戳的备注
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateClientNoteRequest true "添加客户备注请求"
+// @Success 200 {object} vo.Result{data=clientVO.ClientNoteResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/note/create [post]
+func (c *ClientController) CreateOneNote(ctx *gin.Context) {
+	req := new(dto.CreateClientNoteRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.CreateOneNote(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// DeleteOneNote 删除客户备注
+// @Summary 删除客户备注
+// @Description 删除当前用户的客户备注
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.ClientItemIDRequest true "删除客户备注请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/note/delete [post]
+func (c *ClientController) DeleteOneNote(ctx *gin.Context) {
+	req := new(dto.ClientItemIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.clientService.DeleteOneNote(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "客户备注删除成功"))
+}
+
+// CreateOneSession 添加咨询记录
+// @Summary 添加咨询记录
+// @Description 为客户案例添加咨询记录，可关联当前用户的一次 AI 对话
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateClientSessionRequest true "添加咨询记录请求"
+// @Success 200 {object} vo.Result{data=clientVO.ClientSessionResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/session/create [post]
+func (c *ClientController) CreateOneSession(ctx *gin.Context) {
+	req := new(dto.CreateClientSessionRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.CreateOneSession(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// DeleteOneSession 删除咨询记录
+// @Summary 删除咨询记录
+// @Description 删除当前用户的咨询记录，关联的对话不受影响
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.ClientItemIDRequest true "删除咨询记录请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/session/delete [post]
+func (c *ClientController) DeleteOneSession(ctx *gin.Context) {
+	req := new(dto.ClientItemIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.clientService.DeleteOneSession(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "咨询记录删除成功"))
+}
+
+// CreateOneReminder 添加跟进提醒
+// @Summary 添加跟进提醒
+// @Description 为客户案例添加跟进提醒，到期当天会通过邮件汇总提醒
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateClientReminderRequest true "添加跟进提醒请求"
+// @Success 200 {object} vo.Result{data=clientVO.ClientReminderResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/reminder/create [post]
+func (c *ClientController) CreateOneReminder(ctx *gin.Context) {
+	req := new(dto.CreateClientReminderRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.CreateOneReminder(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// CompleteOneReminder 完成跟进提醒
+// @Summary 完成跟进提醒
+// @Description 将跟进提醒标记为已完成
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.ClientItemIDRequest true "完成跟进提醒请求"
+// @Success 200 {object} vo.Result{data=clientVO.ClientReminderResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/reminder/complete [post]
+func (c *ClientController) CompleteOneReminder(ctx *gin.Context) {
+	req := new(dto.ClientItemIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.CompleteOneReminder(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// DeleteOneReminder 删除跟进提醒
+// @Summary 删除跟进提醒
+// @Description 删除当前用户的跟进提醒
+// @Tags 客户档案
+// @Accept json
+// @Produce json
+// @Param request body dto.ClientItemIDRequest true "删除跟进提醒请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/reminder/delete [post]
+func (c *ClientController) DeleteOneReminder(ctx *gin.Context) {
+	req := new(dto.ClientItemIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.clientService.DeleteOneReminder(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "跟进提醒删除成功"))
+}
+
+// GetUpcomingReminders 获取待办跟进提醒
+// @Summary 获取待办跟进提醒
+// @Description 获取当前用户全部客户在未来若干天内未完成的跟进提醒，已逾期的提醒总是包含，按提醒时间升序
+// @Tags 客户档案
+// @Produce json
+// @Param days query int false "查看未来多少天内的提醒，默认为7，最大为90"
+// @Success 200 {object} vo.Result{data=[]clientVO.ClientReminderResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/client/reminder/upcoming [get]
+func (c *ClientController) GetUpcomingReminders(ctx *gin.Context) {
+	req := new(dto.GetUpcomingRemindersRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.clientService.GetUpcomingReminders(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
@@ -0,0 +1,75 @@
+// Package dto 提供客户档案相关的数据传输对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+import (
+	"time"
+)
+
+// CreateClientCaseRequest 创建客户案例请求参数
+type CreateClientCaseRequest struct {
+	BaziID int64    `json:"bazi_id,string" form:"bazi_id" binding:"required"`     // 八字 ID
+	Name   string   `json:"name" form:"name" binding:"max=50"`                    // 客户姓名，不传时使用八字档案姓名
+	Phone  string   `json:"phone" form:"phone" binding:"max=30"`                  // 电话
+	Email  string   `json:"email" form:"email" binding:"omitempty,email,max=100"` // 邮箱
+	WeChat string   `json:"wechat" form:"wechat" binding:"max=50"`                // 微信
+	Tags   []string `json:"tags" form:"tags" binding:"max=10,dive,max=20"`        // 标签
+}
+
+// UpdateClientCaseRequest 修改客户案例请求参数
+type UpdateClientCaseRequest struct {
+	ID     int64    `json:"id,string" form:"id" binding:"required"`                         // 客户案例 ID
+	Name   string   `json:"name" form:"name" binding:"required,max=50"`                     // 客户姓名
+	Phone  string   `json:"phone" form:"phone" binding:"max=30"`                            // 电话
+	Email  string   `json:"email" form:"email" binding:"omitempty,email,max=100"`           // 邮箱
+	WeChat string   `json:"wechat" form:"wechat" binding:"max=50"`                          // 微信
+	Tags   []string `json:"tags" form:"tags" binding:"max=10,dive,max=20"`                  // 标签
+	Status string   `json:"status" form:"status" binding:"omitempty,oneof=active archived"` // 状态 (active/archived)，不传时保持不变
+}
+
+// ClientCaseIDRequest 按 ID 操作客户案例的请求参数，用于查看详情与删除
+type ClientCaseIDRequest struct {
+	ID int64 `json:"id,string" form:"id" query:"id" binding:"required"` // 客户案例 ID
+}
+
+// SearchClientCaseRequest 检索客户案例请求参数
+type SearchClientCaseRequest struct {
+	Keyword  string   `json:"keyword" form:"keyword" query:"keyword"`                                        // 关键字，匹配姓名、电话、邮箱与微信
+	Status   string   `json:"status" form:"status" query:"status" binding:"omitempty,oneof=active archived"` // 状态
+	Tags     []string `json:"tags" form:"tags" query:"tags"`                                                 // 标签，需全部包含
+	PageNo   int      `json:"page_no" form:"page_no" query:"page_no"`                                        // 页码
+	PageSize int      `json:"page_size" form:"page_size" query:"page_size"`                                  // 每页数量
+}
+
+// CreateClientNoteRequest 添加客户备注请求参数
+type CreateClientNoteRequest struct {
+	CaseID  int64  `json:"case_id,string" form:"case_id" binding:"required"`   // 客户案例 ID
+	Content string `json:"content" form:"content" binding:"required,max=5000"` // 备注内容
+}
+
+// CreateClientSessionRequest 添加咨询记录请求参数
+type CreateClientSessionRequest struct {
+	CaseID         int64     `json:"case_id,string" form:"case_id" binding:"required"` // 客户案例 ID
+	ConversationID int64     `json:"conversation_id,string" form:"conversation_id"`    // 关联的对话 ID
+	SessionTime    time.Time `json:"session_time" form:"session_time"`                 // 咨询时间，不传时为当前时间
+	Topic          string    `json:"topic" form:"topic" binding:"max=100"`             // 咨询主题
+	Summary        string    `json:"summary" form:"summary" binding:"max=5000"`        // 咨询纪要
+}
+
+// CreateClientReminderRequest 添加跟进提醒请求参数
+type CreateClientReminderRequest struct {
+	CaseID   int64     `json:"case_id,string" form:"case_id" binding:"required"`  // 客户案例 ID
+	RemindAt time.Time `json:"remind_at" form:"remind_at" binding:"required"`     // 提醒时间
+	Content  string    `json:"content" form:"content" binding:"required,max=500"` // 提醒内容
+}
+
+// ClientItemIDRequest 按 ID 操作客户备注、咨询记录或跟进提醒的请求参数
+type ClientItemIDRequest struct {
+	ID int64 `json:"id,string" form:"id" binding:"required"` // 记录 ID
+}
+
+// GetUpcomingRemindersRequest 获取待办跟进提醒请求参数
+type GetUpcomingRemindersRequest struct {
+	Days int `json:"days" form:"days" query:"days" binding:"omitempty,min=1,max=90"` // 查看未来多少天内的提醒，默认 7 天，已逾期的提醒总是包含
+}
@@ -38,17 +38,32 @@ func NewConversationController(conversationService conversationSrv.ConversationS
 
 // AnalyzeBazi godoc
 // @Summary      分析八字
-// @Description  根据用户ID分析八字
+// @Description  根据用户ID分析八字，format=json 时模型按章节输出 JSON，经校验与修复后分章节返回并保存；指定 sections 时仅分析所选章节
 // @Tags         对话
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {object}  vo.Result{data=interface{}}  "成功"
+// @Param        bazi_id   query     string    false  "八字 ID，默认使用本人档案或最近创建的档案"
+// @Param        format    query     string    false  "输出格式 (text/json)，默认 text"
+// @Param        sections  query     []string  false  "章节键 (personality/family/marriage/health/career/wealth/wuxing/luck/forecast)，可重复传参，默认全部章节"  collectionFormat(multi)
+// @Success      200  {object}  vo.Result{data=conversation.BaziAnalysisResponse}  "成功"
 // @Failure      400  {object}  vo.Result                   "参数错误"
 // @Failure      500  {object}  vo.Result                   "服务器内部错误"
 // @Router       /api/v1/conversation/bazi/analyze [get]
 func (c *ConversationController) AnalyzeBazi(ctx *gin.Context) {
-	result, err := c.conversationService.AnalyzeBaziByUserID(ctx)
+	req := new(dto.AnalyzeBaziRequest)
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	result, err := c.conversationService.AnalyzeBaziByUserID(ctx, req)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, nil, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
 		return
@@ -59,16 +74,30 @@ func (c *ConversationController) AnalyzeBazi(ctx *gin.Context) {
 
 // StreamAnalyzeBazi godoc
 // @Summary      流式分析八字
-// @Description  流式分析用户八字
+// @Description  流式分析用户八字，指定 sections 时仅分析所选章节，完成后按章节保存
 // @Tags         对话
 // @Accept       json
 // @Produce      text/event-stream
 // @Security     BearerAuth
+// @Param        bazi_id   query     string    false  "八字 ID，默认使用本人档案或最近创建的档案"
+// @Param        sections  query     []string  false  "章节键 (personality/family/marriage/health/career/wealth/wuxing/luck/forecast)，可重复传参，默认全部章节"  collectionFormat(multi)
 // @Success      200  {string}  string           "事件流"
 // @Failure      400  {object}  vo.Result        "参数错误"
 // @Failure      500  {object}  vo.Result        "服务器内部错误"
 // @Router       /api/v1/conversation/bazi/analyze/stream [get]
 func (c *ConversationController) StreamAnalyzeBazi(ctx *gin.Context) {
+	req := new(dto.StreamAnalyzeBaziRequest)
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
 	// 设置响应头
 	ctx.Header("Content-Type", "text/event-stream")
 	ctx.Header("Cache-Control", "no-cache")
@@ -132,53 +161,29 @@ func (c *ConversationController) StreamAnalyzeBazi(ctx *gin.Context) {
 	ctx.SSEvent("", string(searchStatusJSON))
 	ctx.Writer.Flush()
 
-	// 开始思考时间
-	thinkingStartTime := time.Now()
-
-	// 思考内容
-	thinkingContent := "分析用户八字..."
-	thinkingContentData := new(conversation.StreamData)
-	thinkingContentData.V = thinkingContent
-	thinkingContentData.P = "response/thinking_content"
-	thinkingContentJSON, _ := json.Marshal(thinkingContentData)
-	ctx.SSEvent("", string(thinkingContentJSON))
-	ctx.Writer.Flush()
+	// 思考过程与回答内容分别推送，并统计思考耗时
+	patcher := newStreamPatcher(ctx)
 
 	// 流式分析八字
-	var fullContent string
 	var tokenCount int = 0
 
-	err = c.conversationService.StreamAnalyzeBaziByUserID(ctx, func(content string, done bool) error {
-		if !done {
-			// 累积完整内容
-			fullContent += content
-			tokenCount += len(content) / 4 // 粗略估算token数量
-
-			// 发送内容更新
-			contentData := new(conversation.StreamData)
-			contentData.V = content
-			contentData.O = conversation.OperationAppend
-			if len(fullContent) == len(content) { // 第一次发送
-				contentData.P = "response/content"
-			}
-			contentJSON, _ := json.Marshal(contentData)
-			ctx.SSEvent("", string(contentJSON))
-			ctx.Writer.Flush()
+	err = c.conversationService.StreamAnalyzeBaziByUserID(ctx, req, func(chunk *conversation.StreamChunk) error {
+		if !chunk.Done {
+			// 发送思考过程与内容更新
+			patcher.push(chunk)
 		} else {
-			// 计算思考时间
-			thinkingElapsedSecs := int(time.Since(thinkingStartTime).Seconds())
-			thinkingElapsedData := new(conversation.StreamData)
-			thinkingElapsedData.V = thinkingElapsedSecs
-			thinkingElapsedData.P = "response/thinking_elapsed_secs"
-			thinkingElapsedData.O = conversation.OperationSet
-			thinkingElapsedJSON, _ := json.Marshal(thinkingElapsedData)
-			ctx.SSEvent("", string(thinkingElapsedJSON))
-			ctx.Writer.Flush()
+			// 仅有思考过程时补发思考耗时
+			patcher.finish()
 
-			// 发送完成状态
+			// 对话累计 Token 用量，含此前各轮与本次回复
+			tokenCount = chunk.AccumulatedTokenUsage
+
+			// 发送完成状态，附带实际响应的 Provider 与是否由兜底 Provider 响应
 			batchValues := []conversation.BatchValue{
 				{V: conversation.StatusFinished, P: "status"},
 				{V: tokenCount, P: "accumulated_token_usage"},
+				{V: chunk.Provider, P: "model"},
+				{V: chunk.Fallback, P: "fallback"},
 			}
 			batchData := new(conversation.StreamData)
 			batchData.V = batchValues
@@ -226,6 +231,41 @@ func (c *ConversationController) StreamAnalyzeBazi(ctx *gin.Context) {
 	}
 }
 
+// RegenerateSection godoc
+// @Summary      重新生成分析章节
+// @Description  重新生成按章节保存的八字分析中的一个章节，其余章节保持不变，消息内容按全部章节重建
+// @Tags         对话
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "AI 回复消息ID"
+// @Param        key  path      string  true  "章节键 (personality/family/marriage/health/career/wealth/wuxing/luck/forecast)"
+// @Success      200  {object}  vo.Result{data=conversation.BaziAnalysisResponse}  "成功"
+// @Failure      400  {object}  vo.Result                   "参数错误"
+// @Failure      500  {object}  vo.Result                   "服务器内部错误"
+// @Router       /api/v1/conversation/messages/{id}/sections/{key}/regenerate [post]
+func (c *ConversationController) RegenerateSection(ctx *gin.Context) {
+	req := new(dto.RegenerateSectionRequest)
+	if err := ctx.ShouldBindUri(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	result, err := c.conversationService.RegenerateSection(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, nil, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, result))
+}
+
 // ContinueConversation godoc
 // @Summary      继续对话
 // @Description  继续与AI的对话
@@ -348,53 +388,29 @@ func (c *ConversationController) StreamContinueConversation(ctx *gin.Context) {
 	ctx.SSEvent("", string(searchStatusJSON))
 	ctx.Writer.Flush()
 
-	// 开始思考时间
-	thinkingStartTime := time.Now()
-
-	// 思考内容
-	thinkingContent := fmt.Sprintf("思考用户的问题: %s", req.Prompt)
-	thinkingContentData := new(conversation.StreamData)
-	thinkingContentData.V = thinkingContent
-	thinkingContentData.P = "response/thinking_content"
-	thinkingContentJSON, _ := json.Marshal(thinkingContentData)
-	ctx.SSEvent("", string(thinkingContentJSON))
-	ctx.Writer.Flush()
+	// 思考过程与回答内容分别推送，并统计思考耗时
+	patcher := newStreamPatcher(ctx)
 
 	// 流式继续对话
-	var fullContent string
 	var tokenCount int = 0
 
-	err = c.conversationService.StreamContinueConversation(ctx, req, func(content string, done bool) error {
-		if !done {
-			// 累积完整内容
-			fullContent += content
-			tokenCount += len(content) / 4 // 粗略估算token数量
-
-			// 发送内容更新
-			contentData := new(conversation.StreamData)
-			contentData.V = content
-			contentData.O = conversation.OperationAppend
-			if len(fullContent) == len(content) { // 第一次发送
-				contentData.P = "response/content"
-			}
-			contentJSON, _ := json.Marshal(contentData)
-			ctx.SSEvent("", string(contentJSON))
-			ctx.Writer.Flush()
+	err = c.conversationService.StreamContinueConversation(ctx, req, func(chunk *conversation.StreamChunk) error {
+		if !chunk.Done {
+			// 发送思考过程与内容更新
+			patcher.push(chunk)
 		} else {
-			// 计算思考时间
-			thinkingElapsedSecs := int(time.Since(thinkingStartTime).Seconds())
-			thinkingElapsedData := new(conversation.StreamData)
-			thinkingElapsedData.V = thinkingElapsedSecs
-			thinkingElapsedData.P = "response/thinking_elapsed_secs"
-			thinkingElapsedData.O = conversation.OperationSet
-			thinkingElapsedJSON, _ := json.Marshal(thinkingElapsedData)
-			ctx.SSEvent("", string(thinkingElapsedJSON))
-			ctx.Writer.Flush()
+			// 仅有思考过程时补发思考耗时
+			patcher.finish()
 
-			// 发送完成状态
+			// 对话累计 Token 用量，含此前各轮与本次回复
+			tokenCount = chunk.AccumulatedTokenUsage
+
+			// 发送完成状态，附带实际响应的 Provider 与是否由兜底 Provider 响应
 			batchValues := []conversation.BatchValue{
 				{V: conversation.StatusFinished, P: "status"},
 				{V: tokenCount, P: "accumulated_token_usage"},
+				{V: chunk.Provider, P: "model"},
+				{V: chunk.Fallback, P: "fallback"},
 			}
 			batchData := new(conversation.StreamData)
 			batchData.V = batchValues
@@ -441,3 +457,23 @@ func (c *ConversationController) StreamContinueConversation(ctx *gin.Context) {
 		ctx.Writer.Flush()
 	}
 }
+
+// AIHealth godoc
+// @Summary      获取 AI 服务健康状态
+// @Description  返回各 AI Provider 的熔断状态、调用统计与最近一次错误，按优先级排列，兜底 Provider 的 fallback 为 true
+// @Tags         对话
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  vo.Result{data=conversation.AIHealthResponse}  "成功"
+// @Failure      500  {object}  vo.Result                   "服务器内部错误"
+// @Router       /api/v1/conversation/ai/health [get]
+func (c *ConversationController) AIHealth(ctx *gin.Context) {
+	result, err := c.conversationService.GetAIHealth(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, nil, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, result))
+}
@@ -3,12 +3,41 @@
 // 创建时间：2025-07-03
 package dto
 
+// 八字分析输出格式
+const (
+	ANALYSIS_FORMAT_TEXT = "text" // 整段 Markdown 文本
+	ANALYSIS_FORMAT_JSON = "json" // 按章节结构化输出
+)
+
+// AnalyzeBaziRequest 八字分析请求参数
+type AnalyzeBaziRequest struct {
+	BaziID   int64    `json:"bazi_id,string" form:"bazi_id" query:"bazi_id"`                                                                                                   // 八字 ID，不传时使用默认八字档案
+	Format   string   `json:"format" form:"format" query:"format" validate:"omitempty,oneof=text json"`                                                                        // 输出格式 (text/json)，json 时按章节结构化输出并分章节保存，默认 text
+	Sections []string `json:"sections" form:"sections" query:"sections" validate:"omitempty,dive,oneof=personality family marriage health career wealth wuxing luck forecast"` // 需要分析的章节键，可重复传参，为空时分析全部章节
+}
+
+// StreamAnalyzeBaziRequest 流式八字分析请求参数
+type StreamAnalyzeBaziRequest struct {
+	BaziID   int64    `json:"bazi_id,string" form:"bazi_id" query:"bazi_id"`                                                                                                   // 八字 ID，不传时使用默认八字档案
+	Sections []string `json:"sections" form:"sections" query:"sections" validate:"omitempty,dive,oneof=personality family marriage health career wealth wuxing luck forecast"` // 需要分析的章节键，可重复传参，为空时分析全部章节
+}
+
+// RegenerateSectionRequest 重新生成分析章节请求参数
+type RegenerateSectionRequest struct {
+	MessageID int64  `json:"message_id,string" uri:"id" binding:"required"`                                                                                    // AI 回复消息ID
+	Key       string `json:"key" uri:"key" binding:"required" validate:"required,oneof=personality family marriage health career wealth wuxing luck forecast"` // 章节键
+}
+
 // ContinueConversationRequest 继续对话请求参数
 type ContinueConversationRequest struct {
-	Prompt string `json:"prompt" form:"prompt" query:"prompt" binding:"required" validate:"required"` // 用户提示内容
+	Prompt         string `json:"prompt" form:"prompt" query:"prompt" binding:"required" validate:"required"`                 // 用户提示内容
+	IncludeJournal bool   `json:"include_journal" form:"include_journal" query:"include_journal"`                             // 是否附带人生日志作为上下文
+	JournalLimit   int    `json:"journal_limit" form:"journal_limit" query:"journal_limit" validate:"omitempty,min=1,max=50"` // 附带最近几条日志，默认 20
 }
 
 // StreamContinueConversationRequest 流式继续对话请求参数
 type StreamContinueConversationRequest struct {
-	Prompt string `json:"prompt" form:"prompt" query:"prompt" binding:"required" validate:"required"` // 用户提示内容
+	Prompt         string `json:"prompt" form:"prompt" query:"prompt" binding:"required" validate:"required"`                 // 用户提示内容
+	IncludeJournal bool   `json:"include_journal" form:"include_journal" query:"include_journal"`                             // 是否附带人生日志作为上下文
+	JournalLimit   int    `json:"journal_limit" form:"journal_limit" query:"journal_limit" validate:"omitempty,min=1,max=50"` // 附带最近几条日志，默认 20
 }
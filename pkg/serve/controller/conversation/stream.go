@@ -0,0 +1,96 @@
+// Package conversation 提供对话流式响应的增量推送
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package conversation
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/vo/conversation"
+)
+
+// 流式响应增量路径
+const (
+	PATCH_PATH_CONTENT          = "response/content"               // 回答内容
+	PATCH_PATH_THINKING_CONTENT = "response/thinking_content"      // 思考过程
+	PATCH_PATH_THINKING_ELAPSED = "response/thinking_elapsed_secs" // 思考耗时
+)
+
+// streamPatcher 将思考过程与回答内容转换为增量推送，连续追加同一路径时省略路径
+type streamPatcher struct {
+	ctx      *gin.Context
+	timer    *utils.ThinkingTimer
+	lastPath string // 上一次推送的路径
+}
+
+// newStreamPatcher 创建增量推送器，并开始统计思考耗时
+// 参数：
+//   - ctx: HTTP上下文
+//
+// 返回值：
+//   - *streamPatcher: 增量推送器
+func newStreamPatcher(ctx *gin.Context) *streamPatcher {
+	return &streamPatcher{ctx: ctx, timer: utils.NewThinkingTimer()}
+}
+
+// push 推送一个数据块，先推送思考过程，思考结束时推送耗时，再推送回答内容
+// 参数：
+//   - chunk: 流式数据块
+func (p *streamPatcher) push(chunk *conversation.StreamChunk) {
+	if chunk.Reasoning != "" {
+		p.append(PATCH_PATH_THINKING_CONTENT, chunk.Reasoning)
+	}
+	if p.timer.Observe(chunk.Reasoning, chunk.Content) {
+		p.set(PATCH_PATH_THINKING_ELAPSED, p.timer.Seconds())
+	}
+	if chunk.Content != "" {
+		p.append(PATCH_PATH_CONTENT, chunk.Content)
+	}
+}
+
+// finish 结束推送，仅有思考过程而无回答内容时补发思考耗时
+func (p *streamPatcher) finish() {
+	if p.timer.Finish() {
+		p.set(PATCH_PATH_THINKING_ELAPSED, p.timer.Seconds())
+	}
+}
+
+// append 追加文本
+// 参数：
+//   - path: 路径
+//   - text: 文本
+func (p *streamPatcher) append(path, text string) {
+	data := new(conversation.StreamData)
+	data.V = text
+	data.O = conversation.OperationAppend
+	if path != p.lastPath {
+		data.P = path
+	}
+	p.send(data)
+	p.lastPath = path
+}
+
+// set 设置值，之后的追加需重新携带路径
+// 参数：
+//   - path: 路径
+//   - v: 值
+func (p *streamPatcher) set(path string, v any) {
+	data := new(conversation.StreamData)
+	data.V = v
+	data.P = path
+	data.O = conversation.OperationSet
+	p.send(data)
+	p.lastPath = path
+}
+
+// send 发送增量数据
+// 参数：
+//   - data: 增量数据
+func (p *streamPatcher) send(data *conversation.StreamData) {
+	dataJSON, _ := json.Marshal(data)
+	p.ctx.SSEvent("", string(dataJSON))
+	p.ctx.Writer.Flush()
+}
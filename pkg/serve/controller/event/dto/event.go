@@ -0,0 +1,32 @@
+// Package dto 提供人生事件相关的数据传输对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+import (
+	"time"
+)
+
+// CreateEventRequest 创建人生事件请求参数
+type CreateEventRequest struct {
+	BaziID      int64     `json:"bazi_id,string" form:"bazi_id" binding:"required" validate:"required"`                                                                                                          // 八字 ID
+	EventType   string    `json:"event_type" form:"event_type" binding:"required,oneof=marriage childbirth career illness bereavement" validate:"required,oneof=marriage childbirth career illness bereavement"` // 事件类型
+	EventDate   time.Time `json:"event_date" form:"event_date" binding:"required" validate:"required"`                                                                                                           // 事件日期
+	Title       string    `json:"title" form:"title" validate:"max=100"`                                                                                                                                         // 标题
+	Description string    `json:"description" form:"description" validate:"max=500"`                                                                                                                             // 描述
+}
+
+// GetEventListRequest 获取人生事件列表请求参数
+type GetEventListRequest struct {
+	BaziID int64 `json:"bazi_id,string" form:"bazi_id" query:"bazi_id" binding:"required"` // 八字 ID
+}
+
+// DeleteEventRequest 删除人生事件请求参数
+type DeleteEventRequest struct {
+	ID int64 `json:"id,string" form:"id" binding:"required"` // 事件 ID
+}
+
+// RectifyBirthTimeRequest 出生时辰校正请求参数
+type RectifyBirthTimeRequest struct {
+	BaziID int64 `json:"bazi_id,string" form:"bazi_id" query:"bazi_id" binding:"required"` // 八字 ID
+}
@@ -0,0 +1,166 @@
+// Package event 提供人生事件相关的控制器功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package event
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "github.com/Done-0/metaphysics/internal/error"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/event/dto"
+	eventSrv "github.com/Done-0/metaphysics/pkg/serve/service/event"
+	"github.com/Done-0/metaphysics/pkg/vo"
+)
+
+// EventController 人生事件控制器
+type EventController struct {
+	eventService eventSrv.EventService
+}
+
+// NewEventController 创建人生事件控制器
+// 参数：
+//   - eventService: 人生事件服务
+//
+// 返回值：
+//   - *EventController: 人生事件控制器
+func NewEventController(eventService eventSrv.EventService) *EventController {
+	return &EventController{
+		eventService: eventService,
+	}
+}
+
+// CreateOneEvent 记录人生事件
+// @Summary 记录人生事件
+// @Description 为指定八字记录一条已发生的人生事件（婚姻、生育、事业变动、疾病、丧亲）
+// @Tags 人生事件
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateEventRequest true "人生事件"
+// @Success 200 {object} vo.Result{data=eventVO.EventResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/event/create [post]
+func (c *EventController) CreateOneEvent(ctx *gin.Context) {
+	req := new(dto.CreateEventRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.eventService.CreateOneEvent(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// GetEventList 获取人生事件列表
+// @Summary 获取人生事件列表
+// @Description 获取当前用户在指定八字下记录的人生事件，按事件日期升序
+// @Tags 人生事件
+// @Produce json
+// @Param bazi_id query string true "八字 ID"
+// @Success 200 {object} vo.Result{data=eventVO.EventListResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/event/list [get]
+func (c *EventController) GetEventList(ctx *gin.Context) {
+	req := new(dto.GetEventListRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.eventService.GetEventList(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// DeleteOneEvent 删除人生事件
+// @Summary 删除人生事件
+// @Description 删除当前用户记录的人生事件
+// @Tags 人生事件
+// @Accept json
+// @Produce json
+// @Param request body dto.DeleteEventRequest true "删除请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/event/delete [post]
+func (c *EventController) DeleteOneEvent(ctx *gin.Context) {
+	req := new(dto.DeleteEventRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.eventService.DeleteOneEvent(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "人生事件删除成功"))
+}
+
+// RectifyBirthTime 校正出生时辰
+// @Summary 校正出生时辰
+// @Description 以十二时辰分别起盘，按大运、流年对已记录事件的引动程度排序候选时辰
+// @Tags 人生事件
+// @Produce json
+// @Param bazi_id query string true "八字 ID"
+// @Success 200 {object} vo.Result{data=eventVO.RectificationResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/event/rectify [get]
+func (c *EventController) RectifyBirthTime(ctx *gin.Context) {
+	req := new(dto.RectifyBirthTimeRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.eventService.RectifyBirthTime(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
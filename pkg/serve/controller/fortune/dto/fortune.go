@@ -0,0 +1,15 @@
+// Package dto 提供每日运势相关的数据传输对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+// GetTodayFortuneRequest 获取今日运势请求参数
+type GetTodayFortuneRequest struct {
+	BaziID int64 `json:"bazi_id,string" form:"bazi_id" query:"bazi_id"` // 八字 ID，不传时使用订阅的八字
+}
+
+// SubscribeFortuneRequest 订阅每日运势请求参数
+type SubscribeFortuneRequest struct {
+	BaziID       int64 `json:"bazi_id,string" form:"bazi_id" binding:"required" validate:"required"` // 八字 ID
+	EmailEnabled bool  `json:"email_enabled" form:"email_enabled"`                                   // 是否接收邮件推送
+}
@@ -0,0 +1,112 @@
+// Package fortune 提供每日运势相关的控制器功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package fortune
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "github.com/Done-0/metaphysics/internal/error"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/fortune/dto"
+	fortuneSrv "github.com/Done-0/metaphysics/pkg/serve/service/fortune"
+	"github.com/Done-0/metaphysics/pkg/vo"
+)
+
+// FortuneController 每日运势控制器
+type FortuneController struct {
+	fortuneService fortuneSrv.FortuneService
+}
+
+// NewFortuneController 创建每日运势控制器
+// 参数：
+//   - fortuneService: 每日运势服务
+//
+// 返回值：
+//   - *FortuneController: 每日运势控制器
+func NewFortuneController(fortuneService fortuneSrv.FortuneService) *FortuneController {
+	return &FortuneController{
+		fortuneService: fortuneService,
+	}
+}
+
+// GetTodayFortune 获取今日运势
+// @Summary 获取今日运势
+// @Description 获取当前用户今日运势，包含事业、财运、感情、健康评分；尚未生成时即时计算
+// @Tags 每日运势
+// @Produce json
+// @Param bazi_id query string false "八字 ID，不传时使用订阅的八字"
+// @Success 200 {object} vo.Result{data=fortuneVO.DailyFortuneResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/fortune/today [get]
+func (c *FortuneController) GetTodayFortune(ctx *gin.Context) {
+	req := new(dto.GetTodayFortuneRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	response, err := c.fortuneService.GetTodayFortune(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// Subscribe 订阅每日运势
+// @Summary 订阅每日运势
+// @Description 订阅每日运势，每天早晨自动生成，可选开启邮件推送
+// @Tags 每日运势
+// @Accept json
+// @Produce json
+// @Param request body dto.SubscribeFortuneRequest true "订阅信息"
+// @Success 200 {object} vo.Result{data=fortuneVO.FortuneSubscriptionResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/fortune/subscribe [post]
+func (c *FortuneController) Subscribe(ctx *gin.Context) {
+	req := new(dto.SubscribeFortuneRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.fortuneService.Subscribe(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// Unsubscribe 取消订阅每日运势
+// @Summary 取消订阅每日运势
+// @Description 取消当前用户的每日运势订阅
+// @Tags 每日运势
+// @Produce json
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/fortune/unsubscribe [post]
+func (c *FortuneController) Unsubscribe(ctx *gin.Context) {
+	if err := c.fortuneService.Unsubscribe(ctx); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "已取消订阅"))
+}
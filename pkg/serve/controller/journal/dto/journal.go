@@ -0,0 +1,43 @@
+// Package dto 提供人生日志相关的数据传输对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+import (
+	"time"
+)
+
+// CreateJournalEntryRequest 创建日志请求参数
+type CreateJournalEntryRequest struct {
+	BaziID    int64     `json:"bazi_id,string" form:"bazi_id" binding:"required" validate:"required"`   // 八字 ID
+	EntryDate time.Time `json:"entry_date" form:"entry_date" binding:"required" validate:"required"`    // 日志日期
+	Title     string    `json:"title" form:"title" validate:"max=100"`                                  // 标题
+	Content   string    `json:"content" form:"content" binding:"required" validate:"required,max=5000"` // 内容
+	Tags      []string  `json:"tags" form:"tags" validate:"max=10,dive,max=20"`                         // 标签
+}
+
+// SearchJournalRequest 检索日志请求参数
+type SearchJournalRequest struct {
+	BaziID    int64     `json:"bazi_id,string" form:"bazi_id" query:"bazi_id"`                            // 八字 ID，不传表示全部
+	Keyword   string    `json:"keyword" form:"keyword" query:"keyword"`                                   // 关键字
+	GanZhi    string    `json:"gan_zhi" form:"gan_zhi" query:"gan_zhi"`                                   // 岁运干支
+	StartDate time.Time `json:"start_date" form:"start_date" query:"start_date" time_format:"2006-01-02"` // 起始日期
+	EndDate   time.Time `json:"end_date" form:"end_date" query:"end_date" time_format:"2006-01-02"`       // 结束日期
+	PageNo    int       `json:"page_no" form:"page_no" query:"page_no"`                                   // 页码
+	PageSize  int       `json:"page_size" form:"page_size" query:"page_size"`                             // 每页数量
+}
+
+// DeleteJournalEntryRequest 删除日志请求参数
+type DeleteJournalEntryRequest struct {
+	ID int64 `json:"id,string" form:"id" binding:"required"` // 日志 ID
+}
+
+// ExportJournalRequest 导出日志请求参数
+type ExportJournalRequest struct {
+	BaziID    int64     `json:"bazi_id,string" form:"bazi_id" query:"bazi_id"`                                                                      // 八字 ID，不传表示全部
+	Keyword   string    `json:"keyword" form:"keyword" query:"keyword"`                                                                             // 关键字
+	GanZhi    string    `json:"gan_zhi" form:"gan_zhi" query:"gan_zhi"`                                                                             // 岁运干支
+	StartDate time.Time `json:"start_date" form:"start_date" query:"start_date" time_format:"2006-01-02"`                                           // 起始日期
+	EndDate   time.Time `json:"end_date" form:"end_date" query:"end_date" time_format:"2006-01-02"`                                                 // 结束日期
+	Format    string    `json:"format" form:"format" query:"format" binding:"omitempty,oneof=csv markdown" validate:"omitempty,oneof=csv markdown"` // 导出格式 (csv/markdown)，默认 csv
+}
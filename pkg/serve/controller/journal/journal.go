@@ -0,0 +1,179 @@
+// Package journal 提供人生日志相关的控制器功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package journal
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "github.com/Done-0/metaphysics/internal/error"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/journal/dto"
+	journalSrv "github.com/Done-0/metaphysics/pkg/serve/service/journal"
+	"github.com/Done-0/metaphysics/pkg/vo"
+)
+
+// JournalController 人生日志控制器
+type JournalController struct {
+	journalService journalSrv.JournalService
+}
+
+// NewJournalController 创建人生日志控制器
+// 参数：
+//   - journalService: 人生日志服务
+//
+// 返回值：
+//   - *JournalController: 人生日志控制器
+func NewJournalController(journalService journalSrv.JournalService) *JournalController {
+	return &JournalController{
+		journalService: journalService,
+	}
+}
+
+// CreateOneEntry 写日志
+// @Summary 写日志
+// @Description 新增一条人生日志，自动注解当时的大运、流年、流月、流日及其与原局的刑冲合害
+// @Tags 人生日志
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateJournalEntryRequest true "日志内容"
+// @Success 200 {object} vo.Result{data=journalVO.JournalEntryResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/journal/create [post]
+func (c *JournalController) CreateOneEntry(ctx *gin.Context) {
+	req := new(dto.CreateJournalEntryRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.journalService.CreateOneEntry(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// SearchEntries 检索日志
+// @Summary 检索日志
+// @Description 按关键字、岁运干支与日期范围检索当前用户的人生日志，按日期倒序分页
+// @Tags 人生日志
+// @Produce json
+// @Param bazi_id query string false "八字 ID"
+// @Param keyword query string false "关键字，匹配标题、内容、标签与岁运注解"
+// @Param gan_zhi query string false "岁运干支，如 庚子"
+// @Param start_date query string false "起始日期 (YYYY-MM-DD)"
+// @Param end_date query string false "结束日期 (YYYY-MM-DD)"
+// @Param page_no query int false "页码，默认为1"
+// @Param page_size query int false "每页记录数，默认为10，最大为100"
+// @Success 200 {object} vo.Result{data=journalVO.JournalListResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/journal/list [get]
+func (c *JournalController) SearchEntries(ctx *gin.Context) {
+	req := new(dto.SearchJournalRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.journalService.SearchEntries(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// DeleteOneEntry 删除日志
+// @Summary 删除日志
+// @Description 删除当前用户的人生日志
+// @Tags 人生日志
+// @Accept json
+// @Produce json
+// @Param request body dto.DeleteJournalEntryRequest true "删除请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/journal/delete [post]
+func (c *JournalController) DeleteOneEntry(ctx *gin.Context) {
+	req := new(dto.DeleteJournalEntryRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.journalService.DeleteOneEntry(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "日志删除成功"))
+}
+
+// ExportEntries 导出日志
+// @Summary 导出日志
+// @Description 按检索条件导出当前用户的人生日志为 CSV 或 Markdown 文件
+// @Tags 人生日志
+// @Produce octet-stream
+// @Param bazi_id query string false "八字 ID"
+// @Param keyword query string false "关键字"
+// @Param gan_zhi query string false "岁运干支"
+// @Param start_date query string false "起始日期 (YYYY-MM-DD)"
+// @Param end_date query string false "结束日期 (YYYY-MM-DD)"
+// @Param format query string false "导出格式 (csv/markdown)，默认 csv"
+// @Success 200 {file} file "导出文件"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/journal/export [get]
+func (c *JournalController) ExportEntries(ctx *gin.Context) {
+	req := new(dto.ExportJournalRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.journalService.ExportEntries(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", response.FileName))
+	ctx.Data(http.StatusOK, response.ContentType, response.Content)
+}
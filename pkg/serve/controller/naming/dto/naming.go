@@ -0,0 +1,17 @@
+// Package dto 提供起名相关的数据传输对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+// SuggestNamesRequest 起名请求参数
+type SuggestNamesRequest struct {
+	BaziID       int64    `json:"bazi_id,string" form:"bazi_id" binding:"required" validate:"required"`                            // 八字 ID
+	Surname      string   `json:"surname" form:"surname" binding:"required" validate:"required,max=2"`                             // 姓氏
+	Gender       string   `json:"gender" form:"gender" binding:"required,oneof=male female" validate:"required,oneof=male female"` // 性别 (male/female)
+	GivenLength  int      `json:"given_length" form:"given_length" validate:"omitempty,oneof=1 2"`                                 // 名字字数（1/2，不传表示均可）
+	Blacklist    []string `json:"blacklist" form:"blacklist"`                                                                      // 额外禁用字或姓名片段
+	TonePatterns []string `json:"tone_patterns" form:"tone_patterns"`                                                              // 平仄模式（含姓氏），如 "仄平平"
+	Limit        int      `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"`                                           // 返回数量
+	Explain      bool     `json:"explain" form:"explain"`                                                                          // 是否调用 AI 解读寓意
+	ExplainTop   int      `json:"explain_top" form:"explain_top" validate:"omitempty,min=1,max=10"`                                // 解读前几个候选名，默认 5
+}
@@ -0,0 +1,67 @@
+// Package naming 提供起名相关的控制器功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package naming
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "github.com/Done-0/metaphysics/internal/error"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/naming/dto"
+	namingSrv "github.com/Done-0/metaphysics/pkg/serve/service/naming"
+	"github.com/Done-0/metaphysics/pkg/vo"
+)
+
+// NamingController 起名控制器
+type NamingController struct {
+	namingService namingSrv.NamingService
+}
+
+// NewNamingController 创建起名控制器
+// 参数：
+//   - namingService: 起名服务
+//
+// 返回值：
+//   - *NamingController: 起名控制器
+func NewNamingController(namingService namingSrv.NamingService) *NamingController {
+	return &NamingController{
+		namingService: namingService,
+	}
+}
+
+// SuggestNames 推荐姓名
+// @Summary 推荐姓名
+// @Description 根据八字喜用五行、五格数理与平仄规则推荐候选姓名，可选 AI 寓意解读
+// @Tags 起名
+// @Accept json
+// @Produce json
+// @Param request body dto.SuggestNamesRequest true "起名请求"
+// @Success 200 {object} vo.Result{data=namingVO.NameSuggestionResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/naming/suggest [post]
+func (c *NamingController) SuggestNames(ctx *gin.Context) {
+	req := new(dto.SuggestNamesRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.namingService.SuggestNames(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
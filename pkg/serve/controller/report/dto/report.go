@@ -0,0 +1,33 @@
+// Package dto 提供流年报告相关的数据传输对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+// SubscribeAnnualReportRequest 订阅流年报告请求参数
+type SubscribeAnnualReportRequest struct {
+	BaziID       int64 `json:"bazi_id,string" form:"bazi_id" binding:"required" validate:"required"` // 八字 ID
+	EmailEnabled bool  `json:"email_enabled" form:"email_enabled"`                                   // 生成后是否邮件通知
+}
+
+// GetAnnualReportRequest 获取流年报告请求参数
+type GetAnnualReportRequest struct {
+	Year    int `json:"year" form:"year" query:"year" binding:"required" validate:"required,min=1900,max=2100"` // 流年所在公历年份
+	Version int `json:"version" form:"version" query:"version" validate:"min=0"`                                // 版本号，不传时返回最新版本
+}
+
+// ListAnnualReportsRequest 获取流年报告列表请求参数
+type ListAnnualReportsRequest struct {
+	Year int `json:"year" form:"year" query:"year" validate:"omitempty,min=1900,max=2100"` // 流年所在公历年份，不传表示全部
+}
+
+// GenerateAnnualReportRequest 生成流年报告请求参数
+type GenerateAnnualReportRequest struct {
+	BaziID int64 `json:"bazi_id,string" form:"bazi_id"`                           // 八字 ID，不传时使用订阅的八字
+	Year   int   `json:"year" form:"year" validate:"omitempty,min=1900,max=2100"` // 流年所在公历年份，不传时为即将到来的流年
+}
+
+// ExportAnalysisRequest 导出命理分析报告请求参数
+type ExportAnalysisRequest struct {
+	BaziID    int64 `json:"bazi_id,string" form:"bazi_id" query:"bazi_id" binding:"required" validate:"required"`          // 八字 ID
+	MessageID int64 `json:"message_id,string" form:"message_id" query:"message_id" binding:"required" validate:"required"` // AI 分析消息 ID
+}
@@ -0,0 +1,255 @@
+// Package report 提供流年报告相关的控制器功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "github.com/Done-0/metaphysics/internal/error"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/report/dto"
+	reportSrv "github.com/Done-0/metaphysics/pkg/serve/service/report"
+	"github.com/Done-0/metaphysics/pkg/vo"
+)
+
+// ReportController 流年报告控制器
+type ReportController struct {
+	reportService reportSrv.ReportService
+}
+
+// NewReportController 创建流年报告控制器
+// 参数：
+//   - reportService: 流年报告服务
+//
+// 返回值：
+//   - *ReportController: 流年报告控制器
+func NewReportController(reportService reportSrv.ReportService) *ReportController {
+	return &ReportController{
+		reportService: reportService,
+	}
+}
+
+// Subscribe 订阅流年报告
+// @Summary 订阅流年报告
+// @Description 订阅后每年农历新年前自动生成来年流年报告，生成后可选邮件通知
+// @Tags 流年报告
+// @Accept json
+// @Produce json
+// @Param request body dto.SubscribeAnnualReportRequest true "订阅信息"
+// @Success 200 {object} vo.Result{data=reportVO.AnnualReportSubscriptionResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/report/annual/subscribe [post]
+func (c *ReportController) Subscribe(ctx *gin.Context) {
+	req := new(dto.SubscribeAnnualReportRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.reportService.Subscribe(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// Unsubscribe 取消订阅流年报告
+// @Summary 取消订阅流年报告
+// @Description 取消当前用户的流年报告订阅，已生成的报告仍可查看
+// @Tags 流年报告
+// @Produce json
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/report/annual/unsubscribe [post]
+func (c *ReportController) Unsubscribe(ctx *gin.Context) {
+	if err := c.reportService.Unsubscribe(ctx); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "已取消订阅"))
+}
+
+// GetAnnualReport 获取流年报告
+// @Summary 获取流年报告
+// @Description 获取指定流年的报告，默认返回最新版本
+// @Tags 流年报告
+// @Produce json
+// @Param year query int true "流年所在公历年份"
+// @Param version query int false "版本号"
+// @Success 200 {object} vo.Result{data=reportVO.AnnualReportResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/report/annual/get [get]
+func (c *ReportController) GetAnnualReport(ctx *gin.Context) {
+	req := new(dto.GetAnnualReportRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.reportService.GetAnnualReport(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// ListAnnualReports 获取流年报告列表
+// @Summary 获取流年报告列表
+// @Description 获取当前用户的流年报告及其历史版本，不含报告正文
+// @Tags 流年报告
+// @Produce json
+// @Param year query int false "流年所在公历年份"
+// @Success 200 {object} vo.Result{data=reportVO.AnnualReportListResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/report/annual/list [get]
+func (c *ReportController) ListAnnualReports(ctx *gin.Context) {
+	req := new(dto.ListAnnualReportsRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.reportService.ListAnnualReports(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// GenerateAnnualReport 生成流年报告
+// @Summary 生成流年报告
+// @Description 即时生成一个新版本的流年报告，默认为即将到来或当前所在的流年
+// @Tags 流年报告
+// @Accept json
+// @Produce json
+// @Param request body dto.GenerateAnnualReportRequest true "生成参数"
+// @Success 200 {object} vo.Result{data=reportVO.AnnualReportResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/report/annual/generate [post]
+func (c *ReportController) GenerateAnnualReport(ctx *gin.Context) {
+	req := new(dto.GenerateAnnualReportRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.reportService.GenerateAnnualReport(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// ExportAnalysisPDF 导出命理分析报告
+// @Summary 导出命理分析报告
+// @Description 将八字命盘、排盘要点与一条 AI 分析消息合成为分页 PDF 报告并下载
+// @Tags 流年报告
+// @Produce application/pdf
+// @Param bazi_id query string true "八字 ID"
+// @Param message_id query string true "AI 分析消息 ID"
+// @Success 200 {file} file "PDF 报告"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/report/analysis/pdf [get]
+func (c *ReportController) ExportAnalysisPDF(ctx *gin.Context) {
+	req := new(dto.ExportAnalysisRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.reportService.ExportAnalysisPDF(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", response.FileName))
+	ctx.Data(http.StatusOK, response.ContentType, response.Content)
+}
+
+// EmailAnalysisPDF 邮件发送命理分析报告
+// @Summary 邮件发送命理分析报告
+// @Description 生成命理分析 PDF 报告，并以附件形式发送到当前用户的注册邮箱
+// @Tags 流年报告
+// @Accept json
+// @Produce json
+// @Param request body dto.ExportAnalysisRequest true "导出参数"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/report/analysis/email [post]
+func (c *ReportController) EmailAnalysisPDF(ctx *gin.Context) {
+	req := new(dto.ExportAnalysisRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.reportService.EmailAnalysisPDF(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "分析报告已发送"))
+}
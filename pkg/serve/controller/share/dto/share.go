@@ -0,0 +1,28 @@
+// Package dto 提供分享链接相关的数据传输对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package dto
+
+// CreateShareRequest 创建分享链接请求参数
+type CreateShareRequest struct {
+	TargetType  string `json:"target_type" form:"target_type" binding:"required,oneof=chart analysis"` // 分享对象类型 (chart/analysis)
+	TargetID    int64  `json:"target_id,string" form:"target_id" binding:"required"`                   // 分享对象 ID，八字 ID 或对话 ID
+	ExpireHours int    `json:"expire_hours" form:"expire_hours" binding:"omitempty,min=1"`             // 有效期（小时），不传时使用默认有效期
+	ShowName    bool   `json:"show_name" form:"show_name"`                                             // 是否展示姓名与精确出生时间，默认隐去姓名并将出生时间粗化为日期与时辰
+}
+
+// ShareIDRequest 按 ID 操作分享链接的请求参数
+type ShareIDRequest struct {
+	ID int64 `json:"id,string" form:"id" binding:"required"` // 分享链接 ID
+}
+
+// GetShareListRequest 获取分享链接列表请求参数
+type GetShareListRequest struct {
+	PageNo   int `json:"page_no" form:"page_no" query:"page_no"`       // 页码
+	PageSize int `json:"page_size" form:"page_size" query:"page_size"` // 每页数量
+}
+
+// ViewShareRequest 公开访问分享内容请求参数
+type ViewShareRequest struct {
+	Token string `json:"token" form:"token" query:"token" binding:"required"` // 分享令牌
+}
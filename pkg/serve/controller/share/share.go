@@ -0,0 +1,167 @@
+// Package share 提供分享链接相关的控制器功能
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package share
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	bizErr "github.com/Done-0/metaphysics/internal/error"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/share/dto"
+	shareSrv "github.com/Done-0/metaphysics/pkg/serve/service/share"
+	"github.com/Done-0/metaphysics/pkg/vo"
+)
+
+// ShareController 分享链接控制器
+type ShareController struct {
+	shareService shareSrv.ShareService
+}
+
+// NewShareController 创建分享链接控制器
+// 参数：
+//   - shareService: 分享链接服务
+//
+// 返回值：
+//   - *ShareController: 分享链接控制器
+func NewShareController(shareService shareSrv.ShareService) *ShareController {
+	return &ShareController{
+		shareService: shareService,
+	}
+}
+
+// CreateOneShare 创建分享链接
+// @Summary 创建分享链接
+// @Description 为当前用户的命盘或已完成的分析生成带有效期的签名分享链接，默认隐去姓名并将出生时间粗化为日期与时辰
+// @Tags 分享链接
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateShareRequest true "创建分享链接请求"
+// @Success 200 {object} vo.Result{data=shareVO.ShareLinkResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/share/create [post]
+func (c *ShareController) CreateOneShare(ctx *gin.Context) {
+	req := new(dto.CreateShareRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.shareService.CreateOneShare(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// GetShareList 获取分享链接列表
+// @Summary 获取分享链接列表
+// @Description 获取当前用户创建的分享链接及其状态与访问次数，按创建时间倒序分页
+// @Tags 分享链接
+// @Produce json
+// @Param page_no query int false "页码，默认为1"
+// @Param page_size query int false "每页记录数，默认为10，最大为100"
+// @Success 200 {object} vo.Result{data=shareVO.ShareListResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/share/list [get]
+func (c *ShareController) GetShareList(ctx *gin.Context) {
+	req := new(dto.GetShareListRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.shareService.GetShareList(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
+
+// RevokeOneShare 撤销分享链接
+// @Summary 撤销分享链接
+// @Description 撤销当前用户的分享链接，撤销后链接立即失效
+// @Tags 分享链接
+// @Accept json
+// @Produce json
+// @Param request body dto.ShareIDRequest true "撤销分享链接请求"
+// @Success 200 {object} vo.Result{data=string} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 500 {object} vo.Result "服务器内部错误"
+// @Security BearerAuth
+// @Router /api/v1/share/revoke [post]
+func (c *ShareController) RevokeOneShare(ctx *gin.Context) {
+	req := new(dto.ShareIDRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	if err := c.shareService.RevokeOneShare(ctx, req); err != nil {
+		ctx.JSON(http.StatusInternalServerError, vo.Fail(ctx, err, bizErr.New(bizErr.SYSTEM_ERROR, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, "分享链接已撤销"))
+}
+
+// ViewShare 查看分享内容
+// @Summary 查看分享内容
+// @Description 通过分享令牌查看命盘或分析的只读视图，无需登录，按 IP 限流
+// @Tags 分享链接
+// @Produce json
+// @Param token query string true "分享令牌"
+// @Success 200 {object} vo.Result{data=shareVO.SharedViewResponse} "成功"
+// @Failure 400 {object} vo.Result "参数错误"
+// @Failure 404 {object} vo.Result "分享链接无效、已撤销或已过期"
+// @Failure 429 {object} vo.Result "请求过于频繁"
+// @Router /api/v1/share/view [get]
+func (c *ShareController) ViewShare(ctx *gin.Context) {
+	req := new(dto.ViewShareRequest)
+	if err := ctx.ShouldBind(req); err != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, err, bizErr.New(bizErr.PARAM_ERROR, err.Error())))
+		return
+	}
+
+	validationErrors := utils.Validator(req)
+	if validationErrors != nil {
+		ctx.JSON(http.StatusBadRequest, vo.Fail(ctx, validationErrors, bizErr.New(bizErr.PARAM_ERROR)))
+		return
+	}
+
+	response, err := c.shareService.ViewShare(ctx, req)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, vo.Fail(ctx, err, bizErr.New(bizErr.NOT_FOUND, err.Error())))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, vo.Success(ctx, response))
+}
@@ -4,11 +4,52 @@
 package bazi
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/Done-0/metaphysics/internal/model/bazi"
 )
 
+// 排序字段
+const (
+	SORT_BY_CREATED    = "created"    // 创建顺序
+	SORT_BY_MODIFIED   = "modified"   // 最后修改时间
+	SORT_BY_BIRTH_TIME = "birth_time" // 出生时间
+	SORT_BY_NAME       = "name"       // 姓名
+)
+
+// 柱位
+const (
+	PILLAR_YEAR  = "year"  // 年柱
+	PILLAR_MONTH = "month" // 月柱
+	PILLAR_DAY   = "day"   // 日柱
+	PILLAR_HOUR  = "hour"  // 时柱
+)
+
+// BaziQuery 八字检索条件
+type BaziQuery struct {
+	UserID         int64     // 用户 ID
+	Relation       string    // 档案关系，为空表示不限
+	Keyword        string    // 关键字，匹配姓名与标签
+	Gender         string    // 性别，为空表示不限
+	Calendar       string    // 日历类型，为空表示不限
+	DayMaster      string    // 日干，为空表示不限
+	Pillar         string    // 干支，为空表示不限
+	PillarPosition string    // 干支所在柱位，为空表示任意一柱
+	BirthStart     time.Time // 出生时间起（含），零值表示不限
+	BirthEnd       time.Time // 出生时间止（不含），零值表示不限
+	Tags           []string  // 标签，需全部包含
+	SortBy         string    // 排序字段，为空按创建顺序
+	Desc           bool      // 是否倒序
+}
+
+// BaziCursor 游标位置，记录上一页最后一条记录的排序值与 ID
+type BaziCursor struct {
+	Value string // 排序字段的值，按创建顺序排序时为空
+	ID    int64  // 记录 ID
+}
+
 // BaziMapper 八字数据访问接口
 type BaziMapper interface {
 	// CreateOneBazi 在事务中创建八字
@@ -19,23 +60,128 @@ type BaziMapper interface {
 	//   - error: 操作过程中的错误
 	CreateOneBazi(ctx *gin.Context, bazi *bazi.Bazi) error
 
-	// GetOneBaziByID 根据 ID 获取八字记录
+	// GetOneBaziByID 根据 ID 获取用户的八字记录，非本人的记录视为不存在
 	// 参数：
 	//   - ctx: 上下文信息
+	//   - userID: 所属用户 ID
 	//   - id: 八字记录ID
 	// 返回值：
 	//   - *bazi.Bazi: 八字记录
 	//   - error: 错误信息
-	GetOneBaziByID(ctx *gin.Context, id int64) (*bazi.Bazi, error)
+	GetOneBaziByID(ctx *gin.Context, userID, id int64) (*bazi.Bazi, error)
 
-	// GetBaziRecordList 获取八字记录列表
+	// GetBaziList 按检索条件分页获取用户的八字记录列表
 	// 参数：
 	//   - ctx: 上下文信息
+	//   - query: 检索条件
 	//   - pageNo: 页码
 	//   - pageSize: 每页数量
 	// 返回值：
 	//   - []*bazi.Bazi: 八字记录列表
 	//   - int64: 总记录数
 	//   - error: 错误信息
-	GetBaziList(ctx *gin.Context, pageNo, pageSize int) ([]*bazi.Bazi, int64, error)
+	GetBaziList(ctx *gin.Context, query *BaziQuery, pageNo, pageSize int) ([]*bazi.Bazi, int64, error)
+
+	// GetBaziListByCursor 按检索条件获取游标之后的八字记录，不统计总数
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - query: 检索条件
+	//   - cursor: 上一页最后一条记录的位置，为 nil 时从头开始
+	//   - limit: 最大记录数
+	// 返回值：
+	//   - []*bazi.Bazi: 八字记录列表
+	//   - error: 错误信息
+	GetBaziListByCursor(ctx *gin.Context, query *BaziQuery, cursor *BaziCursor, limit int) ([]*bazi.Bazi, error)
+
+	// GetDefaultBazi 获取用户的默认八字，优先本人档案，其次最近创建的档案
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 所属用户 ID
+	// 返回值：
+	//   - *bazi.Bazi: 八字记录，不存在时返回 nil
+	//   - error: 错误信息
+	GetDefaultBazi(ctx *gin.Context, userID int64) (*bazi.Bazi, error)
+
+	// CountBaziByRelation 统计用户指定关系的档案数量
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 所属用户 ID
+	//   - relation: 档案关系
+	// 返回值：
+	//   - int64: 档案数量
+	//   - error: 错误信息
+	CountBaziByRelation(ctx *gin.Context, userID int64, relation string) (int64, error)
+
+	// UpdateOneBazi 在事务中更新八字
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - bazi: 八字记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	UpdateOneBazi(ctx *gin.Context, bazi *bazi.Bazi) error
+
+	// GetOneTrashedBaziByID 根据 ID 获取用户回收站中的八字记录
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 所属用户 ID
+	//   - id: 八字记录ID
+	// 返回值：
+	//   - *bazi.Bazi: 八字记录
+	//   - error: 错误信息
+	GetOneTrashedBaziByID(ctx *gin.Context, userID, id int64) (*bazi.Bazi, error)
+
+	// GetTrashedBaziList 获取用户回收站中的八字记录列表
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 所属用户 ID
+	//   - pageNo: 页码
+	//   - pageSize: 每页数量
+	// 返回值：
+	//   - []*bazi.Bazi: 八字记录列表
+	//   - int64: 总记录数
+	//   - error: 错误信息
+	GetTrashedBaziList(ctx *gin.Context, userID int64, pageNo, pageSize int) ([]*bazi.Bazi, int64, error)
+
+	// PurgeOneBazi 在事务中永久删除回收站中的八字记录
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 所属用户 ID
+	//   - id: 八字记录ID
+	// 返回值：
+	//   - error: 操作过程中的错误
+	PurgeOneBazi(ctx *gin.Context, userID, id int64) error
+
+	// CreateBaziInBatch 在同一事务中批量创建八字，任一记录失败时整批回滚
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - bazis: 八字记录列表
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateBaziInBatch(ctx *gin.Context, bazis []*bazi.Bazi) error
+
+	// CreateOneImportJob 在事务中创建批量导入任务
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - job: 导入任务
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneImportJob(ctx *gin.Context, job *bazi.BaziImportJob) error
+
+	// UpdateOneImportJob 在事务中更新批量导入任务的进度
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - job: 导入任务
+	// 返回值：
+	//   - error: 操作过程中的错误
+	UpdateOneImportJob(ctx *gin.Context, job *bazi.BaziImportJob) error
+
+	// GetOneImportJobByID 根据 ID 获取用户的批量导入任务
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 所属用户 ID
+	//   - id: 导入任务 ID
+	// 返回值：
+	//   - *bazi.BaziImportJob: 导入任务
+	//   - error: 错误信息
+	GetOneImportJobByID(ctx *gin.Context, userID, id int64) (*bazi.BaziImportJob, error)
 }
@@ -5,9 +5,12 @@ package impl
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/Done-0/metaphysics/internal/model/bazi"
 	"github.com/Done-0/metaphysics/internal/utils"
@@ -42,18 +45,19 @@ func (m *BaziMapperImpl) CreateOneBazi(ctx *gin.Context, bazi *bazi.Bazi) error
 	})
 }
 
-// GetOneBaziByID 根据 ID 获取八字记录
+// GetOneBaziByID 根据 ID 获取用户的八字记录，非本人的记录视为不存在
 // 参数：
 //   - ctx: 上下文信息
+//   - userID: 所属用户 ID
 //   - id: 八字记录ID
 //
 // 返回值：
 //   - *bazi.Bazi: 八字记录
 //   - error: 错误信息
-func (m *BaziMapperImpl) GetOneBaziByID(ctx *gin.Context, id int64) (*bazi.Bazi, error) {
+func (m *BaziMapperImpl) GetOneBaziByID(ctx *gin.Context, userID, id int64) (*bazi.Bazi, error) {
 	var bazi bazi.Bazi
 	db := utils.GetDBFromContext(ctx)
-	err := db.Where("id = ? AND deleted = ?", id, false).First(&bazi).Error
+	err := db.Where("id = ? AND user_id = ? AND deleted = ?", id, userID, false).First(&bazi).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("八字不存在")
@@ -64,9 +68,10 @@ func (m *BaziMapperImpl) GetOneBaziByID(ctx *gin.Context, id int64) (*bazi.Bazi,
 	return &bazi, nil
 }
 
-// GetBaziList 获取八字列表
+// GetBaziList 按检索条件分页获取用户的八字记录列表
 // 参数：
 //   - ctx: 上下文信息
+//   - query: 检索条件
 //   - pageNo: 页码
 //   - pageSize: 每页数量
 //
@@ -74,23 +79,20 @@ func (m *BaziMapperImpl) GetOneBaziByID(ctx *gin.Context, id int64) (*bazi.Bazi,
 //   - []*bazi.Bazi: 八字记录列表
 //   - int64: 总记录数
 //   - error: 错误信息
-func (m *BaziMapperImpl) GetBaziList(ctx *gin.Context, pageNo, pageSize int) ([]*bazi.Bazi, int64, error) {
+func (m *BaziMapperImpl) GetBaziList(ctx *gin.Context, query *baziMapper.BaziQuery, pageNo, pageSize int) ([]*bazi.Bazi, int64, error) {
 	var bazis []*bazi.Bazi
 	var total int64
 
 	db := utils.GetDBFromContext(ctx)
-
-	query := db.Model(&bazi.Bazi{}).Where("deleted = ?", false)
+	q := applyBaziQuery(db.Model(&bazi.Bazi{}), query)
 
 	// 计算总数
-	if err := query.Count(&total).Error; err != nil {
+	if err := q.Count(&total).Error; err != nil {
 		return nil, 0, fmt.Errorf("查询八字总数失败: %w", err)
 	}
 
-	// 分页查询 - 倒序排序
 	offset := (pageNo - 1) * pageSize
-	if err := query.
-		Order("id DESC").
+	if err := applyBaziOrder(q, query).
 		Offset(offset).
 		Limit(pageSize).
 		Find(&bazis).Error; err != nil {
@@ -99,3 +101,392 @@ func (m *BaziMapperImpl) GetBaziList(ctx *gin.Context, pageNo, pageSize int) ([]
 
 	return bazis, total, nil
 }
+
+// GetBaziListByCursor 按检索条件获取游标之后的八字记录，不统计总数
+// 参数：
+//   - ctx: 上下文信息
+//   - query: 检索条件
+//   - cursor: 上一页最后一条记录的位置，为 nil 时从头开始
+//   - limit: 最大记录数
+//
+// 返回值：
+//   - []*bazi.Bazi: 八字记录列表
+//   - error: 错误信息
+func (m *BaziMapperImpl) GetBaziListByCursor(ctx *gin.Context, query *baziMapper.BaziQuery, cursor *baziMapper.BaziCursor, limit int) ([]*bazi.Bazi, error) {
+	var bazis []*bazi.Bazi
+
+	db := utils.GetDBFromContext(ctx)
+	q := applyBaziQuery(db.Model(&bazi.Bazi{}), query)
+
+	if cursor != nil {
+		cmp := ">"
+		if query.Desc {
+			cmp = "<"
+		}
+
+		column := sortColumn(query.SortBy)
+		if column == "id" {
+			q = q.Where("id "+cmp+" ?", cursor.ID)
+		} else {
+			value, err := cursorValue(query.SortBy, cursor.Value)
+			if err != nil {
+				return nil, err
+			}
+			q = q.Where("("+column+" "+cmp+" ? OR ("+column+" = ? AND id "+cmp+" ?))", value, value, cursor.ID)
+		}
+	}
+
+	if err := applyBaziOrder(q, query).Limit(limit).Find(&bazis).Error; err != nil {
+		return nil, fmt.Errorf("查询八字列表失败: %w", err)
+	}
+
+	return bazis, nil
+}
+
+// GetDefaultBazi 获取用户的默认八字，优先本人档案，其次最近创建的档案
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 所属用户 ID
+//
+// 返回值：
+//   - *bazi.Bazi: 八字记录，不存在时返回 nil
+//   - error: 错误信息
+func (m *BaziMapperImpl) GetDefaultBazi(ctx *gin.Context, userID int64) (*bazi.Bazi, error) {
+	var record bazi.Bazi
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("user_id = ? AND deleted = ?", userID, false).
+		Order(clause.Expr{SQL: "CASE WHEN relation = ? THEN 0 ELSE 1 END", Vars: []interface{}{bazi.RELATION_SELF}}).
+		Order("id DESC").
+		First(&record).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("查询默认八字失败: %w", err)
+	}
+
+	return &record, nil
+}
+
+// CountBaziByRelation 统计用户指定关系的档案数量
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 所属用户 ID
+//   - relation: 档案关系
+//
+// 返回值：
+//   - int64: 档案数量
+//   - error: 错误信息
+func (m *BaziMapperImpl) CountBaziByRelation(ctx *gin.Context, userID int64, relation string) (int64, error) {
+	var count int64
+	db := utils.GetDBFromContext(ctx)
+	err := db.Model(&bazi.Bazi{}).
+		Where("user_id = ? AND relation = ? AND deleted = ?", userID, relation, false).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计八字档案失败: %w", err)
+	}
+
+	return count, nil
+}
+
+// UpdateOneBazi 在事务中更新八字
+// 参数：
+//   - ctx: Gin上下文
+//   - bazi: 八字记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *BaziMapperImpl) UpdateOneBazi(ctx *gin.Context, bazi *bazi.Bazi) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Save(bazi).Error; err != nil {
+			return fmt.Errorf("更新八字失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetOneTrashedBaziByID 根据 ID 获取用户回收站中的八字记录
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 所属用户 ID
+//   - id: 八字记录ID
+//
+// 返回值：
+//   - *bazi.Bazi: 八字记录
+//   - error: 错误信息
+func (m *BaziMapperImpl) GetOneTrashedBaziByID(ctx *gin.Context, userID, id int64) (*bazi.Bazi, error) {
+	var bazi bazi.Bazi
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("id = ? AND user_id = ? AND deleted = ?", id, userID, true).First(&bazi).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("回收站中不存在该八字")
+		}
+		return nil, fmt.Errorf("查询八字失败: %w", err)
+	}
+
+	return &bazi, nil
+}
+
+// GetTrashedBaziList 获取用户回收站中的八字记录列表，按删除时间倒序
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 所属用户 ID
+//   - pageNo: 页码
+//   - pageSize: 每页数量
+//
+// 返回值：
+//   - []*bazi.Bazi: 八字记录列表
+//   - int64: 总记录数
+//   - error: 错误信息
+func (m *BaziMapperImpl) GetTrashedBaziList(ctx *gin.Context, userID int64, pageNo, pageSize int) ([]*bazi.Bazi, int64, error) {
+	var bazis []*bazi.Bazi
+	var total int64
+
+	db := utils.GetDBFromContext(ctx)
+	query := db.Model(&bazi.Bazi{}).Where("user_id = ? AND deleted = ?", userID, true)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询回收站总数失败: %w", err)
+	}
+
+	offset := (pageNo - 1) * pageSize
+	if err := query.
+		Order("gmt_modified DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&bazis).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询回收站列表失败: %w", err)
+	}
+
+	return bazis, total, nil
+}
+
+// PurgeOneBazi 在事务中永久删除回收站中的八字记录，未移入回收站的记录不会被删除
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 所属用户 ID
+//   - id: 八字记录ID
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *BaziMapperImpl) PurgeOneBazi(ctx *gin.Context, userID, id int64) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		result := db.Where("id = ? AND user_id = ? AND deleted = ?", id, userID, true).Delete(&bazi.Bazi{})
+		if result.Error != nil {
+			return fmt.Errorf("永久删除八字失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("回收站中不存在该八字")
+		}
+
+		return nil
+	})
+}
+
+// CreateBaziInBatch 在同一事务中批量创建八字，任一记录失败时整批回滚
+// 参数：
+//   - ctx: Gin上下文
+//   - bazis: 八字记录列表
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *BaziMapperImpl) CreateBaziInBatch(ctx *gin.Context, bazis []*bazi.Bazi) error {
+	if len(bazis) == 0 {
+		return nil
+	}
+
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.CreateInBatches(bazis, len(bazis)).Error; err != nil {
+			return fmt.Errorf("批量保存八字失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// CreateOneImportJob 在事务中创建批量导入任务
+// 参数：
+//   - ctx: Gin上下文
+//   - job: 导入任务
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *BaziMapperImpl) CreateOneImportJob(ctx *gin.Context, job *bazi.BaziImportJob) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(job).Error; err != nil {
+			return fmt.Errorf("保存导入任务失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UpdateOneImportJob 在事务中更新批量导入任务的进度
+// 参数：
+//   - ctx: Gin上下文
+//   - job: 导入任务
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *BaziMapperImpl) UpdateOneImportJob(ctx *gin.Context, job *bazi.BaziImportJob) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Save(job).Error; err != nil {
+			return fmt.Errorf("更新导入任务失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetOneImportJobByID 根据 ID 获取用户的批量导入任务
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 所属用户 ID
+//   - id: 导入任务 ID
+//
+// 返回值：
+//   - *bazi.BaziImportJob: 导入任务
+//   - error: 错误信息
+func (m *BaziMapperImpl) GetOneImportJobByID(ctx *gin.Context, userID, id int64) (*bazi.BaziImportJob, error) {
+	var job bazi.BaziImportJob
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("id = ? AND user_id = ? AND deleted = ?", id, userID, false).First(&job).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("导入任务不存在")
+		}
+		return nil, fmt.Errorf("查询导入任务失败: %w", err)
+	}
+
+	return &job, nil
+}
+
+// applyBaziQuery 应用八字检索条件
+// 参数：
+//   - q: 查询
+//   - query: 检索条件
+//
+// 返回值：
+//   - *gorm.DB: 查询
+func applyBaziQuery(q *gorm.DB, query *baziMapper.BaziQuery) *gorm.DB {
+	q = q.Where("user_id = ? AND deleted = ?", query.UserID, false)
+	escape := utils.LikeEscapeClause(q)
+
+	if query.Relation != "" {
+		q = q.Where("relation = ?", query.Relation)
+	}
+	if query.Keyword != "" {
+		like := "%" + utils.EscapeLike(query.Keyword) + "%"
+		q = q.Where(fmt.Sprintf("(name LIKE ? %[1]s OR label LIKE ? %[1]s)", escape), like, like)
+	}
+	if query.Gender != "" {
+		q = q.Where("gender = ?", query.Gender)
+	}
+	if query.Calendar != "" {
+		q = q.Where("calendar = ?", query.Calendar)
+	}
+	if query.DayMaster != "" {
+		q = q.Where("day_gan = ?", query.DayMaster)
+	}
+	if query.Pillar != "" {
+		switch query.PillarPosition {
+		case baziMapper.PILLAR_YEAR:
+			q = q.Where("year_pillar = ?", query.Pillar)
+		case baziMapper.PILLAR_MONTH:
+			q = q.Where("month_pillar = ?", query.Pillar)
+		case baziMapper.PILLAR_DAY:
+			q = q.Where("day_pillar = ?", query.Pillar)
+		case baziMapper.PILLAR_HOUR:
+			q = q.Where("hour_pillar = ?", query.Pillar)
+		default:
+			q = q.Where("(year_pillar = ? OR month_pillar = ? OR day_pillar = ? OR hour_pillar = ?)", query.Pillar, query.Pillar, query.Pillar, query.Pillar)
+		}
+	}
+	if !query.BirthStart.IsZero() {
+		q = q.Where("birth_time >= ?", query.BirthStart)
+	}
+	if !query.BirthEnd.IsZero() {
+		q = q.Where("birth_time < ?", query.BirthEnd)
+	}
+
+	// 标签以逗号分隔存储，按整段匹配避免 "子" 命中 "子女"
+	for _, tag := range query.Tags {
+		pattern := utils.EscapeLike(tag)
+		q = q.Where(fmt.Sprintf("(tags = ? OR tags LIKE ? %[1]s OR tags LIKE ? %[1]s OR tags LIKE ? %[1]s)", escape), tag, pattern+",%", "%,"+pattern, "%,"+pattern+",%")
+	}
+
+	return q
+}
+
+// applyBaziOrder 应用排序，排序值相同时按 ID 排序以保证顺序稳定
+// 参数：
+//   - q: 查询
+//   - query: 检索条件
+//
+// 返回值：
+//   - *gorm.DB: 查询
+func applyBaziOrder(q *gorm.DB, query *baziMapper.BaziQuery) *gorm.DB {
+	direction := " ASC"
+	if query.Desc {
+		direction = " DESC"
+	}
+
+	if column := sortColumn(query.SortBy); column != "id" {
+		q = q.Order(column + direction)
+	}
+	return q.Order("id" + direction)
+}
+
+// sortColumn 获取排序字段对应的列名
+// 参数：
+//   - sortBy: 排序字段
+//
+// 返回值：
+//   - string: 列名，未知字段按 ID 排序
+func sortColumn(sortBy string) string {
+	switch sortBy {
+	case baziMapper.SORT_BY_MODIFIED:
+		return "gmt_modified"
+	case baziMapper.SORT_BY_BIRTH_TIME:
+		return "birth_time"
+	case baziMapper.SORT_BY_NAME:
+		return "name"
+	default:
+		return "id"
+	}
+}
+
+// cursorValue 将游标中的排序值转换为列类型
+// 参数：
+//   - sortBy: 排序字段
+//   - value: 游标中的排序值
+//
+// 返回值：
+//   - any: 排序值
+//   - error: 排序值无效时返回错误
+func cursorValue(sortBy, value string) (any, error) {
+	switch sortBy {
+	case baziMapper.SORT_BY_MODIFIED:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("游标无效: %w", err)
+		}
+		return v, nil
+	case baziMapper.SORT_BY_BIRTH_TIME:
+		v, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("游标无效: %w", err)
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
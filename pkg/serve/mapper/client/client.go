@@ -0,0 +1,208 @@
+// Package client 提供客户档案相关的数据访问接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package client
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/model/client"
+)
+
+// ClientCaseQuery 客户案例检索条件
+type ClientCaseQuery struct {
+	UserID  int64    // 咨询师用户 ID
+	Keyword string   // 关键字，匹配姓名、电话、邮箱与微信
+	Status  string   // 状态，为空表示不限
+	Tags    []string // 标签，需全部包含
+}
+
+// ClientMapper 客户档案数据访问接口，全部查询均按咨询师用户 ID 隔离
+type ClientMapper interface {
+	// CreateOneCase 在事务中创建客户案例
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - clientCase: 客户案例
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneCase(ctx *gin.Context, clientCase *client.ClientCase) error
+
+	// UpdateOneCase 在事务中更新客户案例
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - clientCase: 客户案例
+	// 返回值：
+	//   - error: 操作过程中的错误
+	UpdateOneCase(ctx *gin.Context, clientCase *client.ClientCase) error
+
+	// GetOneCaseByID 根据 ID 获取客户案例
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - id: 客户案例 ID
+	// 返回值：
+	//   - *client.ClientCase: 客户案例
+	//   - error: 错误信息
+	GetOneCaseByID(ctx *gin.Context, userID, id int64) (*client.ClientCase, error)
+
+	// GetCasesByIDs 批量获取客户案例
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - ids: 客户案例 ID 列表
+	// 返回值：
+	//   - []*client.ClientCase: 客户案例列表
+	//   - error: 错误信息
+	GetCasesByIDs(ctx *gin.Context, userID int64, ids []int64) ([]*client.ClientCase, error)
+
+	// SearchCases 按检索条件分页获取客户案例，按最后修改时间倒序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - query: 检索条件
+	//   - pageNo: 页码
+	//   - pageSize: 每页数量
+	// 返回值：
+	//   - []*client.ClientCase: 客户案例列表
+	//   - int64: 总记录数
+	//   - error: 错误信息
+	SearchCases(ctx *gin.Context, query *ClientCaseQuery, pageNo, pageSize int) ([]*client.ClientCase, int64, error)
+
+	// DeleteOneCase 逻辑删除客户案例
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - id: 客户案例 ID
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneCase(ctx *gin.Context, userID, id int64) error
+
+	// CreateOneNote 在事务中创建客户备注
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - note: 客户备注
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneNote(ctx *gin.Context, note *client.ClientNote) error
+
+	// GetNotesByCaseID 获取客户案例的全部备注，按创建时间倒序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - caseID: 客户案例 ID
+	// 返回值：
+	//   - []*client.ClientNote: 客户备注列表
+	//   - error: 错误信息
+	GetNotesByCaseID(ctx *gin.Context, userID, caseID int64) ([]*client.ClientNote, error)
+
+	// DeleteOneNote 逻辑删除客户备注
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - id: 备注 ID
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneNote(ctx *gin.Context, userID, id int64) error
+
+	// CreateOneSession 在事务中创建咨询记录
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - session: 咨询记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneSession(ctx *gin.Context, session *client.ClientSession) error
+
+	// GetSessionsByCaseID 获取客户案例的全部咨询记录，按咨询时间倒序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - caseID: 客户案例 ID
+	// 返回值：
+	//   - []*client.ClientSession: 咨询记录列表
+	//   - error: 错误信息
+	GetSessionsByCaseID(ctx *gin.Context, userID, caseID int64) ([]*client.ClientSession, error)
+
+	// DeleteOneSession 逻辑删除咨询记录
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - id: 咨询记录 ID
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneSession(ctx *gin.Context, userID, id int64) error
+
+	// CreateOneReminder 在事务中创建跟进提醒
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - reminder: 跟进提醒
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneReminder(ctx *gin.Context, reminder *client.ClientReminder) error
+
+	// UpdateOneReminder 在事务中更新跟进提醒
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - reminder: 跟进提醒
+	// 返回值：
+	//   - error: 操作过程中的错误
+	UpdateOneReminder(ctx *gin.Context, reminder *client.ClientReminder) error
+
+	// GetOneReminderByID 根据 ID 获取跟进提醒
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - id: 提醒 ID
+	// 返回值：
+	//   - *client.ClientReminder: 跟进提醒
+	//   - error: 错误信息
+	GetOneReminderByID(ctx *gin.Context, userID, id int64) (*client.ClientReminder, error)
+
+	// GetRemindersByCaseID 获取客户案例的全部跟进提醒，按提醒时间升序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - caseID: 客户案例 ID
+	// 返回值：
+	//   - []*client.ClientReminder: 跟进提醒列表
+	//   - error: 错误信息
+	GetRemindersByCaseID(ctx *gin.Context, userID, caseID int64) ([]*client.ClientReminder, error)
+
+	// GetPendingReminders 获取咨询师在截止时间前未完成的跟进提醒，按提醒时间升序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - before: 截止时间
+	// 返回值：
+	//   - []*client.ClientReminder: 跟进提醒列表
+	//   - error: 错误信息
+	GetPendingReminders(ctx *gin.Context, userID int64, before time.Time) ([]*client.ClientReminder, error)
+
+	// ListDueReminders 分批获取全部咨询师在截止时间前未完成且未通知的跟进提醒，供定时任务使用
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - before: 截止时间
+	//   - afterID: 上一批最后一条记录的 ID
+	//   - limit: 每批数量
+	// 返回值：
+	//   - []*client.ClientReminder: 跟进提醒列表，按 ID 升序
+	//   - error: 错误信息
+	ListDueReminders(ctx *gin.Context, before time.Time, afterID int64, limit int) ([]*client.ClientReminder, error)
+
+	// MarkRemindersNotified 标记跟进提醒已发送邮件
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - ids: 提醒 ID 列表
+	// 返回值：
+	//   - error: 错误信息
+	MarkRemindersNotified(ctx *gin.Context, ids []int64) error
+
+	// DeleteOneReminder 逻辑删除跟进提醒
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 咨询师用户 ID
+	//   - id: 提醒 ID
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneReminder(ctx *gin.Context, userID, id int64) error
+}
@@ -0,0 +1,451 @@
+// Package impl 提供客户档案相关的数据访问实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/Done-0/metaphysics/internal/model/client"
+	"github.com/Done-0/metaphysics/internal/utils"
+	clientMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/client"
+)
+
+// ClientMapperImpl 客户档案数据访问实现
+type ClientMapperImpl struct{}
+
+// NewClientMapper 创建客户档案数据访问实例
+// 返回值：
+//   - clientMapper.ClientMapper: 客户档案数据访问接口
+func NewClientMapper() clientMapper.ClientMapper {
+	return &ClientMapperImpl{}
+}
+
+// CreateOneCase 在事务中创建客户案例
+// 参数：
+//   - ctx: Gin上下文
+//   - clientCase: 客户案例
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ClientMapperImpl) CreateOneCase(ctx *gin.Context, clientCase *client.ClientCase) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(clientCase).Error; err != nil {
+			return fmt.Errorf("保存客户案例失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UpdateOneCase 在事务中更新客户案例
+// 参数：
+//   - ctx: Gin上下文
+//   - clientCase: 客户案例
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ClientMapperImpl) UpdateOneCase(ctx *gin.Context, clientCase *client.ClientCase) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Save(clientCase).Error; err != nil {
+			return fmt.Errorf("更新客户案例失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetOneCaseByID 根据 ID 获取客户案例
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - id: 客户案例 ID
+//
+// 返回值：
+//   - *client.ClientCase: 客户案例
+//   - error: 错误信息
+func (m *ClientMapperImpl) GetOneCaseByID(ctx *gin.Context, userID, id int64) (*client.ClientCase, error) {
+	var clientCase client.ClientCase
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("id = ? AND user_id = ? AND deleted = ?", id, userID, false).First(&clientCase).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("客户案例不存在")
+		}
+		return nil, fmt.Errorf("查询客户案例失败: %w", err)
+	}
+
+	return &clientCase, nil
+}
+
+// GetCasesByIDs 批量获取客户案例
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - ids: 客户案例 ID 列表
+//
+// 返回值：
+//   - []*client.ClientCase: 客户案例列表
+//   - error: 错误信息
+func (m *ClientMapperImpl) GetCasesByIDs(ctx *gin.Context, userID int64, ids []int64) ([]*client.ClientCase, error) {
+	var cases []*client.ClientCase
+	if len(ids) == 0 {
+		return cases, nil
+	}
+
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("id IN ? AND user_id = ? AND deleted = ?", ids, userID, false).Find(&cases).Error; err != nil {
+		return nil, fmt.Errorf("查询客户案例失败: %w", err)
+	}
+
+	return cases, nil
+}
+
+// SearchCases 按检索条件分页获取客户案例，按最后修改时间倒序
+// 参数：
+//   - ctx: 上下文信息
+//   - query: 检索条件
+//   - pageNo: 页码
+//   - pageSize: 每页数量
+//
+// 返回值：
+//   - []*client.ClientCase: 客户案例列表
+//   - int64: 总记录数
+//   - error: 错误信息
+func (m *ClientMapperImpl) SearchCases(ctx *gin.Context, query *clientMapper.ClientCaseQuery, pageNo, pageSize int) ([]*client.ClientCase, int64, error) {
+	var cases []*client.ClientCase
+	var total int64
+
+	db := utils.GetDBFromContext(ctx)
+	q := db.Model(&client.ClientCase{}).Where("user_id = ? AND deleted = ?", query.UserID, false)
+	escape := utils.LikeEscapeClause(q)
+
+	if query.Keyword != "" {
+		like := "%" + utils.EscapeLike(query.Keyword) + "%"
+		q = q.Where(fmt.Sprintf("(name LIKE ? %[1]s OR phone LIKE ? %[1]s OR email LIKE ? %[1]s OR wechat LIKE ? %[1]s)", escape), like, like, like, like)
+	}
+	if query.Status != "" {
+		q = q.Where("status = ?", query.Status)
+	}
+	// 标签以逗号分隔存储，按整段匹配
+	for _, tag := range query.Tags {
+		pattern := utils.EscapeLike(tag)
+		q = q.Where(fmt.Sprintf("(tags = ? OR tags LIKE ? %[1]s OR tags LIKE ? %[1]s OR tags LIKE ? %[1]s)", escape), tag, pattern+",%", "%,"+pattern, "%,"+pattern+",%")
+	}
+
+	// 计算总数
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询客户案例总数失败: %w", err)
+	}
+
+	if err := q.Order("gmt_modified DESC").
+		Order("id DESC").
+		Offset((pageNo - 1) * pageSize).
+		Limit(pageSize).
+		Find(&cases).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询客户案例列表失败: %w", err)
+	}
+
+	return cases, total, nil
+}
+
+// DeleteOneCase 逻辑删除客户案例
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - id: 客户案例 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ClientMapperImpl) DeleteOneCase(ctx *gin.Context, userID, id int64) error {
+	return softDelete(ctx, &client.ClientCase{}, userID, id, "客户案例不存在")
+}
+
+// CreateOneNote 在事务中创建客户备注
+// 参数：
+//   - ctx: Gin上下文
+//   - note: 客户备注
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ClientMapperImpl) CreateOneNote(ctx *gin.Context, note *client.ClientNote) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(note).Error; err != nil {
+			return fmt.Errorf("保存客户备注失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetNotesByCaseID 获取客户案例的全部备注，按创建时间倒序
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - caseID: 客户案例 ID
+//
+// 返回值：
+//   - []*client.ClientNote: 客户备注列表
+//   - error: 错误信息
+func (m *ClientMapperImpl) GetNotesByCaseID(ctx *gin.Context, userID, caseID int64) ([]*client.ClientNote, error) {
+	var notes []*client.ClientNote
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("user_id = ? AND case_id = ? AND deleted = ?", userID, caseID, false).
+		Order("gmt_create DESC").
+		Find(&notes).Error; err != nil {
+		return nil, fmt.Errorf("查询客户备注失败: %w", err)
+	}
+
+	return notes, nil
+}
+
+// DeleteOneNote 逻辑删除客户备注
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - id: 备注 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ClientMapperImpl) DeleteOneNote(ctx *gin.Context, userID, id int64) error {
+	return softDelete(ctx, &client.ClientNote{}, userID, id, "客户备注不存在")
+}
+
+// CreateOneSession 在事务中创建咨询记录
+// 参数：
+//   - ctx: Gin上下文
+//   - session: 咨询记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ClientMapperImpl) CreateOneSession(ctx *gin.Context, session *client.ClientSession) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(session).Error; err != nil {
+			return fmt.Errorf("保存咨询记录失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetSessionsByCaseID 获取客户案例的全部咨询记录，按咨询时间倒序
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - caseID: 客户案例 ID
+//
+// 返回值：
+//   - []*client.ClientSession: 咨询记录列表
+//   - error: 错误信息
+func (m *ClientMapperImpl) GetSessionsByCaseID(ctx *gin.Context, userID, caseID int64) ([]*client.ClientSession, error) {
+	var sessions []*client.ClientSession
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("user_id = ? AND case_id = ? AND deleted = ?", userID, caseID, false).
+		Order("session_time DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("查询咨询记录失败: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// DeleteOneSession 逻辑删除咨询记录
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - id: 咨询记录 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ClientMapperImpl) DeleteOneSession(ctx *gin.Context, userID, id int64) error {
+	return softDelete(ctx, &client.ClientSession{}, userID, id, "咨询记录不存在")
+}
+
+// CreateOneReminder 在事务中创建跟进提醒
+// 参数：
+//   - ctx: Gin上下文
+//   - reminder: 跟进提醒
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ClientMapperImpl) CreateOneReminder(ctx *gin.Context, reminder *client.ClientReminder) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(reminder).Error; err != nil {
+			return fmt.Errorf("保存跟进提醒失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UpdateOneReminder 在事务中更新跟进提醒
+// 参数：
+//   - ctx: Gin上下文
+//   - reminder: 跟进提醒
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ClientMapperImpl) UpdateOneReminder(ctx *gin.Context, reminder *client.ClientReminder) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Save(reminder).Error; err != nil {
+			return fmt.Errorf("更新跟进提醒失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetOneReminderByID 根据 ID 获取跟进提醒
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - id: 提醒 ID
+//
+// 返回值：
+//   - *client.ClientReminder: 跟进提醒
+//   - error: 错误信息
+func (m *ClientMapperImpl) GetOneReminderByID(ctx *gin.Context, userID, id int64) (*client.ClientReminder, error) {
+	var reminder client.ClientReminder
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("id = ? AND user_id = ? AND deleted = ?", id, userID, false).First(&reminder).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("跟进提醒不存在")
+		}
+		return nil, fmt.Errorf("查询跟进提醒失败: %w", err)
+	}
+
+	return &reminder, nil
+}
+
+// GetRemindersByCaseID 获取客户案例的全部跟进提醒，按提醒时间升序
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - caseID: 客户案例 ID
+//
+// 返回值：
+//   - []*client.ClientReminder: 跟进提醒列表
+//   - error: 错误信息
+func (m *ClientMapperImpl) GetRemindersByCaseID(ctx *gin.Context, userID, caseID int64) ([]*client.ClientReminder, error) {
+	var reminders []*client.ClientReminder
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("user_id = ? AND case_id = ? AND deleted = ?", userID, caseID, false).
+		Order("remind_at ASC").
+		Find(&reminders).Error; err != nil {
+		return nil, fmt.Errorf("查询跟进提醒失败: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// GetPendingReminders 获取咨询师在截止时间前未完成的跟进提醒，按提醒时间升序
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - before: 截止时间
+//
+// 返回值：
+//   - []*client.ClientReminder: 跟进提醒列表
+//   - error: 错误信息
+func (m *ClientMapperImpl) GetPendingReminders(ctx *gin.Context, userID int64, before time.Time) ([]*client.ClientReminder, error) {
+	var reminders []*client.ClientReminder
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("user_id = ? AND done = ? AND deleted = ? AND remind_at < ?", userID, false, false, before).
+		Order("remind_at ASC").
+		Find(&reminders).Error; err != nil {
+		return nil, fmt.Errorf("查询待办跟进提醒失败: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// ListDueReminders 分批获取全部咨询师在截止时间前未完成且未通知的跟进提醒，供定时任务使用
+// 参数：
+//   - ctx: 上下文信息
+//   - before: 截止时间
+//   - afterID: 上一批最后一条记录的 ID
+//   - limit: 每批数量
+//
+// 返回值：
+//   - []*client.ClientReminder: 跟进提醒列表，按 ID 升序
+//   - error: 错误信息
+func (m *ClientMapperImpl) ListDueReminders(ctx *gin.Context, before time.Time, afterID int64, limit int) ([]*client.ClientReminder, error) {
+	var reminders []*client.ClientReminder
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("id > ? AND done = ? AND notified = ? AND deleted = ? AND remind_at < ?", afterID, false, false, false, before).
+		Order("id ASC").
+		Limit(limit).
+		Find(&reminders).Error; err != nil {
+		return nil, fmt.Errorf("查询到期跟进提醒失败: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// MarkRemindersNotified 标记跟进提醒已发送邮件
+// 参数：
+//   - ctx: 上下文信息
+//   - ids: 提醒 ID 列表
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ClientMapperImpl) MarkRemindersNotified(ctx *gin.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Model(&client.ClientReminder{}).Where("id IN ?", ids).Update("notified", true).Error; err != nil {
+		return fmt.Errorf("标记跟进提醒通知状态失败: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOneReminder 逻辑删除跟进提醒
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - id: 提醒 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ClientMapperImpl) DeleteOneReminder(ctx *gin.Context, userID, id int64) error {
+	return softDelete(ctx, &client.ClientReminder{}, userID, id, "跟进提醒不存在")
+}
+
+// softDelete 在事务中逻辑删除咨询师名下的记录
+// 参数：
+//   - ctx: 上下文信息
+//   - model: 模型，用于确定表名
+//   - userID: 咨询师用户 ID
+//   - id: 记录 ID
+//   - notFound: 记录不存在时的错误信息
+//
+// 返回值：
+//   - error: 错误信息
+func softDelete(ctx *gin.Context, model any, userID, id int64, notFound string) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		result := db.Model(model).Where("id = ? AND user_id = ? AND deleted = ?", id, userID, false).Update("deleted", true)
+		if result.Error != nil {
+			return fmt.Errorf("删除失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%s", notFound)
+		}
+
+		return nil
+	})
+}
@@ -70,6 +70,46 @@ type ConversationMapper interface {
 	//   - error: 错误信息
 	SaveMessage(ctx *gin.Context, message *conversationModel.Message) error
 
+	// SaveMessageWithSections 在同一事务中保存消息及其章节
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - message: 消息模型
+	//   - sections: 章节列表，保存时写入消息ID
+	//
+	// 返回值：
+	//   - error: 错误信息
+	SaveMessageWithSections(ctx *gin.Context, message *conversationModel.Message, sections []*conversationModel.MessageSection) error
+
+	// UpdateMessageWithSection 在同一事务中更新消息并保存其中一个章节，章节不存在时新增
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - message: 消息模型
+	//   - section: 章节模型，保存时写入消息ID
+	//
+	// 返回值：
+	//   - error: 错误信息
+	UpdateMessageWithSection(ctx *gin.Context, message *conversationModel.Message, section *conversationModel.MessageSection) error
+
+	// GetMessageSections 获取消息的所有章节
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - messageID: 消息ID
+	//
+	// 返回值：
+	//   - []*conversationModel.MessageSection: 章节列表，按章节顺序排列
+	//   - error: 错误信息
+	GetMessageSections(ctx *gin.Context, messageID int64) ([]*conversationModel.MessageSection, error)
+
+	// GetMessageByID 根据 ID 获取消息
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - id: 消息ID
+	//
+	// 返回值：
+	//   - *conversationModel.Message: 消息模型
+	//   - error: 错误信息
+	GetMessageByID(ctx *gin.Context, id int64) (*conversationModel.Message, error)
+
 	// GetMessagesByConversationID 获取对话的所有消息
 	// 参数：
 	//   - ctx: 上下文信息
@@ -80,6 +120,16 @@ type ConversationMapper interface {
 	//   - error: 错误信息
 	GetMessagesByConversationID(ctx *gin.Context, conversationID int64) ([]*conversationModel.Message, error)
 
+	// SumTokenUsageByConversationID 统计对话所有消息的 Token 用量之和
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - conversationID: 对话ID
+	//
+	// 返回值：
+	//   - int: Token 用量之和
+	//   - error: 错误信息
+	SumTokenUsageByConversationID(ctx *gin.Context, conversationID int64) (int, error)
+
 	// GetLatestConversationHistory 获取最近的对话历史
 	// 参数：
 	//   - ctx: 上下文信息
@@ -173,6 +173,97 @@ func (m *ConversationMapperImpl) SaveMessage(ctx *gin.Context, message *conversa
 	})
 }
 
+// SaveMessageWithSections 在同一事务中保存消息及其章节
+// 参数：
+//   - ctx: 上下文信息
+//   - message: 消息模型
+//   - sections: 章节列表，保存时写入消息ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ConversationMapperImpl) SaveMessageWithSections(ctx *gin.Context, message *conversationModel.Message, sections []*conversationModel.MessageSection) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(message).Error; err != nil {
+			return fmt.Errorf("保存消息记录失败: %w", err)
+		}
+		if len(sections) == 0 {
+			return nil
+		}
+		for _, section := range sections {
+			section.MessageID = message.ID
+		}
+		if err := db.Create(&sections).Error; err != nil {
+			return fmt.Errorf("保存消息章节失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// UpdateMessageWithSection 在同一事务中更新消息并保存其中一个章节，章节不存在时新增
+// 参数：
+//   - ctx: 上下文信息
+//   - message: 消息模型
+//   - section: 章节模型，保存时写入消息ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ConversationMapperImpl) UpdateMessageWithSection(ctx *gin.Context, message *conversationModel.Message, section *conversationModel.MessageSection) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Save(message).Error; err != nil {
+			return fmt.Errorf("更新消息记录失败: %w", err)
+		}
+		section.MessageID = message.ID
+		if err := db.Save(section).Error; err != nil {
+			return fmt.Errorf("保存消息章节失败: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetMessageSections 获取消息的所有章节
+// 参数：
+//   - ctx: 上下文信息
+//   - messageID: 消息ID
+//
+// 返回值：
+//   - []*conversationModel.MessageSection: 章节列表，按章节顺序排列
+//   - error: 错误信息
+func (m *ConversationMapperImpl) GetMessageSections(ctx *gin.Context, messageID int64) ([]*conversationModel.MessageSection, error) {
+	var sections []*conversationModel.MessageSection
+	db := utils.GetDBFromContext(ctx)
+	err := db.
+		Where("message_id = ? AND deleted = ?", messageID, false).
+		Order("sort ASC").
+		Find(&sections).Error
+	if err != nil {
+		return nil, fmt.Errorf("查询消息章节失败: %w", err)
+	}
+	return sections, nil
+}
+
+// GetMessageByID 根据 ID 获取消息
+// 参数：
+//   - ctx: 上下文信息
+//   - id: 消息ID
+//
+// 返回值：
+//   - *conversationModel.Message: 消息模型
+//   - error: 错误信息
+func (m *ConversationMapperImpl) GetMessageByID(ctx *gin.Context, id int64) (*conversationModel.Message, error) {
+	var message conversationModel.Message
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("id = ? AND deleted = ?", id, false).First(&message).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("消息记录不存在")
+		}
+		return nil, fmt.Errorf("查询消息记录失败: %w", err)
+	}
+	return &message, nil
+}
+
 // GetMessagesByConversationID 获取对话的所有消息
 // 参数：
 //   - ctx: 上下文信息
@@ -194,6 +285,27 @@ func (m *ConversationMapperImpl) GetMessagesByConversationID(ctx *gin.Context, c
 	return messages, nil
 }
 
+// SumTokenUsageByConversationID 统计对话所有消息的 Token 用量之和
+// 参数：
+//   - ctx: 上下文信息
+//   - conversationID: 对话ID
+//
+// 返回值：
+//   - int: Token 用量之和
+//   - error: 错误信息
+func (m *ConversationMapperImpl) SumTokenUsageByConversationID(ctx *gin.Context, conversationID int64) (int, error) {
+	var total int
+	db := utils.GetDBFromContext(ctx)
+	err := db.Model(&conversationModel.Message{}).
+		Select("COALESCE(SUM(token_usage), 0)").
+		Where("conversation_id = ? AND deleted = ?", conversationID, false).
+		Scan(&total).Error
+	if err != nil {
+		return 0, fmt.Errorf("统计 Token 用量失败: %w", err)
+	}
+	return total, nil
+}
+
 // GetLatestConversationHistory 获取最近的对话历史
 // 参数：
 //   - ctx: 上下文信息
@@ -0,0 +1,48 @@
+// Package event 提供人生事件相关的数据访问接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package event
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/model/event"
+)
+
+// EventMapper 人生事件数据访问接口
+type EventMapper interface {
+	// CreateOneEvent 在事务中创建人生事件
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - lifeEvent: 人生事件记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneEvent(ctx *gin.Context, lifeEvent *event.LifeEvent) error
+
+	// GetOneEventByID 根据 ID 获取人生事件
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - id: 事件 ID
+	// 返回值：
+	//   - *event.LifeEvent: 人生事件记录
+	//   - error: 错误信息
+	GetOneEventByID(ctx *gin.Context, id int64) (*event.LifeEvent, error)
+
+	// GetEventsByBaziID 获取用户在某八字下的全部人生事件，按事件日期升序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	//   - baziID: 八字 ID
+	// 返回值：
+	//   - []*event.LifeEvent: 人生事件列表
+	//   - error: 错误信息
+	GetEventsByBaziID(ctx *gin.Context, userID, baziID int64) ([]*event.LifeEvent, error)
+
+	// DeleteOneEvent 逻辑删除人生事件
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - id: 事件 ID
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneEvent(ctx *gin.Context, id int64) error
+}
@@ -0,0 +1,104 @@
+// Package impl 提供人生事件相关的数据访问实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/Done-0/metaphysics/internal/model/event"
+	"github.com/Done-0/metaphysics/internal/utils"
+	eventMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/event"
+)
+
+// EventMapperImpl 人生事件数据访问实现
+type EventMapperImpl struct{}
+
+// NewEventMapper 创建人生事件数据访问实例
+// 返回值：
+//   - eventMapper.EventMapper: 人生事件数据访问接口
+func NewEventMapper() eventMapper.EventMapper {
+	return &EventMapperImpl{}
+}
+
+// CreateOneEvent 在事务中创建人生事件
+// 参数：
+//   - ctx: Gin上下文
+//   - lifeEvent: 人生事件记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *EventMapperImpl) CreateOneEvent(ctx *gin.Context, lifeEvent *event.LifeEvent) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(lifeEvent).Error; err != nil {
+			return fmt.Errorf("保存人生事件失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetOneEventByID 根据 ID 获取人生事件
+// 参数：
+//   - ctx: 上下文信息
+//   - id: 事件 ID
+//
+// 返回值：
+//   - *event.LifeEvent: 人生事件记录
+//   - error: 错误信息
+func (m *EventMapperImpl) GetOneEventByID(ctx *gin.Context, id int64) (*event.LifeEvent, error) {
+	var lifeEvent event.LifeEvent
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("id = ? AND deleted = ?", id, false).First(&lifeEvent).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("人生事件不存在")
+		}
+		return nil, fmt.Errorf("查询人生事件失败: %w", err)
+	}
+
+	return &lifeEvent, nil
+}
+
+// GetEventsByBaziID 获取用户在某八字下的全部人生事件，按事件日期升序
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//   - baziID: 八字 ID
+//
+// 返回值：
+//   - []*event.LifeEvent: 人生事件列表
+//   - error: 错误信息
+func (m *EventMapperImpl) GetEventsByBaziID(ctx *gin.Context, userID, baziID int64) ([]*event.LifeEvent, error) {
+	var events []*event.LifeEvent
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("user_id = ? AND bazi_id = ? AND deleted = ?", userID, baziID, false).
+		Order("event_date ASC").
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("查询人生事件列表失败: %w", err)
+	}
+
+	return events, nil
+}
+
+// DeleteOneEvent 逻辑删除人生事件
+// 参数：
+//   - ctx: 上下文信息
+//   - id: 事件 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *EventMapperImpl) DeleteOneEvent(ctx *gin.Context, id int64) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Model(&event.LifeEvent{}).Where("id = ?", id).Update("deleted", true).Error; err != nil {
+			return fmt.Errorf("删除人生事件失败: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,66 @@
+// Package fortune 提供每日运势相关的数据访问接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package fortune
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/model/fortune"
+)
+
+// FortuneMapper 每日运势数据访问接口
+type FortuneMapper interface {
+	// SaveSubscription 创建或更新运势订阅
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - subscription: 订阅记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	SaveSubscription(ctx *gin.Context, subscription *fortune.FortuneSubscription) error
+
+	// GetSubscriptionByUserID 获取用户的运势订阅
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	// 返回值：
+	//   - *fortune.FortuneSubscription: 订阅记录，不存在时返回 nil
+	//   - error: 错误信息
+	GetSubscriptionByUserID(ctx *gin.Context, userID int64) (*fortune.FortuneSubscription, error)
+
+	// ListActiveSubscriptions 按 ID 游标分批获取启用中的订阅
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - afterID: 上一批最后一条记录的 ID
+	//   - limit: 每批数量
+	// 返回值：
+	//   - []*fortune.FortuneSubscription: 订阅列表
+	//   - error: 错误信息
+	ListActiveSubscriptions(ctx *gin.Context, afterID int64, limit int) ([]*fortune.FortuneSubscription, error)
+
+	// SaveDailyFortune 保存每日运势，同一用户同一天已存在时覆盖
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - dailyFortune: 每日运势记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	SaveDailyFortune(ctx *gin.Context, dailyFortune *fortune.DailyFortune) error
+
+	// GetDailyFortune 获取用户某天的运势
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	//   - date: 日期 (YYYY-MM-DD)
+	// 返回值：
+	//   - *fortune.DailyFortune: 每日运势，不存在时返回 nil
+	//   - error: 错误信息
+	GetDailyFortune(ctx *gin.Context, userID int64, date string) (*fortune.DailyFortune, error)
+
+	// MarkEmailed 标记每日运势已发送邮件
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - id: 每日运势 ID
+	// 返回值：
+	//   - error: 错误信息
+	MarkEmailed(ctx *gin.Context, id int64) error
+}
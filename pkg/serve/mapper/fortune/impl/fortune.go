@@ -0,0 +1,161 @@
+// Package impl 提供每日运势相关的数据访问实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/Done-0/metaphysics/internal/model/fortune"
+	"github.com/Done-0/metaphysics/internal/utils"
+	fortuneMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/fortune"
+)
+
+// FortuneMapperImpl 每日运势数据访问实现
+type FortuneMapperImpl struct{}
+
+// NewFortuneMapper 创建每日运势数据访问实例
+// 返回值：
+//   - fortuneMapper.FortuneMapper: 每日运势数据访问接口
+func NewFortuneMapper() fortuneMapper.FortuneMapper {
+	return &FortuneMapperImpl{}
+}
+
+// SaveSubscription 创建或更新运势订阅
+// 参数：
+//   - ctx: Gin上下文
+//   - subscription: 订阅记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *FortuneMapperImpl) SaveSubscription(ctx *gin.Context, subscription *fortune.FortuneSubscription) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Save(subscription).Error; err != nil {
+			return fmt.Errorf("保存运势订阅失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetSubscriptionByUserID 获取用户的运势订阅
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//
+// 返回值：
+//   - *fortune.FortuneSubscription: 订阅记录，不存在时返回 nil
+//   - error: 错误信息
+func (m *FortuneMapperImpl) GetSubscriptionByUserID(ctx *gin.Context, userID int64) (*fortune.FortuneSubscription, error) {
+	var subscription fortune.FortuneSubscription
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("user_id = ? AND deleted = ?", userID, false).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("查询运势订阅失败: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+// ListActiveSubscriptions 按 ID 游标分批获取启用中的订阅
+// 参数：
+//   - ctx: 上下文信息
+//   - afterID: 上一批最后一条记录的 ID
+//   - limit: 每批数量
+//
+// 返回值：
+//   - []*fortune.FortuneSubscription: 订阅列表
+//   - error: 错误信息
+func (m *FortuneMapperImpl) ListActiveSubscriptions(ctx *gin.Context, afterID int64, limit int) ([]*fortune.FortuneSubscription, error) {
+	var subscriptions []*fortune.FortuneSubscription
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("id > ? AND active = ? AND deleted = ?", afterID, true, false).
+		Order("id ASC").
+		Limit(limit).
+		Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("查询运势订阅列表失败: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// SaveDailyFortune 保存每日运势，同一用户同一天已存在时覆盖
+// 参数：
+//   - ctx: Gin上下文
+//   - dailyFortune: 每日运势记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *FortuneMapperImpl) SaveDailyFortune(ctx *gin.Context, dailyFortune *fortune.DailyFortune) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+
+		var existing fortune.DailyFortune
+		err := db.Where("user_id = ? AND fortune_date = ?", dailyFortune.UserID, dailyFortune.FortuneDate).First(&existing).Error
+		switch {
+		case err == nil:
+			dailyFortune.ID = existing.ID
+			dailyFortune.GmtCreate = existing.GmtCreate
+			dailyFortune.Ext = existing.Ext
+			dailyFortune.Emailed = existing.Emailed
+			if err := db.Save(dailyFortune).Error; err != nil {
+				return fmt.Errorf("更新每日运势失败: %w", err)
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := db.Create(dailyFortune).Error; err != nil {
+				return fmt.Errorf("保存每日运势失败: %w", err)
+			}
+		default:
+			return fmt.Errorf("查询每日运势失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetDailyFortune 获取用户某天的运势
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//   - date: 日期 (YYYY-MM-DD)
+//
+// 返回值：
+//   - *fortune.DailyFortune: 每日运势，不存在时返回 nil
+//   - error: 错误信息
+func (m *FortuneMapperImpl) GetDailyFortune(ctx *gin.Context, userID int64, date string) (*fortune.DailyFortune, error) {
+	var dailyFortune fortune.DailyFortune
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("user_id = ? AND fortune_date = ? AND deleted = ?", userID, date, false).First(&dailyFortune).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("查询每日运势失败: %w", err)
+	}
+
+	return &dailyFortune, nil
+}
+
+// MarkEmailed 标记每日运势已发送邮件
+// 参数：
+//   - ctx: 上下文信息
+//   - id: 每日运势 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *FortuneMapperImpl) MarkEmailed(ctx *gin.Context, id int64) error {
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Model(&fortune.DailyFortune{}).Where("id = ?", id).Update("emailed", true).Error; err != nil {
+		return fmt.Errorf("标记运势邮件状态失败: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// Package impl 提供人生日志相关的数据访问实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/Done-0/metaphysics/internal/model/journal"
+	"github.com/Done-0/metaphysics/internal/utils"
+	journalMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/journal"
+)
+
+// JournalMapperImpl 人生日志数据访问实现
+type JournalMapperImpl struct{}
+
+// NewJournalMapper 创建人生日志数据访问实例
+// 返回值：
+//   - journalMapper.JournalMapper: 人生日志数据访问接口
+func NewJournalMapper() journalMapper.JournalMapper {
+	return &JournalMapperImpl{}
+}
+
+// CreateOneEntry 在事务中创建日志
+// 参数：
+//   - ctx: Gin上下文
+//   - entry: 日志记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *JournalMapperImpl) CreateOneEntry(ctx *gin.Context, entry *journal.JournalEntry) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(entry).Error; err != nil {
+			return fmt.Errorf("保存日志失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetOneEntryByID 根据 ID 获取日志
+// 参数：
+//   - ctx: 上下文信息
+//   - id: 日志 ID
+//
+// 返回值：
+//   - *journal.JournalEntry: 日志记录
+//   - error: 错误信息
+func (m *JournalMapperImpl) GetOneEntryByID(ctx *gin.Context, id int64) (*journal.JournalEntry, error) {
+	var entry journal.JournalEntry
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("id = ? AND deleted = ?", id, false).First(&entry).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("日志不存在")
+		}
+		return nil, fmt.Errorf("查询日志失败: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// SearchEntries 按条件检索日志，按日志日期倒序
+// 参数：
+//   - ctx: 上下文信息
+//   - query: 检索条件
+//   - pageNo: 页码
+//   - pageSize: 每页数量，小于等于 0 时返回全部
+//
+// 返回值：
+//   - []*journal.JournalEntry: 日志列表
+//   - int64: 总记录数
+//   - error: 错误信息
+func (m *JournalMapperImpl) SearchEntries(ctx *gin.Context, query *journalMapper.JournalQuery, pageNo, pageSize int) ([]*journal.JournalEntry, int64, error) {
+	var entries []*journal.JournalEntry
+	var total int64
+
+	db := utils.GetDBFromContext(ctx)
+	q := db.Model(&journal.JournalEntry{}).Where("user_id = ? AND deleted = ?", query.UserID, false)
+
+	if query.BaziID != 0 {
+		q = q.Where("bazi_id = ?", query.BaziID)
+	}
+	if query.Keyword != "" {
+		like := "%" + utils.EscapeLike(query.Keyword) + "%"
+		q = q.Where(fmt.Sprintf("(title LIKE ? %[1]s OR content LIKE ? %[1]s OR tags LIKE ? %[1]s OR interactions LIKE ? %[1]s)", utils.LikeEscapeClause(q)), like, like, like, like)
+	}
+	if query.GanZhi != "" {
+		q = q.Where("(luck_pillar = ? OR year_pillar = ? OR month_pillar = ? OR day_pillar = ?)", query.GanZhi, query.GanZhi, query.GanZhi, query.GanZhi)
+	}
+	if !query.StartDate.IsZero() {
+		q = q.Where("entry_date >= ?", query.StartDate)
+	}
+	if !query.EndDate.IsZero() {
+		q = q.Where("entry_date <= ?", query.EndDate)
+	}
+
+	// 计算总数
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询日志总数失败: %w", err)
+	}
+
+	q = q.Order("entry_date DESC")
+	if pageSize > 0 {
+		q = q.Offset((pageNo - 1) * pageSize).Limit(pageSize)
+	}
+	if err := q.Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询日志列表失败: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// DeleteOneEntry 逻辑删除日志
+// 参数：
+//   - ctx: 上下文信息
+//   - id: 日志 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *JournalMapperImpl) DeleteOneEntry(ctx *gin.Context, id int64) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Model(&journal.JournalEntry{}).Where("id = ?", id).Update("deleted", true).Error; err != nil {
+			return fmt.Errorf("删除日志失败: %w", err)
+		}
+
+		return nil
+	})
+}
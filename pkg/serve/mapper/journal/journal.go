@@ -0,0 +1,62 @@
+// Package journal 提供人生日志相关的数据访问接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package journal
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/model/journal"
+)
+
+// JournalQuery 日志检索条件
+type JournalQuery struct {
+	UserID    int64     // 用户 ID
+	BaziID    int64     // 八字 ID，0 表示不限
+	Keyword   string    // 关键字，匹配标题、内容、标签与岁运注解
+	GanZhi    string    // 岁运干支，匹配大运、流年、流月、流日
+	StartDate time.Time // 起始日期，零值表示不限
+	EndDate   time.Time // 结束日期，零值表示不限
+}
+
+// JournalMapper 人生日志数据访问接口
+type JournalMapper interface {
+	// CreateOneEntry 在事务中创建日志
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - entry: 日志记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneEntry(ctx *gin.Context, entry *journal.JournalEntry) error
+
+	// GetOneEntryByID 根据 ID 获取日志
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - id: 日志 ID
+	// 返回值：
+	//   - *journal.JournalEntry: 日志记录
+	//   - error: 错误信息
+	GetOneEntryByID(ctx *gin.Context, id int64) (*journal.JournalEntry, error)
+
+	// SearchEntries 按条件检索日志，按日志日期倒序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - query: 检索条件
+	//   - pageNo: 页码
+	//   - pageSize: 每页数量，小于等于 0 时返回全部
+	// 返回值：
+	//   - []*journal.JournalEntry: 日志列表
+	//   - int64: 总记录数
+	//   - error: 错误信息
+	SearchEntries(ctx *gin.Context, query *JournalQuery, pageNo, pageSize int) ([]*journal.JournalEntry, int64, error)
+
+	// DeleteOneEntry 逻辑删除日志
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - id: 日志 ID
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneEntry(ctx *gin.Context, id int64) error
+}
@@ -0,0 +1,205 @@
+// Package impl 提供流年报告相关的数据访问实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/Done-0/metaphysics/internal/model/report"
+	"github.com/Done-0/metaphysics/internal/utils"
+	reportMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/report"
+)
+
+// ReportMapperImpl 流年报告数据访问实现
+type ReportMapperImpl struct{}
+
+// NewReportMapper 创建流年报告数据访问实例
+// 返回值：
+//   - reportMapper.ReportMapper: 流年报告数据访问接口
+func NewReportMapper() reportMapper.ReportMapper {
+	return &ReportMapperImpl{}
+}
+
+// SaveSubscription 创建或更新流年报告订阅
+// 参数：
+//   - ctx: Gin上下文
+//   - subscription: 订阅记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ReportMapperImpl) SaveSubscription(ctx *gin.Context, subscription *report.AnnualReportSubscription) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Save(subscription).Error; err != nil {
+			return fmt.Errorf("保存流年报告订阅失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetSubscriptionByUserID 获取用户的流年报告订阅
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//
+// 返回值：
+//   - *report.AnnualReportSubscription: 订阅记录，不存在时返回 nil
+//   - error: 错误信息
+func (m *ReportMapperImpl) GetSubscriptionByUserID(ctx *gin.Context, userID int64) (*report.AnnualReportSubscription, error) {
+	var subscription report.AnnualReportSubscription
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("user_id = ? AND deleted = ?", userID, false).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("查询流年报告订阅失败: %w", err)
+	}
+
+	return &subscription, nil
+}
+
+// ListActiveSubscriptions 按 ID 游标分批获取启用中的订阅
+// 参数：
+//   - ctx: 上下文信息
+//   - afterID: 上一批最后一条记录的 ID
+//   - limit: 每批数量
+//
+// 返回值：
+//   - []*report.AnnualReportSubscription: 订阅列表
+//   - error: 错误信息
+func (m *ReportMapperImpl) ListActiveSubscriptions(ctx *gin.Context, afterID int64, limit int) ([]*report.AnnualReportSubscription, error) {
+	var subscriptions []*report.AnnualReportSubscription
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Where("id > ? AND active = ? AND deleted = ?", afterID, true, false).
+		Order("id ASC").
+		Limit(limit).
+		Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("查询流年报告订阅列表失败: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// CreateOneReport 创建报告，版本号在事务内按同一用户同一流年的最大版本号递增
+// 参数：
+//   - ctx: Gin上下文
+//   - annualReport: 报告记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ReportMapperImpl) CreateOneReport(ctx *gin.Context, annualReport *report.AnnualReport) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+
+		var maxVersion int
+		if err := db.Model(&report.AnnualReport{}).
+			Where("user_id = ? AND year = ?", annualReport.UserID, annualReport.Year).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&maxVersion).Error; err != nil {
+			return fmt.Errorf("查询报告版本失败: %w", err)
+		}
+
+		annualReport.Version = maxVersion + 1
+		if err := db.Create(annualReport).Error; err != nil {
+			return fmt.Errorf("创建报告失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UpdateOneReport 更新报告
+// 参数：
+//   - ctx: Gin上下文
+//   - annualReport: 报告记录
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ReportMapperImpl) UpdateOneReport(ctx *gin.Context, annualReport *report.AnnualReport) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Save(annualReport).Error; err != nil {
+			return fmt.Errorf("更新报告失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetReport 获取报告
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//   - year: 流年所在公历年份
+//   - version: 版本号，为 0 时返回最新版本
+//
+// 返回值：
+//   - *report.AnnualReport: 报告
+//   - error: 错误信息
+func (m *ReportMapperImpl) GetReport(ctx *gin.Context, userID int64, year, version int) (*report.AnnualReport, error) {
+	var annualReport report.AnnualReport
+	db := utils.GetDBFromContext(ctx).Where("user_id = ? AND year = ? AND deleted = ?", userID, year, false)
+	if version > 0 {
+		db = db.Where("version = ?", version)
+	}
+
+	if err := db.Order("version DESC").First(&annualReport).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("报告不存在")
+		}
+		return nil, fmt.Errorf("获取报告失败: %w", err)
+	}
+
+	return &annualReport, nil
+}
+
+// ListReports 获取用户的报告列表，按流年与版本倒序
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//   - year: 流年所在公历年份，为 0 时返回全部
+//
+// 返回值：
+//   - []*report.AnnualReport: 报告列表
+//   - error: 错误信息
+func (m *ReportMapperImpl) ListReports(ctx *gin.Context, userID int64, year int) ([]*report.AnnualReport, error) {
+	var reports []*report.AnnualReport
+	db := utils.GetDBFromContext(ctx).Where("user_id = ? AND deleted = ?", userID, false)
+	if year > 0 {
+		db = db.Where("year = ?", year)
+	}
+
+	if err := db.Omit("content", "highlights").Order("year DESC, version DESC").Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("获取报告列表失败: %w", err)
+	}
+
+	return reports, nil
+}
+
+// HasCompletedReport 判断用户某一流年是否已有生成完成的报告
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//   - year: 流年所在公历年份
+//
+// 返回值：
+//   - bool: 是否存在
+//   - error: 错误信息
+func (m *ReportMapperImpl) HasCompletedReport(ctx *gin.Context, userID int64, year int) (bool, error) {
+	var count int64
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Model(&report.AnnualReport{}).
+		Where("user_id = ? AND year = ? AND status = ? AND deleted = ?", userID, year, report.STATUS_COMPLETED, false).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("查询报告状态失败: %w", err)
+	}
+
+	return count > 0, nil
+}
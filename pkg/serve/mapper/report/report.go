@@ -0,0 +1,87 @@
+// Package report 提供流年报告相关的数据访问接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/model/report"
+)
+
+// ReportMapper 流年报告数据访问接口
+type ReportMapper interface {
+	// SaveSubscription 创建或更新流年报告订阅
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - subscription: 订阅记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	SaveSubscription(ctx *gin.Context, subscription *report.AnnualReportSubscription) error
+
+	// GetSubscriptionByUserID 获取用户的流年报告订阅
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	// 返回值：
+	//   - *report.AnnualReportSubscription: 订阅记录，不存在时返回 nil
+	//   - error: 错误信息
+	GetSubscriptionByUserID(ctx *gin.Context, userID int64) (*report.AnnualReportSubscription, error)
+
+	// ListActiveSubscriptions 按 ID 游标分批获取启用中的订阅
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - afterID: 上一批最后一条记录的 ID
+	//   - limit: 每批数量
+	// 返回值：
+	//   - []*report.AnnualReportSubscription: 订阅列表
+	//   - error: 错误信息
+	ListActiveSubscriptions(ctx *gin.Context, afterID int64, limit int) ([]*report.AnnualReportSubscription, error)
+
+	// CreateOneReport 创建报告，版本号在事务内按同一用户同一流年的最大版本号递增
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - annualReport: 报告记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneReport(ctx *gin.Context, annualReport *report.AnnualReport) error
+
+	// UpdateOneReport 更新报告
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - annualReport: 报告记录
+	// 返回值：
+	//   - error: 操作过程中的错误
+	UpdateOneReport(ctx *gin.Context, annualReport *report.AnnualReport) error
+
+	// GetReport 获取报告
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	//   - year: 流年所在公历年份
+	//   - version: 版本号，为 0 时返回最新版本
+	// 返回值：
+	//   - *report.AnnualReport: 报告
+	//   - error: 错误信息
+	GetReport(ctx *gin.Context, userID int64, year, version int) (*report.AnnualReport, error)
+
+	// ListReports 获取用户的报告列表，按流年与版本倒序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	//   - year: 流年所在公历年份，为 0 时返回全部
+	// 返回值：
+	//   - []*report.AnnualReport: 报告列表
+	//   - error: 错误信息
+	ListReports(ctx *gin.Context, userID int64, year int) ([]*report.AnnualReport, error)
+
+	// HasCompletedReport 判断用户某一流年是否已有生成完成的报告
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	//   - year: 流年所在公历年份
+	// 返回值：
+	//   - bool: 是否存在
+	//   - error: 错误信息
+	HasCompletedReport(ctx *gin.Context, userID int64, year int) (bool, error)
+}
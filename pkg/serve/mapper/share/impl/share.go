@@ -0,0 +1,134 @@
+// Package impl 提供分享链接相关的数据访问实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/Done-0/metaphysics/internal/model/share"
+	"github.com/Done-0/metaphysics/internal/utils"
+	shareMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/share"
+)
+
+// ShareMapperImpl 分享链接数据访问实现
+type ShareMapperImpl struct{}
+
+// NewShareMapper 创建分享链接数据访问实例
+// 返回值：
+//   - shareMapper.ShareMapper: 分享链接数据访问接口
+func NewShareMapper() shareMapper.ShareMapper {
+	return &ShareMapperImpl{}
+}
+
+// CreateOneShare 在事务中创建分享链接
+// 参数：
+//   - ctx: Gin上下文
+//   - link: 分享链接
+//
+// 返回值：
+//   - error: 操作过程中的错误
+func (m *ShareMapperImpl) CreateOneShare(ctx *gin.Context, link *share.ShareLink) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		if err := db.Create(link).Error; err != nil {
+			return fmt.Errorf("保存分享链接失败: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetOneShareByID 根据 ID 获取分享链接，供公开访问时校验，不限归属
+// 参数：
+//   - ctx: 上下文信息
+//   - id: 分享链接 ID
+//
+// 返回值：
+//   - *share.ShareLink: 分享链接
+//   - error: 错误信息
+func (m *ShareMapperImpl) GetOneShareByID(ctx *gin.Context, id int64) (*share.ShareLink, error) {
+	var link share.ShareLink
+	db := utils.GetDBFromContext(ctx)
+	err := db.Where("id = ? AND deleted = ?", id, false).First(&link).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("分享链接不存在")
+		}
+		return nil, fmt.Errorf("查询分享链接失败: %w", err)
+	}
+
+	return &link, nil
+}
+
+// GetSharesByUserID 分页获取用户创建的分享链接，按创建时间倒序
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//   - pageNo: 页码
+//   - pageSize: 每页数量
+//
+// 返回值：
+//   - []*share.ShareLink: 分享链接列表
+//   - int64: 总记录数
+//   - error: 错误信息
+func (m *ShareMapperImpl) GetSharesByUserID(ctx *gin.Context, userID int64, pageNo, pageSize int) ([]*share.ShareLink, int64, error) {
+	var links []*share.ShareLink
+	var total int64
+
+	db := utils.GetDBFromContext(ctx)
+	q := db.Model(&share.ShareLink{}).Where("user_id = ? AND deleted = ?", userID, false)
+
+	// 计算总数
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询分享链接总数失败: %w", err)
+	}
+
+	if err := q.Order("gmt_create DESC, id DESC").Offset((pageNo - 1) * pageSize).Limit(pageSize).Find(&links).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询分享链接列表失败: %w", err)
+	}
+
+	return links, total, nil
+}
+
+// RevokeOneShare 撤销用户的分享链接
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//   - id: 分享链接 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ShareMapperImpl) RevokeOneShare(ctx *gin.Context, userID, id int64) error {
+	return utils.RunDBTransaction(ctx, func() error {
+		db := utils.GetDBFromContext(ctx)
+		result := db.Model(&share.ShareLink{}).Where("id = ? AND user_id = ? AND deleted = ?", id, userID, false).Update("revoked", true)
+		if result.Error != nil {
+			return fmt.Errorf("撤销分享链接失败: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("分享链接不存在")
+		}
+
+		return nil
+	})
+}
+
+// IncrViewCount 累加分享链接访问次数
+// 参数：
+//   - ctx: 上下文信息
+//   - id: 分享链接 ID
+//
+// 返回值：
+//   - error: 错误信息
+func (m *ShareMapperImpl) IncrViewCount(ctx *gin.Context, id int64) error {
+	db := utils.GetDBFromContext(ctx)
+	if err := db.Model(&share.ShareLink{}).Where("id = ?", id).UpdateColumn("view_count", gorm.Expr("view_count + ?", 1)).Error; err != nil {
+		return fmt.Errorf("更新分享链接访问次数失败: %w", err)
+	}
+
+	return nil
+}
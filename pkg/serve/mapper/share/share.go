@@ -0,0 +1,59 @@
+// Package share 提供分享链接相关的数据访问接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package share
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/model/share"
+)
+
+// ShareMapper 分享链接数据访问接口
+type ShareMapper interface {
+	// CreateOneShare 在事务中创建分享链接
+	// 参数：
+	//   - ctx: Gin上下文
+	//   - link: 分享链接
+	// 返回值：
+	//   - error: 操作过程中的错误
+	CreateOneShare(ctx *gin.Context, link *share.ShareLink) error
+
+	// GetOneShareByID 根据 ID 获取分享链接，供公开访问时校验，不限归属
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - id: 分享链接 ID
+	// 返回值：
+	//   - *share.ShareLink: 分享链接
+	//   - error: 错误信息
+	GetOneShareByID(ctx *gin.Context, id int64) (*share.ShareLink, error)
+
+	// GetSharesByUserID 分页获取用户创建的分享链接，按创建时间倒序
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	//   - pageNo: 页码
+	//   - pageSize: 每页数量
+	// 返回值：
+	//   - []*share.ShareLink: 分享链接列表
+	//   - int64: 总记录数
+	//   - error: 错误信息
+	GetSharesByUserID(ctx *gin.Context, userID int64, pageNo, pageSize int) ([]*share.ShareLink, int64, error)
+
+	// RevokeOneShare 撤销用户的分享链接
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - userID: 用户 ID
+	//   - id: 分享链接 ID
+	// 返回值：
+	//   - error: 错误信息
+	RevokeOneShare(ctx *gin.Context, userID, id int64) error
+
+	// IncrViewCount 累加分享链接访问次数
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - id: 分享链接 ID
+	// 返回值：
+	//   - error: 错误信息
+	IncrViewCount(ctx *gin.Context, id int64) error
+}
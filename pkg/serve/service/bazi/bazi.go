@@ -4,6 +4,8 @@
 package bazi
 
 import (
+	"io"
+
 	"github.com/Done-0/metaphysics/pkg/serve/controller/bazi/dto"
 	baziVO "github.com/Done-0/metaphysics/pkg/vo/bazi"
 	"github.com/gin-gonic/gin"
@@ -36,4 +38,94 @@ type BaziService interface {
 	//   - *baziVO.BaziListResponse: 八字列表视图对象
 	//   - error: 错误信息
 	GetBaziList(ctx *gin.Context, req *dto.GetBaziListRequest) (*baziVO.BaziListResponse, error)
+
+	// RenderBaziImage 生成命盘图片
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *baziVO.BaziImageResponse: 命盘图片
+	//   - error: 错误信息
+	RenderBaziImage(ctx *gin.Context, req *dto.RenderBaziImageRequest) (*baziVO.BaziImageResponse, error)
+
+	// UpdateBazi 修改八字出生信息、标签与备注，并重新排盘
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *baziVO.BaziResponse: 修改后的八字
+	//   - error: 错误信息
+	UpdateBazi(ctx *gin.Context, req *dto.UpdateBaziRequest) (*baziVO.BaziResponse, error)
+
+	// DeleteBazi 将八字移入回收站
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	DeleteBazi(ctx *gin.Context, req *dto.BaziIDRequest) error
+
+	// RestoreBazi 从回收站恢复八字
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *baziVO.BaziResponse: 恢复后的八字
+	//   - error: 错误信息
+	RestoreBazi(ctx *gin.Context, req *dto.BaziIDRequest) (*baziVO.BaziResponse, error)
+
+	// PurgeBazi 永久删除回收站中的八字
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	PurgeBazi(ctx *gin.Context, req *dto.BaziIDRequest) error
+
+	// DuplicateBazi 复制八字为新的档案
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *baziVO.BaziResponse: 新档案
+	//   - error: 错误信息
+	DuplicateBazi(ctx *gin.Context, req *dto.BaziIDRequest) (*baziVO.BaziResponse, error)
+
+	// GetBaziTrash 获取回收站中的八字列表
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *baziVO.BaziListResponse: 八字列表视图对象
+	//   - error: 错误信息
+	GetBaziTrash(ctx *gin.Context, req *dto.GetBaziTrashRequest) (*baziVO.BaziListResponse, error)
+
+	// ImportBazi 从 CSV/XLSX 批量导入出生档案，同步完成逐行校验后在后台排盘入库
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	//   - fileName: 文件名，用于识别格式
+	//   - file: 文件内容
+	// 返回值：
+	//   - *baziVO.BaziImportResponse: 导入任务与校验错误
+	//   - error: 错误信息
+	ImportBazi(ctx *gin.Context, req *dto.ImportBaziRequest, fileName string, file io.Reader) (*baziVO.BaziImportResponse, error)
+
+	// GetImportJob 查询批量导入任务进度
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *baziVO.BaziImportJobResponse: 导入任务进度
+	//   - error: 错误信息
+	GetImportJob(ctx *gin.Context, req *dto.GetImportJobRequest) (*baziVO.BaziImportJobResponse, error)
+
+	// ImportBaziText 从粘贴的四柱文本或排盘软件导出的 JSON 导入命盘，仅有四柱时出生时间标记为未知
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *baziVO.BaziTextImportResponse: 导入的命盘与识别提示
+	//   - error: 错误信息
+	ImportBaziText(ctx *gin.Context, req *dto.ImportBaziTextRequest) (*baziVO.BaziTextImportResponse, error)
 }
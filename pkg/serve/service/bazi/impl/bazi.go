@@ -4,11 +4,19 @@
 package impl
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/chart"
 	"github.com/Done-0/metaphysics/internal/model/bazi"
+	"github.com/Done-0/metaphysics/internal/render"
 	"github.com/Done-0/metaphysics/internal/utils"
 	"github.com/Done-0/metaphysics/pkg/serve/controller/bazi/dto"
 	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
@@ -16,6 +24,25 @@ import (
 	baziVO "github.com/Done-0/metaphysics/pkg/vo/bazi"
 )
 
+// 命盘图片格式
+const (
+	IMAGE_FORMAT_SVG = "svg" // 矢量图
+	IMAGE_FORMAT_PNG = "png" // 位图
+)
+
+// 分页
+const (
+	DEFAULT_PAGE_SIZE = 10    // 默认每页数量
+	SORT_ORDER_ASC    = "asc" // 正序
+)
+
+// 档案标签
+const (
+	LABEL_MAX_LENGTH       = 50     // 标签最大长度（字符）
+	DUPLICATE_LABEL_SUFFIX = "（副本）" // 副本标签后缀
+	TAG_SEPARATOR          = ","    // 标签分隔符
+)
+
 // BaziServiceImpl 八字服务实现
 type BaziServiceImpl struct {
 	baziMapper baziMapper.BaziMapper
@@ -44,40 +71,41 @@ func NewBaziService(mapper baziMapper.BaziMapper) baziSrv.BaziService {
 //	*baziVO.BaziResponse: 八字分析结果
 //	error: 错误信息
 func (b *BaziServiceImpl) CalculateOneBazi(ctx *gin.Context, req *dto.CalculateBaziRequest) (*baziVO.BaziResponse, error) {
-	baziInfo := utils.CalculateBazi(req.BirthTime, req.Calendar)
-
-	bazi := &bazi.Bazi{
-		UserID:      0,
-		Name:        req.Name,
-		Gender:      req.Gender,
-		BirthTime:   req.BirthTime,
-		Calendar:    req.Calendar,
-		YearPillar:  baziInfo["year"],
-		MonthPillar: baziInfo["month"],
-		DayPillar:   baziInfo["day"],
-		HourPillar:  baziInfo["hour"],
-		YearGan:     baziInfo["year_gan"],
-		YearZhi:     baziInfo["year_zhi"],
-		MonthGan:    baziInfo["month_gan"],
-		MonthZhi:    baziInfo["month_zhi"],
-		DayGan:      baziInfo["day_gan"],
-		DayZhi:      baziInfo["day_zhi"],
-		HourGan:     baziInfo["hour_gan"],
-		HourZhi:     baziInfo["hour_zhi"],
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	relation, err := b.resolveRelation(ctx, userID, req.Relation)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := chart.Calculate(req.Name, req.Gender, req.BirthTime, req.Calendar)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("排盘失败: %v", err)
+		return nil, fmt.Errorf("排盘失败: %w", err)
 	}
 
+	bazi := c.ToModel()
+	bazi.UserID = userID
+	bazi.Relation = relation
+	bazi.Label = req.Label
+	bazi.Notes = req.Notes
+	bazi.Tags = joinTags(req.Tags)
+
 	if err := b.baziMapper.CreateOneBazi(ctx, bazi); err != nil {
 		utils.BizLogger(ctx).Errorf("存储八字失败: %v", err)
 		return nil, fmt.Errorf("存储八字失败: %w", err)
 	}
 
-	vo, err := utils.MapModelToVO(bazi, &baziVO.BaziResponse{})
+	vo, err := mapBaziToVO(bazi)
 	if err != nil {
 		utils.BizLogger(ctx).Errorf("分析八字结果时映射 VO 失败: %v", err)
-		return nil, fmt.Errorf("分析八字结果时映射 VO 失败: %w", err)
+		return nil, err
 	}
 
-	return vo.(*baziVO.BaziResponse), nil
+	return vo, nil
 }
 
 // GetOneBazi 获取八字
@@ -91,21 +119,26 @@ func (b *BaziServiceImpl) CalculateOneBazi(ctx *gin.Context, req *dto.CalculateB
 //	*baziVO.BaziResponse: 八字视图对象
 //	error: 错误信息
 func (b *BaziServiceImpl) GetOneBazi(ctx *gin.Context, req *dto.GetOneBaziRequest) (*baziVO.BaziResponse, error) {
-	bazi, err := b.baziMapper.GetOneBaziByID(ctx, req.ID)
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bazi, err := b.baziMapper.GetOneBaziByID(ctx, userID, req.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	getBaziVO, err := utils.MapModelToVO(bazi, &baziVO.BaziResponse{})
+	getBaziVO, err := mapBaziToVO(bazi)
 	if err != nil {
 		utils.BizLogger(ctx).Errorf("获取八字时映射 VO 失败: %v", err)
-		return nil, fmt.Errorf("获取八字时映射 VO 失败: %w", err)
+		return nil, err
 	}
 
-	return getBaziVO.(*baziVO.BaziResponse), nil
+	return getBaziVO, nil
 }
 
-// GetBaziList 获取八字列表
+// GetBaziList 按检索条件获取八字列表，传入页码时按页分页并统计总数，否则按游标分页
 // 参数：
 //
 //	ctx: 上下文信息
@@ -116,29 +149,586 @@ func (b *BaziServiceImpl) GetOneBazi(ctx *gin.Context, req *dto.GetOneBaziReques
 //	*baziVO.BaziListResponse: 八字列表视图对象
 //	error: 错误信息
 func (b *BaziServiceImpl) GetBaziList(ctx *gin.Context, req *dto.GetBaziListRequest) (*baziVO.BaziListResponse, error) {
-	bazis, total, err := b.baziMapper.GetBaziList(ctx, req.PageNo, req.PageSize)
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := buildBaziQuery(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DEFAULT_PAGE_SIZE
+	}
+
+	if req.PageNo > 0 && req.Cursor == "" {
+		bazis, total, err := b.baziMapper.GetBaziList(ctx, query, req.PageNo, pageSize)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("获取八字列表失败: %v", err)
+			return nil, fmt.Errorf("获取八字列表失败: %w", err)
+		}
+
+		return &baziVO.BaziListResponse{
+			Total:    total,
+			PageNo:   req.PageNo,
+			PageSize: pageSize,
+			List:     b.mapBaziList(ctx, bazis),
+			HasMore:  int64(req.PageNo*pageSize) < total,
+		}, nil
+	}
+
+	cursor, err := decodeCursor(req.Cursor, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// 多取一条用于判断是否还有下一页
+	bazis, err := b.baziMapper.GetBaziListByCursor(ctx, query, cursor, pageSize+1)
 	if err != nil {
 		utils.BizLogger(ctx).Errorf("获取八字列表失败: %v", err)
 		return nil, fmt.Errorf("获取八字列表失败: %w", err)
 	}
 
-	baziVOList := make([]*baziVO.BaziResponse, 0, len(bazis))
-	for _, item := range bazis {
-		vo, err := utils.MapModelToVO(item, &baziVO.BaziResponse{})
-		if err != nil {
-			utils.BizLogger(ctx).Errorf("获取八字列表时映射单个VO失败: %v", err)
-			continue
+	result := &baziVO.BaziListResponse{PageSize: pageSize}
+	if len(bazis) > pageSize {
+		bazis = bazis[:pageSize]
+		result.HasMore = true
+		result.NextCursor = encodeCursor(query, bazis[len(bazis)-1])
+	}
+	result.List = b.mapBaziList(ctx, bazis)
+
+	return result, nil
+}
+
+// RenderBaziImage 生成命盘图片
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	*baziVO.BaziImageResponse: 命盘图片
+//	error: 错误信息
+func (b *BaziServiceImpl) RenderBaziImage(ctx *gin.Context, req *dto.RenderBaziImageRequest) (*baziVO.BaziImageResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := b.baziMapper.GetOneBaziByID(ctx, userID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := chart.FromModel(record)
+	canvas, err := render.BuildChart(c, utils.CalculateLuckPillars(record.BirthTime, record.Calendar, record.Gender))
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("绘制命盘失败: %v", err)
+		return nil, fmt.Errorf("绘制命盘失败: %w", err)
+	}
+
+	fileName := fmt.Sprintf("bazi_%d", record.ID)
+	if req.Format != IMAGE_FORMAT_PNG {
+		return &baziVO.BaziImageResponse{
+			FileName:    fileName + ".svg",
+			ContentType: "image/svg+xml; charset=utf-8",
+			Content:     canvas.SVG(),
+		}, nil
+	}
+
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取配置失败: %v", err)
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	f, err := render.LoadFont(cfg.RenderConfig.RenderFontPath)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("加载命盘字体失败: %v", err)
+		return nil, fmt.Errorf("加载命盘字体失败: %w", err)
+	}
+
+	content, err := canvas.PNG(f, cfg.RenderConfig.RenderPNGScale)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("生成命盘 PNG 失败: %v", err)
+		return nil, fmt.Errorf("生成命盘 PNG 失败: %w", err)
+	}
+
+	return &baziVO.BaziImageResponse{
+		FileName:    fileName + ".png",
+		ContentType: "image/png",
+		Content:     content,
+	}, nil
+}
+
+// UpdateBazi 修改八字出生信息、标签与备注，并重新排盘，未传入的档案标签、备注与标签保持不变
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	*baziVO.BaziResponse: 修改后的八字
+//	error: 错误信息
+func (b *BaziServiceImpl) UpdateBazi(ctx *gin.Context, req *dto.UpdateBaziRequest) (*baziVO.BaziResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := b.baziMapper.GetOneBaziByID(ctx, userID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Relation != "" && req.Relation != record.Relation {
+		if req.Relation == bazi.RELATION_SELF {
+			if err := b.ensureNoSelfProfile(ctx, userID); err != nil {
+				return nil, err
+			}
+		}
+		record.Relation = req.Relation
+	}
+
+	c, err := chart.Calculate(req.Name, req.Gender, req.BirthTime, req.Calendar)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("排盘失败: %v", err)
+		return nil, fmt.Errorf("排盘失败: %w", err)
+	}
+	c.ApplyTo(record)
+	// 档案标签、备注与标签仅在请求中显式传入时修改
+	if req.Label != nil {
+		record.Label = *req.Label
+	}
+	if req.Notes != nil {
+		record.Notes = *req.Notes
+	}
+	if req.Tags != nil {
+		record.Tags = joinTags(*req.Tags)
+	}
+
+	if err := b.baziMapper.UpdateOneBazi(ctx, record); err != nil {
+		utils.BizLogger(ctx).Errorf("修改八字失败: %v", err)
+		return nil, fmt.Errorf("修改八字失败: %w", err)
+	}
+
+	return mapBaziToVO(record)
+}
+
+// DeleteBazi 将八字移入回收站
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (b *BaziServiceImpl) DeleteBazi(ctx *gin.Context, req *dto.BaziIDRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	record, err := b.baziMapper.GetOneBaziByID(ctx, userID, req.ID)
+	if err != nil {
+		return err
+	}
+
+	record.Deleted = true
+	if err := b.baziMapper.UpdateOneBazi(ctx, record); err != nil {
+		utils.BizLogger(ctx).Errorf("删除八字失败: %v", err)
+		return fmt.Errorf("删除八字失败: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreBazi 从回收站恢复八字，已存在其他本人档案时不允许恢复本人档案
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	*baziVO.BaziResponse: 恢复后的八字
+//	error: 错误信息
+func (b *BaziServiceImpl) RestoreBazi(ctx *gin.Context, req *dto.BaziIDRequest) (*baziVO.BaziResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := b.baziMapper.GetOneTrashedBaziByID(ctx, userID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.Relation == bazi.RELATION_SELF {
+		if err := b.ensureNoSelfProfile(ctx, userID); err != nil {
+			return nil, err
 		}
-		baziVOList = append(baziVOList, vo.(*baziVO.BaziResponse))
 	}
 
-	// 构造分页响应
-	result := &baziVO.BaziListResponse{
+	record.Deleted = false
+	if err := b.baziMapper.UpdateOneBazi(ctx, record); err != nil {
+		utils.BizLogger(ctx).Errorf("恢复八字失败: %v", err)
+		return nil, fmt.Errorf("恢复八字失败: %w", err)
+	}
+
+	return mapBaziToVO(record)
+}
+
+// PurgeBazi 永久删除回收站中的八字，引用该八字的历史对话保留各自的命盘快照
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	error: 错误信息
+func (b *BaziServiceImpl) PurgeBazi(ctx *gin.Context, req *dto.BaziIDRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := b.baziMapper.PurgeOneBazi(ctx, userID, req.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("永久删除八字失败: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// DuplicateBazi 复制八字为新的档案，复制的本人档案归为其他关系
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	*baziVO.BaziResponse: 新档案
+//	error: 错误信息
+func (b *BaziServiceImpl) DuplicateBazi(ctx *gin.Context, req *dto.BaziIDRequest) (*baziVO.BaziResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := b.baziMapper.GetOneBaziByID(ctx, userID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicate := chart.FromModel(record).ToModel()
+	duplicate.UserID = userID
+	duplicate.Relation = record.Relation
+	if duplicate.Relation == bazi.RELATION_SELF {
+		duplicate.Relation = bazi.RELATION_OTHER
+	}
+	duplicate.Label = duplicateLabel(record.Label)
+	duplicate.Notes = record.Notes
+	duplicate.Tags = record.Tags
+
+	if err := b.baziMapper.CreateOneBazi(ctx, duplicate); err != nil {
+		utils.BizLogger(ctx).Errorf("复制八字失败: %v", err)
+		return nil, fmt.Errorf("复制八字失败: %w", err)
+	}
+
+	return mapBaziToVO(duplicate)
+}
+
+// GetBaziTrash 获取回收站中的八字列表
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	*baziVO.BaziListResponse: 八字列表视图对象
+//	error: 错误信息
+func (b *BaziServiceImpl) GetBaziTrash(ctx *gin.Context, req *dto.GetBaziTrashRequest) (*baziVO.BaziListResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bazis, total, err := b.baziMapper.GetTrashedBaziList(ctx, userID, req.PageNo, req.PageSize)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取回收站列表失败: %v", err)
+		return nil, fmt.Errorf("获取回收站列表失败: %w", err)
+	}
+
+	return &baziVO.BaziListResponse{
 		Total:    total,
 		PageNo:   req.PageNo,
 		PageSize: req.PageSize,
-		List:     baziVOList,
+		List:     b.mapBaziList(ctx, bazis),
+	}, nil
+}
+
+// resolveRelation 确定新档案的关系，未指定时首份本人档案之前默认为本人，每个用户仅允许一份本人档案
+// 参数：
+//
+//	ctx: 上下文信息
+//	userID: 用户 ID
+//	relation: 请求中的关系
+//
+// 返回值：
+//
+//	string: 档案关系
+//	error: 错误信息
+func (b *BaziServiceImpl) resolveRelation(ctx *gin.Context, userID int64, relation string) (string, error) {
+	if relation != "" && relation != bazi.RELATION_SELF {
+		return relation, nil
+	}
+
+	count, err := b.baziMapper.CountBaziByRelation(ctx, userID, bazi.RELATION_SELF)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("统计本人档案失败: %v", err)
+		return "", fmt.Errorf("统计本人档案失败: %w", err)
+	}
+
+	switch {
+	case count == 0:
+		return bazi.RELATION_SELF, nil
+	case relation == bazi.RELATION_SELF:
+		return "", fmt.Errorf("已存在本人档案")
+	default:
+		return bazi.RELATION_OTHER, nil
+	}
+}
+
+// ensureNoSelfProfile 校验用户尚无本人档案
+// 参数：
+//
+//	ctx: 上下文信息
+//	userID: 用户 ID
+//
+// 返回值：
+//
+//	error: 已存在本人档案或查询失败时返回错误
+func (b *BaziServiceImpl) ensureNoSelfProfile(ctx *gin.Context, userID int64) error {
+	count, err := b.baziMapper.CountBaziByRelation(ctx, userID, bazi.RELATION_SELF)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("统计本人档案失败: %v", err)
+		return fmt.Errorf("统计本人档案失败: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("已存在本人档案")
 	}
+	return nil
+}
 
+// mapBaziToVO 将八字模型映射为视图对象
+// 参数：
+//
+//	record: 八字模型
+//
+// 返回值：
+//
+//	*baziVO.BaziResponse: 八字视图对象
+//	error: 错误信息
+func mapBaziToVO(record *bazi.Bazi) (*baziVO.BaziResponse, error) {
+	vo, err := utils.MapModelToVO(record, &baziVO.BaziResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("八字映射 VO 失败: %w", err)
+	}
+	result := vo.(*baziVO.BaziResponse)
+	result.Tags = splitTags(record.Tags)
 	return result, nil
 }
+
+// duplicateLabel 生成副本档案的标签，超出长度时截断原标签
+// 参数：
+//
+//	label: 原标签
+//
+// 返回值：
+//
+//	string: 副本标签
+func duplicateLabel(label string) string {
+	runes := []rune(label)
+	if limit := LABEL_MAX_LENGTH - len([]rune(DUPLICATE_LABEL_SUFFIX)); len(runes) > limit {
+		runes = runes[:limit]
+	}
+	return string(runes) + DUPLICATE_LABEL_SUFFIX
+}
+
+// mapBaziList 将八字模型列表映射为视图对象，映射失败的记录跳过
+// 参数：
+//
+//	ctx: 上下文信息
+//	bazis: 八字模型列表
+//
+// 返回值：
+//
+//	[]*baziVO.BaziResponse: 八字视图对象列表
+func (b *BaziServiceImpl) mapBaziList(ctx *gin.Context, bazis []*bazi.Bazi) []*baziVO.BaziResponse {
+	list := make([]*baziVO.BaziResponse, 0, len(bazis))
+	for _, item := range bazis {
+		vo, err := mapBaziToVO(item)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("获取八字列表时映射单个VO失败: %v", err)
+			continue
+		}
+		list = append(list, vo)
+	}
+	return list
+}
+
+// buildBaziQuery 根据请求参数构建检索条件，校验日主与干支
+// 参数：
+//
+//	userID: 用户 ID
+//	req: 请求参数
+//
+// 返回值：
+//
+//	*baziMapper.BaziQuery: 检索条件
+//	error: 日主或干支无效时返回错误
+func buildBaziQuery(userID int64, req *dto.GetBaziListRequest) (*baziMapper.BaziQuery, error) {
+	query := &baziMapper.BaziQuery{
+		UserID:         userID,
+		Relation:       req.Relation,
+		Keyword:        strings.TrimSpace(req.Keyword),
+		Gender:         req.Gender,
+		Calendar:       req.Calendar,
+		PillarPosition: req.PillarPosition,
+		BirthStart:     req.BirthStart,
+		Tags:           splitTags(strings.Join(req.Tags, TAG_SEPARATOR)),
+		SortBy:         req.SortBy,
+		Desc:           req.Order != SORT_ORDER_ASC,
+	}
+	if query.SortBy == "" {
+		query.SortBy = baziMapper.SORT_BY_CREATED
+	}
+
+	if req.DayMaster != "" {
+		if !chart.Stem(req.DayMaster).Valid() {
+			return nil, fmt.Errorf("无效的日主: %q", req.DayMaster)
+		}
+		query.DayMaster = req.DayMaster
+	}
+	if req.Pillar != "" {
+		p, err := chart.ParsePillar(req.Pillar)
+		if err != nil {
+			return nil, err
+		}
+		query.Pillar = p.String()
+	}
+
+	// 截止日期包含当天
+	if !req.BirthEnd.IsZero() {
+		query.BirthEnd = req.BirthEnd.AddDate(0, 0, 1)
+	}
+	if !query.BirthStart.IsZero() && !query.BirthEnd.IsZero() && !query.BirthStart.Before(query.BirthEnd) {
+		return nil, fmt.Errorf("出生日期范围无效")
+	}
+
+	return query, nil
+}
+
+// baziCursorToken 游标内容，记录排序条件以拒绝与当前排序不一致的游标
+type baziCursorToken struct {
+	SortBy string `json:"s"` // 排序字段
+	Desc   bool   `json:"d"` // 是否倒序
+	Value  string `json:"v"` // 排序值
+	ID     int64  `json:"i"` // 记录 ID
+}
+
+// encodeCursor 根据最后一条记录生成游标
+// 参数：
+//
+//	query: 检索条件
+//	last: 当前页最后一条记录
+//
+// 返回值：
+//
+//	string: 游标
+func encodeCursor(query *baziMapper.BaziQuery, last *bazi.Bazi) string {
+	token := baziCursorToken{SortBy: query.SortBy, Desc: query.Desc, ID: last.ID}
+	switch query.SortBy {
+	case baziMapper.SORT_BY_MODIFIED:
+		token.Value = strconv.FormatInt(last.GmtModified, 10)
+	case baziMapper.SORT_BY_BIRTH_TIME:
+		token.Value = last.BirthTime.Format(time.RFC3339Nano)
+	case baziMapper.SORT_BY_NAME:
+		token.Value = last.Name
+	}
+
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor 解析游标
+// 参数：
+//
+//	cursor: 游标，为空表示第一页
+//	query: 检索条件
+//
+// 返回值：
+//
+//	*baziMapper.BaziCursor: 游标位置，第一页返回 nil
+//	error: 游标无效或与排序条件不一致时返回错误
+func decodeCursor(cursor string, query *baziMapper.BaziQuery) (*baziMapper.BaziCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("游标无效")
+	}
+	var token baziCursorToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("游标无效")
+	}
+	if token.SortBy != query.SortBy || token.Desc != query.Desc {
+		return nil, fmt.Errorf("游标与排序条件不一致")
+	}
+
+	return &baziMapper.BaziCursor{Value: token.Value, ID: token.ID}, nil
+}
+
+// joinTags 规范化标签并拼接为存储格式，去除空白、重复与分隔符
+// 参数：
+//
+//	tags: 标签列表
+//
+// 返回值：
+//
+//	string: 逗号分隔的标签
+func joinTags(tags []string) string {
+	return strings.Join(splitTags(strings.Join(tags, TAG_SEPARATOR)), TAG_SEPARATOR)
+}
+
+// splitTags 拆分逗号分隔的标签，去除空白与重复
+// 参数：
+//
+//	tags: 逗号分隔的标签
+//
+// 返回值：
+//
+//	[]string: 标签列表
+func splitTags(tags string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(tags, TAG_SEPARATOR) {
+		if tag = strings.TrimSpace(tag); tag != "" && !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -0,0 +1,432 @@
+// Package impl 提供八字分析相关的服务层实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/importer"
+	"github.com/Done-0/metaphysics/internal/model/bazi"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/bazi/dto"
+	baziVO "github.com/Done-0/metaphysics/pkg/vo/bazi"
+)
+
+// 批量导入默认值，配置缺失时使用
+const (
+	DEFAULT_IMPORT_MAX_FILE_SIZE_MB = 10      // 上传文件大小上限（MB）
+	DEFAULT_IMPORT_MAX_ROWS         = 5000    // 单次导入的最大数据行数
+	DEFAULT_IMPORT_CALENDAR         = "solar" // 默认历法
+)
+
+// ImportBazi 从 CSV/XLSX 批量导入出生档案，同步完成逐行校验后在后台排盘入库
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//	fileName: 文件名，用于识别格式
+//	file: 文件内容
+//
+// 返回值：
+//
+//	*baziVO.BaziImportResponse: 导入任务与校验错误
+//	error: 错误信息
+func (b *BaziServiceImpl) ImportBazi(ctx *gin.Context, req *dto.ImportBaziRequest, fileName string, file io.Reader) (*baziVO.BaziImportResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+	importCfg := cfg.ImportConfig
+
+	maxFileSizeMB, maxRows := importCfg.ImportMaxFileSizeMB, importCfg.ImportMaxRows
+	if maxFileSizeMB <= 0 {
+		maxFileSizeMB = DEFAULT_IMPORT_MAX_FILE_SIZE_MB
+	}
+	if maxRows <= 0 {
+		maxRows = DEFAULT_IMPORT_MAX_ROWS
+	}
+
+	rows, err := importer.ReadRows(fileName, file, int64(maxFileSizeMB)<<20)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("文件为空")
+	}
+
+	mapping := make(map[string]string)
+	if req.Mapping != "" {
+		if err := json.Unmarshal([]byte(req.Mapping), &mapping); err != nil {
+			return nil, fmt.Errorf("列映射格式无效，应为 JSON 对象: %w", err)
+		}
+	}
+	columns, err := importer.ResolveColumns(rows[0], mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = importCfg.ImportTimezone
+	}
+	location := time.Local
+	if timezone != "" {
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("时区 %q 无效", timezone)
+		}
+	}
+
+	opts := &importer.Options{
+		Mapping:         mapping,
+		DefaultCalendar: req.Calendar,
+		DefaultRelation: req.Relation,
+		Location:        location,
+	}
+	if opts.DefaultCalendar == "" {
+		opts.DefaultCalendar = DEFAULT_IMPORT_CALENDAR
+	}
+	if opts.DefaultRelation == "" {
+		opts.DefaultRelation = bazi.RELATION_CLIENT
+	}
+
+	var records []*importer.Record
+	var rowErrors []importer.RowError
+	total := 0
+	for i, row := range rows[1:] {
+		if importer.IsBlank(row) {
+			continue
+		}
+		total++
+		if total > maxRows {
+			return nil, fmt.Errorf("数据行数超过上限 %d", maxRows)
+		}
+
+		rowNo := i + 2 // 表头为第 1 行
+		record, err := importer.ParseRow(rowNo, row, columns, opts)
+		if err != nil {
+			rowErrors = append(rowErrors, importer.RowError{Row: rowNo, Message: err.Error()})
+			continue
+		}
+		records = append(records, record)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("文件中没有数据行")
+	}
+
+	job := &bazi.BaziImportJob{
+		UserID:    userID,
+		FileName:  filepath.Base(fileName),
+		Status:    bazi.IMPORT_STATUS_RUNNING,
+		Total:     total,
+		Processed: len(rowErrors),
+		Failed:    len(rowErrors),
+	}
+	if len(records) == 0 {
+		job.Status = bazi.IMPORT_STATUS_COMPLETED
+	}
+	if err := setImportErrors(job, rowErrors); err != nil {
+		return nil, err
+	}
+	if err := b.baziMapper.CreateOneImportJob(ctx, job); err != nil {
+		utils.BizLogger(ctx).Errorf("创建导入任务失败: %v", err)
+		return nil, fmt.Errorf("创建导入任务失败: %w", err)
+	}
+
+	if len(records) > 0 {
+		go b.runImport(job, records, rowErrors, importCfg.ImportWorkers, importCfg.ImportBatchSize)
+	}
+
+	return &baziVO.BaziImportResponse{
+		JobID:    fmt.Sprintf("%d", job.ID),
+		Status:   job.Status,
+		Total:    total,
+		Accepted: len(records),
+		Rejected: len(rowErrors),
+		Errors:   mapImportErrors(rowErrors),
+	}, nil
+}
+
+// GetImportJob 查询批量导入任务进度
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	*baziVO.BaziImportJobResponse: 导入任务进度
+//	error: 错误信息
+func (b *BaziServiceImpl) GetImportJob(ctx *gin.Context, req *dto.GetImportJobRequest) (*baziVO.BaziImportJobResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := b.baziMapper.GetOneImportJobByID(ctx, userID, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	vo, err := utils.MapModelToVO(job, &baziVO.BaziImportJobResponse{})
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("导入任务映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("导入任务映射 VO 失败: %w", err)
+	}
+
+	var rowErrors []importer.RowError
+	if job.Errors != "" {
+		if err := json.Unmarshal([]byte(job.Errors), &rowErrors); err != nil {
+			utils.BizLogger(ctx).Errorf("解析导入错误失败: %v", err)
+		}
+	}
+
+	result := vo.(*baziVO.BaziImportJobResponse)
+	result.JobID = fmt.Sprintf("%d", job.ID)
+	result.Errors = mapImportErrors(rowErrors)
+	return result, nil
+}
+
+// runImport 在后台并发排盘并分批入库，每批入库后更新任务进度
+// 参数：
+//
+//	job: 导入任务
+//	records: 通过校验的出生档案
+//	rowErrors: 校验阶段的行错误
+//	workers: 排盘并发数
+//	batchSize: 每批入库数量
+func (b *BaziServiceImpl) runImport(job *bazi.BaziImportJob, records []*importer.Record, rowErrors []importer.RowError, workers, batchSize int) {
+	ctx := utils.NewJobContext(context.Background())
+	defer func() {
+		if r := recover(); r != nil {
+			utils.BizLogger(ctx).Errorf("导入任务 %d 异常: %v", job.ID, r)
+			job.Status = bazi.IMPORT_STATUS_FAILED
+			job.Message = fmt.Sprintf("导入异常: %v", r)
+			b.saveImportJob(ctx, job, rowErrors)
+		}
+	}()
+
+	err := importer.Process(ctx.Request.Context(), records, workers, batchSize, func(batch []*importer.Outcome) {
+		bazis := make([]*bazi.Bazi, 0, len(batch))
+		rowNos := make([]int, 0, len(batch))
+		for _, outcome := range batch {
+			if outcome.Err != nil {
+				rowErrors = append(rowErrors, importer.RowError{Row: outcome.Record.Row, Message: outcome.Err.Error()})
+				job.Failed++
+				continue
+			}
+
+			record := outcome.Chart.ToModel()
+			record.UserID = job.UserID
+			record.Relation = outcome.Record.Relation
+			record.Label = outcome.Record.Label
+			record.Notes = outcome.Record.Notes
+			record.Tags = joinTags(outcome.Record.Tags)
+			bazis = append(bazis, record)
+			rowNos = append(rowNos, outcome.Record.Row)
+		}
+
+		if err := b.baziMapper.CreateBaziInBatch(ctx, bazis); err != nil {
+			utils.BizLogger(ctx).Errorf("导入任务 %d 批量入库失败: %v", job.ID, err)
+			for _, rowNo := range rowNos {
+				rowErrors = append(rowErrors, importer.RowError{Row: rowNo, Message: "保存失败，请重试"})
+			}
+			job.Failed += len(bazis)
+		} else {
+			job.Succeeded += len(bazis)
+		}
+
+		job.Processed += len(batch)
+		b.saveImportJob(ctx, job, rowErrors)
+	})
+
+	job.Status = bazi.IMPORT_STATUS_COMPLETED
+	if err != nil {
+		job.Status = bazi.IMPORT_STATUS_FAILED
+		job.Message = err.Error()
+	}
+	b.saveImportJob(ctx, job, rowErrors)
+}
+
+// saveImportJob 保存导入任务进度，失败时仅记录日志
+// 参数：
+//
+//	ctx: 上下文信息
+//	job: 导入任务
+//	rowErrors: 行错误
+func (b *BaziServiceImpl) saveImportJob(ctx *gin.Context, job *bazi.BaziImportJob, rowErrors []importer.RowError) {
+	if err := setImportErrors(job, rowErrors); err != nil {
+		utils.BizLogger(ctx).Errorf("序列化导入任务 %d 错误失败: %v", job.ID, err)
+	}
+	if err := b.baziMapper.UpdateOneImportJob(ctx, job); err != nil {
+		utils.BizLogger(ctx).Errorf("更新导入任务 %d 进度失败: %v", job.ID, err)
+	}
+}
+
+// setImportErrors 将行错误按行号排序后序列化到导入任务
+// 参数：
+//
+//	job: 导入任务
+//	rowErrors: 行错误
+//
+// 返回值：
+//
+//	error: 序列化失败时返回错误
+func setImportErrors(job *bazi.BaziImportJob, rowErrors []importer.RowError) error {
+	sorted := append([]importer.RowError{}, rowErrors...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Row < sorted[j].Row })
+
+	data, err := json.Marshal(sorted)
+	if err != nil {
+		return fmt.Errorf("序列化导入错误失败: %w", err)
+	}
+	job.Errors = string(data)
+	return nil
+}
+
+// mapImportErrors 将行错误映射为视图对象，按行号升序
+// 参数：
+//
+//	rowErrors: 行错误
+//
+// 返回值：
+//
+//	[]*baziVO.BaziImportRowError: 行错误视图对象
+func mapImportErrors(rowErrors []importer.RowError) []*baziVO.BaziImportRowError {
+	result := make([]*baziVO.BaziImportRowError, 0, len(rowErrors))
+	for _, rowError := range rowErrors {
+		result = append(result, &baziVO.BaziImportRowError{Row: rowError.Row, Message: rowError.Message})
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Row < result[j].Row })
+	return result
+}
+
+// ImportBaziText 从粘贴的四柱文本或排盘软件导出的 JSON 导入命盘，仅有四柱时出生时间标记为未知
+// 参数：
+//
+//	ctx: 上下文信息
+//	req: 请求参数
+//
+// 返回值：
+//
+//	*baziVO.BaziTextImportResponse: 导入的命盘与识别提示
+//	error: 错误信息
+func (b *BaziServiceImpl) ImportBaziText(ctx *gin.Context, req *dto.ImportBaziTextRequest) (*baziVO.BaziTextImportResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Content) > importer.PASTE_MAX_LENGTH {
+		return nil, fmt.Errorf("粘贴内容不能超过 %d KB", importer.PASTE_MAX_LENGTH>>10)
+	}
+
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = cfg.ImportConfig.ImportTimezone
+	}
+	location := time.Local
+	if timezone != "" {
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("时区 %q 无效", timezone)
+		}
+	}
+
+	opts := &importer.PasteOptions{
+		Name:     req.Name,
+		Gender:   req.Gender,
+		Calendar: req.Calendar,
+		Location: location,
+	}
+
+	format := req.Format
+	if format == "" {
+		format = importer.DetectPasteFormat(req.Content)
+	}
+	var pasted []*importer.PastedChart
+	switch format {
+	case importer.PASTE_FORMAT_JSON:
+		if pasted, err = importer.ParsePastedJSON(req.Content, opts); err != nil {
+			return nil, err
+		}
+	default:
+		p, err := importer.ParsePastedText(req.Content, opts)
+		if err != nil {
+			return nil, err
+		}
+		pasted = []*importer.PastedChart{p}
+	}
+
+	// 多份命盘时不自动判定本人档案
+	relation := req.Relation
+	if len(pasted) == 1 {
+		if relation, err = b.resolveRelation(ctx, userID, req.Relation); err != nil {
+			return nil, err
+		}
+	} else if relation == "" {
+		relation = bazi.RELATION_OTHER
+	} else if relation == bazi.RELATION_SELF {
+		return nil, fmt.Errorf("导入多份命盘时不能指定为本人档案")
+	}
+
+	records := make([]*bazi.Bazi, 0, len(pasted))
+	for i, p := range pasted {
+		c, err := p.ToChart()
+		if err != nil {
+			if len(pasted) == 1 {
+				return nil, fmt.Errorf("排盘失败: %w", err)
+			}
+			return nil, fmt.Errorf("第 %d 份命盘排盘失败: %w", i+1, err)
+		}
+
+		record := c.ToModel()
+		record.UserID = userID
+		record.Relation = relation
+		record.Label = req.Label
+		record.Notes = req.Notes
+		records = append(records, record)
+	}
+
+	if err := b.baziMapper.CreateBaziInBatch(ctx, records); err != nil {
+		utils.BizLogger(ctx).Errorf("存储粘贴导入的八字失败: %v", err)
+		return nil, fmt.Errorf("存储八字失败: %w", err)
+	}
+
+	items := make([]*baziVO.BaziTextImportItem, 0, len(records))
+	for i, record := range records {
+		vo, err := mapBaziToVO(record)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("粘贴导入时映射 VO 失败: %v", err)
+			return nil, err
+		}
+
+		warnings := pasted[i].Warnings
+		if record.BirthTimeUnknown {
+			warnings = append(warnings, "未识别到出生时间，已按出生时间未知保存，大运与流年等依赖出生时间的功能不可用")
+		}
+		if warnings == nil {
+			warnings = []string{}
+		}
+		items = append(items, &baziVO.BaziTextImportItem{Bazi: vo, Warnings: warnings})
+	}
+
+	return &baziVO.BaziTextImportResponse{Items: items}, nil
+}
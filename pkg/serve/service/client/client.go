@@ -0,0 +1,138 @@
+// Package client 提供客户档案相关的服务接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package client
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/pkg/serve/controller/client/dto"
+	clientVO "github.com/Done-0/metaphysics/pkg/vo/client"
+)
+
+// ClientService 客户档案服务接口
+type ClientService interface {
+	// CreateOneCase 基于八字档案创建客户案例
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *clientVO.ClientCaseResponse: 客户案例
+	//   - error: 错误信息
+	CreateOneCase(ctx *gin.Context, req *dto.CreateClientCaseRequest) (*clientVO.ClientCaseResponse, error)
+
+	// UpdateOneCase 修改客户联系方式、标签与状态
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *clientVO.ClientCaseResponse: 客户案例
+	//   - error: 错误信息
+	UpdateOneCase(ctx *gin.Context, req *dto.UpdateClientCaseRequest) (*clientVO.ClientCaseResponse, error)
+
+	// DeleteOneCase 删除客户案例
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneCase(ctx *gin.Context, req *dto.ClientCaseIDRequest) error
+
+	// SearchCases 检索客户案例
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *clientVO.ClientCaseListResponse: 客户案例列表
+	//   - error: 错误信息
+	SearchCases(ctx *gin.Context, req *dto.SearchClientCaseRequest) (*clientVO.ClientCaseListResponse, error)
+
+	// GetCaseDetail 获取客户案例详情，包含备注、咨询记录与跟进提醒
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *clientVO.ClientCaseDetailResponse: 客户案例详情
+	//   - error: 错误信息
+	GetCaseDetail(ctx *gin.Context, req *dto.ClientCaseIDRequest) (*clientVO.ClientCaseDetailResponse, error)
+
+	// CreateOneNote 添加客户备注
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *clientVO.ClientNoteResponse: 客户备注
+	//   - error: 错误信息
+	CreateOneNote(ctx *gin.Context, req *dto.CreateClientNoteRequest) (*clientVO.ClientNoteResponse, error)
+
+	// DeleteOneNote 删除客户备注
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneNote(ctx *gin.Context, req *dto.ClientItemIDRequest) error
+
+	// CreateOneSession 添加咨询记录，可关联当前用户的一次对话
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *clientVO.ClientSessionResponse: 咨询记录
+	//   - error: 错误信息
+	CreateOneSession(ctx *gin.Context, req *dto.CreateClientSessionRequest) (*clientVO.ClientSessionResponse, error)
+
+	// DeleteOneSession 删除咨询记录
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneSession(ctx *gin.Context, req *dto.ClientItemIDRequest) error
+
+	// CreateOneReminder 添加跟进提醒
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *clientVO.ClientReminderResponse: 跟进提醒
+	//   - error: 错误信息
+	CreateOneReminder(ctx *gin.Context, req *dto.CreateClientReminderRequest) (*clientVO.ClientReminderResponse, error)
+
+	// CompleteOneReminder 将跟进提醒标记为已完成
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *clientVO.ClientReminderResponse: 跟进提醒
+	//   - error: 错误信息
+	CompleteOneReminder(ctx *gin.Context, req *dto.ClientItemIDRequest) (*clientVO.ClientReminderResponse, error)
+
+	// DeleteOneReminder 删除跟进提醒
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneReminder(ctx *gin.Context, req *dto.ClientItemIDRequest) error
+
+	// GetUpcomingReminders 获取当前用户全部客户的待办跟进提醒，包含已逾期的提醒
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - []*clientVO.ClientReminderResponse: 跟进提醒列表
+	//   - error: 错误信息
+	GetUpcomingReminders(ctx *gin.Context, req *dto.GetUpcomingRemindersRequest) ([]*clientVO.ClientReminderResponse, error)
+
+	// SendDueReminders 按咨询师汇总当日到期的跟进提醒并发送邮件，供定时任务调用
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - date: 执行日期
+	// 返回值：
+	//   - int: 发送的邮件数量
+	//   - error: 错误信息
+	SendDueReminders(ctx *gin.Context, date time.Time) (int, error)
+}
@@ -0,0 +1,797 @@
+// Package impl 提供客户档案相关的服务层实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/internal/model/client"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/client/dto"
+	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
+	clientMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/client"
+	conversationMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/conversation"
+	userMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/user"
+	clientSrv "github.com/Done-0/metaphysics/pkg/serve/service/client"
+	clientVO "github.com/Done-0/metaphysics/pkg/vo/client"
+)
+
+// 分页与提醒常量
+const (
+	DEFAULT_PAGE_NO   = 1   // 默认页码
+	DEFAULT_PAGE_SIZE = 10  // 默认每页数量
+	MAX_PAGE_SIZE     = 100 // 最大每页数量
+
+	DEFAULT_UPCOMING_DAYS = 7   // 默认查看未来多少天内的提醒
+	DEFAULT_BATCH_SIZE    = 100 // 定时任务每批处理的提醒数量
+
+	TAG_SEPARATOR = "," // 标签分隔符
+)
+
+// ClientServiceImpl 客户档案服务实现
+type ClientServiceImpl struct {
+	baziMapper         baziMapper.BaziMapper
+	clientMapper       clientMapper.ClientMapper
+	conversationMapper conversationMapper.ConversationMapper
+	userMapper         userMapper.UserMapper
+}
+
+// NewClientService 创建客户档案服务实例
+// 参数：
+//   - baziMapperImpl: 八字数据访问接口
+//   - clientMapperImpl: 客户档案数据访问接口
+//   - conversationMapperImpl: 对话数据访问接口
+//   - userMapperImpl: 用户数据访问接口
+//
+// 返回值：
+//   - clientSrv.ClientService: 客户档案服务接口
+func NewClientService(baziMapperImpl baziMapper.BaziMapper, clientMapperImpl clientMapper.ClientMapper, conversationMapperImpl conversationMapper.ConversationMapper, userMapperImpl userMapper.UserMapper) clientSrv.ClientService {
+	return &ClientServiceImpl{
+		baziMapper:         baziMapperImpl,
+		clientMapper:       clientMapperImpl,
+		conversationMapper: conversationMapperImpl,
+		userMapper:         userMapperImpl,
+	}
+}
+
+// CreateOneCase 基于八字档案创建客户案例
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *clientVO.ClientCaseResponse: 客户案例
+//   - error: 错误信息
+func (s *ClientServiceImpl) CreateOneCase(ctx *gin.Context, req *dto.CreateClientCaseRequest) (*clientVO.ClientCaseResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.baziMapper.GetOneBaziByID(ctx, userID, req.BaziID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = record.Name
+	}
+
+	clientCase := &client.ClientCase{
+		UserID: userID,
+		BaziID: record.ID,
+		Name:   name,
+		Phone:  strings.TrimSpace(req.Phone),
+		Email:  strings.TrimSpace(req.Email),
+		WeChat: strings.TrimSpace(req.WeChat),
+		Tags:   joinTags(req.Tags),
+		Status: client.STATUS_ACTIVE,
+	}
+	if err := s.clientMapper.CreateOneCase(ctx, clientCase); err != nil {
+		utils.BizLogger(ctx).Errorf("创建客户案例失败: %v", err)
+		return nil, fmt.Errorf("创建客户案例失败: %w", err)
+	}
+
+	return mapCaseToVO(clientCase)
+}
+
+// UpdateOneCase 修改客户联系方式、标签与状态
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *clientVO.ClientCaseResponse: 客户案例
+//   - error: 错误信息
+func (s *ClientServiceImpl) UpdateOneCase(ctx *gin.Context, req *dto.UpdateClientCaseRequest) (*clientVO.ClientCaseResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCase, err := s.clientMapper.GetOneCaseByID(ctx, userID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCase.Name = strings.TrimSpace(req.Name)
+	clientCase.Phone = strings.TrimSpace(req.Phone)
+	clientCase.Email = strings.TrimSpace(req.Email)
+	clientCase.WeChat = strings.TrimSpace(req.WeChat)
+	clientCase.Tags = joinTags(req.Tags)
+	if req.Status != "" {
+		clientCase.Status = req.Status
+	}
+
+	if err := s.clientMapper.UpdateOneCase(ctx, clientCase); err != nil {
+		utils.BizLogger(ctx).Errorf("更新客户案例失败: %v", err)
+		return nil, fmt.Errorf("更新客户案例失败: %w", err)
+	}
+
+	return mapCaseToVO(clientCase)
+}
+
+// DeleteOneCase 删除客户案例，其备注、咨询记录与提醒随案例一同隐藏
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - error: 错误信息
+func (s *ClientServiceImpl) DeleteOneCase(ctx *gin.Context, req *dto.ClientCaseIDRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.clientMapper.DeleteOneCase(ctx, userID, req.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("删除客户案例失败: %v", err)
+		return fmt.Errorf("删除客户案例失败: %w", err)
+	}
+
+	return nil
+}
+
+// SearchCases 检索客户案例
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *clientVO.ClientCaseListResponse: 客户案例列表
+//   - error: 错误信息
+func (s *ClientServiceImpl) SearchCases(ctx *gin.Context, req *dto.SearchClientCaseRequest) (*clientVO.ClientCaseListResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNo, pageSize := req.PageNo, req.PageSize
+	if pageNo <= 0 {
+		pageNo = DEFAULT_PAGE_NO
+	}
+	if pageSize <= 0 {
+		pageSize = DEFAULT_PAGE_SIZE
+	}
+	if pageSize > MAX_PAGE_SIZE {
+		pageSize = MAX_PAGE_SIZE
+	}
+
+	query := &clientMapper.ClientCaseQuery{
+		UserID:  userID,
+		Keyword: strings.TrimSpace(req.Keyword),
+		Status:  req.Status,
+		Tags:    splitTags(strings.Join(req.Tags, TAG_SEPARATOR)),
+	}
+	cases, total, err := s.clientMapper.SearchCases(ctx, query, pageNo, pageSize)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("检索客户案例失败: %v", err)
+		return nil, fmt.Errorf("检索客户案例失败: %w", err)
+	}
+
+	list := make([]*clientVO.ClientCaseResponse, 0, len(cases))
+	for _, clientCase := range cases {
+		vo, err := mapCaseToVO(clientCase)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("检索客户案例时映射单个VO失败: %v", err)
+			continue
+		}
+		list = append(list, vo)
+	}
+
+	return &clientVO.ClientCaseListResponse{
+		Total:    total,
+		PageNo:   pageNo,
+		PageSize: pageSize,
+		List:     list,
+	}, nil
+}
+
+// GetCaseDetail 获取客户案例详情，包含备注、咨询记录与跟进提醒
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *clientVO.ClientCaseDetailResponse: 客户案例详情
+//   - error: 错误信息
+func (s *ClientServiceImpl) GetCaseDetail(ctx *gin.Context, req *dto.ClientCaseIDRequest) (*clientVO.ClientCaseDetailResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCase, err := s.clientMapper.GetOneCaseByID(ctx, userID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	caseVO, err := mapCaseToVO(clientCase)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &clientVO.ClientCaseDetailResponse{
+		Case:      caseVO,
+		Pillars:   []string{},
+		Notes:     []*clientVO.ClientNoteResponse{},
+		Sessions:  []*clientVO.ClientSessionResponse{},
+		Reminders: []*clientVO.ClientReminderResponse{},
+	}
+
+	// 八字档案可能已被移入回收站，此时仍展示客户资料，仅省略四柱
+	if record, err := s.baziMapper.GetOneBaziByID(ctx, userID, clientCase.BaziID); err == nil {
+		detail.Pillars = chart.FromModel(record).PillarStrings()
+	}
+
+	notes, err := s.clientMapper.GetNotesByCaseID(ctx, userID, clientCase.ID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取客户备注失败: %v", err)
+		return nil, fmt.Errorf("获取客户备注失败: %w", err)
+	}
+	for _, note := range notes {
+		vo, err := mapNoteToVO(note)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("获取客户详情时映射备注VO失败: %v", err)
+			continue
+		}
+		detail.Notes = append(detail.Notes, vo)
+	}
+
+	sessions, err := s.clientMapper.GetSessionsByCaseID(ctx, userID, clientCase.ID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取咨询记录失败: %v", err)
+		return nil, fmt.Errorf("获取咨询记录失败: %w", err)
+	}
+	for _, session := range sessions {
+		vo, err := mapSessionToVO(session)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("获取客户详情时映射咨询记录VO失败: %v", err)
+			continue
+		}
+		detail.Sessions = append(detail.Sessions, vo)
+	}
+
+	reminders, err := s.clientMapper.GetRemindersByCaseID(ctx, userID, clientCase.ID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取跟进提醒失败: %v", err)
+		return nil, fmt.Errorf("获取跟进提醒失败: %w", err)
+	}
+	now := time.Now()
+	for _, reminder := range reminders {
+		detail.Reminders = append(detail.Reminders, mapReminderToVO(reminder, clientCase.Name, now))
+	}
+
+	return detail, nil
+}
+
+// CreateOneNote 添加客户备注
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *clientVO.ClientNoteResponse: 客户备注
+//   - error: 错误信息
+func (s *ClientServiceImpl) CreateOneNote(ctx *gin.Context, req *dto.CreateClientNoteRequest) (*clientVO.ClientNoteResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCase, err := s.clientMapper.GetOneCaseByID(ctx, userID, req.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &client.ClientNote{
+		UserID:  userID,
+		CaseID:  clientCase.ID,
+		Content: strings.TrimSpace(req.Content),
+	}
+	if err := s.clientMapper.CreateOneNote(ctx, note); err != nil {
+		utils.BizLogger(ctx).Errorf("创建客户备注失败: %v", err)
+		return nil, fmt.Errorf("创建客户备注失败: %w", err)
+	}
+	s.touchCase(ctx, clientCase)
+
+	return mapNoteToVO(note)
+}
+
+// DeleteOneNote 删除客户备注
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - error: 错误信息
+func (s *ClientServiceImpl) DeleteOneNote(ctx *gin.Context, req *dto.ClientItemIDRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.clientMapper.DeleteOneNote(ctx, userID, req.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("删除客户备注失败: %v", err)
+		return fmt.Errorf("删除客户备注失败: %w", err)
+	}
+
+	return nil
+}
+
+// CreateOneSession 添加咨询记录，可关联当前用户的一次对话
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *clientVO.ClientSessionResponse: 咨询记录
+//   - error: 错误信息
+func (s *ClientServiceImpl) CreateOneSession(ctx *gin.Context, req *dto.CreateClientSessionRequest) (*clientVO.ClientSessionResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCase, err := s.clientMapper.GetOneCaseByID(ctx, userID, req.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ConversationID != 0 {
+		conversation, err := s.conversationMapper.GetConversationByID(ctx, req.ConversationID)
+		if err != nil {
+			return nil, err
+		}
+		if conversation.UserID != userID {
+			return nil, fmt.Errorf("无权关联该对话")
+		}
+	}
+
+	sessionTime := req.SessionTime
+	if sessionTime.IsZero() {
+		sessionTime = time.Now()
+	}
+
+	session := &client.ClientSession{
+		UserID:         userID,
+		CaseID:         clientCase.ID,
+		ConversationID: req.ConversationID,
+		SessionTime:    sessionTime,
+		Topic:          strings.TrimSpace(req.Topic),
+		Summary:        strings.TrimSpace(req.Summary),
+	}
+	if err := s.clientMapper.CreateOneSession(ctx, session); err != nil {
+		utils.BizLogger(ctx).Errorf("创建咨询记录失败: %v", err)
+		return nil, fmt.Errorf("创建咨询记录失败: %w", err)
+	}
+	s.touchCase(ctx, clientCase)
+
+	return mapSessionToVO(session)
+}
+
+// DeleteOneSession 删除咨询记录
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - error: 错误信息
+func (s *ClientServiceImpl) DeleteOneSession(ctx *gin.Context, req *dto.ClientItemIDRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.clientMapper.DeleteOneSession(ctx, userID, req.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("删除咨询记录失败: %v", err)
+		return fmt.Errorf("删除咨询记录失败: %w", err)
+	}
+
+	return nil
+}
+
+// CreateOneReminder 添加跟进提醒
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *clientVO.ClientReminderResponse: 跟进提醒
+//   - error: 错误信息
+func (s *ClientServiceImpl) CreateOneReminder(ctx *gin.Context, req *dto.CreateClientReminderRequest) (*clientVO.ClientReminderResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCase, err := s.clientMapper.GetOneCaseByID(ctx, userID, req.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder := &client.ClientReminder{
+		UserID:   userID,
+		CaseID:   clientCase.ID,
+		RemindAt: req.RemindAt,
+		Content:  strings.TrimSpace(req.Content),
+	}
+	if err := s.clientMapper.CreateOneReminder(ctx, reminder); err != nil {
+		utils.BizLogger(ctx).Errorf("创建跟进提醒失败: %v", err)
+		return nil, fmt.Errorf("创建跟进提醒失败: %w", err)
+	}
+
+	return mapReminderToVO(reminder, clientCase.Name, time.Now()), nil
+}
+
+// CompleteOneReminder 将跟进提醒标记为已完成
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *clientVO.ClientReminderResponse: 跟进提醒
+//   - error: 错误信息
+func (s *ClientServiceImpl) CompleteOneReminder(ctx *gin.Context, req *dto.ClientItemIDRequest) (*clientVO.ClientReminderResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder, err := s.clientMapper.GetOneReminderByID(ctx, userID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	clientCase, err := s.clientMapper.GetOneCaseByID(ctx, userID, reminder.CaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reminder.Done {
+		reminder.Done = true
+		if err := s.clientMapper.UpdateOneReminder(ctx, reminder); err != nil {
+			utils.BizLogger(ctx).Errorf("更新跟进提醒失败: %v", err)
+			return nil, fmt.Errorf("更新跟进提醒失败: %w", err)
+		}
+	}
+
+	return mapReminderToVO(reminder, clientCase.Name, time.Now()), nil
+}
+
+// DeleteOneReminder 删除跟进提醒
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - error: 错误信息
+func (s *ClientServiceImpl) DeleteOneReminder(ctx *gin.Context, req *dto.ClientItemIDRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.clientMapper.DeleteOneReminder(ctx, userID, req.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("删除跟进提醒失败: %v", err)
+		return fmt.Errorf("删除跟进提醒失败: %w", err)
+	}
+
+	return nil
+}
+
+// GetUpcomingReminders 获取当前用户全部客户的待办跟进提醒，包含已逾期的提醒
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - []*clientVO.ClientReminderResponse: 跟进提醒列表
+//   - error: 错误信息
+func (s *ClientServiceImpl) GetUpcomingReminders(ctx *gin.Context, req *dto.GetUpcomingRemindersRequest) ([]*clientVO.ClientReminderResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	days := req.Days
+	if days <= 0 {
+		days = DEFAULT_UPCOMING_DAYS
+	}
+
+	now := time.Now()
+	reminders, err := s.clientMapper.GetPendingReminders(ctx, userID, now.AddDate(0, 0, days))
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取待办跟进提醒失败: %v", err)
+		return nil, fmt.Errorf("获取待办跟进提醒失败: %w", err)
+	}
+
+	names, err := s.caseNames(ctx, userID, reminders)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*clientVO.ClientReminderResponse, 0, len(reminders))
+	for _, reminder := range reminders {
+		name, ok := names[reminder.CaseID]
+		if !ok {
+			continue // 所属客户案例已删除
+		}
+		result = append(result, mapReminderToVO(reminder, name, now))
+	}
+
+	return result, nil
+}
+
+// SendDueReminders 按咨询师汇总当日到期的跟进提醒并发送邮件，供定时任务调用
+// 参数：
+//   - ctx: 上下文信息
+//   - date: 执行日期
+//
+// 返回值：
+//   - int: 发送的邮件数量
+//   - error: 错误信息
+func (s *ClientServiceImpl) SendDueReminders(ctx *gin.Context, date time.Time) (int, error) {
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return 0, fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	// 截止到执行日期当天结束，使当天稍晚的提醒也能在早间汇总中送达
+	year, month, day := date.Date()
+	before := time.Date(year, month, day, 0, 0, 0, 0, date.Location()).AddDate(0, 0, 1)
+
+	pending := make(map[int64][]*client.ClientReminder)
+	var afterID int64
+	for {
+		if err := ctx.Request.Context().Err(); err != nil {
+			return 0, err
+		}
+
+		reminders, err := s.clientMapper.ListDueReminders(ctx, before, afterID, DEFAULT_BATCH_SIZE)
+		if err != nil {
+			return 0, err
+		}
+		if len(reminders) == 0 {
+			break
+		}
+		afterID = reminders[len(reminders)-1].ID
+
+		for _, reminder := range reminders {
+			pending[reminder.UserID] = append(pending[reminder.UserID], reminder)
+		}
+	}
+
+	sent := 0
+	for userID, reminders := range pending {
+		if s.sendReminderEmail(ctx, cfg.ClientConfig.ClientReminderEmailSubject, userID, reminders, date) {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// sendReminderEmail 向咨询师发送跟进提醒汇总邮件，失败时仅记录日志
+// 参数：
+//   - ctx: 上下文信息
+//   - subject: 邮件主题
+//   - userID: 咨询师用户 ID
+//   - reminders: 到期的跟进提醒
+//   - now: 当前时间
+//
+// 返回值：
+//   - bool: 是否发送成功
+func (s *ClientServiceImpl) sendReminderEmail(ctx *gin.Context, subject string, userID int64, reminders []*client.ClientReminder, now time.Time) bool {
+	names, err := s.caseNames(ctx, userID, reminders)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("发送跟进提醒邮件时获取客户案例失败, 用户: %d, 错误: %v", userID, err)
+		return false
+	}
+
+	ids := make([]int64, 0, len(reminders))
+	var sb strings.Builder
+	sb.WriteString("您有以下客户待跟进：\n\n")
+	for _, reminder := range reminders {
+		ids = append(ids, reminder.ID)
+		name, ok := names[reminder.CaseID]
+		if !ok {
+			continue // 所属客户案例已删除，仅标记为已通知
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s：%s", reminder.RemindAt.In(now.Location()).Format("2006-01-02 15:04"), name, reminder.Content))
+		if reminder.RemindAt.Before(now) {
+			sb.WriteString("（已逾期）")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(names) > 0 {
+		user, err := s.userMapper.GetOneUserByID(ctx, userID)
+		if err != nil || user == nil || user.Email == "" {
+			utils.BizLogger(ctx).Errorf("发送跟进提醒邮件时获取用户失败, 用户: %d, 错误: %v", userID, err)
+			return false
+		}
+		if _, err := utils.SendEmailWithSubject(subject, sb.String(), []string{user.Email}); err != nil {
+			utils.BizLogger(ctx).Errorf("发送跟进提醒邮件失败, 用户: %d, 错误: %v", userID, err)
+			return false
+		}
+	}
+
+	if err := s.clientMapper.MarkRemindersNotified(ctx, ids); err != nil {
+		utils.BizLogger(ctx).Errorf("标记跟进提醒通知状态失败, 用户: %d, 错误: %v", userID, err)
+	}
+
+	return len(names) > 0
+}
+
+// caseNames 批量获取提醒所属客户案例的姓名，已删除的案例不在结果中
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 咨询师用户 ID
+//   - reminders: 跟进提醒列表
+//
+// 返回值：
+//   - map[int64]string: 客户案例 ID 到姓名的映射
+//   - error: 错误信息
+func (s *ClientServiceImpl) caseNames(ctx *gin.Context, userID int64, reminders []*client.ClientReminder) (map[int64]string, error) {
+	names := make(map[int64]string)
+	if len(reminders) == 0 {
+		return names, nil
+	}
+
+	seen := make(map[int64]bool, len(reminders))
+	ids := make([]int64, 0, len(reminders))
+	for _, reminder := range reminders {
+		if !seen[reminder.CaseID] {
+			seen[reminder.CaseID] = true
+			ids = append(ids, reminder.CaseID)
+		}
+	}
+
+	cases, err := s.clientMapper.GetCasesByIDs(ctx, userID, ids)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("批量获取客户案例失败: %v", err)
+		return nil, fmt.Errorf("批量获取客户案例失败: %w", err)
+	}
+	for _, clientCase := range cases {
+		names[clientCase.ID] = clientCase.Name
+	}
+	return names, nil
+}
+
+// touchCase 刷新客户案例的最后修改时间，使最近有动态的客户排在列表前面，失败时仅记录日志
+// 参数：
+//   - ctx: 上下文信息
+//   - clientCase: 客户案例
+func (s *ClientServiceImpl) touchCase(ctx *gin.Context, clientCase *client.ClientCase) {
+	if err := s.clientMapper.UpdateOneCase(ctx, clientCase); err != nil {
+		utils.BizLogger(ctx).Errorf("刷新客户案例修改时间失败: %v", err)
+	}
+}
+
+// mapCaseToVO 将客户案例模型映射为视图对象
+// 参数：
+//   - clientCase: 客户案例
+//
+// 返回值：
+//   - *clientVO.ClientCaseResponse: 客户案例视图对象
+//   - error: 错误信息
+func mapCaseToVO(clientCase *client.ClientCase) (*clientVO.ClientCaseResponse, error) {
+	vo, err := utils.MapModelToVO(clientCase, &clientVO.ClientCaseResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("客户案例映射 VO 失败: %w", err)
+	}
+
+	result := vo.(*clientVO.ClientCaseResponse)
+	result.Tags = splitTags(clientCase.Tags)
+	return result, nil
+}
+
+// mapNoteToVO 将客户备注模型映射为视图对象
+// 参数：
+//   - note: 客户备注
+//
+// 返回值：
+//   - *clientVO.ClientNoteResponse: 客户备注视图对象
+//   - error: 错误信息
+func mapNoteToVO(note *client.ClientNote) (*clientVO.ClientNoteResponse, error) {
+	vo, err := utils.MapModelToVO(note, &clientVO.ClientNoteResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("客户备注映射 VO 失败: %w", err)
+	}
+	return vo.(*clientVO.ClientNoteResponse), nil
+}
+
+// mapSessionToVO 将咨询记录模型映射为视图对象
+// 参数：
+//   - session: 咨询记录
+//
+// 返回值：
+//   - *clientVO.ClientSessionResponse: 咨询记录视图对象
+//   - error: 错误信息
+func mapSessionToVO(session *client.ClientSession) (*clientVO.ClientSessionResponse, error) {
+	vo, err := utils.MapModelToVO(session, &clientVO.ClientSessionResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("咨询记录映射 VO 失败: %w", err)
+	}
+
+	result := vo.(*clientVO.ClientSessionResponse)
+	if session.ConversationID == 0 {
+		result.ConversationID = ""
+	}
+	return result, nil
+}
+
+// mapReminderToVO 将跟进提醒模型映射为视图对象
+// 参数：
+//   - reminder: 跟进提醒
+//   - caseName: 客户姓名
+//   - now: 当前时间，用于判断是否逾期
+//
+// 返回值：
+//   - *clientVO.ClientReminderResponse: 跟进提醒视图对象
+func mapReminderToVO(reminder *client.ClientReminder, caseName string, now time.Time) *clientVO.ClientReminderResponse {
+	return &clientVO.ClientReminderResponse{
+		ID:       fmt.Sprintf("%d", reminder.ID),
+		CaseID:   fmt.Sprintf("%d", reminder.CaseID),
+		CaseName: caseName,
+		RemindAt: reminder.RemindAt,
+		Content:  reminder.Content,
+		Done:     reminder.Done,
+		Overdue:  !reminder.Done && reminder.RemindAt.Before(now),
+	}
+}
+
+// joinTags 去除空白与重复后拼接标签
+// 参数：
+//   - tags: 标签列表
+//
+// 返回值：
+//   - string: 逗号分隔的标签
+func joinTags(tags []string) string {
+	return strings.Join(splitTags(strings.Join(tags, TAG_SEPARATOR)), TAG_SEPARATOR)
+}
+
+// splitTags 拆分逗号分隔的标签，忽略空项与重复项
+// 参数：
+//   - s: 逗号分隔的标签
+//
+// 返回值：
+//   - []string: 标签列表
+func splitTags(s string) []string {
+	result := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(s, TAG_SEPARATOR) {
+		if tag = strings.TrimSpace(tag); tag != "" && !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	return result
+}
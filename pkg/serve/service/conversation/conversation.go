@@ -15,20 +15,32 @@ type ConversationService interface {
 	// AnalyzeBaziByUserID 根据用户ID分析八字
 	// 参数：
 	//   - ctx: 上下文信息
+	//   - req: 请求参数
 	//
 	// 返回值：
-	//   - *conversation.BaziAnalysisResponse: 八字分析结果
+	//   - *conversation.BaziAnalysisResponse: 八字分析结果，结构化输出或指定章节时包含各章节与消息ID
 	//   - error: 错误信息
-	AnalyzeBaziByUserID(ctx *gin.Context) (*conversation.BaziAnalysisResponse, error)
+	AnalyzeBaziByUserID(ctx *gin.Context, req *dto.AnalyzeBaziRequest) (*conversation.BaziAnalysisResponse, error)
 
 	// StreamAnalyzeBaziByUserID 流式分析用户八字
 	// 参数：
 	//   - ctx: 上下文信息
-	//   - handler: 流式响应处理函数
+	//   - req: 请求参数
+	//   - handler: 流式响应处理函数，思考过程与回答内容分别通过 Reasoning 与 Content 推送
 	//
 	// 返回值：
 	//   - error: 错误信息
-	StreamAnalyzeBaziByUserID(ctx *gin.Context, handler func(content string, done bool) error) error
+	StreamAnalyzeBaziByUserID(ctx *gin.Context, req *dto.StreamAnalyzeBaziRequest, handler func(chunk *conversation.StreamChunk) error) error
+
+	// RegenerateSection 重新生成分析消息中的一个章节，其余章节保持不变
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	//
+	// 返回值：
+	//   - *conversation.BaziAnalysisResponse: 更新后的分析结果，包含全部章节，Usage 为本次重新生成的用量
+	//   - error: 错误信息
+	RegenerateSection(ctx *gin.Context, req *dto.RegenerateSectionRequest) (*conversation.BaziAnalysisResponse, error)
 
 	// ContinueConversation 继续与AI的对话
 	// 参数：
@@ -44,11 +56,11 @@ type ConversationService interface {
 	// 参数：
 	//   - ctx: 上下文信息
 	//   - req: 请求参数
-	//   - handler: 流式响应处理函数
+	//   - handler: 流式响应处理函数，思考过程与回答内容分别通过 Reasoning 与 Content 推送
 	//
 	// 返回值：
 	//   - error: 错误信息
-	StreamContinueConversation(ctx *gin.Context, req *dto.StreamContinueConversationRequest, handler func(content string, done bool) error) error
+	StreamContinueConversation(ctx *gin.Context, req *dto.StreamContinueConversationRequest, handler func(chunk *conversation.StreamChunk) error) error
 
 	// GetMessageIDs 获取当前用户的消息ID
 	// 参数：
@@ -59,4 +71,13 @@ type ConversationService interface {
 	//   - int: 响应消息ID
 	//   - error: 错误信息
 	GetMessageIDs(ctx *gin.Context) (int, int, error)
+
+	// GetAIHealth 获取 AI 服务各 Provider 的健康状态
+	// 参数：
+	//   - ctx: 上下文信息
+	//
+	// 返回值：
+	//   - *conversation.AIHealthResponse: 各 Provider 的熔断状态与调用统计，按优先级排列
+	//   - error: 错误信息
+	GetAIHealth(ctx *gin.Context) (*conversation.AIHealthResponse, error)
 }
@@ -5,6 +5,8 @@ package impl
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/requestid"
@@ -12,25 +14,34 @@ import (
 	"github.com/google/uuid"
 
 	internalAI "github.com/Done-0/metaphysics/internal/ai"
+	"github.com/Done-0/metaphysics/internal/ai/prompt"
+	"github.com/Done-0/metaphysics/internal/ai/section"
+	"github.com/Done-0/metaphysics/internal/ai/tokenizer"
 	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
+	baziModel "github.com/Done-0/metaphysics/internal/model/bazi"
 	conversationModel "github.com/Done-0/metaphysics/internal/model/conversation"
 	"github.com/Done-0/metaphysics/internal/utils"
 	"github.com/Done-0/metaphysics/pkg/serve/controller/conversation/dto"
 	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
 	baziMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi/impl"
 	conversationMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/conversation"
+	journalMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/journal"
+	journalMapperImpl "github.com/Done-0/metaphysics/pkg/serve/mapper/journal/impl"
 	conversationSrv "github.com/Done-0/metaphysics/pkg/serve/service/conversation"
 	"github.com/Done-0/metaphysics/pkg/vo/conversation"
 )
 
 const (
-	INITIAL_MESSAGE_ID = 1 // 初始消息ID
+	INITIAL_MESSAGE_ID   = 1  // 初始消息ID
+	DEFAULT_JOURNAL_SIZE = 20 // 默认附带的人生日志条数
 )
 
 // ConversationServiceImpl 对话服务实现
 type ConversationServiceImpl struct {
 	baziMapper         baziMapper.BaziMapper
 	conversationMapper conversationMapper.ConversationMapper
+	journalMapper      journalMapper.JournalMapper
 	aiService          types.Service
 }
 
@@ -39,50 +50,31 @@ func NewConversationService(conversationMapperImpl conversationMapper.Conversati
 	return &ConversationServiceImpl{
 		baziMapper:         baziMapperImpl.NewBaziMapper(),
 		conversationMapper: conversationMapperImpl,
+		journalMapper:      journalMapperImpl.NewJournalMapper(),
 		aiService:          internalAI.New(),
 	}
 }
 
 // AnalyzeBaziByUserID 根据用户ID分析八字
-func (s *ConversationServiceImpl) AnalyzeBaziByUserID(ctx *gin.Context) (*conversation.BaziAnalysisResponse, error) {
+func (s *ConversationServiceImpl) AnalyzeBaziByUserID(ctx *gin.Context, req *dto.AnalyzeBaziRequest) (*conversation.BaziAnalysisResponse, error) {
 	// 获取用户ID
 	id, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// 获取用户八字记录
-	records, total, err := s.baziMapper.GetBaziList(ctx, 1, 1)
+	// 获取指定的八字档案，未指定时使用默认档案
+	record, err := s.getAnalysisBazi(ctx, id, req.BaziID)
 	if err != nil {
-		utils.BizLogger(ctx).Errorf("获取用户八字记录失败: %v", err)
-		return nil, fmt.Errorf("获取用户八字记录失败: %w", err)
-	}
-
-	if total == 0 || len(records) == 0 {
-		utils.BizLogger(ctx).Errorf("未找到用户八字记录")
-		return nil, fmt.Errorf("未找到用户八字记录")
+		return nil, err
 	}
 
-	record := records[0]
-
-	// 构建八字信息
-	baziInfo := map[string]string{
-		"year":      record.YearPillar,
-		"month":     record.MonthPillar,
-		"day":       record.DayPillar,
-		"hour":      record.HourPillar,
-		"year_gan":  record.YearGan,
-		"month_gan": record.MonthGan,
-		"day_gan":   record.DayGan,
-		"hour_gan":  record.HourGan,
-		"year_zhi":  record.YearZhi,
-		"month_zhi": record.MonthZhi,
-		"day_zhi":   record.DayZhi,
-		"hour_zhi":  record.HourZhi,
-	}
+	// 构建八字命盘
+	c := chart.FromModel(record)
 
-	// 调用AI分析八字
-	analysisResponse, err := s.aiService.AnalyzeBaziWithReasoning(ctx, record.Name, record.Gender, record.BirthTime, record.Calendar, baziInfo)
+	// 调用AI分析八字，指定章节时仅分析所选章节
+	opts := types.AnalyzeOptions{Structured: req.Format == dto.ANALYSIS_FORMAT_JSON, Sections: req.Sections}
+	analysisResponse, err := s.aiService.AnalyzeBaziWithReasoning(ctx, c, opts)
 	if err != nil {
 		utils.BizLogger(ctx).Errorf("AI分析八字失败: %v", err)
 		return nil, fmt.Errorf("AI分析八字失败: %w", err)
@@ -99,12 +91,21 @@ func (s *ConversationServiceImpl) AnalyzeBaziByUserID(ctx *gin.Context) (*conver
 		UserID:      id,
 		Title:       "八字分析",
 		SessionID:   sessionID,
-		FirstPrompt: "分析我的八字",
+		FirstPrompt: analyzePromptText(opts.Sections),
+		BaziID:      record.ID,
+	}
+	if conversationRecord.BaziSnapshot, err = c.Snapshot(); err != nil {
+		utils.BizLogger(ctx).Errorf("生成命盘快照失败: %v", err)
 	}
 	if err := s.conversationMapper.SaveConversation(ctx, conversationRecord); err != nil {
 		utils.BizLogger(ctx).Errorf("保存对话记录失败: %v", err)
 	}
 
+	// 结构化输出或指定章节时保存消息，各章节单独存储
+	if opts.Structured || len(opts.Sections) > 0 {
+		s.saveSectionedAnalysis(ctx, conversationRecord, analysisResponse)
+	}
+
 	// 设置请求ID并构建响应
 	reqID := requestid.Get(ctx)
 
@@ -115,42 +116,23 @@ func (s *ConversationServiceImpl) AnalyzeBaziByUserID(ctx *gin.Context) (*conver
 }
 
 // StreamAnalyzeBaziByUserID 流式分析用户八字
-func (s *ConversationServiceImpl) StreamAnalyzeBaziByUserID(ctx *gin.Context, handler func(content string, done bool) error) error {
+func (s *ConversationServiceImpl) StreamAnalyzeBaziByUserID(ctx *gin.Context, req *dto.StreamAnalyzeBaziRequest, handler func(chunk *conversation.StreamChunk) error) error {
 	// 获取用户ID
 	id, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	// 获取用户八字记录
-	records, total, err := s.baziMapper.GetBaziList(ctx, 1, 1)
+	// 获取指定的八字档案，未指定时使用默认档案
+	record, err := s.getAnalysisBazi(ctx, id, req.BaziID)
 	if err != nil {
-		utils.BizLogger(ctx).Errorf("获取用户八字记录失败: %v", err)
-		return fmt.Errorf("获取用户八字记录失败: %w", err)
-	}
-
-	if total == 0 || len(records) == 0 {
-		utils.BizLogger(ctx).Errorf("未找到用户八字记录")
-		return fmt.Errorf("未找到用户八字记录")
+		return err
 	}
 
-	record := records[0]
-
-	// 构建八字信息
-	baziInfo := map[string]string{
-		"year":      record.YearPillar,
-		"month":     record.MonthPillar,
-		"day":       record.DayPillar,
-		"hour":      record.HourPillar,
-		"year_gan":  record.YearGan,
-		"month_gan": record.MonthGan,
-		"day_gan":   record.DayGan,
-		"hour_gan":  record.HourGan,
-		"year_zhi":  record.YearZhi,
-		"month_zhi": record.MonthZhi,
-		"day_zhi":   record.DayZhi,
-		"hour_zhi":  record.HourZhi,
-	}
+	// 构建八字命盘
+	c := chart.FromModel(record)
+	opts := types.AnalyzeOptions{Sections: req.Sections}
+	firstPrompt := analyzePromptText(opts.Sections)
 
 	// 获取消息ID
 	requestID, responseID, err := s.conversationMapper.GetNextMessageIDs(ctx, id)
@@ -168,7 +150,11 @@ func (s *ConversationServiceImpl) StreamAnalyzeBaziByUserID(ctx *gin.Context, ha
 		UserID:      id,
 		Title:       "八字分析",
 		SessionID:   sessionID,
-		FirstPrompt: "分析我的八字",
+		FirstPrompt: firstPrompt,
+		BaziID:      record.ID,
+	}
+	if conversationRecord.BaziSnapshot, err = c.Snapshot(); err != nil {
+		utils.BizLogger(ctx).Errorf("生成命盘快照失败: %v", err)
 	}
 	if err := s.conversationMapper.SaveConversation(ctx, conversationRecord); err != nil {
 		utils.BizLogger(ctx).Errorf("保存对话记录失败: %v", err)
@@ -180,7 +166,7 @@ func (s *ConversationServiceImpl) StreamAnalyzeBaziByUserID(ctx *gin.Context, ha
 		UserID:         id,
 		SessionID:      sessionID,
 		Role:           "USER",
-		Content:        "分析我的八字",
+		Content:        firstPrompt,
 		RequestID:      requestID,
 		ResponseID:     responseID,
 		ParentID:       0,
@@ -189,14 +175,17 @@ func (s *ConversationServiceImpl) StreamAnalyzeBaziByUserID(ctx *gin.Context, ha
 		utils.BizLogger(ctx).Errorf("保存用户消息失败: %v", err)
 	}
 
-	// 存储完整的分析结果
-	var fullAnalysis string
+	// 存储完整的分析结果与思考过程
+	var fullAnalysis, fullThinking string
+	thinkingTimer := utils.NewThinkingTimer()
 
 	// 包装处理函数
 	wrappedHandler := types.StreamHandler(func(chunk *conversation.StreamChunk) error {
 		// 累积完整内容
 		if !chunk.Done {
+			fullThinking += chunk.Reasoning
 			fullAnalysis += chunk.Content
+			thinkingTimer.Observe(chunk.Reasoning, chunk.Content)
 		} else {
 			// 保存对话历史和AI回复
 			if err := s.conversationMapper.SaveConversationHistory(ctx, id, sessionID, fullAnalysis); err != nil {
@@ -204,27 +193,145 @@ func (s *ConversationServiceImpl) StreamAnalyzeBaziByUserID(ctx *gin.Context, ha
 			}
 
 			aiMessage := &conversationModel.Message{
-				ConversationID: conversationRecord.ID,
-				UserID:         id,
-				SessionID:      sessionID,
-				Role:           "ASSISTANT",
-				Content:        fullAnalysis,
-				RequestID:      requestID,
-				ResponseID:     responseID,
-				ParentID:       requestID,
-				TokenUsage:     len(fullAnalysis) / 4, // 粗略估算token数量
+				ConversationID:      conversationRecord.ID,
+				UserID:              id,
+				SessionID:           sessionID,
+				Role:                "ASSISTANT",
+				Content:             fullAnalysis,
+				ThinkingContent:     fullThinking,
+				ThinkingElapsedSecs: thinkingTimer.Seconds(),
+				PromptVersion:       chunk.PromptVersion,
+				RequestID:           requestID,
+				ResponseID:          responseID,
+				ParentID:            requestID,
 			}
-			if err := s.conversationMapper.SaveMessage(ctx, aiMessage); err != nil {
+			// Provider 未返回用量时按本地估算
+			if chunk.Usage == nil {
+				var promptText string
+				if rendered, err := buildAnalyzePrompt(c, opts.Sections); err == nil {
+					promptText = rendered.Text
+				}
+				chunk.Usage = tokenizer.EstimateUsage(promptText, fullThinking, fullAnalysis)
+			}
+			applyTokenUsage(aiMessage, chunk.Usage)
+
+			// 指定章节时按章节标题拆分回答，各章节单独存储
+			if len(opts.Sections) > 0 {
+				keys := section.Normalize(opts.Sections)
+				contents, err := section.SplitMarkdown(fullAnalysis, keys)
+				if err != nil {
+					utils.BizLogger(ctx).Errorf("拆分分析章节失败: %v", err)
+				}
+				sections := newMessageSections(conversationRecord, section.Build(contents), chunk.PromptVersion)
+				if err := s.conversationMapper.SaveMessageWithSections(ctx, aiMessage, sections); err != nil {
+					utils.BizLogger(ctx).Errorf("保存AI回复失败: %v", err)
+				}
+			} else if err := s.conversationMapper.SaveMessage(ctx, aiMessage); err != nil {
 				utils.BizLogger(ctx).Errorf("保存AI回复失败: %v", err)
 			}
+			chunk.AccumulatedTokenUsage = s.accumulatedTokenUsage(ctx, conversationRecord.ID, chunk.Usage)
 		}
 
 		// 转发给原始处理函数
-		return handler(chunk.Content, chunk.Done)
+		return handler(chunk)
 	})
 
 	// 调用AI服务进行流式分析
-	return s.aiService.StreamAnalyzeBazi(ctx, record.Name, record.Gender, record.BirthTime, record.Calendar, baziInfo, wrappedHandler)
+	return s.aiService.StreamAnalyzeBazi(ctx, c, opts, wrappedHandler)
+}
+
+// RegenerateSection 重新生成分析消息中的一个章节，其余章节保持不变
+func (s *ConversationServiceImpl) RegenerateSection(ctx *gin.Context, req *dto.RegenerateSectionRequest) (*conversation.BaziAnalysisResponse, error) {
+	// 获取用户ID
+	id, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 校验消息归属，仅可重新生成本人的AI回复
+	message, err := s.conversationMapper.GetMessageByID(ctx, req.MessageID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取消息记录失败: %v", err)
+		return nil, fmt.Errorf("获取消息记录失败: %w", err)
+	}
+	if message.UserID != id || message.Role != "ASSISTANT" {
+		return nil, fmt.Errorf("消息记录不存在")
+	}
+
+	// 仅按章节保存的分析可重新生成章节
+	records, err := s.conversationMapper.GetMessageSections(ctx, message.ID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取消息章节失败: %v", err)
+		return nil, fmt.Errorf("获取消息章节失败: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("该消息未按章节保存，无法重新生成章节")
+	}
+
+	// 使用对话创建时的命盘，与其余章节保持一致
+	c, err := s.messageChart(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+
+	// 调用AI重新生成所选章节
+	analysisResponse, err := s.aiService.AnalyzeBaziWithReasoning(ctx, c, types.AnalyzeOptions{Structured: true, Sections: []string{req.Key}})
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("AI重新生成章节失败: %v", err)
+		return nil, fmt.Errorf("AI重新生成章节失败: %w", err)
+	}
+	if len(analysisResponse.Sections) != 1 {
+		return nil, fmt.Errorf("AI重新生成章节失败: 未返回章节 %s", req.Key)
+	}
+	regenerated := analysisResponse.Sections[0]
+
+	// 替换或新增该章节，其余章节保持不变
+	idx := slices.IndexFunc(records, func(r *conversationModel.MessageSection) bool { return r.SectionKey == req.Key })
+	if idx < 0 {
+		records = append(records, &conversationModel.MessageSection{
+			ConversationID: message.ConversationID,
+			UserID:         id,
+			SectionKey:     req.Key,
+			Sort:           section.Index(req.Key),
+		})
+		slices.SortStableFunc(records, func(a, b *conversationModel.MessageSection) int { return a.Sort - b.Sort })
+		idx = slices.IndexFunc(records, func(r *conversationModel.MessageSection) bool { return r.SectionKey == req.Key })
+	}
+	record := records[idx]
+	record.Title = regenerated.Title
+	record.Content = regenerated.Content
+	record.PromptVersion = analysisResponse.PromptVersion
+
+	// 按全部章节重建消息内容，并累加本次用量
+	sections := make([]*conversation.AnalysisSection, 0, len(records))
+	for _, r := range records {
+		sections = append(sections, &conversation.AnalysisSection{Key: r.SectionKey, Title: r.Title, Content: r.Content})
+	}
+	message.Content = section.Markdown(sections)
+	// Provider 未返回用量时按本地估算
+	if analysisResponse.Usage == nil {
+		var promptText string
+		if rendered, err := prompt.BuildBaziSectionsPrompt(c, []string{req.Key}, ""); err == nil {
+			promptText = rendered.Text
+		}
+		analysisResponse.Usage = tokenizer.EstimateUsage(promptText, analysisResponse.Reasoning, regenerated.Content)
+	}
+	message.TokenUsage += analysisResponse.Usage.TotalTokens
+	message.PromptTokens += analysisResponse.Usage.PromptTokens
+	message.CompletionTokens += analysisResponse.Usage.CompletionTokens
+	message.ReasoningTokens += analysisResponse.Usage.ReasoningTokens
+	if err := s.conversationMapper.UpdateMessageWithSection(ctx, message, record); err != nil {
+		utils.BizLogger(ctx).Errorf("保存重新生成的章节失败: %v", err)
+		return nil, fmt.Errorf("保存重新生成的章节失败: %w", err)
+	}
+
+	// 返回包含全部章节的分析结果
+	analysisResponse.RequestID = requestid.Get(ctx)
+	analysisResponse.UserID = id
+	analysisResponse.MessageID = message.ID
+	analysisResponse.Analysis = message.Content
+	analysisResponse.Sections = sections
+	return analysisResponse, nil
 }
 
 // ContinueConversation 继续与AI的对话
@@ -284,14 +391,24 @@ func (s *ConversationServiceImpl) ContinueConversation(ctx *gin.Context, req *dt
 		utils.BizLogger(ctx).Errorf("保存用户消息失败: %v", err)
 	}
 
+	// 按需附带人生日志作为上下文
+	var journalLines []string
+	if req.IncludeJournal {
+		journalLines = s.buildJournalContext(ctx, id, req.JournalLimit)
+	}
+
 	// 构建对话提示并请求AI回复
-	prompt := fmt.Sprintf("以下是之前的对话内容：\n\n%s\n\n用户的新问题是：%s", history, req.Prompt)
-	baziInfo := map[string]string{"prompt": prompt}
-	analysisResponse, err := s.aiService.AnalyzeBaziWithReasoning(ctx, "", "", time.Time{}, "", baziInfo)
+	rendered, err := prompt.BuildConversationPrompt(history, req.Prompt, journalLines)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("构建对话提示失败: %v", err)
+		return nil, fmt.Errorf("构建对话提示失败: %w", err)
+	}
+	aiReply, err := s.aiService.GenerateText(ctx, rendered.Text)
 	if err != nil {
 		utils.BizLogger(ctx).Errorf("AI回复失败: %v", err)
 		return nil, fmt.Errorf("AI回复失败: %w", err)
 	}
+	analysisResponse := &conversation.BaziAnalysisResponse{Analysis: aiReply.Text}
 
 	// 更新对话历史
 	newHistory := fmt.Sprintf("%s\n\n用户：%s\n\nAI：%s", history, req.Prompt, analysisResponse.Analysis)
@@ -306,11 +423,17 @@ func (s *ConversationServiceImpl) ContinueConversation(ctx *gin.Context, req *dt
 		SessionID:      sessionID,
 		Role:           "ASSISTANT",
 		Content:        analysisResponse.Analysis,
+		PromptVersion:  rendered.ID(),
 		RequestID:      requestID,
 		ResponseID:     responseID,
 		ParentID:       requestID,
-		TokenUsage:     len(analysisResponse.Analysis) / 4, // 粗略估算token数量
 	}
+	// Provider 未返回用量时按本地估算
+	usage := aiReply.Usage
+	if usage == nil {
+		usage = tokenizer.EstimateUsage(rendered.Text, "", aiReply.Text)
+	}
+	applyTokenUsage(aiMessage, usage)
 	if err := s.conversationMapper.SaveMessage(ctx, aiMessage); err != nil {
 		utils.BizLogger(ctx).Errorf("保存AI回复失败: %v", err)
 	}
@@ -323,13 +446,15 @@ func (s *ConversationServiceImpl) ContinueConversation(ctx *gin.Context, req *dt
 		UserMessage:    req.Prompt,
 		AIResponse:     analysisResponse.Analysis,
 		ConversationID: sessionID,
+		Provider:       string(aiReply.Provider),
+		Fallback:       aiReply.Fallback,
 	}
 
 	return result, nil
 }
 
 // StreamContinueConversation 流式继续对话
-func (s *ConversationServiceImpl) StreamContinueConversation(ctx *gin.Context, req *dto.StreamContinueConversationRequest, handler func(content string, done bool) error) error {
+func (s *ConversationServiceImpl) StreamContinueConversation(ctx *gin.Context, req *dto.StreamContinueConversationRequest, handler func(chunk *conversation.StreamChunk) error) error {
 	// 获取用户ID
 	id, err := utils.GetUserIDFromContext(ctx)
 	if err != nil {
@@ -385,17 +510,30 @@ func (s *ConversationServiceImpl) StreamContinueConversation(ctx *gin.Context, r
 		utils.BizLogger(ctx).Errorf("保存用户消息失败: %v", err)
 	}
 
+	// 按需附带人生日志作为上下文
+	var journalLines []string
+	if req.IncludeJournal {
+		journalLines = s.buildJournalContext(ctx, id, req.JournalLimit)
+	}
+
 	// 构建对话提示
-	prompt := fmt.Sprintf("以下是之前的对话内容：\n\n%s\n\n用户的新问题是：%s", history, req.Prompt)
+	rendered, err := prompt.BuildConversationPrompt(history, req.Prompt, journalLines)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("构建对话提示失败: %v", err)
+		return fmt.Errorf("构建对话提示失败: %w", err)
+	}
 
-	// 存储完整的响应
-	var fullResponse string
+	// 存储完整的响应与思考过程
+	var fullResponse, fullThinking string
+	thinkingTimer := utils.NewThinkingTimer()
 
 	// 包装处理函数
 	wrappedHandler := types.StreamHandler(func(chunk *conversation.StreamChunk) error {
 		// 累积完整内容
 		if !chunk.Done {
+			fullThinking += chunk.Reasoning
 			fullResponse += chunk.Content
+			thinkingTimer.Observe(chunk.Reasoning, chunk.Content)
 		} else {
 			// 更新对话历史和保存AI回复
 			newHistory := fmt.Sprintf("%s\n\n用户：%s\n\nAI：%s", history, req.Prompt, fullResponse)
@@ -404,27 +542,35 @@ func (s *ConversationServiceImpl) StreamContinueConversation(ctx *gin.Context, r
 			}
 
 			aiMessage := &conversationModel.Message{
-				ConversationID: conversationRecord.ID,
-				UserID:         id,
-				SessionID:      sessionID,
-				Role:           "ASSISTANT",
-				Content:        fullResponse,
-				RequestID:      requestID,
-				ResponseID:     responseID,
-				ParentID:       requestID,
-				TokenUsage:     len(fullResponse) / 4, // 粗略估算token数量
+				ConversationID:      conversationRecord.ID,
+				UserID:              id,
+				SessionID:           sessionID,
+				Role:                "ASSISTANT",
+				Content:             fullResponse,
+				ThinkingContent:     fullThinking,
+				ThinkingElapsedSecs: thinkingTimer.Seconds(),
+				PromptVersion:       rendered.ID(),
+				RequestID:           requestID,
+				ResponseID:          responseID,
+				ParentID:            requestID,
 			}
+			// Provider 未返回用量时按本地估算
+			if chunk.Usage == nil {
+				chunk.Usage = tokenizer.EstimateUsage(rendered.Text, fullThinking, fullResponse)
+			}
+			applyTokenUsage(aiMessage, chunk.Usage)
 			if err := s.conversationMapper.SaveMessage(ctx, aiMessage); err != nil {
 				utils.BizLogger(ctx).Errorf("保存AI回复失败: %v", err)
 			}
+			chunk.AccumulatedTokenUsage = s.accumulatedTokenUsage(ctx, conversationRecord.ID, chunk.Usage)
 		}
 
 		// 转发给原始处理函数
-		return handler(chunk.Content, chunk.Done)
+		return handler(chunk)
 	})
 
 	// 调用AI服务进行流式对话
-	return s.aiService.StreamAnalyzeBazi(ctx, "", "", time.Time{}, "", map[string]string{"prompt": prompt}, wrappedHandler)
+	return s.aiService.StreamGenerateText(ctx, rendered.Text, wrappedHandler)
 }
 
 // GetMessageIDs 获取当前用户的消息ID
@@ -436,3 +582,260 @@ func (s *ConversationServiceImpl) GetMessageIDs(ctx *gin.Context) (int, int, err
 
 	return s.conversationMapper.GetNextMessageIDs(ctx, id)
 }
+
+// GetAIHealth 获取 AI 服务各 Provider 的健康状态
+func (s *ConversationServiceImpl) GetAIHealth(ctx *gin.Context) (*conversation.AIHealthResponse, error) {
+	list := internalAI.Health()
+	providers := make([]*conversation.ProviderHealth, 0, len(list))
+	for _, h := range list {
+		providers = append(providers, &conversation.ProviderHealth{
+			Provider:            string(h.Provider),
+			Weight:              h.Weight,
+			Fallback:            h.Fallback,
+			State:               h.State,
+			ConsecutiveFailures: h.ConsecutiveFailures,
+			Successes:           h.Successes,
+			Failures:            h.Failures,
+			LastError:           h.LastError,
+			LastLatencyMs:       h.LastLatencyMs,
+			LastSuccessAt:       h.LastSuccessAt,
+			LastFailureAt:       h.LastFailureAt,
+			OpenedAt:            h.OpenedAt,
+		})
+	}
+	return &conversation.AIHealthResponse{Providers: providers}, nil
+}
+
+// getAnalysisBazi 获取待分析的八字档案，指定 ID 时校验归属，未指定时使用默认档案
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户ID
+//   - baziID: 八字 ID，为 0 时使用默认档案
+//
+// 返回值：
+//   - *baziModel.Bazi: 八字记录
+//   - error: 错误信息
+func (s *ConversationServiceImpl) getAnalysisBazi(ctx *gin.Context, userID, baziID int64) (*baziModel.Bazi, error) {
+	if baziID != 0 {
+		record, err := s.baziMapper.GetOneBaziByID(ctx, userID, baziID)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("获取用户八字记录失败: %v", err)
+			return nil, fmt.Errorf("获取用户八字记录失败: %w", err)
+		}
+		return record, nil
+	}
+
+	record, err := s.baziMapper.GetDefaultBazi(ctx, userID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取用户八字记录失败: %v", err)
+		return nil, fmt.Errorf("获取用户八字记录失败: %w", err)
+	}
+	if record == nil {
+		utils.BizLogger(ctx).Errorf("未找到用户八字记录")
+		return nil, fmt.Errorf("未找到用户八字记录")
+	}
+	return record, nil
+}
+
+// buildJournalContext 获取用户最近的人生日志并格式化为对话上下文，失败时仅记录日志
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户ID
+//   - limit: 日志条数
+//
+// 返回值：
+//   - []string: 每条日志一行的摘要
+func (s *ConversationServiceImpl) buildJournalContext(ctx *gin.Context, userID int64, limit int) []string {
+	if limit <= 0 {
+		limit = DEFAULT_JOURNAL_SIZE
+	}
+
+	entries, _, err := s.journalMapper.SearchEntries(ctx, &journalMapper.JournalQuery{UserID: userID}, 1, limit)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取人生日志失败: %v", err)
+		return nil
+	}
+
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		pillars := fmt.Sprintf("流年%s 流月%s", e.YearPillar, e.MonthPillar)
+		if e.LuckPillar != "" {
+			pillars = fmt.Sprintf("大运%s %s", e.LuckPillar, pillars)
+		}
+		line := fmt.Sprintf("- %s【%s】%s（%s）", e.EntryDate.Format(time.DateOnly), e.Title, e.Content, pillars)
+		if e.Interactions != "" {
+			line += "；引动：" + strings.ReplaceAll(e.Interactions, "\n", "、")
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// saveSectionedAnalysis 保存按章节分析的用户消息与AI回复，AI回复的各章节单独存储
+// 参数：
+//   - ctx: 上下文信息
+//   - conversationRecord: 对话记录
+//   - analysis: 分析结果，保存成功后写入消息ID
+func (s *ConversationServiceImpl) saveSectionedAnalysis(ctx *gin.Context, conversationRecord *conversationModel.Conversation, analysis *conversation.BaziAnalysisResponse) {
+	requestID, responseID, err := s.conversationMapper.GetNextMessageIDs(ctx, conversationRecord.UserID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取消息ID失败: %v", err)
+		requestID = INITIAL_MESSAGE_ID
+		responseID = INITIAL_MESSAGE_ID + 1
+	}
+
+	userMessage := &conversationModel.Message{
+		ConversationID: conversationRecord.ID,
+		UserID:         conversationRecord.UserID,
+		SessionID:      conversationRecord.SessionID,
+		Role:           "USER",
+		Content:        conversationRecord.FirstPrompt,
+		RequestID:      requestID,
+		ResponseID:     responseID,
+		ParentID:       0,
+	}
+	if err := s.conversationMapper.SaveMessage(ctx, userMessage); err != nil {
+		utils.BizLogger(ctx).Errorf("保存用户消息失败: %v", err)
+	}
+
+	aiMessage := &conversationModel.Message{
+		ConversationID:  conversationRecord.ID,
+		UserID:          conversationRecord.UserID,
+		SessionID:       conversationRecord.SessionID,
+		Role:            "ASSISTANT",
+		Content:         analysis.Analysis,
+		ThinkingContent: analysis.Reasoning,
+		PromptVersion:   analysis.PromptVersion,
+		RequestID:       requestID,
+		ResponseID:      responseID,
+		ParentID:        requestID,
+	}
+	if analysis.Usage != nil {
+		applyTokenUsage(aiMessage, analysis.Usage)
+	}
+
+	sections := newMessageSections(conversationRecord, analysis.Sections, analysis.PromptVersion)
+	if err := s.conversationMapper.SaveMessageWithSections(ctx, aiMessage, sections); err != nil {
+		utils.BizLogger(ctx).Errorf("保存AI回复失败: %v", err)
+		return
+	}
+	analysis.MessageID = aiMessage.ID
+}
+
+// messageChart 获取分析消息对应的命盘，对话保存了命盘快照时使用快照，否则使用对话关联的八字档案
+// 参数：
+//   - ctx: 上下文信息
+//   - message: 分析消息
+//
+// 返回值：
+//   - *chart.Chart: 八字命盘
+//   - error: 错误信息
+func (s *ConversationServiceImpl) messageChart(ctx *gin.Context, message *conversationModel.Message) (*chart.Chart, error) {
+	conversationRecord, err := s.conversationMapper.GetConversationByID(ctx, message.ConversationID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取对话记录失败: %v", err)
+		return nil, fmt.Errorf("获取对话记录失败: %w", err)
+	}
+
+	if conversationRecord.BaziSnapshot != "" {
+		c, err := chart.FromSnapshot(conversationRecord.BaziSnapshot)
+		if err == nil {
+			return c, nil
+		}
+		utils.BizLogger(ctx).Errorf("解析对话命盘快照失败: %v", err)
+	}
+
+	record, err := s.baziMapper.GetOneBaziByID(ctx, message.UserID, conversationRecord.BaziID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取用户八字记录失败: %v", err)
+		return nil, fmt.Errorf("获取用户八字记录失败: %w", err)
+	}
+	return chart.FromModel(record), nil
+}
+
+// analyzePromptText 八字分析的用户提示语，指定章节时附带章节标题
+// 参数：
+//   - keys: 指定的章节键，为空时表示全部章节
+//
+// 返回值：
+//   - string: 用户提示语
+func analyzePromptText(keys []string) string {
+	if len(keys) == 0 {
+		return "分析我的八字"
+	}
+
+	titles := make([]string, 0, len(keys))
+	for _, key := range section.Normalize(keys) {
+		if def, ok := section.Lookup(key); ok {
+			titles = append(titles, def.Title)
+		}
+	}
+	return "分析我的八字：" + strings.Join(titles, "、")
+}
+
+// buildAnalyzePrompt 构建流式八字分析提示，用于 Provider 未返回用量时估算
+// 参数：
+//   - c: 八字命盘
+//   - keys: 指定的章节键，为空时表示全部章节
+//
+// 返回值：
+//   - *prompt.Rendered: 渲染后的提示
+//   - error: 渲染失败时返回错误
+func buildAnalyzePrompt(c *chart.Chart, keys []string) (*prompt.Rendered, error) {
+	if len(keys) > 0 {
+		return prompt.BuildBaziSubsetPrompt(c, section.Normalize(keys))
+	}
+	return prompt.BuildBaziPrompt(c)
+}
+
+// newMessageSections 将分析章节转换为消息章节记录，顺序取章节在全部章节中的位置，重新生成时保持不变
+// 参数：
+//   - conversationRecord: 对话记录
+//   - sections: 分析章节
+//   - promptVersion: 提示模板版本
+//
+// 返回值：
+//   - []*conversationModel.MessageSection: 消息章节记录
+func newMessageSections(conversationRecord *conversationModel.Conversation, sections []*conversation.AnalysisSection, promptVersion string) []*conversationModel.MessageSection {
+	records := make([]*conversationModel.MessageSection, 0, len(sections))
+	for _, s := range sections {
+		records = append(records, &conversationModel.MessageSection{
+			ConversationID: conversationRecord.ID,
+			UserID:         conversationRecord.UserID,
+			SectionKey:     s.Key,
+			Title:          s.Title,
+			Content:        s.Content,
+			Sort:           section.Index(s.Key),
+			PromptVersion:  promptVersion,
+		})
+	}
+	return records
+}
+
+// accumulatedTokenUsage 统计对话累计 Token 用量，查询失败时退回本次回复的用量
+// 参数：
+//   - ctx: 上下文信息
+//   - conversationID: 对话ID
+//   - usage: 本次回复的 Token 用量
+//
+// 返回值：
+//   - int: 对话累计 Token 用量
+func (s *ConversationServiceImpl) accumulatedTokenUsage(ctx *gin.Context, conversationID int64, usage *conversation.TokenUsage) int {
+	total, err := s.conversationMapper.SumTokenUsageByConversationID(ctx, conversationID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("统计对话 Token 用量失败: %v", err)
+		return usage.TotalTokens
+	}
+	return total
+}
+
+// applyTokenUsage 将 Token 用量写入消息
+// 参数：
+//   - message: 消息记录
+//   - usage: Token 用量
+func applyTokenUsage(message *conversationModel.Message, usage *conversation.TokenUsage) {
+	message.TokenUsage = usage.TotalTokens
+	message.PromptTokens = usage.PromptTokens
+	message.CompletionTokens = usage.CompletionTokens
+	message.ReasoningTokens = usage.ReasoningTokens
+}
@@ -0,0 +1,49 @@
+// Package event 提供人生事件相关的服务接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package event
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/pkg/serve/controller/event/dto"
+	eventVO "github.com/Done-0/metaphysics/pkg/vo/event"
+)
+
+// EventService 人生事件服务接口
+type EventService interface {
+	// CreateOneEvent 创建人生事件
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *eventVO.EventResponse: 人生事件
+	//   - error: 错误信息
+	CreateOneEvent(ctx *gin.Context, req *dto.CreateEventRequest) (*eventVO.EventResponse, error)
+
+	// GetEventList 获取某八字下的人生事件列表
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *eventVO.EventListResponse: 人生事件列表
+	//   - error: 错误信息
+	GetEventList(ctx *gin.Context, req *dto.GetEventListRequest) (*eventVO.EventListResponse, error)
+
+	// DeleteOneEvent 删除人生事件
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneEvent(ctx *gin.Context, req *dto.DeleteEventRequest) error
+
+	// RectifyBirthTime 根据已记录的人生事件校正出生时辰
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *eventVO.RectificationResponse: 校正结果
+	//   - error: 错误信息
+	RectifyBirthTime(ctx *gin.Context, req *dto.RectifyBirthTimeRequest) (*eventVO.RectificationResponse, error)
+}
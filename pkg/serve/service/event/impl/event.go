@@ -0,0 +1,222 @@
+// Package impl 提供人生事件相关的服务层实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/model/event"
+	"github.com/Done-0/metaphysics/internal/rectification"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/event/dto"
+	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
+	eventMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/event"
+	eventSrv "github.com/Done-0/metaphysics/pkg/serve/service/event"
+	eventVO "github.com/Done-0/metaphysics/pkg/vo/event"
+)
+
+// EventServiceImpl 人生事件服务实现
+type EventServiceImpl struct {
+	baziMapper  baziMapper.BaziMapper
+	eventMapper eventMapper.EventMapper
+}
+
+// NewEventService 创建人生事件服务实例
+// 参数：
+//   - baziMapperImpl: 八字数据访问接口
+//   - eventMapperImpl: 人生事件数据访问接口
+//
+// 返回值：
+//   - eventSrv.EventService: 人生事件服务接口
+func NewEventService(baziMapperImpl baziMapper.BaziMapper, eventMapperImpl eventMapper.EventMapper) eventSrv.EventService {
+	return &EventServiceImpl{
+		baziMapper:  baziMapperImpl,
+		eventMapper: eventMapperImpl,
+	}
+}
+
+// CreateOneEvent 创建人生事件
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *eventVO.EventResponse: 人生事件
+//   - error: 错误信息
+func (s *EventServiceImpl) CreateOneEvent(ctx *gin.Context, req *dto.CreateEventRequest) (*eventVO.EventResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bazi, err := s.baziMapper.GetOneBaziByID(ctx, userID, req.BaziID)
+	if err != nil {
+		return nil, err
+	}
+	if req.EventDate.Before(bazi.BirthTime) {
+		return nil, fmt.Errorf("事件日期不能早于出生日期")
+	}
+
+	lifeEvent := &event.LifeEvent{
+		UserID:      userID,
+		BaziID:      bazi.ID,
+		EventType:   req.EventType,
+		EventDate:   req.EventDate,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+	if err := s.eventMapper.CreateOneEvent(ctx, lifeEvent); err != nil {
+		utils.BizLogger(ctx).Errorf("创建人生事件失败: %v", err)
+		return nil, fmt.Errorf("创建人生事件失败: %w", err)
+	}
+
+	vo, err := utils.MapModelToVO(lifeEvent, &eventVO.EventResponse{})
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("创建人生事件时映射 VO 失败: %v", err)
+		return nil, fmt.Errorf("创建人生事件时映射 VO 失败: %w", err)
+	}
+
+	return vo.(*eventVO.EventResponse), nil
+}
+
+// GetEventList 获取某八字下的人生事件列表
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *eventVO.EventListResponse: 人生事件列表
+//   - error: 错误信息
+func (s *EventServiceImpl) GetEventList(ctx *gin.Context, req *dto.GetEventListRequest) (*eventVO.EventListResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventMapper.GetEventsByBaziID(ctx, userID, req.BaziID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取人生事件列表失败: %v", err)
+		return nil, fmt.Errorf("获取人生事件列表失败: %w", err)
+	}
+
+	list := make([]*eventVO.EventResponse, 0, len(events))
+	for _, item := range events {
+		vo, err := utils.MapModelToVO(item, &eventVO.EventResponse{})
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("获取人生事件列表时映射单个VO失败: %v", err)
+			continue
+		}
+		list = append(list, vo.(*eventVO.EventResponse))
+	}
+
+	return &eventVO.EventListResponse{
+		Total: len(list),
+		List:  list,
+	}, nil
+}
+
+// DeleteOneEvent 删除人生事件
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - error: 错误信息
+func (s *EventServiceImpl) DeleteOneEvent(ctx *gin.Context, req *dto.DeleteEventRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	lifeEvent, err := s.eventMapper.GetOneEventByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if lifeEvent.UserID != userID {
+		return fmt.Errorf("无权删除该人生事件")
+	}
+
+	if err := s.eventMapper.DeleteOneEvent(ctx, lifeEvent.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("删除人生事件失败: %v", err)
+		return fmt.Errorf("删除人生事件失败: %w", err)
+	}
+
+	return nil
+}
+
+// RectifyBirthTime 根据已记录的人生事件校正出生时辰
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *eventVO.RectificationResponse: 校正结果
+//   - error: 错误信息
+func (s *EventServiceImpl) RectifyBirthTime(ctx *gin.Context, req *dto.RectifyBirthTimeRequest) (*eventVO.RectificationResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bazi, err := s.baziMapper.GetOneBaziByID(ctx, userID, req.BaziID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventMapper.GetEventsByBaziID(ctx, userID, bazi.ID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("时辰校正时获取人生事件失败: %v", err)
+		return nil, fmt.Errorf("时辰校正时获取人生事件失败: %w", err)
+	}
+
+	input := &rectification.Input{
+		BirthTime: bazi.BirthTime,
+		Calendar:  bazi.Calendar,
+		Gender:    bazi.Gender,
+		Events:    make([]*rectification.Event, 0, len(events)),
+	}
+	for _, e := range events {
+		input.Events = append(input.Events, &rectification.Event{Type: e.EventType, Date: e.EventDate})
+	}
+
+	candidates, err := rectification.Rectify(input)
+	if err != nil {
+		return nil, fmt.Errorf("时辰校正失败: %w", err)
+	}
+
+	candidateVOs := make([]*eventVO.HourCandidateResponse, 0, len(candidates))
+	for i, c := range candidates {
+		evidences := make([]*eventVO.EvidenceResponse, 0, len(c.Evidences))
+		for _, ev := range c.Evidences {
+			evidences = append(evidences, &eventVO.EvidenceResponse{
+				EventType: ev.EventType,
+				EventDate: ev.EventDate,
+				Source:    ev.Source,
+				GanZhi:    ev.GanZhi,
+				Target:    ev.Target,
+				Detail:    ev.Detail,
+				Weight:    ev.Weight,
+			})
+		}
+		candidateVOs = append(candidateVOs, &eventVO.HourCandidateResponse{
+			Rank:       i + 1,
+			HourZhi:    c.HourZhi,
+			HourPillar: c.HourPillar,
+			TimeRange:  c.TimeRange,
+			Score:      c.Score,
+			Confidence: c.Confidence,
+			Explained:  c.Explained,
+			Evidences:  evidences,
+		})
+	}
+
+	return &eventVO.RectificationResponse{
+		BaziID:            fmt.Sprintf("%d", bazi.ID),
+		CurrentHourPillar: bazi.HourPillar,
+		EventCount:        len(events),
+		Candidates:        candidateVOs,
+	}, nil
+}
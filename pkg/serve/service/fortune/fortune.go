@@ -0,0 +1,50 @@
+// Package fortune 提供每日运势相关的服务接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package fortune
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/pkg/serve/controller/fortune/dto"
+	fortuneVO "github.com/Done-0/metaphysics/pkg/vo/fortune"
+)
+
+// FortuneService 每日运势服务接口
+type FortuneService interface {
+	// GetTodayFortune 获取今日运势，尚未生成时即时计算并保存
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *fortuneVO.DailyFortuneResponse: 今日运势
+	//   - error: 错误信息
+	GetTodayFortune(ctx *gin.Context, req *dto.GetTodayFortuneRequest) (*fortuneVO.DailyFortuneResponse, error)
+
+	// Subscribe 订阅每日运势
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *fortuneVO.FortuneSubscriptionResponse: 订阅信息
+	//   - error: 错误信息
+	Subscribe(ctx *gin.Context, req *dto.SubscribeFortuneRequest) (*fortuneVO.FortuneSubscriptionResponse, error)
+
+	// Unsubscribe 取消订阅每日运势
+	// 参数：
+	//   - ctx: 上下文信息
+	// 返回值：
+	//   - error: 错误信息
+	Unsubscribe(ctx *gin.Context) error
+
+	// GenerateDailyFortunes 为全部订阅用户生成指定日期的运势，并向开启邮件推送的用户发送邮件
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - date: 日期
+	// 返回值：
+	//   - int: 生成的运势数量
+	//   - error: 错误信息
+	GenerateDailyFortunes(ctx *gin.Context, date time.Time) (int, error)
+}
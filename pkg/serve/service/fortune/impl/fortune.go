@@ -0,0 +1,439 @@
+// Package impl 提供每日运势相关的服务层实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/configs"
+	internalAI "github.com/Done-0/metaphysics/internal/ai"
+	"github.com/Done-0/metaphysics/internal/ai/prompt"
+	"github.com/Done-0/metaphysics/internal/fortune"
+	"github.com/Done-0/metaphysics/internal/model/bazi"
+	fortuneModel "github.com/Done-0/metaphysics/internal/model/fortune"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/fortune/dto"
+	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
+	fortuneMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/fortune"
+	userMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/user"
+	fortuneSrv "github.com/Done-0/metaphysics/pkg/serve/service/fortune"
+	fortuneVO "github.com/Done-0/metaphysics/pkg/vo/fortune"
+)
+
+// 每日运势常量
+const (
+	DEFAULT_BATCH_SIZE    = 10   // 默认每批处理的订阅数量
+	INTERACTION_SEPARATOR = "\n" // 流日关系分隔符
+)
+
+// polishLinePattern 匹配润色结果中的 "[编号] 内容" 行
+var polishLinePattern = regexp.MustCompile(`^\[(\d+)\]\s*(.+)$`)
+
+// FortuneServiceImpl 每日运势服务实现
+type FortuneServiceImpl struct {
+	baziMapper    baziMapper.BaziMapper
+	fortuneMapper fortuneMapper.FortuneMapper
+	userMapper    userMapper.UserMapper
+}
+
+// NewFortuneService 创建每日运势服务实例
+// 参数：
+//   - baziMapperImpl: 八字数据访问接口
+//   - fortuneMapperImpl: 每日运势数据访问接口
+//   - userMapperImpl: 用户数据访问接口
+//
+// 返回值：
+//   - fortuneSrv.FortuneService: 每日运势服务接口
+func NewFortuneService(baziMapperImpl baziMapper.BaziMapper, fortuneMapperImpl fortuneMapper.FortuneMapper, userMapperImpl userMapper.UserMapper) fortuneSrv.FortuneService {
+	return &FortuneServiceImpl{
+		baziMapper:    baziMapperImpl,
+		fortuneMapper: fortuneMapperImpl,
+		userMapper:    userMapperImpl,
+	}
+}
+
+// GetTodayFortune 获取今日运势，尚未生成时即时计算并保存
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *fortuneVO.DailyFortuneResponse: 今日运势
+//   - error: 错误信息
+func (s *FortuneServiceImpl) GetTodayFortune(ctx *gin.Context, req *dto.GetTodayFortuneRequest) (*fortuneVO.DailyFortuneResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+	today := time.Now().In(loadLocation(cfg.FortuneConfig.FortuneTimezone))
+	date := today.Format(time.DateOnly)
+
+	baziID := req.BaziID
+	if baziID == 0 {
+		subscription, err := s.fortuneMapper.GetSubscriptionByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if subscription == nil || !subscription.Active {
+			return nil, fmt.Errorf("未订阅每日运势，请指定八字 ID")
+		}
+		baziID = subscription.BaziID
+	}
+
+	existing, err := s.fortuneMapper.GetDailyFortune(ctx, userID, date)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取今日运势失败: %v", err)
+		return nil, fmt.Errorf("获取今日运势失败: %w", err)
+	}
+	if existing != nil && existing.BaziID == baziID {
+		return mapFortuneToVO(existing)
+	}
+
+	record, err := s.baziMapper.GetOneBaziByID(ctx, userID, baziID)
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := calculateDailyFortune(userID, record, today)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("计算今日运势失败: %v", err)
+		return nil, fmt.Errorf("计算今日运势失败: %w", err)
+	}
+
+	if err := s.fortuneMapper.SaveDailyFortune(ctx, daily); err != nil {
+		utils.BizLogger(ctx).Errorf("保存今日运势失败: %v", err)
+		return nil, fmt.Errorf("保存今日运势失败: %w", err)
+	}
+
+	return mapFortuneToVO(daily)
+}
+
+// Subscribe 订阅每日运势
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *fortuneVO.FortuneSubscriptionResponse: 订阅信息
+//   - error: 错误信息
+func (s *FortuneServiceImpl) Subscribe(ctx *gin.Context, req *dto.SubscribeFortuneRequest) (*fortuneVO.FortuneSubscriptionResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.baziMapper.GetOneBaziByID(ctx, userID, req.BaziID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.fortuneMapper.GetSubscriptionByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		subscription = &fortuneModel.FortuneSubscription{UserID: userID}
+	}
+	subscription.BaziID = record.ID
+	subscription.EmailEnabled = req.EmailEnabled
+	subscription.Active = true
+
+	if err := s.fortuneMapper.SaveSubscription(ctx, subscription); err != nil {
+		utils.BizLogger(ctx).Errorf("订阅每日运势失败: %v", err)
+		return nil, fmt.Errorf("订阅每日运势失败: %w", err)
+	}
+
+	return mapSubscriptionToVO(subscription)
+}
+
+// Unsubscribe 取消订阅每日运势
+// 参数：
+//   - ctx: 上下文信息
+//
+// 返回值：
+//   - error: 错误信息
+func (s *FortuneServiceImpl) Unsubscribe(ctx *gin.Context) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := s.fortuneMapper.GetSubscriptionByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if subscription == nil || !subscription.Active {
+		return nil
+	}
+
+	subscription.Active = false
+	if err := s.fortuneMapper.SaveSubscription(ctx, subscription); err != nil {
+		utils.BizLogger(ctx).Errorf("取消订阅每日运势失败: %v", err)
+		return fmt.Errorf("取消订阅每日运势失败: %w", err)
+	}
+
+	return nil
+}
+
+// GenerateDailyFortunes 为全部订阅用户生成指定日期的运势，并向开启邮件推送的用户发送邮件
+// 参数：
+//   - ctx: 上下文信息
+//   - date: 日期
+//
+// 返回值：
+//   - int: 生成的运势数量
+//   - error: 错误信息
+func (s *FortuneServiceImpl) GenerateDailyFortunes(ctx *gin.Context, date time.Time) (int, error) {
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return 0, fmt.Errorf("获取配置失败: %w", err)
+	}
+	batchSize := cfg.FortuneConfig.FortuneBatchSize
+	if batchSize <= 0 {
+		batchSize = DEFAULT_BATCH_SIZE
+	}
+
+	generated := 0
+	var afterID int64
+	for {
+		if err := ctx.Request.Context().Err(); err != nil {
+			return generated, err
+		}
+
+		subscriptions, err := s.fortuneMapper.ListActiveSubscriptions(ctx, afterID, batchSize)
+		if err != nil {
+			return generated, err
+		}
+		if len(subscriptions) == 0 {
+			break
+		}
+		afterID = subscriptions[len(subscriptions)-1].ID
+
+		batch := make([]*fortuneModel.DailyFortune, 0, len(subscriptions))
+		emailTargets := make(map[int64]bool, len(subscriptions))
+		for _, subscription := range subscriptions {
+			record, err := s.baziMapper.GetOneBaziByID(ctx, subscription.UserID, subscription.BaziID)
+			if err != nil {
+				utils.BizLogger(ctx).Errorf("生成每日运势时获取八字失败, 用户: %d, 错误: %v", subscription.UserID, err)
+				continue
+			}
+
+			daily, err := calculateDailyFortune(subscription.UserID, record, date)
+			if err != nil {
+				utils.BizLogger(ctx).Errorf("计算每日运势失败, 用户: %d, 错误: %v", subscription.UserID, err)
+				continue
+			}
+			batch = append(batch, daily)
+			emailTargets[subscription.UserID] = subscription.EmailEnabled
+		}
+
+		if cfg.FortuneConfig.FortuneAIPolish {
+			polishFortunes(ctx, batch)
+		}
+
+		for _, daily := range batch {
+			if err := s.fortuneMapper.SaveDailyFortune(ctx, daily); err != nil {
+				utils.BizLogger(ctx).Errorf("保存每日运势失败, 用户: %d, 错误: %v", daily.UserID, err)
+				continue
+			}
+			generated++
+
+			if emailTargets[daily.UserID] && !daily.Emailed {
+				s.sendFortuneEmail(ctx, cfg.FortuneConfig.FortuneEmailSubject, daily)
+			}
+		}
+	}
+
+	return generated, nil
+}
+
+// sendFortuneEmail 发送每日运势邮件，失败时仅记录日志
+// 参数：
+//   - ctx: 上下文信息
+//   - subject: 邮件主题
+//   - daily: 每日运势
+func (s *FortuneServiceImpl) sendFortuneEmail(ctx *gin.Context, subject string, daily *fortuneModel.DailyFortune) {
+	user, err := s.userMapper.GetOneUserByID(ctx, daily.UserID)
+	if err != nil || user == nil || user.Email == "" {
+		utils.BizLogger(ctx).Errorf("发送每日运势邮件时获取用户失败, 用户: %d, 错误: %v", daily.UserID, err)
+		return
+	}
+
+	if _, err := utils.SendEmailWithSubject(subject, formatFortuneEmail(daily), []string{user.Email}); err != nil {
+		utils.BizLogger(ctx).Errorf("发送每日运势邮件失败, 用户: %d, 错误: %v", daily.UserID, err)
+		return
+	}
+
+	if err := s.fortuneMapper.MarkEmailed(ctx, daily.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("标记每日运势邮件状态失败, 用户: %d, 错误: %v", daily.UserID, err)
+	}
+}
+
+// calculateDailyFortune 根据八字计算某日运势
+// 参数：
+//   - userID: 用户 ID
+//   - record: 八字
+//   - date: 日期
+//
+// 返回值：
+//   - *fortuneModel.DailyFortune: 每日运势
+//   - error: 错误信息
+func calculateDailyFortune(userID int64, record *bazi.Bazi, date time.Time) (*fortuneModel.DailyFortune, error) {
+	result, err := fortune.Calculate(&fortune.Chart{
+		Gans: []string{record.YearGan, record.MonthGan, record.DayGan, record.HourGan},
+		Zhis: []string{record.YearZhi, record.MonthZhi, record.DayZhi, record.HourZhi},
+	}, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fortuneModel.DailyFortune{
+		UserID:            userID,
+		BaziID:            record.ID,
+		FortuneDate:       result.Date,
+		DayPillar:         result.DayPillar,
+		TenGod:            result.TenGod,
+		OverallScore:      result.Overall,
+		Level:             result.Level,
+		CareerScore:       result.Scores[fortune.DIMENSION_CAREER],
+		WealthScore:       result.Scores[fortune.DIMENSION_WEALTH],
+		RelationshipScore: result.Scores[fortune.DIMENSION_RELATIONSHIP],
+		HealthScore:       result.Scores[fortune.DIMENSION_HEALTH],
+		Interactions:      strings.Join(result.Interactions, INTERACTION_SEPARATOR),
+		Summary:           result.Summary,
+	}, nil
+}
+
+// polishFortunes 通过 AI 服务批量润色运势文案，失败时保留规则文案
+// 参数：
+//   - ctx: 上下文信息
+//   - batch: 同一批次的每日运势
+func polishFortunes(ctx *gin.Context, batch []*fortuneModel.DailyFortune) {
+	if len(batch) == 0 {
+		return
+	}
+
+	summaries := make([]string, 0, len(batch))
+	for _, daily := range batch {
+		summaries = append(summaries, daily.Summary)
+	}
+
+	rendered, err := prompt.BuildFortunePolishPrompt(summaries)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("构建运势润色提示失败: %v", err)
+		return
+	}
+
+	reply, err := internalAI.New().GenerateText(ctx, rendered.Text)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("润色每日运势失败: %v", err)
+		return
+	}
+
+	for _, line := range strings.Split(reply.Text, "\n") {
+		matches := polishLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		index, err := strconv.Atoi(matches[1])
+		if err != nil || index < 1 || index > len(batch) {
+			continue
+		}
+		batch[index-1].Polished = strings.TrimSpace(matches[2])
+	}
+}
+
+// formatFortuneEmail 生成每日运势邮件正文
+// 参数：
+//   - daily: 每日运势
+//
+// 返回值：
+//   - string: 邮件正文
+func formatFortuneEmail(daily *fortuneModel.DailyFortune) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<h3>%s %s日 · 综合%s（%d 分）</h3>", daily.FortuneDate, daily.DayPillar, daily.Level, daily.OverallScore))
+	sb.WriteString(fmt.Sprintf("<p>事业 %d ｜ 财运 %d ｜ 感情 %d ｜ 健康 %d</p>",
+		daily.CareerScore, daily.WealthScore, daily.RelationshipScore, daily.HealthScore))
+	sb.WriteString(fmt.Sprintf("<p>%s</p>", displaySummary(daily)))
+	return sb.String()
+}
+
+// displaySummary 获取展示用运势文案，已润色时优先使用润色文案
+// 参数：
+//   - daily: 每日运势
+//
+// 返回值：
+//   - string: 运势文案
+func displaySummary(daily *fortuneModel.DailyFortune) string {
+	if daily.Polished != "" {
+		return daily.Polished
+	}
+	return daily.Summary
+}
+
+// loadLocation 加载时区，失败时使用本地时区
+// 参数：
+//   - name: 时区名称
+//
+// 返回值：
+//   - *time.Location: 时区
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+// mapFortuneToVO 将每日运势模型映射为视图对象
+// 参数：
+//   - daily: 每日运势
+//
+// 返回值：
+//   - *fortuneVO.DailyFortuneResponse: 每日运势响应
+//   - error: 错误信息
+func mapFortuneToVO(daily *fortuneModel.DailyFortune) (*fortuneVO.DailyFortuneResponse, error) {
+	vo, err := utils.MapModelToVO(daily, &fortuneVO.DailyFortuneResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("每日运势映射VO失败: %w", err)
+	}
+
+	response := vo.(*fortuneVO.DailyFortuneResponse)
+	response.Summary = displaySummary(daily)
+	response.Interactions = []string{}
+	if daily.Interactions != "" {
+		response.Interactions = strings.Split(daily.Interactions, INTERACTION_SEPARATOR)
+	}
+	return response, nil
+}
+
+// mapSubscriptionToVO 将运势订阅模型映射为视图对象
+// 参数：
+//   - subscription: 运势订阅
+//
+// 返回值：
+//   - *fortuneVO.FortuneSubscriptionResponse: 运势订阅响应
+//   - error: 错误信息
+func mapSubscriptionToVO(subscription *fortuneModel.FortuneSubscription) (*fortuneVO.FortuneSubscriptionResponse, error) {
+	vo, err := utils.MapModelToVO(subscription, &fortuneVO.FortuneSubscriptionResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("运势订阅映射VO失败: %w", err)
+	}
+
+	return vo.(*fortuneVO.FortuneSubscriptionResponse), nil
+}
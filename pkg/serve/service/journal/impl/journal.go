@@ -0,0 +1,356 @@
+// Package impl 提供人生日志相关的服务层实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/internal/model/bazi"
+	"github.com/Done-0/metaphysics/internal/model/journal"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/journal/dto"
+	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
+	journalMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/journal"
+	journalSrv "github.com/Done-0/metaphysics/pkg/serve/service/journal"
+	journalVO "github.com/Done-0/metaphysics/pkg/vo/journal"
+)
+
+// 分页与导出常量
+const (
+	DEFAULT_PAGE_NO   = 1   // 默认页码
+	DEFAULT_PAGE_SIZE = 10  // 默认每页数量
+	MAX_PAGE_SIZE     = 100 // 最大每页数量
+
+	EXPORT_FORMAT_CSV      = "csv"      // CSV 格式
+	EXPORT_FORMAT_MARKDOWN = "markdown" // Markdown 格式
+
+	TAG_SEPARATOR         = ","  // 标签分隔符
+	INTERACTION_SEPARATOR = "\n" // 岁运注解分隔符
+)
+
+// JournalServiceImpl 人生日志服务实现
+type JournalServiceImpl struct {
+	baziMapper    baziMapper.BaziMapper
+	journalMapper journalMapper.JournalMapper
+}
+
+// NewJournalService 创建人生日志服务实例
+// 参数：
+//   - baziMapperImpl: 八字数据访问接口
+//   - journalMapperImpl: 人生日志数据访问接口
+//
+// 返回值：
+//   - journalSrv.JournalService: 人生日志服务接口
+func NewJournalService(baziMapperImpl baziMapper.BaziMapper, journalMapperImpl journalMapper.JournalMapper) journalSrv.JournalService {
+	return &JournalServiceImpl{
+		baziMapper:    baziMapperImpl,
+		journalMapper: journalMapperImpl,
+	}
+}
+
+// CreateOneEntry 创建日志，并自动注解当时的岁运干支及其与原局的关系
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *journalVO.JournalEntryResponse: 日志
+//   - error: 错误信息
+func (s *JournalServiceImpl) CreateOneEntry(ctx *gin.Context, req *dto.CreateJournalEntryRequest) (*journalVO.JournalEntryResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.baziMapper.GetOneBaziByID(ctx, userID, req.BaziID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &journal.JournalEntry{
+		UserID:    userID,
+		BaziID:    record.ID,
+		EntryDate: req.EntryDate,
+		Title:     req.Title,
+		Content:   req.Content,
+		Tags:      strings.Join(req.Tags, TAG_SEPARATOR),
+	}
+	annotateEntry(entry, record)
+
+	if err := s.journalMapper.CreateOneEntry(ctx, entry); err != nil {
+		utils.BizLogger(ctx).Errorf("创建日志失败: %v", err)
+		return nil, fmt.Errorf("创建日志失败: %w", err)
+	}
+
+	return mapEntryToVO(entry)
+}
+
+// SearchEntries 检索日志
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *journalVO.JournalListResponse: 日志列表
+//   - error: 错误信息
+func (s *JournalServiceImpl) SearchEntries(ctx *gin.Context, req *dto.SearchJournalRequest) (*journalVO.JournalListResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNo, pageSize := req.PageNo, req.PageSize
+	if pageNo <= 0 {
+		pageNo = DEFAULT_PAGE_NO
+	}
+	if pageSize <= 0 {
+		pageSize = DEFAULT_PAGE_SIZE
+	}
+	if pageSize > MAX_PAGE_SIZE {
+		pageSize = MAX_PAGE_SIZE
+	}
+
+	query := &journalMapper.JournalQuery{
+		UserID:    userID,
+		BaziID:    req.BaziID,
+		Keyword:   req.Keyword,
+		GanZhi:    req.GanZhi,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+	entries, total, err := s.journalMapper.SearchEntries(ctx, query, pageNo, pageSize)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("检索日志失败: %v", err)
+		return nil, fmt.Errorf("检索日志失败: %w", err)
+	}
+
+	list := make([]*journalVO.JournalEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		vo, err := mapEntryToVO(entry)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("检索日志时映射单个VO失败: %v", err)
+			continue
+		}
+		list = append(list, vo)
+	}
+
+	return &journalVO.JournalListResponse{
+		Total:    total,
+		PageNo:   pageNo,
+		PageSize: pageSize,
+		List:     list,
+	}, nil
+}
+
+// DeleteOneEntry 删除日志
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - error: 错误信息
+func (s *JournalServiceImpl) DeleteOneEntry(ctx *gin.Context, req *dto.DeleteJournalEntryRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry, err := s.journalMapper.GetOneEntryByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if entry.UserID != userID {
+		return fmt.Errorf("无权删除该日志")
+	}
+
+	if err := s.journalMapper.DeleteOneEntry(ctx, entry.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("删除日志失败: %v", err)
+		return fmt.Errorf("删除日志失败: %w", err)
+	}
+
+	return nil
+}
+
+// ExportEntries 导出日志
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *journalVO.JournalExportResponse: 导出文件
+//   - error: 错误信息
+func (s *JournalServiceImpl) ExportEntries(ctx *gin.Context, req *dto.ExportJournalRequest) (*journalVO.JournalExportResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &journalMapper.JournalQuery{
+		UserID:    userID,
+		BaziID:    req.BaziID,
+		Keyword:   req.Keyword,
+		GanZhi:    req.GanZhi,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+	entries, _, err := s.journalMapper.SearchEntries(ctx, query, 0, 0)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("导出日志失败: %v", err)
+		return nil, fmt.Errorf("导出日志失败: %w", err)
+	}
+
+	fileName := fmt.Sprintf("journal_%s", time.Now().Format("20060102150405"))
+	switch req.Format {
+	case EXPORT_FORMAT_MARKDOWN:
+		return &journalVO.JournalExportResponse{
+			FileName:    fileName + ".md",
+			ContentType: "text/markdown; charset=utf-8",
+			Content:     []byte(formatEntriesMarkdown(entries)),
+		}, nil
+	default:
+		content, err := formatEntriesCSV(entries)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("生成 CSV 失败: %v", err)
+			return nil, fmt.Errorf("生成 CSV 失败: %w", err)
+		}
+		return &journalVO.JournalExportResponse{
+			FileName:    fileName + ".csv",
+			ContentType: "text/csv; charset=utf-8",
+			Content:     content,
+		}, nil
+	}
+}
+
+// formatEntriesMarkdown 将日志格式化为 Markdown 文本
+// 参数：
+//   - entries: 日志列表
+//
+// 返回值：
+//   - string: Markdown 文本
+func formatEntriesMarkdown(entries []*journal.JournalEntry) string {
+	var sb strings.Builder
+	for _, e := range entries {
+		sb.WriteString(fmt.Sprintf("### %s %s\n\n", e.EntryDate.Format(time.DateOnly), e.Title))
+		sb.WriteString(fmt.Sprintf("- 岁运：大运 %s，流年 %s，流月 %s，流日 %s\n", orNone(e.LuckPillar), e.YearPillar, e.MonthPillar, e.DayPillar))
+		if e.Tags != "" {
+			sb.WriteString(fmt.Sprintf("- 标签：%s\n", e.Tags))
+		}
+		if e.Interactions != "" {
+			sb.WriteString(fmt.Sprintf("- 引动：%s\n", strings.ReplaceAll(e.Interactions, INTERACTION_SEPARATOR, "；")))
+		}
+		sb.WriteString("\n")
+		sb.WriteString(e.Content)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// annotateEntry 为日志注解当时的大运、流年、流月、流日及其与原局的关系
+// 参数：
+//   - entry: 日志记录
+//   - record: 八字记录
+func annotateEntry(entry *journal.JournalEntry, record *bazi.Bazi) {
+	natal := []string{record.YearPillar, record.MonthPillar, record.DayPillar, record.HourPillar}
+	luck := utils.FindLuckPillar(utils.CalculateLuckPillars(record.BirthTime, record.Calendar, record.Gender), entry.EntryDate.Year())
+
+	entry.YearPillar = utils.GetAnnualGanZhi(entry.EntryDate)
+	entry.MonthPillar = utils.GetMonthlyGanZhi(entry.EntryDate)
+	entry.DayPillar = utils.GetDailyGanZhi(entry.EntryDate)
+
+	var interactions []*utils.PillarInteraction
+	if luck != nil {
+		entry.LuckPillar = luck.GanZhi
+		interactions = append(interactions, utils.FindPillarInteractions("大运", luck.GanZhi, natal)...)
+	}
+	interactions = append(interactions, utils.FindPillarInteractions("流年", entry.YearPillar, natal)...)
+	interactions = append(interactions, utils.FindPillarInteractions("流月", entry.MonthPillar, natal)...)
+	interactions = append(interactions, utils.FindPillarInteractions("流日", entry.DayPillar, natal)...)
+
+	lines := make([]string, 0, len(interactions))
+	for _, it := range interactions {
+		lines = append(lines, it.String())
+	}
+	entry.Interactions = strings.Join(lines, INTERACTION_SEPARATOR)
+}
+
+// mapEntryToVO 将日志模型映射为视图对象
+// 参数：
+//   - entry: 日志记录
+//
+// 返回值：
+//   - *journalVO.JournalEntryResponse: 日志视图对象
+//   - error: 错误信息
+func mapEntryToVO(entry *journal.JournalEntry) (*journalVO.JournalEntryResponse, error) {
+	vo, err := utils.MapModelToVO(entry, &journalVO.JournalEntryResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("日志映射 VO 失败: %w", err)
+	}
+
+	result := vo.(*journalVO.JournalEntryResponse)
+	result.Tags = splitNonEmpty(entry.Tags, TAG_SEPARATOR)
+	result.Interactions = splitNonEmpty(entry.Interactions, INTERACTION_SEPARATOR)
+	return result, nil
+}
+
+// formatEntriesCSV 将日志格式化为 CSV
+// 参数：
+//   - entries: 日志列表
+//
+// 返回值：
+//   - []byte: CSV 内容（带 UTF-8 BOM，便于表格软件识别中文）
+//   - error: 错误信息
+func formatEntriesCSV(entries []*journal.JournalEntry) ([]byte, error) {
+	buf := bytes.NewBufferString("\uFEFF")
+	w := csv.NewWriter(buf)
+	if err := w.Write([]string{"日期", "标题", "内容", "标签", "大运", "流年", "流月", "流日", "引动"}); err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if err := w.Write([]string{
+			e.EntryDate.Format(time.DateOnly), e.Title, e.Content, e.Tags,
+			e.LuckPillar, e.YearPillar, e.MonthPillar, e.DayPillar,
+			strings.ReplaceAll(e.Interactions, INTERACTION_SEPARATOR, "；"),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// splitNonEmpty 按分隔符拆分字符串，忽略空项
+// 参数：
+//   - s: 原始字符串
+//   - sep: 分隔符
+//
+// 返回值：
+//   - []string: 拆分结果
+func splitNonEmpty(s, sep string) []string {
+	result := []string{}
+	for _, item := range strings.Split(s, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// orNone 空值显示为“未起运”
+// 参数：
+//   - s: 原始字符串
+//
+// 返回值：
+//   - string: 显示文本
+func orNone(s string) string {
+	if s == "" {
+		return "未起运"
+	}
+	return s
+}
@@ -0,0 +1,49 @@
+// Package journal 提供人生日志相关的服务接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package journal
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/pkg/serve/controller/journal/dto"
+	journalVO "github.com/Done-0/metaphysics/pkg/vo/journal"
+)
+
+// JournalService 人生日志服务接口
+type JournalService interface {
+	// CreateOneEntry 创建日志，并自动注解当时的岁运干支及其与原局的关系
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *journalVO.JournalEntryResponse: 日志
+	//   - error: 错误信息
+	CreateOneEntry(ctx *gin.Context, req *dto.CreateJournalEntryRequest) (*journalVO.JournalEntryResponse, error)
+
+	// SearchEntries 检索日志
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *journalVO.JournalListResponse: 日志列表
+	//   - error: 错误信息
+	SearchEntries(ctx *gin.Context, req *dto.SearchJournalRequest) (*journalVO.JournalListResponse, error)
+
+	// DeleteOneEntry 删除日志
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	DeleteOneEntry(ctx *gin.Context, req *dto.DeleteJournalEntryRequest) error
+
+	// ExportEntries 导出日志
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *journalVO.JournalExportResponse: 导出文件
+	//   - error: 错误信息
+	ExportEntries(ctx *gin.Context, req *dto.ExportJournalRequest) (*journalVO.JournalExportResponse, error)
+}
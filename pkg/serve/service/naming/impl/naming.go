@@ -0,0 +1,170 @@
+// Package impl 提供起名相关的服务层实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/configs"
+	internalAI "github.com/Done-0/metaphysics/internal/ai"
+	"github.com/Done-0/metaphysics/internal/ai/prompt"
+	"github.com/Done-0/metaphysics/internal/naming"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/naming/dto"
+	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
+	namingSrv "github.com/Done-0/metaphysics/pkg/serve/service/naming"
+	namingVO "github.com/Done-0/metaphysics/pkg/vo/naming"
+)
+
+// 默认 AI 解读的候选名数量
+const defaultExplainTop = 5
+
+// NamingServiceImpl 起名服务实现
+type NamingServiceImpl struct {
+	baziMapper baziMapper.BaziMapper
+}
+
+// NewNamingService 创建起名服务实例
+// 参数：
+//   - mapper: 八字数据访问接口
+//
+// 返回值：
+//   - namingSrv.NamingService: 起名服务接口
+func NewNamingService(mapper baziMapper.BaziMapper) namingSrv.NamingService {
+	return &NamingServiceImpl{
+		baziMapper: mapper,
+	}
+}
+
+// SuggestNames 根据八字喜用五行推荐姓名
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *namingVO.NameSuggestionResponse: 起名结果
+//   - error: 错误信息
+func (n *NamingServiceImpl) SuggestNames(ctx *gin.Context, req *dto.SuggestNamesRequest) (*namingVO.NameSuggestionResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bazi, err := n.baziMapper.GetOneBaziByID(ctx, userID, req.BaziID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("起名时获取八字失败: %v", err)
+		return nil, fmt.Errorf("起名时获取八字失败: %w", err)
+	}
+
+	analysis := utils.AnalyzeWuxing(
+		[]string{bazi.YearGan, bazi.MonthGan, bazi.DayGan, bazi.HourGan},
+		[]string{bazi.YearZhi, bazi.MonthZhi, bazi.DayZhi, bazi.HourZhi},
+	)
+
+	// 每次请求读取最新配置，便于热更新禁用字与平仄规则
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("起名时加载配置失败: %v", err)
+		return nil, fmt.Errorf("起名时加载配置失败: %w", err)
+	}
+
+	candidates, err := naming.New(cfg.NamingConfig).Generate(&naming.Options{
+		Surname:      req.Surname,
+		Gender:       req.Gender,
+		Favorable:    analysis.Favorable,
+		GivenLength:  req.GivenLength,
+		Blacklist:    req.Blacklist,
+		TonePatterns: req.TonePatterns,
+		Limit:        req.Limit,
+	})
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("生成候选姓名失败: %v", err)
+		return nil, fmt.Errorf("生成候选姓名失败: %w", err)
+	}
+
+	candidateVOs := make([]*namingVO.NameCandidateResponse, 0, len(candidates))
+	for _, c := range candidates {
+		candidateVOs = append(candidateVOs, &namingVO.NameCandidateResponse{
+			FullName:  c.FullName,
+			GivenName: c.GivenName,
+			Pinyin:    c.Pinyin,
+			Tones:     c.Tones,
+			Wuxing:    c.Wuxing,
+			Strokes:   c.Strokes,
+			Meaning:   c.Meaning,
+			Wuge: &namingVO.WugeResponse{
+				Tian:     c.Wuge.Tian,
+				Ren:      c.Wuge.Ren,
+				Di:       c.Wuge.Di,
+				Wai:      c.Wuge.Wai,
+				Zong:     c.Wuge.Zong,
+				RenLuck:  c.Wuge.RenLuck,
+				DiLuck:   c.Wuge.DiLuck,
+				WaiLuck:  c.Wuge.WaiLuck,
+				ZongLuck: c.Wuge.ZongLuck,
+			},
+			Score: c.Score,
+		})
+	}
+
+	if req.Explain {
+		n.explainCandidates(ctx, candidateVOs, req.Gender, analysis.Favorable, req.ExplainTop)
+	}
+
+	return &namingVO.NameSuggestionResponse{
+		BaziID:          fmt.Sprintf("%d", bazi.ID),
+		DayMaster:       analysis.DayMaster,
+		DayMasterWuxing: analysis.DayMasterWuxing,
+		IsStrong:        analysis.IsStrong,
+		Favorable:       analysis.Favorable,
+		Missing:         analysis.Missing,
+		Candidates:      candidateVOs,
+	}, nil
+}
+
+// explainCandidates 并发调用 AI 为排名靠前的候选名生成寓意解读，单个失败不影响整体结果
+// 参数：
+//   - ctx: 上下文信息
+//   - candidates: 候选姓名
+//   - gender: 性别
+//   - favorable: 喜用五行
+//   - top: 解读数量
+func (n *NamingServiceImpl) explainCandidates(ctx *gin.Context, candidates []*namingVO.NameCandidateResponse, gender string, favorable []string, top int) {
+	if top <= 0 {
+		top = defaultExplainTop
+	}
+	if top > len(candidates) {
+		top = len(candidates)
+	}
+	if top == 0 {
+		return
+	}
+
+	aiService := internalAI.New()
+
+	var wg sync.WaitGroup
+	for _, c := range candidates[:top] {
+		wg.Add(1)
+		go func(c *namingVO.NameCandidateResponse) {
+			defer wg.Done()
+
+			rendered, err := prompt.BuildNameMeaningPrompt(c.FullName, gender, favorable)
+			if err != nil {
+				utils.BizLogger(ctx).Errorf("构建姓名寓意解读提示失败: %v", err)
+				return
+			}
+
+			result, err := aiService.GenerateText(ctx, rendered.Text)
+			if err != nil {
+				utils.BizLogger(ctx).Errorf("生成姓名寓意解读失败: %v", err)
+				return
+			}
+			c.Explanation = result.Text
+		}(c)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,23 @@
+// Package naming 提供起名相关的服务接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package naming
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/pkg/serve/controller/naming/dto"
+	namingVO "github.com/Done-0/metaphysics/pkg/vo/naming"
+)
+
+// NamingService 起名服务接口
+type NamingService interface {
+	// SuggestNames 根据八字喜用五行推荐姓名
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *namingVO.NameSuggestionResponse: 起名结果
+	//   - error: 错误信息
+	SuggestNames(ctx *gin.Context, req *dto.SuggestNamesRequest) (*namingVO.NameSuggestionResponse, error)
+}
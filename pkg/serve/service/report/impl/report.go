@@ -0,0 +1,655 @@
+// Package impl 提供流年报告相关的服务层实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/configs"
+	internalAI "github.com/Done-0/metaphysics/internal/ai"
+	"github.com/Done-0/metaphysics/internal/ai/prompt"
+	"github.com/Done-0/metaphysics/internal/ai/types"
+	"github.com/Done-0/metaphysics/internal/chart"
+	"github.com/Done-0/metaphysics/internal/fortune"
+	"github.com/Done-0/metaphysics/internal/interpret"
+	"github.com/Done-0/metaphysics/internal/model/bazi"
+	conversationModel "github.com/Done-0/metaphysics/internal/model/conversation"
+	reportModel "github.com/Done-0/metaphysics/internal/model/report"
+	"github.com/Done-0/metaphysics/internal/render"
+	internalReport "github.com/Done-0/metaphysics/internal/report"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/report/dto"
+	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
+	conversationMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/conversation"
+	reportMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/report"
+	userMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/user"
+	reportSrv "github.com/Done-0/metaphysics/pkg/serve/service/report"
+	reportVO "github.com/Done-0/metaphysics/pkg/vo/report"
+)
+
+// 批量生成常量
+const (
+	DEFAULT_CONCURRENCY = 2   // 默认 AI 调用并发数
+	SUBSCRIPTION_BATCH  = 100 // 每批读取的订阅数量
+)
+
+// 命理分析报告常量
+const (
+	ANALYSIS_TITLE         = "八字命理分析报告"              // 报告标题
+	ANALYSIS_EMAIL_SUBJECT = "【Metaphysics】八字命理分析报告" // 报告邮件主题
+	MESSAGE_ROLE_ASSISTANT = "ASSISTANT"             // AI 回复消息角色
+)
+
+// ReportServiceImpl 流年报告服务实现
+type ReportServiceImpl struct {
+	baziMapper         baziMapper.BaziMapper
+	reportMapper       reportMapper.ReportMapper
+	userMapper         userMapper.UserMapper
+	conversationMapper conversationMapper.ConversationMapper
+}
+
+// NewReportService 创建流年报告服务实例
+// 参数：
+//   - baziMapperImpl: 八字数据访问接口
+//   - reportMapperImpl: 流年报告数据访问接口
+//   - userMapperImpl: 用户数据访问接口
+//   - conversationMapperImpl: 对话数据访问接口
+//
+// 返回值：
+//   - reportSrv.ReportService: 流年报告服务接口
+func NewReportService(baziMapperImpl baziMapper.BaziMapper, reportMapperImpl reportMapper.ReportMapper, userMapperImpl userMapper.UserMapper, conversationMapperImpl conversationMapper.ConversationMapper) reportSrv.ReportService {
+	return &ReportServiceImpl{
+		baziMapper:         baziMapperImpl,
+		reportMapper:       reportMapperImpl,
+		userMapper:         userMapperImpl,
+		conversationMapper: conversationMapperImpl,
+	}
+}
+
+// Subscribe 订阅流年报告
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *reportVO.AnnualReportSubscriptionResponse: 订阅信息
+//   - error: 错误信息
+func (s *ReportServiceImpl) Subscribe(ctx *gin.Context, req *dto.SubscribeAnnualReportRequest) (*reportVO.AnnualReportSubscriptionResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.baziMapper.GetOneBaziByID(ctx, userID, req.BaziID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.reportMapper.GetSubscriptionByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		subscription = &reportModel.AnnualReportSubscription{UserID: userID}
+	}
+	subscription.BaziID = record.ID
+	subscription.EmailEnabled = req.EmailEnabled
+	subscription.Active = true
+
+	if err := s.reportMapper.SaveSubscription(ctx, subscription); err != nil {
+		utils.BizLogger(ctx).Errorf("订阅流年报告失败: %v", err)
+		return nil, fmt.Errorf("订阅流年报告失败: %w", err)
+	}
+
+	vo, err := utils.MapModelToVO(subscription, &reportVO.AnnualReportSubscriptionResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("流年报告订阅映射VO失败: %w", err)
+	}
+	return vo.(*reportVO.AnnualReportSubscriptionResponse), nil
+}
+
+// Unsubscribe 取消订阅流年报告
+// 参数：
+//   - ctx: 上下文信息
+//
+// 返回值：
+//   - error: 错误信息
+func (s *ReportServiceImpl) Unsubscribe(ctx *gin.Context) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := s.reportMapper.GetSubscriptionByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if subscription == nil || !subscription.Active {
+		return nil
+	}
+
+	subscription.Active = false
+	if err := s.reportMapper.SaveSubscription(ctx, subscription); err != nil {
+		utils.BizLogger(ctx).Errorf("取消订阅流年报告失败: %v", err)
+		return fmt.Errorf("取消订阅流年报告失败: %w", err)
+	}
+
+	return nil
+}
+
+// GetAnnualReport 获取流年报告
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *reportVO.AnnualReportResponse: 流年报告
+//   - error: 错误信息
+func (s *ReportServiceImpl) GetAnnualReport(ctx *gin.Context, req *dto.GetAnnualReportRequest) (*reportVO.AnnualReportResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	annualReport, err := s.reportMapper.GetReport(ctx, userID, req.Year, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReportToVO(annualReport)
+}
+
+// ListAnnualReports 获取流年报告版本列表
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *reportVO.AnnualReportListResponse: 报告列表
+//   - error: 错误信息
+func (s *ReportServiceImpl) ListAnnualReports(ctx *gin.Context, req *dto.ListAnnualReportsRequest) (*reportVO.AnnualReportListResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.reportMapper.ListReports(ctx, userID, req.Year)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取流年报告列表失败: %v", err)
+		return nil, fmt.Errorf("获取流年报告列表失败: %w", err)
+	}
+
+	list := make([]*reportVO.AnnualReportResponse, 0, len(reports))
+	for _, annualReport := range reports {
+		vo, err := mapReportToVO(annualReport)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("获取流年报告列表时映射单个VO失败: %v", err)
+			continue
+		}
+		list = append(list, vo)
+	}
+
+	return &reportVO.AnnualReportListResponse{List: list}, nil
+}
+
+// GenerateAnnualReport 为当前用户即时生成一个新版本的流年报告
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *reportVO.AnnualReportResponse: 流年报告
+//   - error: 错误信息
+func (s *ReportServiceImpl) GenerateAnnualReport(ctx *gin.Context, req *dto.GenerateAnnualReportRequest) (*reportVO.AnnualReportResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	baziID := req.BaziID
+	if baziID == 0 {
+		subscription, err := s.reportMapper.GetSubscriptionByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if subscription == nil {
+			return nil, fmt.Errorf("未订阅流年报告，请指定八字 ID")
+		}
+		baziID = subscription.BaziID
+	}
+
+	year := req.Year
+	if year == 0 {
+		now := time.Now().In(loadLocation(cfg.AnnualReportConfig.AnnualReportTimezone))
+		nextYear, due := internalReport.DueYear(now, cfg.AnnualReportConfig.AnnualReportLeadDays)
+		year = nextYear
+		if !due {
+			year = nextYear - 1
+		}
+	}
+
+	record, err := s.baziMapper.GetOneBaziByID(ctx, userID, baziID)
+	if err != nil {
+		return nil, err
+	}
+
+	annualReport, err := s.generate(ctx, internalAI.New(), userID, record, year)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReportToVO(annualReport)
+}
+
+// GenerateAnnualReports 为全部订阅用户批量生成流年报告，已有完成报告的用户跳过
+// 参数：
+//   - ctx: 上下文信息
+//   - year: 流年所在公历年份
+//
+// 返回值：
+//   - int: 生成成功的报告数量
+//   - error: 错误信息
+func (s *ReportServiceImpl) GenerateAnnualReports(ctx *gin.Context, year int) (int, error) {
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return 0, fmt.Errorf("获取配置失败: %w", err)
+	}
+	concurrency := cfg.AnnualReportConfig.AnnualReportConcurrency
+	if concurrency <= 0 {
+		concurrency = DEFAULT_CONCURRENCY
+	}
+	aiService := internalAI.New()
+
+	var generated int64
+	tasks := make(chan *reportModel.AnnualReportSubscription)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 每个 worker 使用独立的上下文，避免事务状态在 goroutine 间共享
+			workerCtx := utils.NewJobContext(ctx.Request.Context())
+			for subscription := range tasks {
+				if s.generateForSubscription(workerCtx, aiService, subscription, year, cfg.AnnualReportConfig.AnnualReportEmailSubject) {
+					atomic.AddInt64(&generated, 1)
+				}
+			}
+		}()
+	}
+
+	var afterID int64
+	var listErr error
+	for listErr == nil && ctx.Request.Context().Err() == nil {
+		subscriptions, err := s.reportMapper.ListActiveSubscriptions(ctx, afterID, SUBSCRIPTION_BATCH)
+		if err != nil {
+			listErr = err
+			break
+		}
+		if len(subscriptions) == 0 {
+			break
+		}
+		afterID = subscriptions[len(subscriptions)-1].ID
+
+		for _, subscription := range subscriptions {
+			done, err := s.reportMapper.HasCompletedReport(ctx, subscription.UserID, year)
+			if err != nil {
+				utils.BizLogger(ctx).Errorf("查询流年报告状态失败, 用户: %d, 错误: %v", subscription.UserID, err)
+				continue
+			}
+			if !done {
+				tasks <- subscription
+			}
+		}
+	}
+	close(tasks)
+	wg.Wait()
+
+	if listErr != nil {
+		return int(generated), listErr
+	}
+	return int(generated), ctx.Request.Context().Err()
+}
+
+// generateForSubscription 为单个订阅生成报告并通知用户，失败时仅记录日志
+// 参数：
+//   - ctx: 上下文信息
+//   - aiService: AI 服务
+//   - subscription: 订阅记录
+//   - year: 流年所在公历年份
+//   - subject: 通知邮件主题
+//
+// 返回值：
+//   - bool: 是否生成成功
+func (s *ReportServiceImpl) generateForSubscription(ctx *gin.Context, aiService types.Service, subscription *reportModel.AnnualReportSubscription, year int, subject string) bool {
+	record, err := s.baziMapper.GetOneBaziByID(ctx, subscription.UserID, subscription.BaziID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("生成流年报告时获取八字失败, 用户: %d, 错误: %v", subscription.UserID, err)
+		return false
+	}
+
+	annualReport, err := s.generate(ctx, aiService, subscription.UserID, record, year)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("生成流年报告失败, 用户: %d, 错误: %v", subscription.UserID, err)
+		return false
+	}
+
+	if subscription.EmailEnabled {
+		s.notify(ctx, subject, annualReport)
+	}
+	return true
+}
+
+// generate 计算规则要点并调用 AI 撰写报告，生成过程以新版本记录保存
+// 参数：
+//   - ctx: 上下文信息
+//   - aiService: AI 服务
+//   - userID: 用户 ID
+//   - record: 八字
+//   - year: 流年所在公历年份
+//
+// 返回值：
+//   - *reportModel.AnnualReport: 报告
+//   - error: 错误信息
+func (s *ReportServiceImpl) generate(ctx *gin.Context, aiService types.Service, userID int64, record *bazi.Bazi, year int) (*reportModel.AnnualReport, error) {
+	chart := &fortune.Chart{
+		Gans: []string{record.YearGan, record.MonthGan, record.DayGan, record.HourGan},
+		Zhis: []string{record.YearZhi, record.MonthZhi, record.DayZhi, record.HourZhi},
+	}
+	luckPillars := utils.CalculateLuckPillars(record.BirthTime, record.Calendar, record.Gender)
+	annual, err := internalReport.BuildAnnual(chart, luckPillars, year)
+	if err != nil {
+		return nil, fmt.Errorf("计算流年要点失败: %w", err)
+	}
+
+	highlights, err := json.Marshal(annual)
+	if err != nil {
+		return nil, fmt.Errorf("序列化流年要点失败: %w", err)
+	}
+
+	annualReport := &reportModel.AnnualReport{
+		UserID:     userID,
+		BaziID:     record.ID,
+		Year:       year,
+		Status:     reportModel.STATUS_PENDING,
+		YearPillar: annual.YearPillar,
+		LuckPillar: annual.LuckPillar,
+		Highlights: string(highlights),
+	}
+	if err := s.reportMapper.CreateOneReport(ctx, annualReport); err != nil {
+		return nil, err
+	}
+
+	var result *types.TextResult
+	rendered, genErr := prompt.BuildAnnualReportPrompt(
+		record.Name, record.Gender,
+		[]string{record.YearPillar, record.MonthPillar, record.DayPillar, record.HourPillar},
+		annual.LuckPillar, year, annual.YearPillar, formatHighlights(annual),
+	)
+	if genErr == nil {
+		result, genErr = aiService.GenerateText(ctx, rendered.Text)
+	}
+	if genErr != nil {
+		annualReport.Status = reportModel.STATUS_FAILED
+		annualReport.ErrorMessage = genErr.Error()
+	} else {
+		annualReport.Status = reportModel.STATUS_COMPLETED
+		annualReport.Content = result.Text
+	}
+
+	if err := s.reportMapper.UpdateOneReport(ctx, annualReport); err != nil {
+		return nil, err
+	}
+	if genErr != nil {
+		return nil, fmt.Errorf("AI 撰写流年报告失败: %w", genErr)
+	}
+
+	return annualReport, nil
+}
+
+// notify 邮件通知用户报告已生成，失败时仅记录日志
+// 参数：
+//   - ctx: 上下文信息
+//   - subject: 邮件主题
+//   - annualReport: 报告
+func (s *ReportServiceImpl) notify(ctx *gin.Context, subject string, annualReport *reportModel.AnnualReport) {
+	user, err := s.userMapper.GetOneUserByID(ctx, annualReport.UserID)
+	if err != nil || user == nil || user.Email == "" {
+		utils.BizLogger(ctx).Errorf("通知流年报告时获取用户失败, 用户: %d, 错误: %v", annualReport.UserID, err)
+		return
+	}
+
+	content := fmt.Sprintf("<p>您的 %d %s年流年报告（第 %d 版）已生成，请登录查看完整内容。</p><pre style=\"white-space: pre-wrap\">%s</pre>",
+		annualReport.Year, annualReport.YearPillar, annualReport.Version, html.EscapeString(annualReport.Content))
+	if _, err := utils.SendEmailWithSubject(subject, content, []string{user.Email}); err != nil {
+		utils.BizLogger(ctx).Errorf("发送流年报告通知失败, 用户: %d, 错误: %v", annualReport.UserID, err)
+		return
+	}
+
+	annualReport.Notified = true
+	if err := s.reportMapper.UpdateOneReport(ctx, annualReport); err != nil {
+		utils.BizLogger(ctx).Errorf("标记流年报告通知状态失败, 用户: %d, 错误: %v", annualReport.UserID, err)
+	}
+}
+
+// ExportAnalysisPDF 导出命理分析 PDF 报告
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *reportVO.AnalysisPDFResponse: PDF 报告
+//   - error: 错误信息
+func (s *ReportServiceImpl) ExportAnalysisPDF(ctx *gin.Context, req *dto.ExportAnalysisRequest) (*reportVO.AnalysisPDFResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildAnalysisPDF(ctx, userID, req)
+}
+
+// EmailAnalysisPDF 将命理分析 PDF 报告以附件形式发送到当前用户邮箱
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - error: 错误信息
+func (s *ReportServiceImpl) EmailAnalysisPDF(ctx *gin.Context, req *dto.ExportAnalysisRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userMapper.GetOneUserByID(ctx, userID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("发送分析报告时获取用户失败: %v", err)
+		return fmt.Errorf("发送分析报告时获取用户失败: %w", err)
+	}
+	if user == nil || user.Email == "" {
+		return fmt.Errorf("当前用户未绑定邮箱")
+	}
+
+	file, err := s.buildAnalysisPDF(ctx, userID, req)
+	if err != nil {
+		return err
+	}
+
+	content := fmt.Sprintf("您好，附件为您导出的%s，请查收。", ANALYSIS_TITLE)
+	attachment := &utils.EmailAttachment{FileName: file.FileName, Content: file.Content}
+	if _, err := utils.SendEmailWithAttachments(ANALYSIS_EMAIL_SUBJECT, content, []string{user.Email}, attachment); err != nil {
+		utils.BizLogger(ctx).Errorf("发送分析报告邮件失败: %v", err)
+		return fmt.Errorf("发送分析报告邮件失败: %w", err)
+	}
+	return nil
+}
+
+// buildAnalysisPDF 组合命盘、排盘要点与 AI 分析消息生成 PDF 报告
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 当前用户 ID
+//   - req: 请求参数
+//
+// 返回值：
+//   - *reportVO.AnalysisPDFResponse: PDF 报告
+//   - error: 错误信息
+func (s *ReportServiceImpl) buildAnalysisPDF(ctx *gin.Context, userID int64, req *dto.ExportAnalysisRequest) (*reportVO.AnalysisPDFResponse, error) {
+	record, err := s.baziMapper.GetOneBaziByID(ctx, userID, req.BaziID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.conversationMapper.GetMessageByID(ctx, req.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.UserID != userID {
+		return nil, fmt.Errorf("消息记录不存在")
+	}
+	if message.Role != MESSAGE_ROLE_ASSISTANT || message.Content == "" {
+		return nil, fmt.Errorf("仅支持导出 AI 分析消息")
+	}
+
+	c := s.analysisChart(ctx, record, message)
+	canvas, err := render.BuildChart(c, utils.CalculateLuckPillars(c.BirthTime, c.Calendar, c.Gender))
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("绘制命盘失败: %v", err)
+		return nil, fmt.Errorf("绘制命盘失败: %w", err)
+	}
+
+	facts, err := interpret.Analyze(c)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("计算排盘要点失败: %v", err)
+		return nil, fmt.Errorf("计算排盘要点失败: %w", err)
+	}
+
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取配置失败: %v", err)
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	// 配置的字体不可用时改用系统中文字体，并记录实际使用的字体
+	fontPath, err := internalReport.ResolvePDFFont(cfg.RenderConfig.RenderPDFFontPath)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("生成分析报告失败: %v", err)
+		return nil, err
+	}
+	if fontPath != cfg.RenderConfig.RenderPDFFontPath {
+		utils.BizLogger(ctx).Warnf("PDF 字体 %q 不可用，改用系统字体 %s", cfg.RenderConfig.RenderPDFFontPath, fontPath)
+	}
+
+	content, err := internalReport.BuildAnalysisPDF(&internalReport.AnalysisDocument{
+		Title:       ANALYSIS_TITLE,
+		Chart:       c,
+		Canvas:      canvas,
+		Annotations: interpret.Annotate(facts),
+		Sections:    internalReport.ParseSections(message.Content),
+		GeneratedAt: time.Now(),
+	}, fontPath)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("生成分析报告失败: %v", err)
+		return nil, fmt.Errorf("生成分析报告失败: %w", err)
+	}
+
+	return &reportVO.AnalysisPDFResponse{
+		FileName:    fmt.Sprintf("bazi_analysis_%d_%d.pdf", record.ID, message.ID),
+		ContentType: "application/pdf",
+		Content:     content,
+	}, nil
+}
+
+// formatHighlights 将规则分析结果格式化为提示中的要点列表
+// 参数：
+//   - annual: 流年规则分析结果
+//
+// 返回值：
+//   - []string: 要点，每项一行
+func formatHighlights(annual *internalReport.Annual) []string {
+	lines := make([]string, 0, len(annual.Months)+1)
+	yearLine := fmt.Sprintf("流年 %s：%s当值，综合%s（%d 分）", annual.YearPillar, annual.YearTenGod, annual.YearLevel, annual.YearScore)
+	for _, it := range annual.YearInteractions {
+		yearLine += "，" + it
+	}
+	lines = append(lines, yearLine)
+	for _, month := range annual.Months {
+		lines = append(lines, month.String())
+	}
+	return lines
+}
+
+// loadLocation 加载时区，失败时使用本地时区
+// 参数：
+//   - name: 时区名称
+//
+// 返回值：
+//   - *time.Location: 时区
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+// mapReportToVO 将报告模型映射为视图对象
+// 参数：
+//   - annualReport: 报告
+//
+// 返回值：
+//   - *reportVO.AnnualReportResponse: 报告响应
+//   - error: 错误信息
+func mapReportToVO(annualReport *reportModel.AnnualReport) (*reportVO.AnnualReportResponse, error) {
+	vo, err := utils.MapModelToVO(annualReport, &reportVO.AnnualReportResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("流年报告映射VO失败: %w", err)
+	}
+
+	response := vo.(*reportVO.AnnualReportResponse)
+	if annualReport.Highlights != "" {
+		var annual internalReport.Annual
+		if err := json.Unmarshal([]byte(annualReport.Highlights), &annual); err != nil {
+			return nil, fmt.Errorf("解析流年要点失败: %w", err)
+		}
+		response.Highlights = &annual
+	}
+	return response, nil
+}
+
+// analysisChart 获取分析消息对应的命盘，对话保存了该八字的命盘快照时使用快照，使报告与对话当时的命盘一致
+// 参数：
+//   - ctx: 上下文信息
+//   - record: 八字记录
+//   - message: 分析消息
+//
+// 返回值：
+//   - *chart.Chart: 八字命盘
+func (s *ReportServiceImpl) analysisChart(ctx *gin.Context, record *bazi.Bazi, message *conversationModel.Message) *chart.Chart {
+	conversation, err := s.conversationMapper.GetConversationByID(ctx, message.ConversationID)
+	if err != nil || conversation.BaziID != record.ID || conversation.BaziSnapshot == "" {
+		return chart.FromModel(record)
+	}
+
+	snapshot, err := chart.FromSnapshot(conversation.BaziSnapshot)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("解析对话命盘快照失败: %v", err)
+		return chart.FromModel(record)
+	}
+	return snapshot
+}
@@ -0,0 +1,83 @@
+// Package report 提供流年报告相关的服务接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/pkg/serve/controller/report/dto"
+	reportVO "github.com/Done-0/metaphysics/pkg/vo/report"
+)
+
+// ReportService 流年报告服务接口
+type ReportService interface {
+	// Subscribe 订阅流年报告
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *reportVO.AnnualReportSubscriptionResponse: 订阅信息
+	//   - error: 错误信息
+	Subscribe(ctx *gin.Context, req *dto.SubscribeAnnualReportRequest) (*reportVO.AnnualReportSubscriptionResponse, error)
+
+	// Unsubscribe 取消订阅流年报告
+	// 参数：
+	//   - ctx: 上下文信息
+	// 返回值：
+	//   - error: 错误信息
+	Unsubscribe(ctx *gin.Context) error
+
+	// GetAnnualReport 获取流年报告
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *reportVO.AnnualReportResponse: 流年报告
+	//   - error: 错误信息
+	GetAnnualReport(ctx *gin.Context, req *dto.GetAnnualReportRequest) (*reportVO.AnnualReportResponse, error)
+
+	// ListAnnualReports 获取流年报告版本列表
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *reportVO.AnnualReportListResponse: 报告列表
+	//   - error: 错误信息
+	ListAnnualReports(ctx *gin.Context, req *dto.ListAnnualReportsRequest) (*reportVO.AnnualReportListResponse, error)
+
+	// GenerateAnnualReport 为当前用户即时生成一个新版本的流年报告
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *reportVO.AnnualReportResponse: 流年报告
+	//   - error: 错误信息
+	GenerateAnnualReport(ctx *gin.Context, req *dto.GenerateAnnualReportRequest) (*reportVO.AnnualReportResponse, error)
+
+	// GenerateAnnualReports 为全部订阅用户批量生成流年报告，已有完成报告的用户跳过
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - year: 流年所在公历年份
+	// 返回值：
+	//   - int: 生成成功的报告数量
+	//   - error: 错误信息
+	GenerateAnnualReports(ctx *gin.Context, year int) (int, error)
+
+	// ExportAnalysisPDF 导出命理分析 PDF 报告
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *reportVO.AnalysisPDFResponse: PDF 报告
+	//   - error: 错误信息
+	ExportAnalysisPDF(ctx *gin.Context, req *dto.ExportAnalysisRequest) (*reportVO.AnalysisPDFResponse, error)
+
+	// EmailAnalysisPDF 将命理分析 PDF 报告以附件形式发送到当前用户邮箱
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	EmailAnalysisPDF(ctx *gin.Context, req *dto.ExportAnalysisRequest) error
+}
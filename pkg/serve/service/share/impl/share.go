@@ -0,0 +1,540 @@
+// Package impl 提供分享链接相关的服务层实现
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/configs"
+	"github.com/Done-0/metaphysics/internal/chart"
+	conversationModel "github.com/Done-0/metaphysics/internal/model/conversation"
+	"github.com/Done-0/metaphysics/internal/model/share"
+	"github.com/Done-0/metaphysics/internal/utils"
+	"github.com/Done-0/metaphysics/pkg/serve/controller/share/dto"
+	baziMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/bazi"
+	conversationMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/conversation"
+	shareMapper "github.com/Done-0/metaphysics/pkg/serve/mapper/share"
+	shareSrv "github.com/Done-0/metaphysics/pkg/serve/service/share"
+	shareVO "github.com/Done-0/metaphysics/pkg/vo/share"
+)
+
+// 分页与有效期常量
+const (
+	DEFAULT_PAGE_NO   = 1   // 默认页码
+	DEFAULT_PAGE_SIZE = 10  // 默认每页数量
+	MAX_PAGE_SIZE     = 100 // 最大每页数量
+
+	DEFAULT_EXPIRE_HOURS = 72  // 默认有效期（小时）
+	MAX_EXPIRE_HOURS     = 720 // 最长有效期（小时）
+
+	DEFAULT_SHARE_PATH = "/api/v1/share/view" // 未配置分享地址时使用的访问路径
+	ROLE_USER          = "USER"               // 用户消息角色
+	ROLE_ASSISTANT     = "ASSISTANT"          // 助手消息角色
+	NAME_MASK          = "*"                  // 姓名脱敏字符
+	BIRTH_MASK         = "[已隐去]"              // 出生日期与时间的替换文本
+)
+
+// COMPOUND_SURNAMES 常见复姓，用于拆分姓氏与名字
+var COMPOUND_SURNAMES = []string{
+	"欧阳", "司马", "上官", "诸葛", "东方", "皇甫", "尉迟", "公孙", "慕容", "令狐", "长孙", "宇文",
+	"司徒", "夏侯", "轩辕", "端木", "独孤", "南宫", "西门", "百里", "呼延", "申屠", "太史", "闻人",
+}
+
+// NAME_HONORIFICS 称谓后缀，单字姓氏仅在后接称谓时脱敏，避免误伤正文中的同形字
+var NAME_HONORIFICS = []string{"先生", "女士", "小姐", "老师", "同学", "总", "氏"}
+
+// birthPatterns 出生日期与时间的匹配规则，按从长到短的顺序依次替换
+var birthPatterns = []*regexp.Regexp{
+	// 数字日期，可带时间，如 1990-05-12 14:30、1990年5月12日14时30分
+	regexp.MustCompile(`\d{4}\s*[-/.年]\s*\d{1,2}\s*[-/.月]\s*\d{1,2}\s*[日号]?(?:\s*T?\s*\d{1,2}\s*(?:[:：]\s*\d{1,2}(?:\s*[:：]\s*\d{1,2})?|[点时](?:\s*\d{1,2}\s*分)?))?`),
+	// 汉字日期，如 一九九〇年五月十二日、一九九〇年腊月初八
+	regexp.MustCompile(`[〇零一二三四五六七八九]{4}年[正一二三四五六七八九十冬腊]{1,2}月(?:[初一二三四五六七八九十廿卅]{1,3}[日号]?)?`),
+	// 年月，如 1990年5月
+	regexp.MustCompile(`\d{4}\s*年\s*\d{1,2}\s*月`),
+	// 单独的时刻，如 14:30、14点30分
+	regexp.MustCompile(`\d{1,2}\s*(?:[:：]\s*\d{2}(?:\s*[:：]\s*\d{2})?|[点时]\s*\d{1,2}\s*分)`),
+}
+
+// ShareServiceImpl 分享链接服务实现
+type ShareServiceImpl struct {
+	baziMapper         baziMapper.BaziMapper
+	conversationMapper conversationMapper.ConversationMapper
+	shareMapper        shareMapper.ShareMapper
+}
+
+// NewShareService 创建分享链接服务实例
+// 参数：
+//   - baziMapperImpl: 八字数据访问接口
+//   - conversationMapperImpl: 对话数据访问接口
+//   - shareMapperImpl: 分享链接数据访问接口
+//
+// 返回值：
+//   - shareSrv.ShareService: 分享链接服务接口
+func NewShareService(baziMapperImpl baziMapper.BaziMapper, conversationMapperImpl conversationMapper.ConversationMapper, shareMapperImpl shareMapper.ShareMapper) shareSrv.ShareService {
+	return &ShareServiceImpl{
+		baziMapper:         baziMapperImpl,
+		conversationMapper: conversationMapperImpl,
+		shareMapper:        shareMapperImpl,
+	}
+}
+
+// CreateOneShare 为命盘或已完成的分析生成签名分享链接
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *shareVO.ShareLinkResponse: 分享链接
+//   - error: 错误信息
+func (s *ShareServiceImpl) CreateOneShare(ctx *gin.Context, req *dto.CreateShareRequest) (*shareVO.ShareLinkResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	var messageID int64
+	switch req.TargetType {
+	case share.TARGET_CHART:
+		if _, err := s.baziMapper.GetOneBaziByID(ctx, userID, req.TargetID); err != nil {
+			return nil, err
+		}
+	case share.TARGET_ANALYSIS:
+		message, err := s.getShareableAnalysis(ctx, userID, req.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		messageID = message.ID
+	default:
+		return nil, fmt.Errorf("不支持的分享类型: %s", req.TargetType)
+	}
+
+	defaultHours, maxHours := cfg.ShareConfig.ShareDefaultExpireHours, cfg.ShareConfig.ShareMaxExpireHours
+	if defaultHours <= 0 {
+		defaultHours = DEFAULT_EXPIRE_HOURS
+	}
+	if maxHours <= 0 {
+		maxHours = MAX_EXPIRE_HOURS
+	}
+	hours := req.ExpireHours
+	if hours <= 0 {
+		hours = defaultHours
+	}
+	if hours > maxHours {
+		return nil, fmt.Errorf("有效期不能超过 %d 小时", maxHours)
+	}
+
+	link := &share.ShareLink{
+		UserID:     userID,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		MessageID:  messageID,
+		ExpireAt:   time.Now().Add(time.Duration(hours) * time.Hour).Truncate(time.Second),
+		ShowName:   req.ShowName,
+	}
+	if err := s.shareMapper.CreateOneShare(ctx, link); err != nil {
+		utils.BizLogger(ctx).Errorf("创建分享链接失败: %v", err)
+		return nil, fmt.Errorf("创建分享链接失败: %w", err)
+	}
+
+	return mapShareToVO(link, cfg.ShareConfig.ShareBaseURL)
+}
+
+// GetShareList 获取当前用户创建的分享链接
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *shareVO.ShareListResponse: 分享链接列表
+//   - error: 错误信息
+func (s *ShareServiceImpl) GetShareList(ctx *gin.Context, req *dto.GetShareListRequest) (*shareVO.ShareListResponse, error) {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := configs.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("获取配置失败: %w", err)
+	}
+
+	pageNo, pageSize := req.PageNo, req.PageSize
+	if pageNo <= 0 {
+		pageNo = DEFAULT_PAGE_NO
+	}
+	if pageSize <= 0 {
+		pageSize = DEFAULT_PAGE_SIZE
+	}
+	if pageSize > MAX_PAGE_SIZE {
+		pageSize = MAX_PAGE_SIZE
+	}
+
+	links, total, err := s.shareMapper.GetSharesByUserID(ctx, userID, pageNo, pageSize)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取分享链接列表失败: %v", err)
+		return nil, fmt.Errorf("获取分享链接列表失败: %w", err)
+	}
+
+	list := make([]*shareVO.ShareLinkResponse, 0, len(links))
+	for _, link := range links {
+		vo, err := mapShareToVO(link, cfg.ShareConfig.ShareBaseURL)
+		if err != nil {
+			utils.BizLogger(ctx).Errorf("获取分享链接列表时映射单个VO失败: %v", err)
+			continue
+		}
+		list = append(list, vo)
+	}
+
+	return &shareVO.ShareListResponse{
+		Total:    total,
+		PageNo:   pageNo,
+		PageSize: pageSize,
+		List:     list,
+	}, nil
+}
+
+// RevokeOneShare 撤销分享链接，撤销后链接立即失效
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - error: 错误信息
+func (s *ShareServiceImpl) RevokeOneShare(ctx *gin.Context, req *dto.ShareIDRequest) error {
+	userID, err := utils.GetUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.shareMapper.RevokeOneShare(ctx, userID, req.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("撤销分享链接失败: %v", err)
+		return fmt.Errorf("撤销分享链接失败: %w", err)
+	}
+
+	return nil
+}
+
+// ViewShare 校验分享令牌并返回只读视图，无需登录
+// 参数：
+//   - ctx: 上下文信息
+//   - req: 请求参数
+//
+// 返回值：
+//   - *shareVO.SharedViewResponse: 分享内容
+//   - error: 错误信息
+func (s *ShareServiceImpl) ViewShare(ctx *gin.Context, req *dto.ViewShareRequest) (*shareVO.SharedViewResponse, error) {
+	shareID, err := utils.ParseShareToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.shareMapper.GetOneShareByID(ctx, shareID)
+	if err != nil {
+		return nil, fmt.Errorf("分享链接无效")
+	}
+	if link.Revoked {
+		return nil, fmt.Errorf("分享链接已被撤销")
+	}
+	if time.Now().After(link.ExpireAt) {
+		return nil, fmt.Errorf("分享链接已过期")
+	}
+
+	view := &shareVO.SharedViewResponse{
+		TargetType: link.TargetType,
+		ExpireAt:   link.ExpireAt,
+	}
+
+	switch link.TargetType {
+	case share.TARGET_CHART:
+		record, err := s.baziMapper.GetOneBaziByID(ctx, link.UserID, link.TargetID)
+		if err != nil {
+			return nil, fmt.Errorf("分享的命盘已不存在")
+		}
+		view.Chart = mapSharedChart(chart.FromModel(record), link.ShowName)
+	case share.TARGET_ANALYSIS:
+		if err := s.fillAnalysisView(ctx, link, view); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("不支持的分享类型: %s", link.TargetType)
+	}
+
+	if err := s.shareMapper.IncrViewCount(ctx, link.ID); err != nil {
+		utils.BizLogger(ctx).Errorf("更新分享访问次数失败: %v", err)
+	}
+
+	return view, nil
+}
+
+// getShareableAnalysis 校验对话属于当前用户且已有分析结果，返回被分享的分析消息
+// 参数：
+//   - ctx: 上下文信息
+//   - userID: 用户 ID
+//   - conversationID: 对话 ID
+//
+// 返回值：
+//   - *conversationModel.Message: 分析消息
+//   - error: 不可分享时返回错误
+func (s *ShareServiceImpl) getShareableAnalysis(ctx *gin.Context, userID, conversationID int64) (*conversationModel.Message, error) {
+	conversation, err := s.conversationMapper.GetConversationByID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if conversation.UserID != userID {
+		return nil, fmt.Errorf("无权分享该对话")
+	}
+
+	messages, err := s.conversationMapper.GetMessagesByConversationID(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if message := findAnalysisMessage(messages, 0); message != nil {
+		return message, nil
+	}
+	return nil, fmt.Errorf("分析尚未完成，暂不能分享")
+}
+
+// fillAnalysisView 填充分析分享的命盘、标题与消息，命盘优先使用对话快照，消息仅包含被分享的分析及其请求
+// 参数：
+//   - ctx: 上下文信息
+//   - link: 分享链接
+//   - view: 分享内容
+//
+// 返回值：
+//   - error: 错误信息
+func (s *ShareServiceImpl) fillAnalysisView(ctx *gin.Context, link *share.ShareLink, view *shareVO.SharedViewResponse) error {
+	conversation, err := s.conversationMapper.GetConversationByID(ctx, link.TargetID)
+	if err != nil || conversation.UserID != link.UserID {
+		return fmt.Errorf("分享的分析已不存在")
+	}
+
+	var c *chart.Chart
+	if conversation.BaziSnapshot != "" {
+		c, _ = chart.FromSnapshot(conversation.BaziSnapshot)
+	}
+	if c == nil && conversation.BaziID != 0 {
+		if record, err := s.baziMapper.GetOneBaziByID(ctx, link.UserID, conversation.BaziID); err == nil {
+			c = chart.FromModel(record)
+		}
+	}
+
+	name := ""
+	if c != nil {
+		name = c.Name
+		view.Chart = mapSharedChart(c, link.ShowName)
+	}
+	redact := func(text string) string {
+		if link.ShowName {
+			return text
+		}
+		return redactText(text, name)
+	}
+
+	messages, err := s.conversationMapper.GetMessagesByConversationID(ctx, conversation.ID)
+	if err != nil {
+		utils.BizLogger(ctx).Errorf("获取分享的分析消息失败: %v", err)
+		return fmt.Errorf("获取分享的分析消息失败: %w", err)
+	}
+
+	// 早期创建的链接未记录消息 ID，按首条分析处理，分享之后的追问始终不展示
+	analysis := findAnalysisMessage(messages, link.MessageID)
+	if analysis == nil {
+		return fmt.Errorf("分享的分析已不存在")
+	}
+
+	view.Title = redact(conversation.Title)
+	view.Messages = make([]*shareVO.SharedMessageResponse, 0, 2)
+	for _, message := range messages {
+		if message.ID != analysis.ID && (message.Role != ROLE_USER || message.RequestID != analysis.RequestID) {
+			continue
+		}
+		view.Messages = append(view.Messages, &shareVO.SharedMessageResponse{
+			Role:      message.Role,
+			Content:   redact(message.Content),
+			GmtCreate: message.GmtCreate,
+		})
+	}
+
+	return nil
+}
+
+// findAnalysisMessage 查找被分享的分析消息
+// 参数：
+//   - messages: 对话消息，按创建时间升序
+//   - messageID: 分析消息 ID，为 0 时取首条已完成的助手消息
+//
+// 返回值：
+//   - *conversationModel.Message: 分析消息，不存在时为 nil
+func findAnalysisMessage(messages []*conversationModel.Message, messageID int64) *conversationModel.Message {
+	for _, message := range messages {
+		if message.Role != ROLE_ASSISTANT || message.Content == "" {
+			continue
+		}
+		if messageID == 0 || message.ID == messageID {
+			return message
+		}
+	}
+	return nil
+}
+
+// mapShareToVO 将分享链接模型映射为视图对象，令牌由记录 ID 与过期时间重新签发
+// 参数：
+//   - link: 分享链接
+//   - baseURL: 分享地址
+//
+// 返回值：
+//   - *shareVO.ShareLinkResponse: 分享链接视图对象
+//   - error: 错误信息
+func mapShareToVO(link *share.ShareLink, baseURL string) (*shareVO.ShareLinkResponse, error) {
+	token, err := utils.GenerateShareToken(link.ID, link.ExpireAt)
+	if err != nil {
+		return nil, fmt.Errorf("生成分享令牌失败: %w", err)
+	}
+
+	vo, err := utils.MapModelToVO(link, &shareVO.ShareLinkResponse{})
+	if err != nil {
+		return nil, fmt.Errorf("分享链接映射 VO 失败: %w", err)
+	}
+
+	result := vo.(*shareVO.ShareLinkResponse)
+	result.Token = token
+	result.URL = shareURL(baseURL, token)
+	result.Expired = time.Now().After(link.ExpireAt)
+	return result, nil
+}
+
+// shareURL 拼接分享链接
+// 参数：
+//   - baseURL: 分享地址，为空时使用默认访问路径
+//   - token: 分享令牌
+//
+// 返回值：
+//   - string: 分享链接
+func shareURL(baseURL, token string) string {
+	if baseURL == "" {
+		baseURL = DEFAULT_SHARE_PATH
+	}
+	separator := "?"
+	if strings.Contains(baseURL, "?") {
+		separator = "&"
+	}
+	return baseURL + separator + "token=" + url.QueryEscape(token)
+}
+
+// mapSharedChart 将命盘映射为分享视图，未开启展示姓名时隐去姓名，并将出生时间粗化为日期与时辰
+// 参数：
+//   - c: 八字命盘
+//   - showName: 是否展示姓名
+//
+// 返回值：
+//   - *shareVO.SharedChartResponse: 分享的命盘
+func mapSharedChart(c *chart.Chart, showName bool) *shareVO.SharedChartResponse {
+	resp := &shareVO.SharedChartResponse{
+		Name:             c.Name,
+		Gender:           c.Gender,
+		BirthTime:        c.BirthTime,
+		BirthTimeUnknown: c.BirthTime.IsZero(),
+		Calendar:         c.Calendar,
+		Pillars:          c.PillarStrings(),
+		DayMaster:        c.DayMaster().String(),
+	}
+	if !resp.BirthTimeUnknown && !c.Hour.IsZero() {
+		resp.BirthPeriod = c.Hour.Branch.String() + "时"
+	}
+	if showName {
+		return resp
+	}
+
+	// 精确出生时间足以识别命主，隐去姓名时仅保留日期，时辰已由时柱体现
+	resp.Name = maskName(c.Name)
+	if !resp.BirthTimeUnknown {
+		y, m, d := c.BirthTime.Date()
+		resp.BirthTime = time.Date(y, m, d, 0, 0, 0, 0, c.BirthTime.Location())
+		resp.BirthTimeCoarsened = true
+	}
+	return resp
+}
+
+// maskName 姓名脱敏，所有字符均替换为脱敏字符
+// 参数：
+//   - name: 姓名
+//
+// 返回值：
+//   - string: 脱敏后的姓名
+func maskName(name string) string {
+	return strings.Repeat(NAME_MASK, utf8.RuneCountInString(strings.TrimSpace(name)))
+}
+
+// redactText 隐去文本中的姓名及其姓氏、名字部分，并移除出生日期与时间
+// 参数：
+//   - text: 原文本
+//   - name: 命主姓名，可为空
+//
+// 返回值：
+//   - string: 脱敏后的文本
+func redactText(text, name string) string {
+	if replacer := nameReplacer(name); replacer != nil {
+		text = replacer.Replace(text)
+	}
+	for _, pattern := range birthPatterns {
+		text = pattern.ReplaceAllString(text, BIRTH_MASK)
+	}
+	return text
+}
+
+// nameReplacer 构建姓名脱敏替换器，依次匹配全名、名字与姓氏，单字姓氏仅在后接称谓时替换
+// 参数：
+//   - name: 姓名
+//
+// 返回值：
+//   - *strings.Replacer: 替换器，姓名为空时为 nil
+func nameReplacer(name string) *strings.Replacer {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	// 替换器按参数顺序匹配，较长的部分在前，保证全名先于其组成部分替换
+	pairs := []string{name, maskName(name)}
+	if fields := strings.Fields(name); len(fields) > 1 {
+		// 外文姓名按空格拆分，单个字母的缩写不单独脱敏
+		for _, field := range fields {
+			if utf8.RuneCountInString(field) > 1 {
+				pairs = append(pairs, field, maskName(field))
+			}
+		}
+		return strings.NewReplacer(pairs...)
+	}
+
+	surname := string([]rune(name)[:1])
+	for _, compound := range COMPOUND_SURNAMES {
+		if strings.HasPrefix(name, compound) && name != compound {
+			surname = compound
+			break
+		}
+	}
+	// 单字的名字过于常见，仅随全名一并脱敏
+	if given := strings.TrimPrefix(name, surname); utf8.RuneCountInString(given) > 1 {
+		pairs = append(pairs, given, maskName(given))
+	}
+	for _, honorific := range NAME_HONORIFICS {
+		pairs = append(pairs, surname+honorific, maskName(surname)+honorific)
+	}
+	if utf8.RuneCountInString(surname) > 1 {
+		pairs = append(pairs, surname, maskName(surname))
+	}
+	return strings.NewReplacer(pairs...)
+}
@@ -0,0 +1,50 @@
+// Package impl 分享链接服务测试
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package impl
+
+import (
+	"testing"
+
+	conversationModel "github.com/Done-0/metaphysics/internal/model/conversation"
+)
+
+func TestRedactText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"王小明", "王小明的日主为甲木，小明今年宜守成，王先生需注意流年", "***的日主为甲木，**今年宜守成，*先生需注意流年"},
+		{"王小明", "帝王之相，王字单独出现不脱敏", "帝王之相，王字单独出现不脱敏"},
+		{"欧阳娜", "欧阳娜出生于1990年5月12日14时30分，欧阳女士", "***出生于[已隐去]，**女士"},
+		{"欧阳娜", "欧阳家族", "**家族"},
+		{"John Smith", "Mr. Smith, born 1990-05-12 14:30", "Mr. *****, born [已隐去]"},
+		{"", "生于一九九〇年五月十二日 8:05", "生于[已隐去] [已隐去]"},
+		{"", "1990年5月出生，有3点需要注意", "[已隐去]出生，有3点需要注意"},
+	}
+	for _, tt := range tests {
+		if got := redactText(tt.text, tt.name); got != tt.want {
+			t.Errorf("redactText(%q, %q) = %q, 期望 %q", tt.text, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindAnalysisMessage(t *testing.T) {
+	messages := []*conversationModel.Message{
+		{ID: 1, Role: ROLE_USER, Content: "分析八字", RequestID: 1},
+		{ID: 2, Role: ROLE_ASSISTANT, Content: "分析结果", RequestID: 1},
+		{ID: 3, Role: ROLE_USER, Content: "追问", RequestID: 2},
+		{ID: 4, Role: ROLE_ASSISTANT, Content: "追问回复", RequestID: 2},
+	}
+
+	if got := findAnalysisMessage(messages, 0); got == nil || got.ID != 2 {
+		t.Errorf("未指定消息 ID 时应返回首条分析, got %+v", got)
+	}
+	if got := findAnalysisMessage(messages, 4); got == nil || got.ID != 4 {
+		t.Errorf("应返回指定的分析消息, got %+v", got)
+	}
+	if got := findAnalysisMessage(messages, 3); got != nil {
+		t.Errorf("用户消息不是分析消息, got %+v", got)
+	}
+}
@@ -0,0 +1,49 @@
+// Package share 提供分享链接相关的服务接口
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package share
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Done-0/metaphysics/pkg/serve/controller/share/dto"
+	shareVO "github.com/Done-0/metaphysics/pkg/vo/share"
+)
+
+// ShareService 分享链接服务接口
+type ShareService interface {
+	// CreateOneShare 为命盘或已完成的分析生成签名分享链接
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *shareVO.ShareLinkResponse: 分享链接
+	//   - error: 错误信息
+	CreateOneShare(ctx *gin.Context, req *dto.CreateShareRequest) (*shareVO.ShareLinkResponse, error)
+
+	// GetShareList 获取当前用户创建的分享链接
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *shareVO.ShareListResponse: 分享链接列表
+	//   - error: 错误信息
+	GetShareList(ctx *gin.Context, req *dto.GetShareListRequest) (*shareVO.ShareListResponse, error)
+
+	// RevokeOneShare 撤销分享链接，撤销后链接立即失效
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - error: 错误信息
+	RevokeOneShare(ctx *gin.Context, req *dto.ShareIDRequest) error
+
+	// ViewShare 校验分享令牌并返回只读视图，无需登录
+	// 参数：
+	//   - ctx: 上下文信息
+	//   - req: 请求参数
+	// 返回值：
+	//   - *shareVO.SharedViewResponse: 分享内容
+	//   - error: 错误信息
+	ViewShare(ctx *gin.Context, req *dto.ViewShareRequest) (*shareVO.SharedViewResponse, error)
+}
@@ -3,11 +3,19 @@
 // 创建时间：2023-10-18
 package bazi
 
+import "time"
+
 // BaziResponse 八字分析响应
 // @Description 八字分析响应
+// @Property    ID        string true "八字 ID"
 // @Property    Name      string true "姓名"
 // @Property    Gender    string true "性别"
 // @Property    Calendar  string true "日历类型 (lunar/solar)"
+// @Property    Relation  string true "与用户的关系 (self/spouse/child/client/other)"
+// @Property    Label     string false "档案标签"
+// @Property    Notes     string false "备注"
+// @Property    Tags      []string false "标签"
+// @Property    BirthTime string true "出生时间"
 // @Property    YearPillar  string true "年柱（干支）"
 // @Property    MonthPillar string true "月柱（干支）"
 // @Property    DayPillar   string true "日柱（干支）"
@@ -22,10 +30,18 @@ package bazi
 // @Property    HourZhi     string true "时支"
 type BaziResponse struct {
 	// 基本信息
-	RequestID string `json:"request_id"` // 请求 ID
-	Name      string `json:"name"`       // 姓名
-	Gender    string `json:"gender"`     // 性别
-	Calendar  string `json:"calendar"`   // 日历类型 (lunar/solar)
+	RequestID        string    `json:"request_id"`         // 请求 ID
+	ID               string    `json:"id"`                 // 八字 ID
+	Name             string    `json:"name"`               // 姓名
+	Gender           string    `json:"gender"`             // 性别
+	BirthTime        time.Time `json:"birth_time"`         // 出生时间
+	BirthTimeUnknown bool      `json:"birth_time_unknown"` // 出生时间是否未知
+	Calendar         string    `json:"calendar"`           // 日历类型 (lunar/solar)
+	Relation         string    `json:"relation"`           // 与用户的关系 (self/spouse/child/client/other)
+	Label            string    `json:"label"`              // 档案标签
+	Notes            string    `json:"notes"`              // 备注
+	Tags             []string  `json:"tags"`               // 标签
+	GmtModified      string    `json:"gmt_modified"`       // 最后修改时间
 
 	// 四柱干支
 	YearPillar  string `json:"year_pillar"`  // 年柱（干支）
@@ -52,9 +68,87 @@ type BaziResponse struct {
 // @Property pageNo    int   true "当前页"
 // @Property pageSize  int   true "当前分页记录数"
 // @Property list      []BaziResponse true "分页内容"
+// @Property nextCursor string false "下一页游标，仅游标分页返回"
+// @Property hasMore   bool  false "是否还有下一页，仅游标分页返回"
 type BaziListResponse struct {
-	Total    int64           `json:"total"`    // 总条数
-	PageNo   int             `json:"pageNo"`   // 当前页
-	PageSize int             `json:"pageSize"` // 当前分页记录数
-	List     []*BaziResponse `json:"list"`     // 分页内容
+	Total      int64           `json:"total"`                // 总条数，游标分页时不统计
+	PageNo     int             `json:"pageNo"`               // 当前页
+	PageSize   int             `json:"pageSize"`             // 当前分页记录数
+	List       []*BaziResponse `json:"list"`                 // 分页内容
+	NextCursor string          `json:"nextCursor,omitempty"` // 下一页游标
+	HasMore    bool            `json:"hasMore"`              // 是否还有下一页
+}
+
+// BaziImageResponse 命盘图片
+type BaziImageResponse struct {
+	FileName    string // 文件名
+	ContentType string // 内容类型
+	Content     []byte // 图片内容
+}
+
+// BaziImportRowError 批量导入的单行错误
+// @Description 批量导入的单行错误
+// @Property row int true "表格行号，表头为第 1 行"
+// @Property message string true "错误信息"
+type BaziImportRowError struct {
+	Row     int    `json:"row"`     // 表格行号
+	Message string `json:"message"` // 错误信息
+}
+
+// BaziImportResponse 批量导入受理响应，校验失败的行立即返回，其余行在后台排盘入库
+// @Description 批量导入受理响应
+// @Property job_id string true "导入任务 ID，用于轮询进度"
+// @Property status string true "任务状态 (running/completed/failed)"
+// @Property total int true "数据行数"
+// @Property accepted int true "通过校验、进入排盘的行数"
+// @Property rejected int true "未通过校验的行数"
+// @Property errors []BaziImportRowError true "未通过校验的行"
+type BaziImportResponse struct {
+	JobID    string                `json:"job_id"`   // 导入任务 ID
+	Status   string                `json:"status"`   // 任务状态
+	Total    int                   `json:"total"`    // 数据行数
+	Accepted int                   `json:"accepted"` // 通过校验的行数
+	Rejected int                   `json:"rejected"` // 未通过校验的行数
+	Errors   []*BaziImportRowError `json:"errors"`   // 未通过校验的行
+}
+
+// BaziTextImportItem 粘贴导入的单份命盘
+// @Description 粘贴导入的单份命盘
+// @Property bazi BaziResponse true "保存的八字档案"
+// @Property warnings []string true "识别提示，如出生时间未知、四柱与出生时间不一致"
+type BaziTextImportItem struct {
+	Bazi     *BaziResponse `json:"bazi"`     // 保存的八字档案
+	Warnings []string      `json:"warnings"` // 识别提示
+}
+
+// BaziTextImportResponse 粘贴导入命盘响应
+// @Description 粘贴导入命盘响应
+// @Property items []BaziTextImportItem true "导入的命盘"
+type BaziTextImportResponse struct {
+	Items []*BaziTextImportItem `json:"items"` // 导入的命盘
+}
+
+// BaziImportJobResponse 批量导入任务进度响应
+// @Description 批量导入任务进度响应
+// @Property job_id string true "导入任务 ID"
+// @Property file_name string true "导入文件名"
+// @Property status string true "任务状态 (running/completed/failed)"
+// @Property total int true "数据行数"
+// @Property processed int true "已处理行数"
+// @Property succeeded int true "成功导入行数"
+// @Property failed int true "失败行数"
+// @Property errors []BaziImportRowError true "全部失败行，含校验与排盘错误"
+// @Property message string false "任务失败原因"
+type BaziImportJobResponse struct {
+	JobID       string                `json:"job_id"`       // 导入任务 ID
+	FileName    string                `json:"file_name"`    // 导入文件名
+	Status      string                `json:"status"`       // 任务状态
+	Total       int                   `json:"total"`        // 数据行数
+	Processed   int                   `json:"processed"`    // 已处理行数
+	Succeeded   int                   `json:"succeeded"`    // 成功导入行数
+	Failed      int                   `json:"failed"`       // 失败行数
+	Errors      []*BaziImportRowError `json:"errors"`       // 失败行
+	Message     string                `json:"message"`      // 任务失败原因
+	GmtCreate   string                `json:"gmt_create"`   // 创建时间
+	GmtModified string                `json:"gmt_modified"` // 最后更新时间
 }
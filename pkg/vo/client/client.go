@@ -0,0 +1,109 @@
+// Package client 提供客户档案相关的视图对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package client
+
+import (
+	"time"
+)
+
+// ClientCaseResponse 客户案例响应
+// @Description 客户案例响应
+// @Property ID string true "客户案例 ID"
+// @Property BaziID string true "八字 ID"
+// @Property Name string true "客户姓名"
+// @Property Phone string false "电话"
+// @Property Email string false "邮箱"
+// @Property WeChat string false "微信"
+// @Property Tags []string false "标签"
+// @Property Status string true "状态 (active/archived)"
+type ClientCaseResponse struct {
+	ID          string   `json:"id"`           // 客户案例 ID
+	BaziID      string   `json:"bazi_id"`      // 八字 ID
+	Name        string   `json:"name"`         // 客户姓名
+	Phone       string   `json:"phone"`        // 电话
+	Email       string   `json:"email"`        // 邮箱
+	WeChat      string   `json:"wechat"`       // 微信
+	Tags        []string `json:"tags"`         // 标签
+	Status      string   `json:"status"`       // 状态
+	GmtCreate   string   `json:"gmt_create"`   // 创建时间
+	GmtModified string   `json:"gmt_modified"` // 最后修改时间
+}
+
+// ClientCaseListResponse 客户案例列表响应
+// @Description 客户案例列表响应
+// @Property total     int64 true "总条数"
+// @Property pageNo    int   true "当前页"
+// @Property pageSize  int   true "当前分页记录数"
+// @Property list      []ClientCaseResponse true "分页内容"
+type ClientCaseListResponse struct {
+	Total    int64                 `json:"total"`    // 总条数
+	PageNo   int                   `json:"pageNo"`   // 当前页
+	PageSize int                   `json:"pageSize"` // 当前分页记录数
+	List     []*ClientCaseResponse `json:"list"`     // 分页内容
+}
+
+// ClientNoteResponse 客户备注响应
+// @Description 客户备注响应
+// @Property ID string true "备注 ID"
+// @Property CaseID string true "客户案例 ID"
+// @Property Content string true "备注内容"
+// @Property GmtCreate string true "创建时间"
+type ClientNoteResponse struct {
+	ID        string `json:"id"`         // 备注 ID
+	CaseID    string `json:"case_id"`    // 客户案例 ID
+	Content   string `json:"content"`    // 备注内容
+	GmtCreate string `json:"gmt_create"` // 创建时间
+}
+
+// ClientSessionResponse 咨询记录响应
+// @Description 咨询记录响应
+// @Property ID string true "咨询记录 ID"
+// @Property CaseID string true "客户案例 ID"
+// @Property ConversationID string false "关联的对话 ID"
+// @Property SessionTime string true "咨询时间"
+// @Property Topic string false "咨询主题"
+// @Property Summary string false "咨询纪要"
+type ClientSessionResponse struct {
+	ID             string    `json:"id"`              // 咨询记录 ID
+	CaseID         string    `json:"case_id"`         // 客户案例 ID
+	ConversationID string    `json:"conversation_id"` // 关联的对话 ID
+	SessionTime    time.Time `json:"session_time"`    // 咨询时间
+	Topic          string    `json:"topic"`           // 咨询主题
+	Summary        string    `json:"summary"`         // 咨询纪要
+	GmtCreate      string    `json:"gmt_create"`      // 创建时间
+}
+
+// ClientReminderResponse 跟进提醒响应
+// @Description 跟进提醒响应
+// @Property ID string true "提醒 ID"
+// @Property CaseID string true "客户案例 ID"
+// @Property CaseName string false "客户姓名"
+// @Property RemindAt string true "提醒时间"
+// @Property Content string true "提醒内容"
+// @Property Done bool true "是否已完成"
+// @Property Overdue bool true "是否已逾期"
+type ClientReminderResponse struct {
+	ID       string    `json:"id"`        // 提醒 ID
+	CaseID   string    `json:"case_id"`   // 客户案例 ID
+	CaseName string    `json:"case_name"` // 客户姓名
+	RemindAt time.Time `json:"remind_at"` // 提醒时间
+	Content  string    `json:"content"`   // 提醒内容
+	Done     bool      `json:"done"`      // 是否已完成
+	Overdue  bool      `json:"overdue"`   // 是否已逾期
+}
+
+// ClientCaseDetailResponse 客户案例详情响应
+// @Description 客户案例详情响应，包含八字概要、备注、咨询记录与跟进提醒
+// @Property Case ClientCaseResponse true "客户案例"
+// @Property Pillars []string true "四柱干支"
+// @Property Notes []ClientNoteResponse true "备注，按创建时间倒序"
+// @Property Sessions []ClientSessionResponse true "咨询记录，按咨询时间倒序"
+// @Property Reminders []ClientReminderResponse true "跟进提醒，按提醒时间升序"
+type ClientCaseDetailResponse struct {
+	Case      *ClientCaseResponse       `json:"case"`      // 客户案例
+	Pillars   []string                  `json:"pillars"`   // 四柱干支
+	Notes     []*ClientNoteResponse     `json:"notes"`     // 备注
+	Sessions  []*ClientSessionResponse  `json:"sessions"`  // 咨询记录
+	Reminders []*ClientReminderResponse `json:"reminders"` // 跟进提醒
+}
@@ -3,6 +3,10 @@
 // 创建时间：2025-07-03
 package conversation
 
+import (
+	"time"
+)
+
 // EventType 事件类型
 type EventType string
 
@@ -68,10 +72,35 @@ type CloseEventData struct {
 // StreamChunk 流式响应数据块
 // @Description 流式响应数据块
 // @Property Content string true "内容"
+// @Property Reasoning string false "推理模型的思考过程"
 // @Property Done bool true "是否完成"
+// @Property Usage TokenUsage false "Token 用量，仅在结束块中返回"
+// @Property AccumulatedTokenUsage int false "对话累计 Token 用量，仅在结束块中返回"
+// @Property Provider string false "实际响应的 AI 服务提供商"
+// @Property Fallback bool false "是否由兜底 Provider（规则解读）响应"
+// @Property PromptVersion string false "提示模板版本，仅在结束块中返回"
 type StreamChunk struct {
-	Content string `json:"content"` // 内容
-	Done    bool   `json:"done"`    // 是否完成
+	Content               string      `json:"content"`                           // 内容
+	Reasoning             string      `json:"reasoning,omitempty"`               // 推理模型的思考过程，与回答内容分开推送
+	Done                  bool        `json:"done"`                              // 是否完成
+	Usage                 *TokenUsage `json:"usage,omitempty"`                   // Token 用量，仅在结束块中返回
+	AccumulatedTokenUsage int         `json:"accumulated_token_usage,omitempty"` // 对话累计 Token 用量，含已保存的全部消息，仅在结束块中返回
+	Provider              string      `json:"provider,omitempty"`                // 实际响应的 AI 服务提供商
+	Fallback              bool        `json:"fallback,omitempty"`                // 是否由兜底 Provider（规则解读）响应
+	PromptVersion         string      `json:"prompt_version,omitempty"`          // 提示模板版本，格式为 {名称}@{版本}，仅在结束块中返回
+}
+
+// TokenUsage Token 用量
+// @Description Token 用量
+// @Property PromptTokens int true "提示 Token 数"
+// @Property CompletionTokens int true "生成 Token 数，含思考过程"
+// @Property ReasoningTokens int false "思考过程 Token 数"
+// @Property TotalTokens int true "总 Token 数"
+type TokenUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`              // 提示 Token 数
+	CompletionTokens int `json:"completion_tokens"`          // 生成 Token 数，含思考过程
+	ReasoningTokens  int `json:"reasoning_tokens,omitempty"` // 思考过程 Token 数
+	TotalTokens      int `json:"total_tokens"`               // 总 Token 数
 }
 
 // StreamData 流式数据
@@ -98,7 +127,8 @@ type BatchValue struct {
 // @Description 响应详情
 // @Property MessageID int true "消息ID"
 // @Property ParentID int true "父消息ID"
-// @Property Model string true "模型"
+// @Property Model string true "模型，完成时为实际响应的 AI 服务提供商"
+// @Property Fallback bool true "是否由兜底 Provider 响应"
 // @Property Role string true "角色"
 // @Property Content string true "内容"
 // @Property ThinkingEnabled bool true "是否启用思考"
@@ -117,7 +147,8 @@ type BatchValue struct {
 type ResponseDetail struct {
 	MessageID             int           `json:"message_id"`              // 消息ID
 	ParentID              int           `json:"parent_id"`               // 父消息ID
-	Model                 string        `json:"model"`                   // 模型
+	Model                 string        `json:"model"`                   // 模型，完成时为实际响应的 AI 服务提供商
+	Fallback              bool          `json:"fallback"`                // 是否由兜底 Provider 响应
 	Role                  string        `json:"role"`                    // 角色
 	Content               string        `json:"content"`                 // 内容
 	ThinkingEnabled       bool          `json:"thinking_enabled"`        // 是否启用思考
@@ -153,16 +184,41 @@ type ResponseValue struct {
 // @Property DayPillar string true "日柱"
 // @Property HourPillar string true "时柱"
 // @Property Analysis string true "分析结果"
+// @Property Reasoning string false "推理模型的思考过程"
+// @Property Usage TokenUsage false "Token 用量"
+// @Property Provider string false "实际响应的 AI 服务提供商"
+// @Property Fallback bool false "是否由兜底 Provider（规则解读）响应"
+// @Property PromptVersion string false "提示模板版本"
+// @Property MessageID int64 false "AI 回复消息ID，结构化输出时返回"
+// @Property Sections []AnalysisSection false "分析章节，结构化输出时返回"
 type BaziAnalysisResponse struct {
-	RequestID   string `json:"request_id"`   // 请求ID
-	UserID      int64  `json:"user_id"`      // 用户ID
-	Name        string `json:"name"`         // 姓名
-	Gender      string `json:"gender"`       // 性别
-	YearPillar  string `json:"year_pillar"`  // 年柱
-	MonthPillar string `json:"month_pillar"` // 月柱
-	DayPillar   string `json:"day_pillar"`   // 日柱
-	HourPillar  string `json:"hour_pillar"`  // 时柱
-	Analysis    string `json:"analysis"`     // 分析结果
+	RequestID     string             `json:"request_id"`               // 请求ID
+	UserID        int64              `json:"user_id"`                  // 用户ID
+	Name          string             `json:"name"`                     // 姓名
+	Gender        string             `json:"gender"`                   // 性别
+	YearPillar    string             `json:"year_pillar"`              // 年柱
+	MonthPillar   string             `json:"month_pillar"`             // 月柱
+	DayPillar     string             `json:"day_pillar"`               // 日柱
+	HourPillar    string             `json:"hour_pillar"`              // 时柱
+	Analysis      string             `json:"analysis"`                 // 分析结果
+	Reasoning     string             `json:"reasoning,omitempty"`      // 推理模型的思考过程
+	Usage         *TokenUsage        `json:"usage,omitempty"`          // Token 用量
+	Provider      string             `json:"provider,omitempty"`       // 实际响应的 AI 服务提供商
+	Fallback      bool               `json:"fallback,omitempty"`       // 是否由兜底 Provider（规则解读）响应
+	PromptVersion string             `json:"prompt_version,omitempty"` // 提示模板版本，格式为 {名称}@{版本}
+	MessageID     int64              `json:"message_id,omitempty"`     // AI 回复消息ID，结构化输出时返回
+	Sections      []*AnalysisSection `json:"sections,omitempty"`       // 分析章节，结构化输出时返回，按章节顺序排列
+}
+
+// AnalysisSection 分析章节
+// @Description 分析章节
+// @Property Key string true "章节键"
+// @Property Title string true "章节标题"
+// @Property Content string true "章节内容"
+type AnalysisSection struct {
+	Key     string `json:"key"`     // 章节键 (personality/family/marriage/health/career/wealth/wuxing/luck/forecast)
+	Title   string `json:"title"`   // 章节标题
+	Content string `json:"content"` // 章节内容（Markdown）
 }
 
 // ConversationResponse 对话响应
@@ -172,10 +228,50 @@ type BaziAnalysisResponse struct {
 // @Property UserMessage string true "用户消息"
 // @Property AIResponse string true "AI响应"
 // @Property ConversationID string true "对话ID"
+// @Property Provider string false "实际响应的 AI 服务提供商"
+// @Property Fallback bool false "是否由兜底 Provider 响应"
 type ConversationResponse struct {
-	RequestID      string `json:"request_id"`      // 请求ID
-	UserID         int64  `json:"user_id"`         // 用户ID
-	UserMessage    string `json:"user_message"`    // 用户消息
-	AIResponse     string `json:"ai_response"`     // AI响应
-	ConversationID string `json:"conversation_id"` // 对话ID
+	RequestID      string `json:"request_id"`         // 请求ID
+	UserID         int64  `json:"user_id"`            // 用户ID
+	UserMessage    string `json:"user_message"`       // 用户消息
+	AIResponse     string `json:"ai_response"`        // AI响应
+	ConversationID string `json:"conversation_id"`    // 对话ID
+	Provider       string `json:"provider,omitempty"` // 实际响应的 AI 服务提供商
+	Fallback       bool   `json:"fallback,omitempty"` // 是否由兜底 Provider 响应
+}
+
+// AIHealthResponse AI 服务健康状态响应
+// @Description AI 服务健康状态响应
+// @Property Providers []ProviderHealth true "各 Provider 的健康状态，按优先级排列"
+type AIHealthResponse struct {
+	Providers []*ProviderHealth `json:"providers"` // 各 Provider 的健康状态，按优先级排列
+}
+
+// ProviderHealth Provider 健康状态
+// @Description Provider 健康状态
+// @Property Provider string true "Provider 名称"
+// @Property Weight int true "路由权重"
+// @Property Fallback bool true "是否为兜底 Provider"
+// @Property State string true "熔断器状态 (closed/open/half_open)"
+// @Property ConsecutiveFailures int true "连续失败次数"
+// @Property Successes int64 true "累计成功次数"
+// @Property Failures int64 true "累计失败次数"
+// @Property LastError string false "最近一次错误"
+// @Property LastLatencyMs int64 true "最近一次成功调用耗时（毫秒）"
+// @Property LastSuccessAt string false "最近一次成功时间"
+// @Property LastFailureAt string false "最近一次失败时间"
+// @Property OpenedAt string false "最近一次熔断时间"
+type ProviderHealth struct {
+	Provider            string    `json:"provider"`             // Provider 名称
+	Weight              int       `json:"weight"`               // 路由权重
+	Fallback            bool      `json:"fallback"`             // 是否为兜底 Provider
+	State               string    `json:"state"`                // 熔断器状态 (closed/open/half_open)
+	ConsecutiveFailures int       `json:"consecutive_failures"` // 连续失败次数
+	Successes           int64     `json:"successes"`            // 累计成功次数
+	Failures            int64     `json:"failures"`             // 累计失败次数
+	LastError           string    `json:"last_error"`           // 最近一次错误
+	LastLatencyMs       int64     `json:"last_latency_ms"`      // 最近一次成功调用耗时（毫秒）
+	LastSuccessAt       time.Time `json:"last_success_at"`      // 最近一次成功时间
+	LastFailureAt       time.Time `json:"last_failure_at"`      // 最近一次失败时间
+	OpenedAt            time.Time `json:"opened_at"`            // 最近一次熔断时间
 }
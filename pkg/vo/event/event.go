@@ -0,0 +1,79 @@
+// Package event 提供人生事件相关的视图对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package event
+
+import (
+	"time"
+)
+
+// EventResponse 人生事件响应
+// @Description 人生事件响应
+// @Property ID string true "事件 ID"
+// @Property BaziID string true "八字 ID"
+// @Property EventType string true "事件类型"
+// @Property EventDate string true "事件日期"
+// @Property Title string false "标题"
+// @Property Description string false "描述"
+type EventResponse struct {
+	ID          string    `json:"id"`          // 事件 ID
+	BaziID      string    `json:"bazi_id"`     // 八字 ID
+	EventType   string    `json:"event_type"`  // 事件类型
+	EventDate   time.Time `json:"event_date"`  // 事件日期
+	Title       string    `json:"title"`       // 标题
+	Description string    `json:"description"` // 描述
+	GmtCreate   string    `json:"gmt_create"`  // 创建时间
+}
+
+// EventListResponse 人生事件列表响应
+// @Description 人生事件列表响应
+// @Property Total int true "总条数"
+// @Property List []EventResponse true "事件列表"
+type EventListResponse struct {
+	Total int              `json:"total"` // 总条数
+	List  []*EventResponse `json:"list"`  // 事件列表
+}
+
+// EvidenceResponse 时辰校正引动证据
+// @Description 时辰校正引动证据
+type EvidenceResponse struct {
+	EventType string  `json:"event_type"` // 事件类型
+	EventDate string  `json:"event_date"` // 事件日期
+	Source    string  `json:"source"`     // 引动来源（大运/流年）
+	GanZhi    string  `json:"gan_zhi"`    // 引动干支
+	Target    string  `json:"target"`     // 被引动的柱
+	Detail    string  `json:"detail"`     // 说明
+	Weight    float64 `json:"weight"`     // 得分
+}
+
+// HourCandidateResponse 候选时辰
+// @Description 候选时辰
+// @Property HourZhi string true "时支"
+// @Property HourPillar string true "时柱"
+// @Property TimeRange string true "时间范围"
+// @Property Score float64 true "总分"
+// @Property Confidence float64 true "相对可信度"
+// @Property Explained int true "有引动证据的事件数"
+type HourCandidateResponse struct {
+	Rank       int                 `json:"rank"`        // 排名
+	HourZhi    string              `json:"hour_zhi"`    // 时支
+	HourPillar string              `json:"hour_pillar"` // 时柱
+	TimeRange  string              `json:"time_range"`  // 时间范围
+	Score      float64             `json:"score"`       // 总分
+	Confidence float64             `json:"confidence"`  // 相对可信度（0-1）
+	Explained  int                 `json:"explained"`   // 有引动证据的事件数
+	Evidences  []*EvidenceResponse `json:"evidences"`   // 引动证据
+}
+
+// RectificationResponse 出生时辰校正响应
+// @Description 出生时辰校正响应
+// @Property BaziID string true "八字 ID"
+// @Property CurrentHourPillar string true "当前记录的时柱"
+// @Property EventCount int true "参与校正的事件数"
+// @Property Candidates []HourCandidateResponse true "按得分排序的候选时辰"
+type RectificationResponse struct {
+	BaziID            string                   `json:"bazi_id"`             // 八字 ID
+	CurrentHourPillar string                   `json:"current_hour_pillar"` // 当前记录的时柱
+	EventCount        int                      `json:"event_count"`         // 参与校正的事件数
+	Candidates        []*HourCandidateResponse `json:"candidates"`          // 按得分排序的候选时辰
+}
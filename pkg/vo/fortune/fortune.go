@@ -0,0 +1,46 @@
+// Package fortune 提供每日运势相关的视图对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package fortune
+
+// DailyFortuneResponse 每日运势响应
+// @Description 每日运势响应
+// @Property ID string true "每日运势 ID"
+// @Property BaziID string true "八字 ID"
+// @Property FortuneDate string true "日期"
+// @Property DayPillar string true "流日干支"
+// @Property TenGod string true "流日天干十神"
+// @Property OverallScore int true "综合得分"
+// @Property Level string true "综合等级"
+// @Property CareerScore int true "事业得分"
+// @Property WealthScore int true "财运得分"
+// @Property RelationshipScore int true "感情得分"
+// @Property HealthScore int true "健康得分"
+// @Property Interactions []string true "流日与原局的关系"
+// @Property Summary string true "运势文案"
+type DailyFortuneResponse struct {
+	ID                string   `json:"id"`                 // 每日运势 ID
+	BaziID            string   `json:"bazi_id"`            // 八字 ID
+	FortuneDate       string   `json:"fortune_date"`       // 日期
+	DayPillar         string   `json:"day_pillar"`         // 流日干支
+	TenGod            string   `json:"ten_god"`            // 流日天干十神
+	OverallScore      int      `json:"overall_score"`      // 综合得分
+	Level             string   `json:"level"`              // 综合等级
+	CareerScore       int      `json:"career_score"`       // 事业得分
+	WealthScore       int      `json:"wealth_score"`       // 财运得分
+	RelationshipScore int      `json:"relationship_score"` // 感情得分
+	HealthScore       int      `json:"health_score"`       // 健康得分
+	Interactions      []string `json:"interactions"`       // 流日与原局的关系
+	Summary           string   `json:"summary"`            // 运势文案，已润色时为润色后的文案
+}
+
+// FortuneSubscriptionResponse 运势订阅响应
+// @Description 运势订阅响应
+// @Property BaziID string true "八字 ID"
+// @Property EmailEnabled bool true "是否接收邮件推送"
+// @Property Active bool true "是否启用"
+type FortuneSubscriptionResponse struct {
+	BaziID       string `json:"bazi_id"`       // 八字 ID
+	EmailEnabled bool   `json:"email_enabled"` // 是否接收邮件推送
+	Active       bool   `json:"active"`        // 是否启用
+}
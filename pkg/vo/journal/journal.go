@@ -0,0 +1,56 @@
+// Package journal 提供人生日志相关的视图对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package journal
+
+import (
+	"time"
+)
+
+// JournalEntryResponse 日志响应
+// @Description 日志响应
+// @Property ID string true "日志 ID"
+// @Property BaziID string true "八字 ID"
+// @Property EntryDate string true "日志日期"
+// @Property Title string false "标题"
+// @Property Content string true "内容"
+// @Property Tags []string false "标签"
+// @Property LuckPillar string false "当时所行大运"
+// @Property YearPillar string true "流年干支"
+// @Property MonthPillar string true "流月干支"
+// @Property DayPillar string true "流日干支"
+// @Property Interactions []string true "岁运与原局的刑冲合害"
+type JournalEntryResponse struct {
+	ID           string    `json:"id"`           // 日志 ID
+	BaziID       string    `json:"bazi_id"`      // 八字 ID
+	EntryDate    time.Time `json:"entry_date"`   // 日志日期
+	Title        string    `json:"title"`        // 标题
+	Content      string    `json:"content"`      // 内容
+	Tags         []string  `json:"tags"`         // 标签
+	LuckPillar   string    `json:"luck_pillar"`  // 当时所行大运
+	YearPillar   string    `json:"year_pillar"`  // 流年干支
+	MonthPillar  string    `json:"month_pillar"` // 流月干支
+	DayPillar    string    `json:"day_pillar"`   // 流日干支
+	Interactions []string  `json:"interactions"` // 岁运与原局的刑冲合害
+	GmtCreate    string    `json:"gmt_create"`   // 创建时间
+}
+
+// JournalListResponse 日志列表响应
+// @Description 日志列表响应
+// @Property total     int64 true "总条数"
+// @Property pageNo    int   true "当前页"
+// @Property pageSize  int   true "当前分页记录数"
+// @Property list      []JournalEntryResponse true "分页内容"
+type JournalListResponse struct {
+	Total    int64                   `json:"total"`    // 总条数
+	PageNo   int                     `json:"pageNo"`   // 当前页
+	PageSize int                     `json:"pageSize"` // 当前分页记录数
+	List     []*JournalEntryResponse `json:"list"`     // 分页内容
+}
+
+// JournalExportResponse 日志导出结果
+type JournalExportResponse struct {
+	FileName    string // 文件名
+	ContentType string // 内容类型
+	Content     []byte // 文件内容
+}
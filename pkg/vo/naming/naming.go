@@ -0,0 +1,67 @@
+// Package naming 提供起名相关的视图对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package naming
+
+// WugeResponse 五格数理
+// @Description 五格数理
+// @Property Tian int true "天格"
+// @Property Ren int true "人格"
+// @Property Di int true "地格"
+// @Property Wai int true "外格"
+// @Property Zong int true "总格"
+type WugeResponse struct {
+	Tian     int    `json:"tian"`      // 天格
+	Ren      int    `json:"ren"`       // 人格
+	Di       int    `json:"di"`        // 地格
+	Wai      int    `json:"wai"`       // 外格
+	Zong     int    `json:"zong"`      // 总格
+	RenLuck  string `json:"ren_luck"`  // 人格吉凶
+	DiLuck   string `json:"di_luck"`   // 地格吉凶
+	WaiLuck  string `json:"wai_luck"`  // 外格吉凶
+	ZongLuck string `json:"zong_luck"` // 总格吉凶
+}
+
+// NameCandidateResponse 候选姓名
+// @Description 候选姓名
+// @Property FullName string true "全名"
+// @Property GivenName string true "名字"
+// @Property Pinyin string true "拼音"
+// @Property Tones string true "平仄"
+// @Property Wuxing []string true "名字用字五行"
+// @Property Strokes []int true "名字用字康熙笔画"
+// @Property Meaning string true "字义"
+// @Property Wuge WugeResponse true "五格数理"
+// @Property Score float64 true "综合评分"
+// @Property Explanation string false "AI 寓意解读"
+type NameCandidateResponse struct {
+	FullName    string        `json:"full_name"`             // 全名
+	GivenName   string        `json:"given_name"`            // 名字
+	Pinyin      string        `json:"pinyin"`                // 拼音
+	Tones       string        `json:"tones"`                 // 平仄
+	Wuxing      []string      `json:"wuxing"`                // 名字用字五行
+	Strokes     []int         `json:"strokes"`               // 名字用字康熙笔画
+	Meaning     string        `json:"meaning"`               // 字义
+	Wuge        *WugeResponse `json:"wuge"`                  // 五格数理
+	Score       float64       `json:"score"`                 // 综合评分
+	Explanation string        `json:"explanation,omitempty"` // AI 寓意解读
+}
+
+// NameSuggestionResponse 起名响应
+// @Description 起名响应
+// @Property BaziID string true "八字 ID"
+// @Property DayMaster string true "日主"
+// @Property DayMasterWuxing string true "日主五行"
+// @Property IsStrong bool true "日主是否偏旺"
+// @Property Favorable []string true "喜用五行"
+// @Property Missing []string true "原局缺失五行"
+// @Property Candidates []NameCandidateResponse true "候选姓名"
+type NameSuggestionResponse struct {
+	BaziID          string                   `json:"bazi_id"`           // 八字 ID
+	DayMaster       string                   `json:"day_master"`        // 日主
+	DayMasterWuxing string                   `json:"day_master_wuxing"` // 日主五行
+	IsStrong        bool                     `json:"is_strong"`         // 日主是否偏旺
+	Favorable       []string                 `json:"favorable"`         // 喜用五行
+	Missing         []string                 `json:"missing"`           // 原局缺失五行
+	Candidates      []*NameCandidateResponse `json:"candidates"`        // 候选姓名
+}
@@ -0,0 +1,59 @@
+// Package report 提供流年报告相关的视图对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package report
+
+import (
+	internalReport "github.com/Done-0/metaphysics/internal/report"
+)
+
+// AnnualReportResponse 流年报告响应
+// @Description 流年报告响应
+// @Property ID string true "报告 ID"
+// @Property BaziID string true "八字 ID"
+// @Property Year int true "流年所在公历年份"
+// @Property Version int true "版本号"
+// @Property Status string true "状态 (pending/completed/failed)"
+// @Property YearPillar string true "流年干支"
+// @Property LuckPillar string false "当年所行大运"
+// @Property Highlights object false "规则分析结果，含逐月要点"
+// @Property Content string false "报告正文"
+// @Property ErrorMessage string false "失败原因"
+type AnnualReportResponse struct {
+	ID           string                 `json:"id"`                   // 报告 ID
+	BaziID       string                 `json:"bazi_id"`              // 八字 ID
+	Year         int                    `json:"year"`                 // 流年所在公历年份
+	Version      int                    `json:"version"`              // 版本号
+	Status       string                 `json:"status"`               // 状态
+	YearPillar   string                 `json:"year_pillar"`          // 流年干支
+	LuckPillar   string                 `json:"luck_pillar"`          // 当年所行大运
+	Highlights   *internalReport.Annual `json:"highlights,omitempty"` // 规则分析结果
+	Content      string                 `json:"content,omitempty"`    // 报告正文
+	ErrorMessage string                 `json:"error_message"`        // 失败原因
+	GmtCreate    string                 `json:"gmt_create"`           // 创建时间
+}
+
+// AnnualReportListResponse 流年报告列表响应
+// @Description 流年报告列表响应，不含报告正文
+// @Property List []AnnualReportResponse true "报告列表"
+type AnnualReportListResponse struct {
+	List []*AnnualReportResponse `json:"list"` // 报告列表
+}
+
+// AnnualReportSubscriptionResponse 流年报告订阅响应
+// @Description 流年报告订阅响应
+// @Property BaziID string true "八字 ID"
+// @Property EmailEnabled bool true "生成后是否邮件通知"
+// @Property Active bool true "是否启用"
+type AnnualReportSubscriptionResponse struct {
+	BaziID       string `json:"bazi_id"`       // 八字 ID
+	EmailEnabled bool   `json:"email_enabled"` // 生成后是否邮件通知
+	Active       bool   `json:"active"`        // 是否启用
+}
+
+// AnalysisPDFResponse 命理分析 PDF 报告
+type AnalysisPDFResponse struct {
+	FileName    string // 文件名
+	ContentType string // 内容类型
+	Content     []byte // 文件内容
+}
@@ -0,0 +1,97 @@
+// Package share 提供分享链接相关的视图对象
+// 创建者：Done-0
+// 创建时间：2026-10-19
+package share
+
+import (
+	"time"
+)
+
+// ShareLinkResponse 分享链接响应
+// @Description 分享链接响应
+// @Property ID string true "分享链接 ID"
+// @Property TargetType string true "分享对象类型 (chart/analysis)"
+// @Property TargetID string true "分享对象 ID"
+// @Property MessageID string false "分享的分析消息 ID"
+// @Property Token string true "分享令牌"
+// @Property URL string true "分享链接"
+// @Property ExpireAt string true "过期时间"
+// @Property ShowName bool true "是否展示姓名"
+// @Property Revoked bool true "是否已撤销"
+// @Property Expired bool true "是否已过期"
+// @Property ViewCount int64 true "访问次数"
+type ShareLinkResponse struct {
+	ID         string    `json:"id"`          // 分享链接 ID
+	TargetType string    `json:"target_type"` // 分享对象类型
+	TargetID   string    `json:"target_id"`   // 分享对象 ID
+	MessageID  string    `json:"message_id"`  // 分享的分析消息 ID
+	Token      string    `json:"token"`       // 分享令牌
+	URL        string    `json:"url"`         // 分享链接
+	ExpireAt   time.Time `json:"expire_at"`   // 过期时间
+	ShowName   bool      `json:"show_name"`   // 是否展示姓名
+	Revoked    bool      `json:"revoked"`     // 是否已撤销
+	Expired    bool      `json:"expired"`     // 是否已过期
+	ViewCount  int64     `json:"view_count"`  // 访问次数
+	GmtCreate  string    `json:"gmt_create"`  // 创建时间
+}
+
+// ShareListResponse 分享链接列表响应
+// @Description 分享链接列表响应
+// @Property total     int64 true "总条数"
+// @Property pageNo    int   true "当前页"
+// @Property pageSize  int   true "当前分页记录数"
+// @Property list      []ShareLinkResponse true "分页内容"
+type ShareListResponse struct {
+	Total    int64                `json:"total"`    // 总条数
+	PageNo   int                  `json:"pageNo"`   // 当前页
+	PageSize int                  `json:"pageSize"` // 当前分页记录数
+	List     []*ShareLinkResponse `json:"list"`     // 分页内容
+}
+
+// SharedChartResponse 分享的命盘，仅包含排盘信息，不含备注、标签等私有字段
+// @Description 分享的命盘
+// @Property Name string true "姓名，未开启展示时为脱敏后的姓名"
+// @Property Gender string true "性别"
+// @Property BirthTime string true "出生时间，隐去姓名时仅保留日期"
+// @Property BirthTimeCoarsened bool true "出生时间是否已粗化为日期"
+// @Property BirthPeriod string false "出生时辰，如 午时"
+// @Property Calendar string true "日历类型 (lunar/solar)"
+// @Property Pillars []string true "四柱干支（年、月、日、时）"
+// @Property DayMaster string true "日主"
+type SharedChartResponse struct {
+	Name               string    `json:"name"`                   // 姓名
+	Gender             string    `json:"gender"`                 // 性别
+	BirthTime          time.Time `json:"birth_time"`             // 出生时间
+	BirthTimeUnknown   bool      `json:"birth_time_unknown"`     // 出生时间是否未知
+	BirthTimeCoarsened bool      `json:"birth_time_coarsened"`   // 出生时间是否已粗化为日期，隐去姓名时为 true
+	BirthPeriod        string    `json:"birth_period,omitempty"` // 出生时辰，出生时间未知时为空
+	Calendar           string    `json:"calendar"`               // 日历类型
+	Pillars            []string  `json:"pillars"`                // 四柱干支
+	DayMaster          string    `json:"day_master"`             // 日主
+}
+
+// SharedMessageResponse 分享的分析消息
+// @Description 分享的分析消息
+// @Property Role string true "角色 (USER/ASSISTANT)"
+// @Property Content string true "消息内容"
+// @Property GmtCreate string true "发送时间"
+type SharedMessageResponse struct {
+	Role      string    `json:"role"`       // 角色
+	Content   string    `json:"content"`    // 消息内容
+	GmtCreate time.Time `json:"gmt_create"` // 发送时间
+}
+
+// SharedViewResponse 分享内容只读视图
+// @Description 分享内容只读视图，命盘分享仅含 chart，分析分享另含标题与消息
+// @Property TargetType string true "分享对象类型 (chart/analysis)"
+// @Property ExpireAt string true "过期时间"
+// @Property Chart SharedChartResponse false "命盘"
+// @Property Title string false "分析标题"
+// @Property Messages []SharedMessageResponse false "分析消息，按时间升序"
+type SharedViewResponse struct {
+	TargetType string                   `json:"target_type"`        // 分享对象类型
+	ExpireAt   time.Time                `json:"expire_at"`          // 过期时间
+	Chart      *SharedChartResponse     `json:"chart,omitempty"`    // 命盘
+	Title      string                   `json:"title,omitempty"`    // 分析标题
+	Messages   []*SharedMessageResponse `json:"messages,omitempty"` // 分析消息
+}